@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"time"
 
@@ -216,6 +217,15 @@ func (h *nineHandler) Update(event tcell.Event, scene engine.IScene) {
 	h.HandleKeyboardForActions(event, actions)
 }
 
+// runEngine function runs the given engine and it exits with an error status
+// if the engine returns any error.
+func runEngine(appEngine *engine.Engine) {
+	if err := appEngine.Run(60.0); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
 func demoOne() {
 	fmt.Println("ThEngine demo-one")
 	camera := engine.NewCamera(nil, api.NewSize(40, 80))
@@ -227,7 +237,7 @@ func demoOne() {
 	appEngine.Init()
 	//camera.Draw(true, appEngine.GetScreen())
 	appEngine.GetScreen().Show()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoTwo() {
@@ -257,7 +267,7 @@ func demoTwo() {
 		panic(fmt.Sprintf("can not set scene %s as visible", scene.GetName()))
 	}
 	appEngine.Init()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoThree() {
@@ -280,7 +290,7 @@ func demoThree() {
 	appEngine.GetSceneManager().SetSceneAsActive(scene)
 	appEngine.GetSceneManager().SetSceneAsVisible(scene)
 	appEngine.Init()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoFour(dryRun bool) {
@@ -302,7 +312,7 @@ func demoFour(dryRun bool) {
 	appEngine.GetSceneManager().SetSceneAsVisible(scene)
 	// appEngine.SetDryRun(dryRun)
 	appEngine.Init()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoFive(dryRun bool) {
@@ -334,7 +344,7 @@ func demoFive(dryRun bool) {
 	screen.SetCursorStyle(tcell.CursorStyleBlinkingBlock)
 	//screen.SetStyle(style)
 	screen.ShowCursor(0, 0)
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoSix(dryRun bool) {
@@ -369,7 +379,7 @@ func demoSix(dryRun bool) {
 	screen := appEngine.GetScreen()
 	//screen.SetCursorStyle(tcell.CursorStyleBlinkingBlock)
 	screen.SetCursorStyle(tcell.CursorStyleSteadyBlock)
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoSeven(dryRun bool) {
@@ -392,7 +402,7 @@ func demoSeven(dryRun bool) {
 	appEngine.GetSceneManager().SetSceneAsActive(scene)
 	appEngine.GetSceneManager().SetSceneAsVisible(scene)
 	appEngine.Init()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoEight(dryRun bool) {
@@ -425,7 +435,7 @@ func demoEight(dryRun bool) {
 	appEngine.GetSceneManager().SetSceneAsActive(scene)
 	appEngine.GetSceneManager().SetSceneAsVisible(scene)
 	appEngine.Init()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoNine(dryRun bool) {
@@ -448,7 +458,7 @@ func demoNine(dryRun bool) {
 	appEngine.GetSceneManager().SetSceneAsActive(scene)
 	appEngine.GetSceneManager().SetSceneAsVisible(scene)
 	appEngine.Init()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoTen(dryRun bool) {
@@ -472,7 +482,7 @@ func demoTen(dryRun bool) {
 	appEngine.GetSceneManager().SetSceneAsActive(scene)
 	appEngine.GetSceneManager().SetSceneAsVisible(scene)
 	appEngine.Init()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoEleven(dryRun bool) {
@@ -498,6 +508,27 @@ func demoEleven(dryRun bool) {
 	menu := widgets.NewTopMenu("menu/1", api.NewPoint(1, 6), api.NewSize(40, 3), &styleOne, menuItems, 0)
 	scene.AddEntity(menu)
 
+	table := widgets.NewTable("table/1", api.NewPoint(1, 10), api.NewSize(34, 6), &styleOne, []*widgets.TableColumn{
+		widgets.NewTableColumn("Item", widgets.TableColumnString).WithFlex(1),
+		widgets.NewTableColumn("Qty", widgets.TableColumnInt),
+		widgets.NewTableColumn("Weight", widgets.TableColumnFloat).WithWidth(7),
+	})
+	table.AddRow("dagger", 2, 0.5)
+	table.AddRow("rope", 1, 1.25)
+	table.AddRow("torch", 5, 0.75)
+	table.AddRow("potion", 3, 0.2)
+	table.AddRow("shield", 1, 6.0)
+	scene.AddEntity(table)
+
+	sheet := widgets.NewTabs("tabs/1", api.NewPoint(40, 1), api.NewSize(30, 8), &styleOne)
+	sheet.AddTab("Stats", widgets.NewText("text/stats/1", nil, nil, &styleOne, "STR 12\nDEX 14\nCON 10"))
+	sheet.AddTab("Inventory", widgets.NewListBox("list-box/inventory/1", api.NewPoint(0, 0), api.NewSize(20, 5), &styleOne, []string{"dagger", "rope", "torch"}, 0))
+	spells := widgets.NewListBox("list-box/spells/1", api.NewPoint(0, 0), api.NewSize(12, 5), &styleOne, []string{"fireball", "heal"}, 0)
+	spellText := widgets.NewText("text/spells/1", nil, nil, &styleOne, "[red]3d6[/] fire damage")
+	spellText.SetMarkup(true)
+	sheet.AddTab("Spells", widgets.NewHSplitPane("split-pane/1", nil, nil, &styleOne, spells, spellText))
+	scene.AddEntity(sheet)
+
 	appEngine := engine.GetEngine()
 	appEngine.InitResources()
 	appEngine.GetSceneManager().AddScene(scene)
@@ -506,7 +537,7 @@ func demoEleven(dryRun bool) {
 	appEngine.GetSceneManager().UpdateFocus()
 	appEngine.Init()
 	appEngine.Start()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoTwelve(dryRun bool) {
@@ -581,7 +612,7 @@ func demoTwelve(dryRun bool) {
 	appEngine.GetSceneManager().SetSceneAsVisible(scene)
 	appEngine.Init()
 	appEngine.Start()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoThirteen(dryRun bool) {
@@ -613,7 +644,7 @@ func demoThirteen(dryRun bool) {
 	appEngine.GetSceneManager().SetSceneAsVisible(scene)
 	appEngine.Init()
 	appEngine.Start()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoFourteen(dryRun bool) {
@@ -677,7 +708,7 @@ func demoFourteen(dryRun bool) {
 	appEngine.GetSceneManager().SetSceneAsVisible(scene)
 	appEngine.Init()
 	appEngine.Start()
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoFifteen(dryRun bool) {
@@ -756,7 +787,7 @@ func demoFifteen(dryRun bool) {
 
 	modalDialog.Open(dialog)
 
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
 
 func demoSixteen(dryRun bool) {
@@ -779,6 +810,12 @@ func demoSixteen(dryRun bool) {
 	anchoredText := widgets.NewAnchoredText("widget/anchored-text/1", textThree, textFour)
 	scene.AddEntity(anchoredText)
 
+	// same output using inline markup in a single text widget.
+	markupText := widgets.NewText("widget/text/5", api.NewPoint(1, 5), nil, &constants.WhiteOverBlack,
+		"Hello, [red]Jose Carlos[/]! [[[b]markup[/]] [:blue]works[/]")
+	markupText.SetMarkup(true)
+	scene.AddEntity(markupText)
+
 	choose := widgets.NewChoose("choose/1", api.NewPoint(1, 10), api.NewSize(20, 5), &constants.WhiteOverBlack, []string{"ok", "cancel", "help"}, 0)
 	scene.AddEntity(choose)
 
@@ -790,14 +827,55 @@ func demoSixteen(dryRun bool) {
 	appEngine.Init()
 	appEngine.Start()
 
-	appEngine.Run(60.0)
+	runEngine(appEngine)
+}
+
+func demoSeventeen(dryRun bool) {
+	tools.Logger.WithField("module", "main").
+		WithField("dry-mode", dryRun).
+		Infof("ThEngine demo-seventeen")
+	fmt.Println("ThEngine demo-seventeen")
+	camera := engine.NewCamera(api.NewPoint(0, 0), api.NewSize(90, 30))
+	scene := engine.NewScene("scene", camera)
+
+	desktop := widgets.NewWindowManager("window-manager/1", api.NewPoint(0, 0), api.NewSize(90, 30))
+	scene.AddEntity(desktop)
+
+	sprites := widgets.NewListBox("list-box/sprites/1", api.NewPoint(0, 0), api.NewSize(20, 8), &constants.WhiteOverBlack,
+		[]string{"player", "goblin", "wall", "door"}, 0)
+	desktop.Open(widgets.NewWindow("window/sprites/1", "Sprites", api.NewPoint(1, 1), api.NewSize(24, 10),
+		&constants.WhiteOverBlack, sprites))
+
+	root := widgets.NewTreeNode("scene", "scene", nil).
+		AddChildren(widgets.NewTreeNode("player", "entity", nil), widgets.NewTreeNode("goblin", "entity", nil))
+	tree := widgets.NewTreeView("tree-view/inspector/1", api.NewPoint(0, 0), api.NewSize(20, 8), &constants.WhiteOverBlack,
+		[]*widgets.TreeNode{root})
+	inspector := widgets.NewWindow("window/inspector/1", "Inspector", api.NewPoint(20, 5), api.NewSize(30, 12),
+		&constants.WhiteOverBlack, tree)
+	inspector.SetResizable(true)
+	desktop.Open(inspector)
+
+	appEngine := engine.GetEngine()
+	appEngine.InitResources()
+	appEngine.GetSceneManager().AddScene(scene)
+	appEngine.GetSceneManager().SetSceneAsActive(scene)
+	appEngine.GetSceneManager().SetSceneAsVisible(scene)
+	appEngine.GetSceneManager().UpdateFocus()
+	appEngine.Init()
+	appEngine.Start()
+
+	runEngine(appEngine)
 }
 
 func main() {
+	if err := tools.ConfigureLogger(tools.NewLogConfig("trace")); err != nil {
+		panic(err)
+	}
 	//demoEleven(false)
 	//demoTwelve(false)
 	//demoFourteen(false)
 	//demoFifteen(false)
 	demoSixteen(false)
+	//demoSeventeen(false)
 	//demoSnake(false)
 }
@@ -404,10 +404,8 @@ func (h *AppHandler) Consume() {
 				h.HighScore = textPoints.Points
 			}
 			sceneManager.DeactivateScene(gameOverScene)
-			// Remove all entities from the scene.
+			// Remove all entities from the scene and from the focus manager.
 			mainScene.Clean()
-			// Remove all entities from the scene in the focus manager.
-			engine.GetEngine().GetFocusManager().RemoveEntitiesInScene(mainScene)
 			// Run scene setup and start.
 			h.SetUpMainScene(mainScene)
 			mainScene.Start()
@@ -452,11 +450,11 @@ func (h *AppHandler) SetUpMainScene(mainScene engine.IScene) {
 
 	foodTimer := widgets.NewTimer(TimerFoodWidgetName, 5*time.Second, widgets.ForeverTimer)
 	foodTimer.SetWidgetCallback(func(entity engine.IEntity, args ...any) bool {
-		x := tools.RandomRing.Intn(78) + 1
-		y := tools.RandomRing.Intn(18) + 2
+		x := tools.GetRandomStream(tools.RandomStreamDefault).Intn(78) + 1
+		y := tools.GetRandomStream(tools.RandomStreamDefault).Intn(18) + 2
 		FoodPieceCounter++
 		foodPieceName := fmt.Sprintf(TimerFoodPieceWidgetName, FoodPieceCounter)
-		duration := tools.RandomRing.Intn(30) + 10
+		duration := tools.GetRandomStream(tools.RandomStreamDefault).Intn(30) + 10
 		var points int
 		var style tcell.Style
 		if duration < 20 {
@@ -537,5 +535,5 @@ func demoSnake(dryRun bool) {
 
 	theAppHandler.SetUp(appEngine)
 
-	appEngine.Run(60.0)
+	runEngine(appEngine)
 }
@@ -100,9 +100,9 @@ func (w *ShuffleDie) Update(tcell.Event, engine.IScene) {
 	if w.ticks >= w.maxTicks {
 		var newface string
 		if w.faces < 10 {
-			newface = fmt.Sprintf("%d", tools.RandomRing.Intn(w.faces+1))
+			newface = fmt.Sprintf("%d", tools.GetRandomStream(tools.RandomStreamDefault).Intn(w.faces+1))
 		} else {
-			newface = fmt.Sprintf("%02d", tools.RandomRing.Intn(w.faces+1))
+			newface = fmt.Sprintf("%02d", tools.GetRandomStream(tools.RandomStreamDefault).Intn(w.faces+1))
 		}
 		w.GetCanvas().WriteStringInCanvasAt(newface, w.GetStyle(), api.NewPoint(1, 1))
 		w.ticks = 0
@@ -0,0 +1,17 @@
+// locales.go module contains all locale files embedded in the game binary.
+package assets
+
+import (
+	"embed"
+
+	"github.com/jrecuero/thengine/pkg/i18n"
+)
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// LoadLocales function loads all embedded locale files into the active i18n
+// catalog.
+func LoadLocales() error {
+	return i18n.GetCatalog().LoadFS(localesFS, "locales")
+}
@@ -0,0 +1,24 @@
+// prefabs.go module contains all entity prefabs embedded in the game binary.
+package assets
+
+import (
+	"embed"
+
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+const (
+	EnemyPrefabName      = "enemy"
+	GoblinPrefabName     = "enemy/goblin"
+	PoisonTrapPrefabName = "trap/poison"
+	WallPrefabName       = "wall"
+)
+
+//go:embed prefabs.json
+var prefabsFS embed.FS
+
+// LoadPrefabs function loads all embedded prefabs into the prefab manager used
+// to import entities.
+func LoadPrefabs() error {
+	return engine.GetPrefabManager().LoadFromFS(prefabsFS, "prefabs.json")
+}
@@ -0,0 +1,27 @@
+// sprites.go module contains all sprite assets embedded in the game binary.
+package assets
+
+import (
+	"embed"
+
+	"github.com/jrecuero/thengine/pkg/constants"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+const (
+	ThroneSpriteAssetName = "sprite/throne"
+)
+
+//go:embed throne.txt
+var spritesFS embed.FS
+
+// NewAssetManager function creates a new asset manager with all embedded
+// sprites registered, and all sprites required by the given scene to be
+// preloaded when the scene is activated.
+func NewAssetManager(sceneName string) *engine.AssetManager {
+	manager := engine.NewAssetManager(spritesFS)
+	widgets.RegisterSpriteAsset(manager, ThroneSpriteAssetName, "throne.txt", &constants.MaroonOverBlack)
+	manager.AddSceneAssets(sceneName, ThroneSpriteAssetName)
+	return manager
+}
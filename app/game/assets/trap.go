@@ -3,6 +3,8 @@
 package assets
 
 import (
+	"fmt"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/app/game/dad/constants"
 	"github.com/jrecuero/thengine/app/game/dad/rules"
@@ -16,8 +18,10 @@ import (
 // -----------------------------------------------------------------------------
 
 const (
-	detectIndex = 0
-	disarmIndex = 1
+	detectIndex     = 0
+	disarmIndex     = 1
+	defaultDetectDC = 8
+	defaultDisarmDC = 10
 )
 
 // -----------------------------------------------------------------------------
@@ -65,6 +69,13 @@ func NewTrap(name string, position *api.Point, size *api.Size, style *tcell.Styl
 	return t
 }
 
+// NewEmptyTrap function creates a new Trap instance with default values, to
+// be unmarshaled from a map, like a prefab.
+func NewEmptyTrap() *Trap {
+	return NewTrap("", nil, nil, nil, defaultDetectDC, defaultDisarmDC,
+		rules.DiceThrow1d4, constants.Poison)
+}
+
 // -----------------------------------------------------------------------------
 // Trap private methods
 // -----------------------------------------------------------------------------
@@ -102,6 +113,42 @@ func (t *Trap) CanDisarm(unit rules.IUnit, opts ...any) bool {
 
 }
 
+// UnmarshalMap method unmarshals the trap from the given map. Besides entity
+// values, the map can contain "detect_dc", "disarm_dc", "damage" as a dice
+// throw short name like "1d4" and "damage_type".
+func (t *Trap) UnmarshalMap(content map[string]any, origin *api.Point) error {
+	if err := t.Widget.UnmarshalMap(content, origin); err != nil {
+		return err
+	}
+	detectDC, disarmDC := defaultDetectDC, defaultDisarmDC
+	if dc, ok := content["detect_dc"].(float64); ok {
+		detectDC = int(dc)
+	}
+	if dc, ok := content["disarm_dc"].(float64); ok {
+		disarmDC = int(dc)
+	}
+	diceThrow := t.Damage.GetDiceThrow()
+	if shortName, ok := content["damage"].(string); ok {
+		if diceThrow = rules.GetDiceThrowFromString(shortName); diceThrow == nil {
+			return fmt.Errorf("trap %s: dice throw %s not found", t.GetName(), shortName)
+		}
+	}
+	damageType := t.Damage.GetDamageType()
+	if str, ok := content["damage_type"].(string); ok {
+		damageType = rules.DamageType(str)
+	}
+	t.SetDiceThrow(diceThrow)
+	t.SetDamageType(damageType)
+	detect := &rules.SavingThrowDamage{
+		SavingThrow: rules.NewSavingThrow(constants.Perception, detectDC),
+	}
+	disarm := &rules.SavingThrowDamage{
+		SavingThrow: rules.NewSavingThrow(constants.Sleight, disarmDC),
+	}
+	t.Damage.SetSavingThrowsDamage([]*rules.SavingThrowDamage{detect, disarm})
+	return nil
+}
+
 //func (t *Trap) Update(event tcell.Event, scene engine.IScene) {
 //    t.Widget.Update(event, scene)
 //}
@@ -8,23 +8,26 @@ import (
 	"github.com/jrecuero/thengine/pkg/widgets"
 )
 
+// CommandLine structure displays all game messages in a log view, so they are
+// wrapped and they can be scrolled back.
 type CommandLine struct {
-	*widgets.Text
+	*widgets.LogView
 }
 
 func NewCommandLine(name string, position *api.Point, size *api.Size, style *tcell.Style) *CommandLine {
-	return &CommandLine{
-		Text: widgets.NewText(name, position, size, style, ">"),
+	commandLine := &CommandLine{
+		LogView: widgets.NewLogView(name, position, size, style),
 	}
+	commandLine.AddLine(">")
+	return commandLine
 }
 
+// AddText method adds every line in the given string to the log view, where
+// any empty line is skipped.
 func (t *CommandLine) AddText(str string) {
-	maxLines := t.GetSize().H
-	text := t.GetText()
-	lines := strings.Split(text, "\n")
-	if len(lines) >= maxLines {
-		text = strings.Join(lines[1:], "\n")
+	for _, line := range strings.Split(str, "\n") {
+		if line != "" {
+			t.AddLine(line)
+		}
 	}
-	newText := text + str
-	t.SetText(newText)
 }
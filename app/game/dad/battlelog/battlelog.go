@@ -1,15 +1,28 @@
 // battlelog package contains a dedicated logging information for all battle
-// data.
+// data. Entries can contain inline markup tags to be rendered by any text
+// widget.
 package battlelog
 
 import (
 	"fmt"
 	"strings"
+
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/i18n"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+// debug and info prefixes are escaped so they are not handled as markup tags.
+const (
+	debugStr = "[[DEBUG]"
+	infoStr  = "[[INFO]"
 )
 
+// Message IDs for all battle log messages to be translated.
 const (
-	debugStr = "[DEBUG]"
-	infoStr  = "[INFO]"
+	AttackHitMsg  = "battlelog.attack.hit"
+	AttackMissMsg = "battlelog.attack.miss"
+	DieRollMsg    = "battlelog.dieroll"
 )
 
 var (
@@ -36,6 +49,23 @@ func NewBattleLog() *BattleLog {
 	}
 }
 
+// -----------------------------------------------------------------------------
+// BattleLog private functions
+// -----------------------------------------------------------------------------
+
+// escapeParams function escapes all string parameters, like unit names, so
+// they are never handled as markup tags.
+func escapeParams(params i18n.Params) i18n.Params {
+	result := i18n.Params{}
+	for key, value := range params {
+		if str, ok := value.(string); ok {
+			value = engine.EscapeMarkup(str)
+		}
+		result[key] = value
+	}
+	return result
+}
+
 // -----------------------------------------------------------------------------
 // BattleLog public methods
 // -----------------------------------------------------------------------------
@@ -54,6 +84,18 @@ func (l *BattleLog) PushInfo(str string) {
 	l.Push(fmt.Sprintf("%s %s", infoStr, str))
 }
 
+// PushDebugf method pushes the given message ID translated to the active
+// locale with all parameters substituted as a debug entry.
+func (l *BattleLog) PushDebugf(id string, params i18n.Params) {
+	l.PushDebug(i18n.Tf(id, escapeParams(params)))
+}
+
+// PushInfof method pushes the given message ID translated to the active
+// locale with all parameters substituted as an info entry.
+func (l *BattleLog) PushInfof(id string, params i18n.Params) {
+	l.PushInfo(i18n.Tf(id, escapeParams(params)))
+}
+
 func (l *BattleLog) Pop() string {
 	var result string
 	if l.IsAny() {
@@ -91,3 +133,6 @@ func (l *BattleLog) Clear() {
 func (l *BattleLog) IsAny() bool {
 	return len(l.cache) != l.index
 }
+
+// BattleLog can be bound to a LogView as a live feed.
+var _ widgets.ILogFeed = (*BattleLog)(nil)
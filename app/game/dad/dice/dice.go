@@ -44,10 +44,14 @@ type IDie interface {
 	DisadvantageSureRoll() int // return a disadvantage roll without zero.
 	DoubleRoll() (int, int)
 	DoubleSureRoll() (int, int)
-	GetName() string // returns the name of the die.
-	GetFaces() int   // returns the number of faces for the die.
-	Roll() int       // returns a roll die.
-	SureRoll() int   // returns a roll die without a zero
+	GetName() string   // returns the name of the die.
+	GetFaces() int     // returns the number of faces for the die.
+	GetStream() string // returns the random stream name for the die.
+	Roll() int         // returns a roll die.
+	RollFrom(*tools.RandomStream) int
+	SetStream(string)
+	SureRoll() int // returns a roll die without a zero
+	SureRollFrom(*tools.RandomStream) int
 	ToString() string
 }
 
@@ -63,6 +67,7 @@ type Die struct {
 	faces    int    // number of faces in the die.
 	loaded   int    // loaded die value
 	isLoaded bool   // is a loaded die that always return same value
+	stream   string // random stream the die is rolled from.
 }
 
 // NewDie function create a new Die instance.
@@ -72,6 +77,7 @@ func NewDie(name string, faces int) *Die {
 		faces:    faces,
 		isLoaded: false,
 		loaded:   0,
+		stream:   tools.RandomStreamDefault,
 	}
 }
 
@@ -82,6 +88,7 @@ func NewLoadedDie(name string, loaded int) *Die {
 		faces:    0,
 		isLoaded: true,
 		loaded:   loaded,
+		stream:   tools.RandomStreamDefault,
 	}
 }
 
@@ -141,20 +148,44 @@ func (d *Die) GetFaces() int {
 	return d.faces
 }
 
-// Roll method returns a roll die [0-faces]
+// GetStream method returns the name of the random stream the die is rolled
+// from.
+func (d *Die) GetStream() string {
+	return d.stream
+}
+
+// Roll method returns a roll die [0-faces] from the die random stream.
 func (d *Die) Roll() int {
+	return d.RollFrom(tools.GetRandomStream(d.stream))
+}
+
+// RollFrom method returns a roll die [0-faces] from the given random stream.
+func (d *Die) RollFrom(stream *tools.RandomStream) int {
 	if d.isLoaded {
 		return d.loaded
 	}
-	return tools.RandomRing.Intn(d.GetFaces() + 1)
+	return stream.Intn(d.GetFaces() + 1)
 }
 
-// SureRoll method returns a roll die without a zero [1-faces]
+// SetStream method sets the name of the random stream the die is rolled
+// from.
+func (d *Die) SetStream(stream string) {
+	d.stream = stream
+}
+
+// SureRoll method returns a roll die without a zero [1-faces] from the die
+// random stream.
 func (d *Die) SureRoll() int {
+	return d.SureRollFrom(tools.GetRandomStream(d.stream))
+}
+
+// SureRollFrom method returns a roll die without a zero [1-faces] from the
+// given random stream.
+func (d *Die) SureRollFrom(stream *tools.RandomStream) int {
 	if d.isLoaded {
 		return d.loaded
 	}
-	return tools.RandomRing.Intn(d.GetFaces()) + 1
+	return stream.Intn(d.GetFaces()) + 1
 }
 
 // ToString method returns the dice struct as a string.
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/jrecuero/thengine/app/game/dad/dice"
+	"github.com/jrecuero/thengine/pkg/tools"
 )
 
 var (
@@ -20,6 +21,19 @@ var (
 	DiceThrow4d6  = NewDiceThrow("dice-throw/4d6", "4d6", []dice.IDie{dice.DieSix, dice.DieSix, dice.DieSix, dice.DieSix})
 )
 
+// GetDiceThrowFromString function returns the predefined dice throw with the
+// given short name, like "1d4", or nil if it does not exist.
+func GetDiceThrowFromString(shortName string) IDiceThrow {
+	for _, diceThrow := range []*DiceThrow{DiceThrow1d2, DiceThrow1d3, DiceThrow1d4,
+		DiceThrow1d5, DiceThrow1d6, DiceThrow1d8, DiceThrow1d10, DiceThrow1d12,
+		DiceThrow1d20, DiceThrow2d6, DiceThrow4d6} {
+		if diceThrow.GetShortName() == shortName {
+			return diceThrow
+		}
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 //
 // IDiceThrow
@@ -32,11 +46,14 @@ type IDiceThrow interface {
 	GetDices() []dice.IDie
 	GetExtra() int
 	GetName() string
+	GetStream() string
 	Roll() int
+	RollFrom(*tools.RandomStream) int
 	SetDescription(string)
 	SetDices([]dice.IDie)
 	SetName(string)
 	SetExtra(int)
+	SetStream(string)
 	SureRoll() int
 	ToString() string
 }
@@ -54,14 +71,17 @@ type DiceThrow struct {
 	description string      // dice throw description.
 	dices       []dice.IDie // dice throw score.
 	extra       int         // dice throw extra score.
+	stream      string      // random stream all dices are rolled from.
 }
 
-// NewDiceThrow function creates a new DiceThrow instance.
+// NewDiceThrow function creates a new DiceThrow instance. Dice throws are
+// rolled from the combat random stream by default.
 func NewDiceThrow(name string, shortname string, dices []dice.IDie) *DiceThrow {
 	return &DiceThrow{
 		name:      name,
 		shortName: shortname,
 		dices:     dices,
+		stream:    tools.RandomStreamCombat,
 	}
 }
 
@@ -93,11 +113,24 @@ func (d *DiceThrow) GetShortName() string {
 	return d.shortName
 }
 
-// Roll method returns dice throw score value.
+// GetStream method returns the name of the random stream all dices are
+// rolled from.
+func (d *DiceThrow) GetStream() string {
+	return d.stream
+}
+
+// Roll method returns dice throw score value from the dice throw random
+// stream.
 func (d *DiceThrow) Roll() int {
+	return d.RollFrom(tools.GetRandomStream(d.stream))
+}
+
+// RollFrom method returns dice throw score value from the given random
+// stream.
+func (d *DiceThrow) RollFrom(stream *tools.RandomStream) int {
 	score := 0
 	for _, dice := range d.dices {
-		score += dice.Roll()
+		score += dice.RollFrom(stream)
 	}
 	score += d.GetExtra()
 	//if score > 30 {
@@ -125,6 +158,12 @@ func (d *DiceThrow) SetName(name string) {
 	d.name = name
 }
 
+// SetStream method sets the name of the random stream all dices are rolled
+// from.
+func (d *DiceThrow) SetStream(stream string) {
+	d.stream = stream
+}
+
 // SetShortName method sets dice throw short name.
 func (d *DiceThrow) SetShortName(name string) {
 	d.shortName = name
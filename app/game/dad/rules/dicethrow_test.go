@@ -0,0 +1,37 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/jrecuero/thengine/app/game/dad/dice"
+	"github.com/jrecuero/thengine/app/game/dad/rules"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+func TestDiceThrowRoll(t *testing.T) {
+	throw := rules.NewDiceThrow("throw/1", "2d20", []dice.IDie{dice.NewDie("d20", 20), dice.NewDie("d20", 20)})
+	if got := throw.GetStream(); got != tools.RandomStreamCombat {
+		t.Errorf("[0] GetStream Error exp:%s got:%s", tools.RandomStreamCombat, got)
+	}
+
+	tools.SeedRandom(1234)
+	exp := []int{throw.Roll(), throw.Roll(), throw.Roll()}
+	tools.SeedRandom(1234)
+	tools.GetRandomStream(tools.RandomStreamLoot).Intn(100)
+	for i, e := range exp {
+		if got := throw.Roll(); got != e {
+			t.Errorf("[1:%d] Roll Error exp:%d got:%d", i, e, got)
+		}
+		if e < 0 || e > 40 {
+			t.Errorf("[1:%d] Roll Error exp:0..40 got:%d", i, e)
+		}
+	}
+
+	stream1 := tools.NewRandomStream("test", 99)
+	stream2 := tools.NewRandomStream("test", 99)
+	for i := 0; i < 5; i++ {
+		if exp, got := throw.RollFrom(stream1), throw.RollFrom(stream2); exp != got {
+			t.Errorf("[2:%d] RollFrom Error exp:%d got:%d", i, exp, got)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/jrecuero/thengine/app/game/dad/battlelog"
 	"github.com/jrecuero/thengine/app/game/dad/constants"
 	"github.com/jrecuero/thengine/app/game/dad/dice"
+	"github.com/jrecuero/thengine/pkg/i18n"
 	"github.com/jrecuero/thengine/pkg/tools"
 )
 
@@ -32,6 +33,7 @@ type IUnit interface {
 	GetAbilities() IAbilities
 	GetArmorClass() int // unit AC 10 + mod(dex) + mod(gear)
 	GetAttacks() IAttacks
+	GetBattleLog() *battlelog.BattleLog
 	GetClass() IClass
 	GetConditions() []ICondition
 	GetConditionResistances() map[string]int
@@ -62,6 +64,7 @@ type IUnit interface {
 	RollConditions() []int
 	SetAbilities(IAbilities)
 	SetAttacks(IAttacks)
+	SetBattleLog(*battlelog.BattleLog)
 	SetClass(IClass)
 	SetConditions([]ICondition)
 	SetConditionResistances(map[string]int)
@@ -110,9 +113,10 @@ type IUnit interface {
 // representing a unique entity with its own attributes, abilities, and role in
 // the game world.
 type Unit struct {
-	abilities            IAbilities   // unit abilities.
-	activables           []IActivable // unit activables in a turn.
-	attacks              IAttacks     // unit type of attacks
+	abilities            IAbilities           // unit abilities.
+	activables           []IActivable         // unit activables in a turn.
+	attacks              IAttacks             // unit type of attacks
+	battleLog            *battlelog.BattleLog // unit battle log
 	class                IClass
 	conditions           []ICondition   // unit conditions or status effects
 	conditionResistances map[string]int // unit condition resistances
@@ -137,6 +141,7 @@ func NewUnit(name string) *Unit {
 	return &Unit{
 		abilities:            NewAbilities(),
 		attacks:              NewAttacks(nil),
+		battleLog:            battlelog.BLog,
 		class:                nil,
 		conditions:           nil,
 		conditionResistances: make(map[string]int),
@@ -320,6 +325,12 @@ func (u *Unit) GetArmorClass() int {
 	return result
 }
 
+// GetBattleLog method returns the battle log where all unit battle entries
+// are pushed.
+func (u *Unit) GetBattleLog() *battlelog.BattleLog {
+	return u.battleLog
+}
+
 // GetClass method returns the unit class.
 func (u *Unit) GetClass() IClass {
 	return u.class
@@ -373,7 +384,7 @@ func (u *Unit) GetDieRoll() int {
 		weaponStrModifier = tools.NilToInt(u.GetGear().GetRollBonusForAction(constants.SavingThrowRollStrength))
 	}
 	result := die20 + strModifier + weaponStrModifier
-	battlelog.BLog.PushDebug(fmt.Sprintf("[%s] die-roll %d+%d+%d", u.GetUName(), die20, strModifier, weaponStrModifier))
+	u.battleLog.PushDebug(fmt.Sprintf("[%s] die-roll %d+%d+%d", u.GetUName(), die20, strModifier, weaponStrModifier))
 	tools.Logger.WithField("module", "unit").
 		WithField("method", "GetDieRoll").
 		Debugf("[%s] die-roll %d+%d+%d", u.GetUName(), die20, strModifier, weaponStrModifier)
@@ -632,7 +643,8 @@ func (u *Unit) RollDamage(index int, other IUnit) (bool, int) {
 	otherHp -= damage
 	other.GetHitPoints().SetScore(otherHp)
 	//battlelog.BLog.PushInfo(fmt.Sprintf("[%s] %s roll:%dvs%d🛡️%d⚔%d⚁", u.GetUName(), attack.GetName(), dieRoll, ac, damage, stDamage))
-	battlelog.BLog.PushInfo(fmt.Sprintf("[%s]\t%s\t%d⚔%d⚁", u.GetUName(), attackIcon, damage, stDamage))
+	u.battleLog.PushInfof(battlelog.AttackHitMsg, i18n.Params{
+		"unit": u.GetUName(), "icon": attackIcon, "damage": damage, "strength": stDamage})
 	return true, damage
 }
 
@@ -657,10 +669,12 @@ func (u *Unit) RollDieRoll(index int, other IUnit) bool {
 	// if die-roll is greater than the other unit armor class, it is a hit.
 	if dieRoll < ac {
 		//battlelog.BLog.PushInfo(fmt.Sprintf("[%s] miss!\troll:%dvs%d🛡️", u.GetUName(), dieRoll, ac))
-		battlelog.BLog.PushInfo(fmt.Sprintf("[%s]\t❌\troll:%dvs%d🛡️", u.GetUName(), dieRoll, ac))
+		u.battleLog.PushInfof(battlelog.AttackMissMsg, i18n.Params{
+			"unit": u.GetUName(), "roll": dieRoll, "ac": ac})
 		return false
 	}
-	battlelog.BLog.PushInfo(fmt.Sprintf("[%s]\troll:%dvs%d🛡️", u.GetUName(), dieRoll, ac))
+	u.battleLog.PushInfof(battlelog.DieRollMsg, i18n.Params{
+		"unit": u.GetUName(), "roll": dieRoll, "ac": ac})
 	return true
 }
 
@@ -674,6 +688,12 @@ func (u *Unit) SetAttacks(attacks IAttacks) {
 	u.attacks = attacks
 }
 
+// SetBattleLog method sets the battle log where all unit battle entries are
+// pushed, so every game can have its own battle log.
+func (u *Unit) SetBattleLog(battleLog *battlelog.BattleLog) {
+	u.battleLog = battleLog
+}
+
 // SetClass method sets a new class to the unit.
 func (u *Unit) SetClass(class IClass) {
 	u.class = class
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jrecuero/thengine/app/game/dad/rules"
+	"github.com/jrecuero/thengine/pkg/devtools"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+const (
+	DatabaseTreeName = "devtools/console/database"
+)
+
+// newDatabaseSectionNode function creates the tree node for the given
+// database section, where sections and entries are loaded when the node is
+// expanded.
+func newDatabaseSectionNode(section *rules.DatabaseSection) *widgets.TreeNode {
+	return widgets.NewTreeNode(section.GetName(), "section", section).
+		WithLoader(func(node *widgets.TreeNode) []*widgets.TreeNode {
+			return newDatabaseNodes(section.GetSections(), section.GetEntries())
+		})
+}
+
+// newDatabaseNodes function creates tree nodes for all given sections and
+// entries sorted by name.
+func newDatabaseNodes(sections map[string]*rules.DatabaseSection, entries map[string]*rules.DatabaseEntry) []*widgets.TreeNode {
+	nodes := []*widgets.TreeNode{}
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		nodes = append(nodes, newDatabaseSectionNode(sections[name]))
+	}
+	names = make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		nodes = append(nodes, widgets.NewTreeNode(name, "entry", entries[name]))
+	}
+	return nodes
+}
+
+// dbCommand function opens or closes a tree view in the developer console
+// to browse all database sections and entries.
+func dbCommand(console *devtools.Console, args []string) (string, error) {
+	scene := console.GetScene()
+	if tree := scene.GetEntityByName(DatabaseTreeName); tree != nil {
+		scene.RemoveEntity(tree)
+		return "database closed", nil
+	}
+	inspector := scene.GetEntityByName("devtools/console/inspector")
+	if inspector == nil {
+		return "", fmt.Errorf("console inspector not found")
+	}
+	tree := widgets.NewTreeView(DatabaseTreeName, inspector.GetPosition(), inspector.GetSize(),
+		inspector.GetStyle(), newDatabaseNodes(rules.DBase.GetSections(), nil))
+	tree.SetIcon("section", '▤')
+	tree.SetZLevel(2)
+	tree.SetWidgetCallback(func(entity engine.IEntity, args ...any) bool {
+		if node := tree.GetSelectedNode(); node != nil {
+			if entry, ok := node.GetPayload().(*rules.DatabaseEntry); ok {
+				console.Print(fmt.Sprintf("%s %T", entry.GetName(), entry.GetCreator()))
+			}
+		}
+		return true
+	})
+	if err := scene.AddEntity(tree); err != nil {
+		return "", err
+	}
+	return "database opened", nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jrecuero/thengine/app/game/dad/rules"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/devtools"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/i18n"
+)
+
+// hpCommand function sets hit points for the unit selected in the developer
+// console.
+func hpCommand(console *devtools.Console, args []string) (string, error) {
+	if len(args) < 1 {
+		return "", fmt.Errorf("usage: hp <score> [max]")
+	}
+	entity, _ := console.GetSelected()
+	unit, ok := entity.(rules.IUnit)
+	if !ok {
+		return "", fmt.Errorf("no unit selected")
+	}
+	hitPoints := unit.GetHitPoints()
+	if len(args) > 1 {
+		maxScore, err := strconv.Atoi(args[1])
+		if err != nil {
+			return "", fmt.Errorf("invalid number %s", args[1])
+		}
+		hitPoints.SetMaxScore(maxScore)
+	}
+	score, err := strconv.Atoi(args[0])
+	if err != nil {
+		return "", fmt.Errorf("invalid number %s", args[0])
+	}
+	hitPoints.SetScore(score)
+	return fmt.Sprintf("%s hp %d/%d", entity.GetName(), hitPoints.GetScore(), hitPoints.GetMaxScore()), nil
+}
+
+// localeCommand function sets the locale for all user-facing text.
+func localeCommand(console *devtools.Console, args []string) (string, error) {
+	if len(args) < 1 {
+		return fmt.Sprintf("%s %v", i18n.GetLocale(), i18n.GetCatalog().GetLocales()), nil
+	}
+	i18n.SetLocale(args[0])
+	return i18n.Tf("locale.changed", i18n.Params{"locale": args[0]}), nil
+}
+
+// quitCommand function asks the game for a clean quit, so it is saved before
+// it exits.
+func quitCommand(console *devtools.Console, args []string) (string, error) {
+	console.GetEngine().Quit()
+	return "quit", nil
+}
+
+// newDevConsole function creates the developer console for the game in the
+// given engine, opened with the console hot key.
+func newDevConsole(e *engine.Engine) *devtools.Console {
+	console := devtools.NewConsoleForEngine(e, api.NewPoint(0, 18), api.NewSize(100, 12))
+	console.RegisterCommand(&devtools.Command{
+		Name:    "db",
+		Usage:   "db",
+		Help:    "open or close the database browser",
+		Handler: dbCommand,
+	})
+	console.RegisterCommand(&devtools.Command{
+		Name:    "hp",
+		Usage:   "hp <score> [max]",
+		Help:    "set hit points for the selected unit",
+		Handler: hpCommand,
+	})
+	console.RegisterCommand(&devtools.Command{
+		Name:    "locale",
+		Usage:   "locale [name]",
+		Help:    "display or set the locale",
+		Handler: localeCommand,
+	})
+	console.RegisterCommand(&devtools.Command{
+		Name:    "quit",
+		Usage:   "quit",
+		Help:    "quit the game, saving it if a save file was given",
+		Handler: quitCommand,
+	})
+	console.BindHotKey(devtools.ConsoleHotKey)
+	return console
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/app/game/dad/gear/weapons"
 	"github.com/jrecuero/thengine/app/game/dad/rules"
@@ -20,6 +22,7 @@ func NewEmptyEnemy() *Enemy {
 		Widget: widgets.NewEmptyWidget(),
 		Unit:   rules.NewUnit("enemy"),
 	}
+	enemy.AddTag(EnemyTag)
 	return enemy
 }
 
@@ -31,10 +34,33 @@ func NewEnemy(name string, position *api.Point, style *tcell.Style) *Enemy {
 	cell := engine.NewCell(enemy.GetStyle(), 'E')
 	//cell := engine.NewCell(enemy.GetStyle(), '👺')
 	enemy.GetCanvas().SetCellAt(nil, cell)
+	enemy.AddTag(EnemyTag)
 	enemy.populate(nil)
 	return enemy
 }
 
+// NewEnemyFromPrefab function creates a new Enemy instance from the given
+// prefab with the given name, position and style. A nil style uses the prefab
+// style.
+func NewEnemyFromPrefab(prefab string, name string, position *api.Point, style *tcell.Style) (*Enemy, error) {
+	overrides := map[string]any{
+		"name":     name,
+		"position": []int{position.X, position.Y},
+	}
+	entities, err := engine.GetPrefabManager().Instantiate(prefab, overrides, nil, &BuiltIn{})
+	if err != nil {
+		return nil, err
+	}
+	enemy, ok := entities[0].(*Enemy)
+	if !ok {
+		return nil, fmt.Errorf("prefab %s is not an enemy", prefab)
+	}
+	if style != nil {
+		enemy.SetStyle(style)
+	}
+	return enemy, nil
+}
+
 func (e *Enemy) populate(content map[string]any) {
 	tools.Logger.WithField("module", "enemy").
 		WithField("method", "populate").
@@ -50,7 +76,11 @@ func (e *Enemy) populate(content map[string]any) {
 		"charisma":     10,
 	}
 	e.Populate(defaults, content)
-	e.GetLevel().SetToGive(600)
+	experience := 600
+	if value, ok := content["experience"].(float64); ok {
+		experience = int(value)
+	}
+	e.GetLevel().SetToGive(experience)
 	if _, ok := content["gear"]; ok {
 		e.GetGear().UnmarshalMap(content)
 	} else {
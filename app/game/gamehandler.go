@@ -12,10 +12,6 @@ import (
 	"github.com/jrecuero/thengine/pkg/widgets"
 )
 
-var (
-	theGameHandler *GameHandler
-)
-
 // -----------------------------------------------------------------------------
 // Private private types
 // -----------------------------------------------------------------------------
@@ -89,26 +85,6 @@ func displayEnemyHealthBar(scene engine.IScene, ent engine.IEntity) {
 	enemyHealthBar.SetCompleted(enemy.GetHitPoints().GetScore())
 }
 
-func getEnemiesInScene(scene engine.IScene) []engine.IEntity {
-	var result []engine.IEntity
-	for _, entity := range scene.GetEntities() {
-		if _, ok := entity.(*Enemy); ok {
-			result = append(result, entity)
-		}
-	}
-	return result
-}
-
-func getTrapsInScene(scene engine.IScene) []assets.ITrap {
-	var result []assets.ITrap
-	for _, entity := range scene.GetEntities() {
-		if trap, ok := entity.(*assets.Trap); ok {
-			result = append(result, trap)
-		}
-	}
-	return result
-}
-
 func hideEnemyHealthBar(scene engine.IScene) {
 	tmpText := scene.GetEntityByName(EnemyNameTextName)
 	enemyText, _ := tmpText.(*widgets.Text)
@@ -136,14 +112,6 @@ func isAnyTrapAdjacent(player engine.IEntity, traps []assets.ITrap) assets.ITrap
 	return nil
 }
 
-func readFromBattleLog(scene engine.IScene) {
-	for battlelog.BLog.IsAny() {
-		if str := battlelog.BLog.PopInfo(); str != "" {
-			writeToCommandLine(scene, fmt.Sprintf("\n> %s", str))
-		}
-	}
-}
-
 func updateDataBox(scene engine.IScene, player *Player) {
 	if tmp := scene.GetEntityByName(PlayerNameTextName); tmp != nil {
 		if playerNameText, ok := tmp.(*widgets.Text); ok {
@@ -191,28 +159,35 @@ func writeToCommandLine(scene engine.IScene, str string) {
 //
 // -----------------------------------------------------------------------------
 
+// GameHandler structure handles every game turn. Every game has its own
+// handler, state machine and battle log, so several games can run in the
+// same process.
 type GameHandler struct {
-	*engine.Entity
+	*widgets.Widget
 	player             *Player
 	enemy              *Enemy
 	playerActionOption int
+	stateMachine       *StateMachine
+	battleLog          *battlelog.BattleLog
 }
 
-func NewGameHandler() *GameHandler {
-	if theGameHandler == nil {
-		tools.Logger.WithField("module", "gamehandler").
-			WithField("function", "NewGameHandler").
-			Debugf("handler/game/1")
-		theGameHandler = &GameHandler{
-			Entity:             engine.NewHandler("handler/game/1"),
-			player:             nil,
-			enemy:              nil,
-			playerActionOption: -1,
-		}
-		theGameHandler.SetFocusType(engine.SingleFocus)
-		theGameHandler.SetFocusEnable(true)
+func NewGameHandler(battleLog *battlelog.BattleLog) *GameHandler {
+	tools.Logger.WithField("module", "gamehandler").
+		WithField("function", "NewGameHandler").
+		Debugf("handler/game/1")
+	gameHandler := &GameHandler{
+		Widget:             widgets.NewNamedWidget("handler/game/1"),
+		player:             nil,
+		enemy:              nil,
+		playerActionOption: -1,
+		stateMachine:       NewStateMachine("state-machine/1"),
+		battleLog:          battleLog,
 	}
-	return theGameHandler
+	gameHandler.SetFocusType(engine.SingleFocus)
+	gameHandler.SetFocusEnable(true)
+	// arrow keys move the player.
+	gameHandler.SetHandlesArrows(true)
+	return gameHandler
 }
 
 // -----------------------------------------------------------------------------
@@ -231,6 +206,16 @@ func (h *GameHandler) playerSelection(entity engine.IEntity, args ...any) bool {
 	return true
 }
 
+// readFromBattleLog method writes all battle log info entries to the command
+// line.
+func (h *GameHandler) readFromBattleLog(scene engine.IScene) {
+	for h.battleLog.IsAny() {
+		if str := h.battleLog.PopInfo(); str != "" {
+			writeToCommandLine(scene, fmt.Sprintf("\n> %s", str))
+		}
+	}
+}
+
 // -----------------------------------------------------------------------------
 // GameHandler public methods
 // -----------------------------------------------------------------------------
@@ -245,12 +230,12 @@ func (h *GameHandler) EnemyAttack(scene engine.IScene) {
 		tools.Logger.WithField("module", "gamehandler").
 			WithField("method", "Update").
 			Debugf("Enemy %s to %s", h.enemy.GetName(), h.player.GetName())
-		readFromBattleLog(scene)
+		h.readFromBattleLog(scene)
 	}
 }
 
 func (h *GameHandler) PlayerAttack(scene engine.IScene, attack *attackInfo) {
-	enemies := getEnemiesInScene(scene)
+	enemies := scene.GetEntitiesWithTag(EnemyTag)
 	if enemy := isAnyEnemyAdjacent(h.player, enemies); enemy != nil {
 		if e, ok := enemy.(*Enemy); ok {
 			h.enemy = e
@@ -258,7 +243,7 @@ func (h *GameHandler) PlayerAttack(scene engine.IScene, attack *attackInfo) {
 			if hit := h.player.RollDieRoll(attack.index, e); hit {
 				h.player.RollDamage(attack.index, e)
 			}
-			readFromBattleLog(scene)
+			h.readFromBattleLog(scene)
 			// if enemy has equal or lower than 0 hit points, remove it from
 			// the scene.
 			if e.GetHitPoints().GetScore() <= 0 {
@@ -338,7 +323,7 @@ func (h *GameHandler) PlayerMove(scene engine.IScene, playerNewPosition *api.Poi
 	}
 
 	// check for any trap that is adjacent to the final player position.
-	traps := getTrapsInScene(scene)
+	traps := engine.FindEntities[assets.ITrap](scene, nil)
 	if t := isAnyTrapAdjacent(h.player, traps); t != nil {
 		if trap, ok := t.(*assets.Trap); ok {
 			if pass := trap.CanDetect(h.player); pass {
@@ -385,7 +370,7 @@ func (h *GameHandler) RunStartTurn(scene engine.IScene, input *inputAction) {
 	tools.Logger.WithField("module", "gamehandler").
 		WithField("method", "StartTurn").
 		Debugf("START TURN %+v", input)
-	enemies := getEnemiesInScene(scene)
+	enemies := scene.GetEntitiesWithTag(EnemyTag)
 	// TODO: initiative should be checked for player and enemies in order to
 	// define who should be take action first and in which order.
 	if input != nil {
@@ -406,7 +391,7 @@ func (h *GameHandler) RunStartTurn(scene engine.IScene, input *inputAction) {
 				options.SetZLevel(1)
 				options.SetWidgetCallback(h.playerSelection, scene)
 				scene.AddEntity(options)
-				theEngine.GetSceneManager().UpdateFocus()
+				scene.GetEngine().GetSceneManager().UpdateFocus()
 				return
 			}
 		}
@@ -415,8 +400,8 @@ func (h *GameHandler) RunStartTurn(scene engine.IScene, input *inputAction) {
 }
 
 func (h *GameHandler) RunStateMachineTurn(scene engine.IScene, input *inputAction) {
-	TheStateMachine.Next()
-	state := TheStateMachine.GetState()
+	h.stateMachine.Next()
+	state := h.stateMachine.GetState()
 	switch state {
 	case WaitingSM:
 		// do nothing
@@ -457,7 +442,7 @@ func (h *GameHandler) Update(event tcell.Event, scene engine.IScene) {
 	if !ok {
 		return
 	}
-	if !TheStateMachine.IsWaiting() {
+	if !h.stateMachine.IsWaiting() {
 		return
 	}
 	h.player = player
@@ -491,7 +476,7 @@ func (h *GameHandler) Update(event tcell.Event, scene engine.IScene) {
 		h.RunStateMachineTurn(scene, input)
 	}
 
-	enemies := getEnemiesInScene(scene)
+	enemies := scene.GetEntitiesWithTag(EnemyTag)
 	if enemy := isAnyEnemyAdjacent(player, enemies); enemy != nil {
 		displayEnemyHealthBar(scene, enemy)
 	} else {
@@ -1,16 +1,19 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/app/game/assets"
-	dad_constants "github.com/jrecuero/thengine/app/game/dad/constants"
-	"github.com/jrecuero/thengine/app/game/dad/rules"
+	"github.com/jrecuero/thengine/app/game/dad/battlelog"
 	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/builder"
 	"github.com/jrecuero/thengine/pkg/constants"
 	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/server"
 	"github.com/jrecuero/thengine/pkg/tools"
 	"github.com/jrecuero/thengine/pkg/widgets"
 )
@@ -20,14 +23,21 @@ import (
 // -----------------------------------------------------------------------------
 
 var (
-	theCamera              = engine.NewCamera(api.NewPoint(0, 0), api.NewSize(90, 30))
-	theEngine              = engine.GetEngine()
 	theStyleBlueOverBlack  = tcell.StyleDefault.Foreground(tcell.ColorBlue).Background(tcell.ColorBlack)
 	theStyleWhiteOverRed   = tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorRed)
 	theStyleGreenOverBlack = tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorBlack)
 	theStyleRedOverBlack   = tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlack)
 	theFPS                 = 60.0
 	thePlayerName          = "player/hero/1"
+	theServeAddress        = flag.String("serve", "", "serve the game over TCP at the given address")
+	theLogLevel            = flag.String("log-level", "trace", "log level: trace, debug, info, warn or error")
+	theLogOutputs          = flag.String("log-output", "file,ring", "comma separated log outputs: file, stderr, ring or none")
+	theLogFilename         = flag.String("log-file", tools.DefaultLogFilename, "log file name")
+	theLogMaxSize          = flag.Int64("log-max-size", 0, "log file size in bytes to rotate it, 0 never rotates it")
+	theLogMaxBackups       = flag.Int("log-max-backups", 3, "number of rotated log files to keep")
+	theLogModules          = flag.String("log-modules", "", "log level for every module: module=level,...")
+	theSeed                = flag.Int64("seed", 0, "random seed, 0 seeds from the clock")
+	theSaveFilename        = flag.String("save", "", "file the game is saved to when it quits")
 )
 
 // -----------------------------------------------------------------------------
@@ -41,7 +51,6 @@ var (
 	TheDataBoxSize          = api.NewSize(20, 20)
 	TheCommandLineBoxOrigin = api.NewPoint(0, 20)
 	TheCommandLineBoxSize   = api.NewSize(100, 10)
-	TheEnemies              = []*Enemy{}
 )
 
 // -----------------------------------------------------------------------------
@@ -63,7 +72,9 @@ const (
 	EnemyHealthBarName       = "health-bar/enemy/live/1"
 	PlayerPosTextName        = "text/player-position/1"
 	InventoryTextName        = "text/inventory/1"
+	StatsBoxName             = "box/stats/1"
 	DoorEventName            = "entity/event/door/1"
+	EnemyTag                 = "enemy"
 )
 
 // -----------------------------------------------------------------------------
@@ -82,8 +93,8 @@ type BuiltIn struct {
 // Module public methods
 // -----------------------------------------------------------------------------
 
-func GenerateEnemyName() string {
-	enemiesLen := len(TheEnemies) + 1
+func GenerateEnemyName(enemies []*Enemy) string {
+	enemiesLen := len(enemies) + 1
 	result := fmt.Sprintf("widget/enemy/%d", enemiesLen)
 	return result
 }
@@ -98,6 +109,8 @@ func (b *BuiltIn) GetClassFromString(className string) engine.IEntity {
 			WithField("method", "GetClassFromString").
 			Infof("Created a new empty enemy")
 		return NewEmptyEnemy()
+	case "Trap":
+		return assets.NewEmptyTrap()
 	default:
 		return engine.NewEmptyEntity()
 	}
@@ -161,6 +174,9 @@ func buildBoxesAndWalls(scene engine.IScene) {
 	comandLineBox := engine.NewEntity(CommandLineBoxEntityName, TheCommandLineBoxOrigin,
 		TheCommandLineBoxSize, &theStyleBlueOverBlack)
 	comandLineBox.GetCanvas().WriteRectangleInCanvasAt(nil, nil, &theStyleBlueOverBlack, engine.CanvasRectSingleLine)
+	// the command line box and the command line, where the battle log is
+	// displayed, stay at the bottom of the screen.
+	comandLineBox.SetLayout(engine.NewLayout(engine.LayoutAnchorBottomLeft, nil))
 	scene.AddEntity(comandLineBox)
 
 	topWall := NewWall("widget/wall/top/1", api.NewPoint(0, 0), api.NewSize(80, 1), nil)
@@ -183,110 +199,117 @@ func buildBoxesAndWalls(scene engine.IScene) {
 	//    api.NewSize(98, 8), &theStyleBlueOverBlack, ">")
 	commandLine := NewCommandLine(CommandLineTextName, api.NewPoint(1, 21),
 		api.NewSize(98, 8), &theStyleBlueOverBlack)
+	commandLine.SetLayout(engine.NewLayout(engine.LayoutAnchorBottomLeft, api.NewPoint(1, 1)))
 	scene.AddEntity(commandLine)
 }
 
 func buildUI(scene engine.IScene, player *Player, enemy *Enemy) {
+	// player and enemy stats are arranged in a column at the right of the
+	// game box.
+	statsBox := widgets.NewVBox(StatsBoxName, api.NewPoint(81, 1), nil, 0)
 
 	hpText := fmt.Sprintf("HP:  %d", player.GetHitPoints().GetScore())
-	playerLiveText := widgets.NewText(PlayerLiveTextName, api.NewPoint(81, 1),
+	playerLiveText := widgets.NewText(PlayerLiveTextName, nil,
 		api.NewSize(10, 1), &theStyleBlueOverBlack, hpText)
-	scene.AddEntity(playerLiveText)
+	statsBox.Add(playerLiveText)
 
 	strText := fmt.Sprintf("STR: %d", player.GetAbilities().GetStrength().GetScore())
-	playerStrengthText := widgets.NewText(PlayerStrengthTextName, api.NewPoint(81, 2),
+	playerStrengthText := widgets.NewText(PlayerStrengthTextName, nil,
 		api.NewSize(10, 1), &theStyleBlueOverBlack, strText)
-	scene.AddEntity(playerStrengthText)
+	statsBox.Add(playerStrengthText)
 
 	dexText := fmt.Sprintf("DEX: %d", player.GetAbilities().GetDexterity().GetScore())
-	playerDexterityText := widgets.NewText(PlayerDexterityTextName, api.NewPoint(81, 3),
+	playerDexterityText := widgets.NewText(PlayerDexterityTextName, nil,
 		api.NewSize(10, 1), &theStyleBlueOverBlack, dexText)
-	scene.AddEntity(playerDexterityText)
+	statsBox.Add(playerDexterityText)
 
 	acText := fmt.Sprintf("AC:  %d", player.GetArmorClass())
-	playerACText := widgets.NewText(PlayerACTextName, api.NewPoint(81, 4),
+	playerACText := widgets.NewText(PlayerACTextName, nil,
 		api.NewSize(10, 1), &theStyleBlueOverBlack, acText)
-	scene.AddEntity(playerACText)
+	statsBox.Add(playerACText)
+	statsBox.AddSpace(4)
 
 	playerLevel := player.GetLevel()
 	playerStr := fmt.Sprintf("%s L%d [%d]", player.GetUName(), playerLevel.GetScore(), playerLevel.GetExperience())
-	playerNameText := widgets.NewText(PlayerNameTextName, api.NewPoint(81, 9),
+	playerNameText := widgets.NewText(PlayerNameTextName, nil,
 		api.NewSize(18, 1), &theStyleBlueOverBlack, playerStr)
-	scene.AddEntity(playerNameText)
+	statsBox.Add(playerNameText)
 
-	playerHealthBar := NewHealthBar(PlayerHealthBar, api.NewPoint(81, 10),
+	playerHealthBar := NewHealthBar(PlayerHealthBar, api.NewPoint(0, 0),
 		api.NewSize(18, 1), player.GetHitPoints().GetScore())
 	playerHealthBar.SetCompleted(player.GetHitPoints().GetScore())
-	scene.AddEntity(playerHealthBar)
+	statsBox.Add(playerHealthBar)
 
 	enemyText := fmt.Sprintf("%s\t[AC:%d]", enemy.GetUName(), enemy.GetArmorClass())
-	enemyNameText := widgets.NewText(EnemyNameTextName, api.NewPoint(81, 11),
+	enemyNameText := widgets.NewText(EnemyNameTextName, nil,
 		api.NewSize(18, 1), &theStyleBlueOverBlack, enemyText)
 	enemyNameText.SetVisible(false)
-	scene.AddEntity(enemyNameText)
+	statsBox.Add(enemyNameText)
 
-	enemyHealthBar := NewHealthBar(EnemyHealthBarName, api.NewPoint(81, 12),
+	enemyHealthBar := NewHealthBar(EnemyHealthBarName, api.NewPoint(0, 0),
 		api.NewSize(18, 1), enemy.GetHitPoints().GetScore())
 	enemyHealthBar.SetCompleted(enemy.GetHitPoints().GetScore())
 	enemyHealthBar.SetVisible(false)
-	scene.AddEntity(enemyHealthBar)
-
-	playerPosText := widgets.NewText(PlayerPosTextName, api.NewPoint(70, 19),
-		api.NewSize(10, 1), &constants.WhiteOverBlack, "[2,2]")
-	playerPosText.SetZLevel(1)
-	scene.AddEntity(playerPosText)
+	statsBox.Add(enemyHealthBar)
+	statsBox.AddSpace(1)
 
 	inventoryStr := "Inventory\n"
 	inventory := player.GetInventory()
 	for _, consumable := range inventory.GetConsumables() {
 		inventoryStr += consumable.GetUName() + "\n"
 	}
-	inventoryText := widgets.NewText(InventoryTextName, api.NewPoint(81, 14),
+	inventoryText := widgets.NewText(InventoryTextName, nil,
 		api.NewSize(10, 5), &constants.WhiteOverBlack, inventoryStr)
+	statsBox.Add(inventoryText)
 
-	scene.AddEntity(inventoryText)
-}
+	scene.AddEntity(statsBox)
 
-func newTrap(name string, pos *api.Point, style *tcell.Style) *assets.Trap {
-	detectDC, disarmDC := 8, 10
-	trap := assets.NewTrap(name, pos, api.NewSize(1, 1), style,
-		detectDC, disarmDC, rules.DiceThrow1d4, dad_constants.Poison)
-	//trapDamage := &rules.SavingThrowDamage{
-	//    SavingThrow: rules.NewSavingThrow(dad_constants.Perception, trapDC),
-	//    Damage:      rules.NewDamage(rules.DiceThrow1d3, dad_constants.Poison),
-	//}
-	//trap.Damage = rules.NewNoDamage()
-	//trap.Damage.SetSavingThrows([]*rules.SavingThrowDamage{trapDamage})
-	trap.GetCanvas().SetCellAt(nil, engine.NewCell(&constants.RedOverBlack, '*'))
-	return trap
+	playerPosText := widgets.NewText(PlayerPosTextName, api.NewPoint(70, 19),
+		api.NewSize(10, 1), &constants.WhiteOverBlack, "[2,2]")
+	playerPosText.SetZLevel(1)
+	scene.AddEntity(playerPosText)
 }
 
-func main() {
-	tools.Logger.WithField("module", "main").Infof("The Game")
-	mainScene := engine.NewScene("scene/main/1", theCamera)
+// newGame function creates all scenes for a new game in the given engine.
+// Every game has its own camera, battle log and game handler, so several
+// games can run in the same process. It returns the player.
+func newGame(e *engine.Engine) *Player {
+	camera := engine.NewCamera(api.NewPoint(0, 0), api.NewSize(90, 30))
+	// the camera is resized with the screen.
+	camera.SetLayout(engine.NewLayout(engine.LayoutAnchorTopLeft, nil).
+		WithSize(engine.LayoutPercent(100), engine.LayoutPercent(100)))
+	mainScene := e.NewScene("scene/main/1", camera)
+	// sprites for the main scene are preloaded when the scene is activated.
+	e.SetAssetManager(assets.NewAssetManager(mainScene.GetName()))
+	// arrow keys move the focus between dialog buttons, like in the door
+	// dialog. The game handler keeps using them to move the player.
+	e.GetFocusManager().SetArrowNavigation(true)
+	battleLog := battlelog.NewBattleLog()
 
 	buildBoxesAndWalls(mainScene)
 
 	player := NewPlayer(thePlayerName, api.NewPoint(2, 2), &theStyleGreenOverBlack)
+	player.SetBattleLog(battleLog)
 	mainScene.AddEntity(player)
 
-	//entities := engine.ImportEntitiesFromJSON("app/game/assets/first_map.json",
-	//    api.NewPoint(1, 1), &BuiltIn{})
-	//for _, ent := range entities {
-	//    mainScene.AddEntity(ent)
-	//}
-
-	enemy1 := NewEnemy(GenerateEnemyName(), api.NewPoint(5, 5), &theStyleWhiteOverRed)
-	mainScene.AddEntity(enemy1)
-	TheEnemies = append(TheEnemies, enemy1)
-
-	enemy2 := NewEnemy(GenerateEnemyName(), api.NewPoint(67, 7), &constants.AquaOverWhite)
-	mainScene.AddEntity(enemy2)
-	TheEnemies = append(TheEnemies, enemy2)
-
-	enemy3 := NewEnemy(GenerateEnemyName(), api.NewPoint(18, 6), &constants.RedOverBlack)
-	mainScene.AddEntity(enemy3)
-	TheEnemies = append(TheEnemies, enemy3)
+	enemies := []*Enemy{}
+	for _, spawn := range []struct {
+		position *api.Point
+		style    *tcell.Style
+	}{
+		{api.NewPoint(5, 5), &theStyleWhiteOverRed},
+		{api.NewPoint(67, 7), &constants.AquaOverWhite},
+		{api.NewPoint(18, 6), &constants.RedOverBlack},
+	} {
+		enemy, err := NewEnemyFromPrefab(assets.EnemyPrefabName, GenerateEnemyName(enemies), spawn.position, spawn.style)
+		if err != nil {
+			panic(err)
+		}
+		enemy.SetBattleLog(battleLog)
+		mainScene.AddEntity(enemy)
+		enemies = append(enemies, enemy)
+	}
+	enemy1 := enemies[0]
 
 	// Events
 	doorEvent := NewDoorEvent(DoorEventName, api.NewPoint(10, 4), api.NewSize(1, 1),
@@ -305,8 +328,11 @@ func main() {
 	//diceTwoAnimWidget.Shuffle()
 	//mainScene.AddEntity(diceTwoAnimWidget)
 
-	throne := widgets.NewSprite("sprite/throne/1", api.NewPoint(67, 6), nil)
-	throne.StringToSprite("--\\\n #|\n--/", &constants.MaroonOverBlack)
+	throne, err := widgets.NewSpriteFromAsset(e.GetAssetManager(), assets.ThroneSpriteAssetName,
+		"sprite/throne/1", api.NewPoint(67, 6))
+	if err != nil {
+		panic(err)
+	}
 	throne.SetSolid(true)
 	mainScene.AddEntity(throne)
 
@@ -335,18 +361,89 @@ func main() {
 	//trap.Damage = rules.NewNoDamage()
 	//trap.Damage.SetSavingThrows([]*rules.SavingThrowDamage{trapDamage})
 	//trap.GetCanvas().SetCellAt(nil, engine.NewCell(&constants.RedOverBlack, '*'))
-	trap := newTrap("widget/trap/1", api.NewPoint(14, 4), &constants.RedOverBlack)
-	mainScene.AddEntity(trap)
+	traps, err := engine.GetPrefabManager().Instantiate(assets.PoisonTrapPrefabName,
+		map[string]any{"name": "widget/trap/1", "position": []int{14, 4}}, nil, &BuiltIn{})
+	if err != nil {
+		panic(err)
+	}
+	for _, trap := range traps {
+		mainScene.AddEntity(trap)
+	}
 
-	gameHandler := NewGameHandler()
+	gameHandler := NewGameHandler(battleLog)
 	mainScene.AddEntity(gameHandler)
 
+	e.GetSceneManager().AddScene(mainScene)
+	e.GetSceneManager().SetSceneAsActive(mainScene)
+	e.GetSceneManager().SetSceneAsVisible(mainScene)
+	e.GetSceneManager().UpdateFocus()
+	newDevConsole(e)
+	return player
+}
+
+// configureLogger function configures the logger with command line flags.
+func configureLogger() error {
+	config := tools.NewLogConfig(*theLogLevel)
+	config.Outputs = strings.Split(*theLogOutputs, ",")
+	config.Filename = *theLogFilename
+	config.MaxSize = *theLogMaxSize
+	config.MaxBackups = *theLogMaxBackups
+	modules, err := tools.ParseLogModules(*theLogModules)
+	if err != nil {
+		return err
+	}
+	config.Modules = modules
+	return tools.ConfigureLogger(config)
+}
+
+// serve function serves a new game for every client connected to the given
+// address. Locales and prefabs are loaded before serving and they are shared
+// by all clients.
+func serve(address string) {
+	srv := server.NewServer(address, theFPS, func(session *server.Session) error {
+		newGame(session.GetEngine())
+		return nil
+	})
+	if err := srv.ListenAndServe(); err != nil {
+		tools.Logger.WithField("module", "main").Errorf("serve: %s", err.Error())
+		fmt.Println(err)
+	}
+}
+
+func main() {
+	flag.Parse()
+	if err := configureLogger(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if *theSeed != 0 {
+		tools.SeedRandom(*theSeed)
+	}
+	tools.Logger.WithField("module", "main").Infof("The Game seed %d", tools.GetRandom().GetSeed())
+	if err := assets.LoadLocales(); err != nil {
+		tools.Logger.WithField("module", "main").Errorf("load locales: %s", err.Error())
+	}
+	if err := assets.LoadPrefabs(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if *theServeAddress != "" {
+		serve(*theServeAddress)
+		return
+	}
+	theEngine := engine.GetEngine()
 	theEngine.InitResources()
-	theEngine.GetSceneManager().AddScene(mainScene)
-	theEngine.GetSceneManager().SetSceneAsActive(mainScene)
-	theEngine.GetSceneManager().SetSceneAsVisible(mainScene)
-	theEngine.GetSceneManager().UpdateFocus()
+	player := newGame(theEngine)
+	if *theSaveFilename != "" {
+		// the game is saved when it quits, after all entities are stopped.
+		theEngine.AddShutdownHook(func() error {
+			return NewGameSave(player).SaveToFile(*theSaveFilename)
+		})
+	}
 	theEngine.Init()
 	theEngine.Start()
-	theEngine.Run(theFPS)
+	if err := theEngine.Run(theFPS); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 }
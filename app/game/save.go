@@ -0,0 +1,56 @@
+// save.go contains the game state saved when the game quits.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+//
+// GameSave
+//
+// -----------------------------------------------------------------------------
+
+// GameSave structure contains the game state saved when the game quits.
+// Random contains the state for all random streams, so the game can continue
+// with the same random numbers.
+type GameSave struct {
+	PlayerName   string             `json:"player_name"`
+	Position     []int              `json:"position"`
+	HitPoints    int                `json:"hp"`
+	MaxHitPoints int                `json:"max_hp"`
+	Random       *tools.RandomState `json:"random"`
+}
+
+// NewGameSave function creates a new GameSave instance with the current
+// state for the given player.
+func NewGameSave(player *Player) *GameSave {
+	position := player.GetPosition()
+	return &GameSave{
+		PlayerName:   player.GetName(),
+		Position:     []int{position.X, position.Y},
+		HitPoints:    player.GetHitPoints().GetScore(),
+		MaxHitPoints: player.GetHitPoints().GetMaxScore(),
+		Random:       tools.GetRandom().GetState(),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// GameSave public methods
+// -----------------------------------------------------------------------------
+
+// SaveToFile method writes the game state to the given file as JSON.
+func (s *GameSave) SaveToFile(filename string) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("save %s: %s", filename, err.Error())
+	}
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		return fmt.Errorf("save %s: %s", filename, err.Error())
+	}
+	return nil
+}
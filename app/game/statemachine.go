@@ -10,10 +10,6 @@ const (
 	EndSM
 )
 
-var (
-	TheStateMachine *StateMachine = NewStateMachine("state-machine/1")
-)
-
 type StateMachine struct {
 	name  string
 	state StateMachineType
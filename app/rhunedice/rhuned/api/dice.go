@@ -9,15 +9,19 @@ import (
 type IDice interface {
 	GetFaces() []IFace
 	GetName() string
+	GetStream() string
 	Roll() IFace
+	RollFrom(*tools.RandomStream) IFace
 	SetFaces([]IFace)
 	SetName(string)
+	SetStream(string)
 	String() string
 }
 
 type Dice struct {
-	faces []IFace
-	name  string
+	faces  []IFace
+	name   string
+	stream string
 }
 
 //func NewRandom(max int) int {
@@ -31,8 +35,9 @@ type Dice struct {
 
 func NewDice(name string, faces []IFace) *Dice {
 	return &Dice{
-		faces: faces,
-		name:  name,
+		faces:  faces,
+		name:   name,
+		stream: tools.RandomStreamCombat,
 	}
 }
 
@@ -44,9 +49,19 @@ func (d *Dice) GetName() string {
 	return d.name
 }
 
+func (d *Dice) GetStream() string {
+	return d.stream
+}
+
+// Roll method returns a random face from the dice random stream.
 func (d *Dice) Roll() IFace {
+	return d.RollFrom(tools.GetRandomStream(d.stream))
+}
+
+// RollFrom method returns a random face from the given random stream.
+func (d *Dice) RollFrom(stream *tools.RandomStream) IFace {
 	nbrFaces := len(d.faces)
-	index := tools.RandomRing.Intn(nbrFaces)
+	index := stream.Intn(nbrFaces)
 	//index := NewRandom(nbrFaces)
 	return d.faces[index]
 }
@@ -59,6 +74,10 @@ func (d *Dice) SetName(name string) {
 	d.name = name
 }
 
+func (d *Dice) SetStream(stream string) {
+	d.stream = stream
+}
+
 func (d *Dice) String() string {
 	return fmt.Sprintf("%s", d.name)
 }
@@ -57,6 +57,7 @@ func updateColor(ent engine.IEntity, args ...any) bool {
 
 	attrsInput := widgets.NewTextInput("text-input/color/attrs/1",
 		api.NewPoint(32, 9), api.NewSize(10, 1), &TheStyleBoldBlackOverGreen, strconv.Itoa(int(cursorAttrs)))
+	attrsInput.SetValidator(engine.NewIntegerValidator("validator/color/attrs/1", &TheStyleWhiteOverRed))
 	colorScene.AddEntity(attrsInput)
 
 	input := &colorinput{
@@ -98,7 +99,14 @@ func acceptColor(entity engine.IEntity, args ...any) bool {
 	fg := input.Fg.GetInputText()
 	bg := input.Bg.GetInputText()
 	tmp := input.Attrs.GetInputText()
-	attrs, _ := strconv.Atoi(tmp)
+	attrs := 0
+	if tmp != "" {
+		var err error
+		if attrs, err = strconv.Atoi(tmp); err != nil {
+			// the color scene is kept open, so attributes can be fixed.
+			return false
+		}
+	}
 	style := tcell.StyleDefault.
 		Foreground(tcell.GetColor(fg)).
 		Background(tcell.GetColor(bg)).
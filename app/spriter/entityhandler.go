@@ -47,33 +47,57 @@ func NewEntityHandler(drawingScene engine.IScene, cursor *Cursor, response func(
 	return handler
 }
 
-func (h *EntityHandler) processEntityTextInput(entityTextInput *EntityTextInput) engine.IEntity {
+// parsePair function returns both integers in the given string with two
+// numbers separated by a comma.
+func parsePair(str string) (int, int, error) {
+	pair := strings.Split(str, ",")
+	if len(pair) != 2 {
+		return 0, 0, fmt.Errorf("%s is not a pair of numbers", str)
+	}
+	first, err := strconv.Atoi(pair[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	second, err := strconv.Atoi(pair[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return first, second, nil
+}
+
+func (h *EntityHandler) processEntityTextInput(entityTextInput *EntityTextInput) (engine.IEntity, error) {
 	result := engine.NewEmptyEntity()
 	result.SetClassName(entityTextInput.ClassName.GetInputText())
 	result.SetName(entityTextInput.Name.GetInputText())
 
 	// Process position.
-	posTmp := entityTextInput.Position.GetInputText()
-	posSlice := strings.Split(posTmp, ",")
-	x, _ := strconv.Atoi(posSlice[0])
-	y, _ := strconv.Atoi(posSlice[1])
+	x, y, err := parsePair(entityTextInput.Position.GetInputText())
+	if err != nil {
+		return nil, err
+	}
 	position := api.NewPoint(x, y)
 	result.SetPosition(position)
 
 	// Process size.
-	sizeTmp := entityTextInput.Size.GetInputText()
-	sizeSlice := strings.Split(sizeTmp, ",")
-	width, _ := strconv.Atoi(sizeSlice[0])
-	height, _ := strconv.Atoi(sizeSlice[1])
+	width, height, err := parsePair(entityTextInput.Size.GetInputText())
+	if err != nil {
+		return nil, err
+	}
 	size := api.NewSize(width, height)
 	result.SetSize(size)
 
 	// Process style
 	styleTmp := entityTextInput.Style.GetInputText()
 	styleSlice := strings.Split(styleTmp, ",")
+	if len(styleSlice) != 3 {
+		return nil, fmt.Errorf("%s is not a fg,bg,attrs style", styleTmp)
+	}
 	fg := styleSlice[0]
 	bg := styleSlice[1]
-	attrs, _ := strconv.Atoi(styleSlice[2])
+	attrs, err := strconv.Atoi(styleSlice[2])
+	if err != nil {
+		return nil, err
+	}
 	style := tcell.StyleDefault.
 		Foreground(tcell.GetColor(fg)).
 		Background(tcell.GetColor(bg)).
@@ -82,6 +106,9 @@ func (h *EntityHandler) processEntityTextInput(entityTextInput *EntityTextInput)
 	// Process cell
 	canvas := engine.NewCanvas(size)
 	ch := entityTextInput.Rune.GetInputText()
+	if ch == "" {
+		return nil, fmt.Errorf("rune is empty")
+	}
 	cell := engine.NewCell(&style, rune(ch[0]))
 	if size.IsOneSize() {
 		canvas.SetCellAt(nil, cell)
@@ -91,7 +118,7 @@ func (h *EntityHandler) processEntityTextInput(entityTextInput *EntityTextInput)
 	result.SetCanvas(canvas)
 	result.SetStyle(&style)
 
-	return result
+	return result, nil
 }
 
 func (h *EntityHandler) acceptCallback(entity engine.IEntity, args ...any) bool {
@@ -99,7 +126,14 @@ func (h *EntityHandler) acceptCallback(entity engine.IEntity, args ...any) bool
 	tools.Logger.WithField("module", "entityhandler").
 		WithField("method", "acceptCallback").
 		Debugf("create new entity")
-	result := h.processEntityTextInput(textInput)
+	result, err := h.processEntityTextInput(textInput)
+	if err != nil {
+		// the entity scene is kept open, so the input can be fixed.
+		tools.Logger.WithField("module", "entityhandler").
+			WithField("method", "acceptCallback").
+			Errorf("invalid entity input: %s", err.Error())
+		return false
+	}
 
 	theEngine := engine.GetEngine()
 	sceneManager := theEngine.GetSceneManager()
@@ -174,11 +208,15 @@ func (h *EntityHandler) createEntityScene() {
 		&TheStyleWhiteOverBlack, "Position  : ")
 	entityScene.AddEntity(positionText)
 
+	// position and size are entered as two numbers separated by a comma.
+	pairValidator, _ := engine.NewRegexpValidator("validator/pair/1", `^\d+,\d+$`, &TheStyleWhiteOverRed)
+
 	positionTextInput := widgets.NewTextInput(PositionTextInputName,
 		api.NewPoint(TheEntityBoxOrigin.X+13, TheEntityBoxOrigin.Y+3),
 		api.NewSize(20, 1),
 		&TheStyleBlackOverWhite,
 		fmt.Sprintf("%d,%d", h.cursor.GetPosition().X, h.cursor.GetPosition().Y))
+	positionTextInput.SetValidator(pairValidator)
 	entityScene.AddEntity(positionTextInput)
 
 	sizeText := widgets.NewText(SizeTextName,
@@ -192,6 +230,7 @@ func (h *EntityHandler) createEntityScene() {
 		api.NewSize(20, 1),
 		&TheStyleBlackOverWhite,
 		fmt.Sprintf("%d,%d", h.cursor.GetSize().W, h.cursor.GetSize().H))
+	sizeTextInput.SetValidator(pairValidator)
 	entityScene.AddEntity(sizeTextInput)
 
 	styleText := widgets.NewText(StyleTextName,
@@ -219,6 +258,7 @@ func (h *EntityHandler) createEntityScene() {
 		api.NewSize(1, 1),
 		&TheStyleBlackOverWhite,
 		fmt.Sprintf("%s", string(h.cursor.GetCanvas().GetCellAt(nil).GetRune())))
+	runeTextInput.SetMaxLength(1)
 	entityScene.AddEntity(runeTextInput)
 
 	newEntityTextInput := &EntityTextInput{
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/engine"
@@ -56,6 +59,7 @@ var (
 	TheStyleBoldBlackOverWhite     = tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite).Attributes(tcell.AttrBold)
 	TheStyleBoldBlackOverGreen     = tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorGreen).Attributes(tcell.AttrBold)
 	TheStyleBoldGreenOverBlack     = tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorBlack).Attributes(tcell.AttrBold)
+	TheStyleWhiteOverRed           = tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorRed)
 	TheDrawingBoxOrigin            = api.NewPoint(0, theMenuBoxHeight)
 	TheDrawingBoxSize              = api.NewSize(theDrawingBoxWidth, theDrawingBoxHeight)
 	TheDrawingBoxRect              = api.NewRect(TheDrawingBoxOrigin, TheDrawingBoxSize)
@@ -123,9 +127,15 @@ func createDrawingBox(scene engine.IScene) {
 }
 
 func main() {
+	if err := tools.ConfigureLogger(tools.NewLogConfig("trace")); err != nil {
+		panic(err)
+	}
 	tools.Logger.WithField("module", "main").
 		WithField("function", "main").
 		Infof("Spriter App")
+	// the camera is resized with the screen.
+	theCamera.SetLayout(engine.NewLayout(engine.LayoutAnchorTopLeft, nil).
+		WithSize(engine.LayoutPercent(100), engine.LayoutPercent(100)))
 	drawingScene := engine.NewScene(DrawingSceneName, theCamera)
 
 	createSpriteMenuItem := widgets.NewExtendedMenuItem("New SPR", false, nil, nil, nil)
@@ -153,7 +163,10 @@ func main() {
 	theEngine.GetSceneManager().UpdateFocus()
 	theEngine.Init()
 	theEngine.Start()
-	theEngine.Run(theFPS)
+	if err := theEngine.Run(theFPS); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 }
 
 func menuColor(entity engine.IEntity, args ...any) bool {
@@ -161,7 +174,7 @@ func menuColor(entity engine.IEntity, args ...any) bool {
 }
 
 func menuExit(ent engine.IEntity, args ...any) bool {
-	engine.GetEngine().End()
+	engine.GetEngine().Quit()
 	return true
 }
 
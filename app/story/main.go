@@ -1,13 +1,22 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/jrecuero/thengine/pkg/engine"
 	"github.com/jrecuero/thengine/pkg/tools"
 )
 
 func main() {
+	if err := tools.ConfigureLogger(tools.NewLogConfig("trace")); err != nil {
+		panic(err)
+	}
 	tools.Logger.WithField("module", "main").WithField("function", "main").Infof("RhuneDice launched...")
 
+	// the camera is resized with the screen.
+	theCamera.SetLayout(engine.NewLayout(engine.LayoutAnchorTopLeft, nil).
+		WithSize(engine.LayoutPercent(100), engine.LayoutPercent(100)))
 	mainScene := engine.NewScene(TheMainSceneName, theCamera)
 	storyHandler := NewStoryHandler()
 
@@ -22,5 +31,8 @@ func main() {
 	theEngine.GetSceneManager().UpdateFocus()
 	theEngine.Init()
 	theEngine.Start()
-	theEngine.Run(theFPS)
+	if err := theEngine.Run(theFPS); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 }
@@ -5,12 +5,12 @@ go 1.21.6
 require (
 	github.com/gdamore/tcell/v2 v2.7.4
 	github.com/google/uuid v1.6.0
+	github.com/lucasb-eyer/go-colorful v1.2.0
 	github.com/sirupsen/logrus v1.9.3
 )
 
 require (
 	github.com/gdamore/encoding v1.0.0 // indirect
-	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
 	github.com/mattn/go-runewidth v0.0.15 // indirect
 	github.com/rivo/uniseg v0.4.3 // indirect
 	golang.org/x/sys v0.17.0 // indirect
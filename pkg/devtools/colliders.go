@@ -0,0 +1,118 @@
+// colliders.go contains the collider outlines overlay, which draws the
+// collider for every solid entity in all visible scenes, so invisible walls and
+// sprite colliders can be checked while the application runs.
+package devtools
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	ColliderOutlinesSceneName  = "devtools/colliders"
+	ColliderOutlinesEntityName = "devtools/colliders/outlines"
+)
+
+// -----------------------------------------------------------------------------
+//
+// ColliderOutlines
+//
+// -----------------------------------------------------------------------------
+
+// ColliderOutlines structure defines the entity that draws colliders for all
+// solid entities. Every collider is rendered with the camera of the scene the
+// entity belongs to.
+type ColliderOutlines struct {
+	*engine.Entity
+	scene engine.IScene
+}
+
+// NewColliderOutlines function creates a new ColliderOutlines instance with
+// its own scene.
+func NewColliderOutlines() *ColliderOutlines {
+	return newColliderOutlines(nil)
+}
+
+// newColliderOutlines function creates a new ColliderOutlines instance for
+// the given engine, or the default engine if it is nil.
+func newColliderOutlines(e *engine.Engine) *ColliderOutlines {
+	style := engine.NewStyle(tcell.ColorFuchsia, tcell.ColorBlack, tcell.AttrBold)
+	outlines := &ColliderOutlines{
+		Entity: engine.NewEntity(ColliderOutlinesEntityName, api.NewPoint(0, 0), api.NewSize(0, 0), style),
+		scene:  engine.NewScene(ColliderOutlinesSceneName, engine.NewCamera(nil, api.NewSize(0, 0))),
+	}
+	if e != nil {
+		outlines.scene.SetEngine(e)
+	}
+	outlines.scene.AddEntity(outlines)
+	return outlines
+}
+
+// -----------------------------------------------------------------------------
+// ColliderOutlines private methods
+// -----------------------------------------------------------------------------
+
+// drawCollider method draws the given collider with the given camera.
+func (o *ColliderOutlines) drawCollider(camera engine.ICamera, collider *engine.Collider) {
+	cell := engine.NewCell(o.GetStyle(), '+')
+	for _, point := range collider.GetPoints() {
+		camera.RenderCellAt(point, cell)
+	}
+	if rect := collider.GetRect(); rect != nil {
+		canvas := engine.NewCanvas(rect.Size)
+		canvas.WriteRectangleInCanvasAt(nil, nil, o.GetStyle(), engine.CanvasRectSingleLine)
+		canvas.RenderAt(camera, rect.Origin)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// ColliderOutlines public methods
+// -----------------------------------------------------------------------------
+
+// Draw method draws colliders for all solid entities in all visible scenes.
+func (o *ColliderOutlines) Draw(scene engine.IScene) {
+	for _, visibleScene := range o.scene.GetEngine().GetSceneManager().GetAllVisibleScenes() {
+		if isDevtoolsScene(visibleScene) {
+			continue
+		}
+		for _, entity := range visibleScene.GetEntities() {
+			if !entity.IsSolid() {
+				continue
+			}
+			if collider := entity.GetCollider(); collider != nil {
+				o.drawCollider(visibleScene.GetCamera(), collider)
+			}
+		}
+	}
+}
+
+// GetScene method returns the scene the overlay belongs to.
+func (o *ColliderOutlines) GetScene() engine.IScene {
+	return o.scene
+}
+
+// IsDisplayed method checks if the overlay is being displayed.
+func (o *ColliderOutlines) IsDisplayed() bool {
+	return o.scene.GetEngine().GetSceneManager().IsSceneVisible(o.scene)
+}
+
+// Toggle method displays or hides the overlay. The overlay is drawn on top
+// of any other visible scene.
+func (o *ColliderOutlines) Toggle() {
+	sceneManager := o.scene.GetEngine().GetSceneManager()
+	if !sceneManager.IsSceneAvailable(o.scene) {
+		sceneManager.AddScene(o.scene)
+	}
+	if sceneManager.IsSceneVisible(o.scene) {
+		sceneManager.RemoveSceneAsVisible(o.scene)
+		return
+	}
+	sceneManager.PushVisibleSceneAsLast(o.scene)
+}
+
+var _ engine.IEntity = (*ColliderOutlines)(nil)
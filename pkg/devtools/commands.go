@@ -0,0 +1,382 @@
+// commands.go contains all built-in commands available in the developer
+// console.
+package devtools
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// parseValue function converts the given string into an integer or a boolean
+// value if possible, or returns the same string.
+func parseValue(str string) any {
+	if value, err := strconv.Atoi(str); err == nil {
+		return value
+	}
+	if value, err := strconv.ParseBool(str); err == nil {
+		return value
+	}
+	return str
+}
+
+// builtinCommands function returns all commands registered in every console.
+func builtinCommands() []*Command {
+	return []*Command{
+		{
+			Name:    "cache",
+			Usage:   "cache <key> [value]",
+			Help:    "set a cache entry, or delete it if there is no value",
+			Handler: cacheCommand,
+		},
+		{
+			Name:    "close",
+			Usage:   "close",
+			Help:    "close the console",
+			Handler: closeCommand,
+		},
+		{
+			Name:    "colliders",
+			Usage:   "colliders",
+			Help:    "toggle collider outlines",
+			Handler: collidersCommand,
+		},
+		{
+			Name:    "help",
+			Usage:   "help",
+			Help:    "display all commands",
+			Handler: helpCommand,
+		},
+		{
+			Name:    "loglevel",
+			Usage:   "loglevel <module> [level]",
+			Help:    "set the log level for a module, or remove it if there is no level",
+			Handler: logLevelCommand,
+		},
+		{
+			Name:    "logs",
+			Usage:   "logs [up|down [lines]|filter <text>|clear]",
+			Help:    "toggle the log viewer, scroll it, filter it or clear the log",
+			Handler: logsCommand,
+		},
+		{
+			Name:    "scenes",
+			Usage:   "scenes",
+			Help:    "display all scenes",
+			Handler: scenesCommand,
+		},
+		{
+			Name:    "select",
+			Usage:   "select <name>",
+			Help:    "select an entity or a scene by name",
+			Handler: selectCommand,
+		},
+		{
+			Name:    "set",
+			Usage:   "set <field> <value>...",
+			Help:    "set name, class, position, size, zlevel, plevel, active, visible, solid or focus",
+			Handler: setCommand,
+		},
+		{
+			Name:    "spawn",
+			Usage:   "spawn <name> <x> <y> [rune]",
+			Help:    "spawn a new entity in the selected scene",
+			Handler: spawnCommand,
+		},
+		{
+			Name:    "teleport",
+			Usage:   "teleport <x> <y> [name]",
+			Help:    "move the selected or the given entity",
+			Handler: teleportCommand,
+		},
+	}
+}
+
+// checkArgs function checks the minimum number of arguments for the given
+// command.
+func checkArgs(args []string, min int, usage string) error {
+	if len(args) < min {
+		return fmt.Errorf("usage: %s", usage)
+	}
+	return nil
+}
+
+// parseInts function converts all given strings into integers.
+func parseInts(args ...string) ([]int, error) {
+	result := make([]int, len(args))
+	for i, arg := range args {
+		value, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", arg)
+		}
+		result[i] = value
+	}
+	return result, nil
+}
+
+// selectedEntity function returns the entity selected in the console.
+func selectedEntity(console *Console) (engine.IEntity, error) {
+	entity, _ := console.GetSelected()
+	if entity == nil {
+		return nil, fmt.Errorf("no entity selected")
+	}
+	return entity, nil
+}
+
+// cacheCommand function sets or deletes an entry in the selected entity
+// cache.
+func cacheCommand(console *Console, args []string) (string, error) {
+	if err := checkArgs(args, 1, "cache <key> [value]"); err != nil {
+		return "", err
+	}
+	entity, err := selectedEntity(console)
+	if err != nil {
+		return "", err
+	}
+	key := args[0]
+	if len(args) == 1 {
+		entity.GetCache().Delete(key)
+		return fmt.Sprintf("%s deleted", key), nil
+	}
+	value := parseValue(strings.Join(args[1:], " "))
+	entity.GetCache().Set(key, value)
+	return fmt.Sprintf("%s=%v", key, value), nil
+}
+
+// closeCommand function closes the console.
+func closeCommand(console *Console, args []string) (string, error) {
+	console.Close()
+	return "", nil
+}
+
+// collidersCommand function toggles collider outlines.
+func collidersCommand(console *Console, args []string) (string, error) {
+	colliders := console.GetColliderOutlines()
+	colliders.Toggle()
+	return fmt.Sprintf("colliders %t", colliders.IsDisplayed()), nil
+}
+
+// helpCommand function displays all commands registered.
+func helpCommand(console *Console, args []string) (string, error) {
+	lines := []string{}
+	for _, command := range console.GetCommands() {
+		lines = append(lines, fmt.Sprintf("%s: %s", command.Usage, command.Help))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// logLevelCommand function sets the log level for the given module.
+func logLevelCommand(console *Console, args []string) (string, error) {
+	if len(args) < 1 {
+		return "", fmt.Errorf("usage: loglevel <module> [level]")
+	}
+	level := ""
+	if len(args) > 1 {
+		level = args[1]
+	}
+	if err := tools.SetModuleLogLevel(args[0], level); err != nil {
+		return "", err
+	}
+	if level == "" {
+		return fmt.Sprintf("log level %s removed", args[0]), nil
+	}
+	return fmt.Sprintf("log level %s %s", args[0], level), nil
+}
+
+// logsCommand function toggles the log viewer, or scrolls, filters or clears
+// the lines it displays.
+func logsCommand(console *Console, args []string) (string, error) {
+	viewer := console.GetLogViewer()
+	if len(args) == 0 {
+		viewer.Toggle()
+		return fmt.Sprintf("logs %t", viewer.IsDisplayed()), nil
+	}
+	switch args[0] {
+	case "up", "down":
+		lines := viewer.GetSize().H
+		if len(args) > 1 {
+			value, err := strconv.Atoi(args[1])
+			if err != nil {
+				return "", fmt.Errorf("invalid number %s", args[1])
+			}
+			lines = value
+		}
+		if args[0] == "down" {
+			lines = -lines
+		}
+		viewer.Scroll(lines)
+		return "", nil
+	case "filter":
+		viewer.SetFilter(strings.Join(args[1:], " "))
+		return fmt.Sprintf("logs filter %q", viewer.GetFilter()), nil
+	case "clear":
+		if ring := viewer.GetRing(); ring != nil {
+			ring.Clear()
+		}
+		viewer.Refresh()
+		return "logs cleared", nil
+	}
+	return "", fmt.Errorf("usage: logs [up|down [lines]|filter <text>|clear]")
+}
+
+// scenesCommand function displays all scenes with their state.
+func scenesCommand(console *Console, args []string) (string, error) {
+	sceneManager := console.GetEngine().GetSceneManager()
+	lines := []string{}
+	for _, scene := range sceneManager.GetAllScenes() {
+		if isDevtoolsScene(scene) {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s active:%t visible:%t entities:%d",
+			scene.GetName(), console.isSavedScene(scene) || sceneManager.IsSceneActive(scene),
+			sceneManager.IsSceneVisible(scene), len(scene.GetEntities())))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// selectCommand function selects an entity or a scene by name.
+func selectCommand(console *Console, args []string) (string, error) {
+	if err := checkArgs(args, 1, "select <name>"); err != nil {
+		return "", err
+	}
+	name := args[0]
+	for _, scene := range console.GetEngine().GetSceneManager().GetAllScenes() {
+		if isDevtoolsScene(scene) {
+			continue
+		}
+		if scene.GetName() == name {
+			console.Select(scene, nil)
+			return fmt.Sprintf("scene %s selected", name), nil
+		}
+		if entity := scene.GetEntityByName(name); entity != nil {
+			console.Select(scene, entity)
+			return fmt.Sprintf("entity %s selected", name), nil
+		}
+	}
+	return "", fmt.Errorf("%s not found", name)
+}
+
+// setCommand function sets the given field in the selected entity.
+func setCommand(console *Console, args []string) (string, error) {
+	usage := "set <field> <value>..."
+	if err := checkArgs(args, 2, usage); err != nil {
+		return "", err
+	}
+	entity, err := selectedEntity(console)
+	if err != nil {
+		return "", err
+	}
+	field, values := args[0], args[1:]
+	switch field {
+	case "name":
+		entity.SetName(values[0])
+	case "class":
+		entity.SetClassName(values[0])
+	case "position", "size":
+		if err := checkArgs(values, 2, fmt.Sprintf("set %s <x> <y>", field)); err != nil {
+			return "", err
+		}
+		ints, err := parseInts(values[:2]...)
+		if err != nil {
+			return "", err
+		}
+		if field == "position" {
+			entity.SetPosition(api.NewPoint(ints[0], ints[1]))
+		} else {
+			entity.SetSize(api.NewSize(ints[0], ints[1]))
+			entity.Refresh()
+		}
+	case "zlevel", "plevel":
+		ints, err := parseInts(values[0])
+		if err != nil {
+			return "", err
+		}
+		if field == "zlevel" {
+			entity.SetZLevel(ints[0])
+		} else {
+			entity.SetPLevel(ints[0])
+		}
+		// entities are sorted by levels, so the scene is sorted again.
+		_, scene := console.GetSelected()
+		scene.SortEntities()
+	case "active", "visible", "solid", "focus":
+		value, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return "", fmt.Errorf("invalid boolean %s", values[0])
+		}
+		switch field {
+		case "active":
+			entity.SetActive(value)
+		case "visible":
+			entity.SetVisible(value)
+		case "solid":
+			entity.SetSolid(value)
+		case "focus":
+			entity.SetFocusEnable(value)
+		}
+	default:
+		return "", fmt.Errorf("unknown field %s", field)
+	}
+	return fmt.Sprintf("%s=%s", field, strings.Join(values, " ")), nil
+}
+
+// spawnCommand function creates a new entity in the selected scene.
+func spawnCommand(console *Console, args []string) (string, error) {
+	if err := checkArgs(args, 3, "spawn <name> <x> <y> [rune]"); err != nil {
+		return "", err
+	}
+	scene := console.GetTargetScene()
+	if scene == nil {
+		return "", fmt.Errorf("no scene selected")
+	}
+	ints, err := parseInts(args[1:3]...)
+	if err != nil {
+		return "", err
+	}
+	ch := '@'
+	if len(args) > 3 {
+		ch = []rune(args[3])[0]
+	}
+	style := engine.NewStyle(tcell.ColorWhite, tcell.ColorBlack, tcell.AttrNone)
+	entity := engine.NewEntity(args[0], api.NewPoint(ints[0], ints[1]), api.NewSize(1, 1), style)
+	entity.GetCanvas().SetCellAt(nil, engine.NewCell(style, ch))
+	if err := scene.AddEntity(entity); err != nil {
+		return "", err
+	}
+	console.Select(scene, entity)
+	return fmt.Sprintf("%s spawned in %s", args[0], scene.GetName()), nil
+}
+
+// teleportCommand function moves the selected entity, or the entity with the
+// given name in the selected scene, to the given position.
+func teleportCommand(console *Console, args []string) (string, error) {
+	if err := checkArgs(args, 2, "teleport <x> <y> [name]"); err != nil {
+		return "", err
+	}
+	ints, err := parseInts(args[:2]...)
+	if err != nil {
+		return "", err
+	}
+	var entity engine.IEntity
+	if len(args) > 2 {
+		if scene := console.GetTargetScene(); scene != nil {
+			entity = scene.GetEntityByName(args[2])
+		}
+		if entity == nil {
+			return "", fmt.Errorf("%s not found", args[2])
+		}
+	} else if entity, err = selectedEntity(console); err != nil {
+		return "", err
+	}
+	entity.SetPosition(api.NewPoint(ints[0], ints[1]))
+	return fmt.Sprintf("%s teleported to %s", entity.GetName(), entity.GetPosition().ToString()), nil
+}
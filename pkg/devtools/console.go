@@ -0,0 +1,511 @@
+// console.go contains the developer console. The console is a scene opened
+// with a hot key on top of the running application, that displays all scenes
+// and entities in a tree, displays all attributes for the selected entity and
+// runs commands entered in the command prompt to change them live.
+package devtools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	ConsoleSceneName = "devtools/console"
+	ConsoleHotKey    = tcell.KeyF11
+)
+
+// -----------------------------------------------------------------------------
+// Package private constants
+// -----------------------------------------------------------------------------
+
+const (
+	consoleListWidth   = 32
+	consolePrompt      = "> "
+	consoleHistorySize = 50
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// CommandHandler type defines the function called to run a console command
+// with the given arguments. It returns the output to be displayed.
+type CommandHandler func(console *Console, args []string) (string, error)
+
+// Command structure defines a command that can be run from the console
+// prompt.
+type Command struct {
+	Name    string
+	Usage   string
+	Help    string
+	Handler CommandHandler
+}
+
+// -----------------------------------------------------------------------------
+// Package private types
+// -----------------------------------------------------------------------------
+
+// consoleEntry structure defines the payload for every node in the console
+// tree, which is a scene or an entity in a scene.
+type consoleEntry struct {
+	scene  engine.IScene
+	entity engine.IEntity
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// isDevtoolsScene function checks if the given scene is owned by devtools or
+// by the engine, those scenes are not inspected.
+func isDevtoolsScene(scene engine.IScene) bool {
+	name := scene.GetName()
+	return strings.HasPrefix(name, "devtools/") || name == engine.DebugOverlaySceneName
+}
+
+// fitLines function returns the given lines padded and truncated to fill the
+// given size.
+func fitLines(lines []string, size *api.Size) string {
+	result := make([]string, size.H)
+	for i := range result {
+		line := ""
+		if i < len(lines) {
+			line = lines[i]
+		}
+		if len(line) > size.W {
+			line = line[:size.W]
+		}
+		result[i] = fmt.Sprintf("%-*s", size.W, line)
+	}
+	return strings.Join(result, "\n")
+}
+
+// -----------------------------------------------------------------------------
+//
+// Console
+//
+// -----------------------------------------------------------------------------
+
+// Console structure defines the developer console.
+// savedScenes contains all scenes active when the console was opened, they
+// are restored when the console is closed.
+type Console struct {
+	scene         engine.IScene
+	tree          *widgets.TreeView
+	inspector     *widgets.Text
+	output        *widgets.Text
+	prompt        *widgets.TextInput
+	commands      map[string]*Command
+	outputLines   []string
+	savedScenes   []engine.IScene
+	selected      engine.IEntity
+	selectedScene engine.IScene
+	colliders     *ColliderOutlines
+	logs          *LogViewer
+}
+
+// NewConsole function creates a new Console instance displayed at the given
+// position and size in the screen.
+func NewConsole(position *api.Point, size *api.Size) *Console {
+	return NewConsoleForEngine(nil, position, size)
+}
+
+// NewConsoleForEngine function creates a new Console instance for the given
+// engine, displayed at the given position and size in the screen. The
+// default engine is used when the given engine is nil.
+func NewConsoleForEngine(e *engine.Engine, position *api.Point, size *api.Size) *Console {
+	tools.Logger.WithField("module", "console").
+		WithField("function", "NewConsoleForEngine").
+		Infof("%s %s", position.ToString(), size.ToString())
+	camera := engine.NewCamera(nil, api.NewSize(position.X+size.W, position.Y+size.H))
+	console := &Console{
+		scene:       engine.NewScene(ConsoleSceneName, camera),
+		commands:    make(map[string]*Command),
+		outputLines: []string{},
+	}
+	if e != nil {
+		console.scene.SetEngine(e)
+	}
+	style := engine.NewStyle(tcell.ColorWhite, tcell.ColorNavy, tcell.AttrNone)
+	x, y := position.Get()
+	listWidth := tools.Min(consoleListWidth, size.W/3)
+	panelWidth := (size.W - listWidth - 1) / 2
+	panelHeight := size.H - 1
+
+	background := engine.NewEntity("devtools/console/background", api.ClonePoint(position), api.CloneSize(size), style)
+	background.GetCanvas().FillWithCell(engine.NewCell(style, ' '))
+	console.scene.AddEntity(background)
+
+	label := widgets.NewText("devtools/console/label", api.NewPoint(x, y+panelHeight),
+		api.NewSize(len(consolePrompt), 1), style, consolePrompt)
+	label.SetZLevel(1)
+	console.scene.AddEntity(label)
+
+	console.prompt = widgets.NewTextInput("devtools/console/prompt", api.NewPoint(x+len(consolePrompt), y+panelHeight),
+		api.NewSize(size.W-len(consolePrompt), 1), style, "")
+	console.prompt.SetZLevel(1)
+	console.prompt.SetWidgetCallback(console.promptCallback)
+	console.prompt.SetHistorySize(consoleHistorySize)
+	console.prompt.SetCompleter(console.completeCommand)
+	console.scene.AddEntity(console.prompt)
+
+	console.tree = widgets.NewTreeView("devtools/console/tree", api.NewPoint(x, y),
+		api.NewSize(listWidth, panelHeight), style, nil)
+	console.tree.SetZLevel(1)
+	console.tree.SetWidgetCallback(console.treeCallback)
+	console.scene.AddEntity(console.tree)
+
+	console.inspector = widgets.NewText("devtools/console/inspector", api.NewPoint(x+listWidth+1, y),
+		api.NewSize(panelWidth, panelHeight), style, "")
+	console.inspector.SetZLevel(1)
+	console.scene.AddEntity(console.inspector)
+
+	console.output = widgets.NewText("devtools/console/output", api.NewPoint(x+listWidth+panelWidth+2, y),
+		api.NewSize(size.W-listWidth-panelWidth-2, panelHeight), style, "")
+	console.output.SetZLevel(1)
+	console.scene.AddEntity(console.output)
+
+	console.colliders = newColliderOutlines(e)
+	// the log viewer is displayed above the console.
+	logsHeight := y
+	if logsHeight == 0 {
+		logsHeight = size.H
+	}
+	console.logs = newLogViewer(e, api.NewPoint(x, 0), api.NewSize(size.W, logsHeight), tools.GetLogRing())
+	for _, command := range builtinCommands() {
+		console.RegisterCommand(command)
+	}
+	console.Refresh()
+	return console
+}
+
+// -----------------------------------------------------------------------------
+// Console private methods
+// -----------------------------------------------------------------------------
+
+// completeCommand method returns all command names starting with the given
+// string, and displays them if there are several.
+func (c *Console) completeCommand(str string) []string {
+	if strings.Contains(str, " ") {
+		return nil
+	}
+	result := []string{}
+	for _, command := range c.GetCommands() {
+		if strings.HasPrefix(command.Name, str) {
+			result = append(result, command.Name)
+		}
+	}
+	if len(result) > 1 {
+		c.Print(strings.Join(result, " "))
+	}
+	return result
+}
+
+// inspectorLines method returns all lines to be displayed in the inspector
+// for the selected entity or scene.
+func (c *Console) inspectorLines() []string {
+	if c.selected == nil {
+		if c.selectedScene == nil {
+			return []string{"nothing selected"}
+		}
+		sceneManager := c.GetEngine().GetSceneManager()
+		return []string{
+			fmt.Sprintf("scene: %s", c.selectedScene.GetName()),
+			fmt.Sprintf("entities: %d", len(c.selectedScene.GetEntities())),
+			fmt.Sprintf("active: %t visible: %t",
+				c.isSavedScene(c.selectedScene) || sceneManager.IsSceneActive(c.selectedScene),
+				sceneManager.IsSceneVisible(c.selectedScene)),
+		}
+	}
+	entity := c.selected
+	lines := []string{
+		fmt.Sprintf("name: %s", entity.GetName()),
+		fmt.Sprintf("class: %s", entity.GetClassName()),
+		fmt.Sprintf("scene: %s", c.selectedScene.GetName()),
+		fmt.Sprintf("position: %s", entity.GetPosition().ToString()),
+		fmt.Sprintf("size: %s", entity.GetSize().ToString()),
+		fmt.Sprintf("zlevel: %d plevel: %d", entity.GetZLevel(), entity.GetPLevel()),
+		fmt.Sprintf("active: %t visible: %t solid: %t", entity.IsActive(), entity.IsVisible(), entity.IsSolid()),
+		fmt.Sprintf("focus: %t has-focus: %t", entity.IsFocusEnable(), entity.HasFocus()),
+		"cache:",
+	}
+	if cache, ok := entity.GetCache().(*api.Cache); ok {
+		data := cache.GetCache()
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("  %s: %v", key, data[key]))
+		}
+	}
+	return lines
+}
+
+// isSavedScene method checks if the given scene was active when the console
+// was opened.
+func (c *Console) isSavedScene(scene engine.IScene) bool {
+	_, ok := tools.Contains(c.savedScenes, scene)
+	return ok
+}
+
+// promptCallback method is called when a command is entered in the prompt.
+func (c *Console) promptCallback(entity engine.IEntity, args ...any) bool {
+	line := c.prompt.GetInputText()
+	c.prompt.SetInputText("")
+	c.Run(line)
+	return true
+}
+
+// treeCallback method is called when a node is selected in the tree.
+func (c *Console) treeCallback(entity engine.IEntity, args ...any) bool {
+	if node := c.tree.GetSelectedNode(); node != nil {
+		entry := node.GetPayload().(*consoleEntry)
+		c.Select(entry.scene, entry.entity)
+	}
+	return true
+}
+
+// updateTree method updates the tree with all scenes and their entities.
+// Scenes collapsed in the tree are kept collapsed.
+func (c *Console) updateTree() {
+	collapsed := map[engine.IScene]bool{}
+	for _, node := range c.tree.GetRoots() {
+		if !node.IsExpanded() {
+			collapsed[node.GetPayload().(*consoleEntry).scene] = true
+		}
+	}
+	roots := []*widgets.TreeNode{}
+	var selected *widgets.TreeNode
+	for _, scene := range c.GetEngine().GetSceneManager().GetAllScenes() {
+		if isDevtoolsScene(scene) {
+			continue
+		}
+		sceneNode := widgets.NewTreeNode(scene.GetName(), "scene", &consoleEntry{scene: scene}).
+			WithExpanded(!collapsed[scene])
+		if scene == c.selectedScene && c.selected == nil {
+			selected = sceneNode
+		}
+		for _, entity := range scene.GetEntities() {
+			node := widgets.NewTreeNode(entity.GetName(), "entity", &consoleEntry{scene: scene, entity: entity})
+			if entity == c.selected {
+				selected = node
+			}
+			sceneNode.AddChildren(node)
+		}
+		roots = append(roots, sceneNode)
+	}
+	c.tree.SetRoots(roots)
+	if selected != nil {
+		c.tree.SelectNode(selected)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Console public methods
+// -----------------------------------------------------------------------------
+
+// BindHotKey method registers the given key in the engine to open and close
+// the console.
+func (c *Console) BindHotKey(key tcell.Key) {
+	c.GetEngine().AddHotKey(key, c.Toggle)
+}
+
+// Close method closes the console and activates again all scenes that were
+// active when the console was opened.
+func (c *Console) Close() {
+	if !c.IsOpen() {
+		return
+	}
+	sceneManager := c.GetEngine().GetSceneManager()
+	sceneManager.RemoveSceneAsActive(c.scene)
+	sceneManager.RemoveSceneAsVisible(c.scene)
+	for _, scene := range c.savedScenes {
+		sceneManager.SetSceneAsActive(scene)
+	}
+	c.savedScenes = nil
+	sceneManager.UpdateFocus()
+}
+
+// GetColliderOutlines method returns the collider outlines overlay.
+func (c *Console) GetColliderOutlines() *ColliderOutlines {
+	return c.colliders
+}
+
+// GetCommand method returns the command registered with the given name.
+func (c *Console) GetCommand(name string) *Command {
+	return c.commands[name]
+}
+
+// GetCommands method returns all registered commands sorted by name.
+func (c *Console) GetCommands() []*Command {
+	names := make([]string, 0, len(c.commands))
+	for name := range c.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]*Command, 0, len(names))
+	for _, name := range names {
+		result = append(result, c.commands[name])
+	}
+	return result
+}
+
+// GetEngine method returns the engine the console belongs to.
+func (c *Console) GetEngine() *engine.Engine {
+	return c.scene.GetEngine()
+}
+
+// GetLogViewer method returns the log viewer overlay.
+func (c *Console) GetLogViewer() *LogViewer {
+	return c.logs
+}
+
+// GetOutput method returns all lines displayed in the console output.
+func (c *Console) GetOutput() []string {
+	return c.outputLines
+}
+
+// GetScene method returns the console scene.
+func (c *Console) GetScene() engine.IScene {
+	return c.scene
+}
+
+// GetSelected method returns the selected entity and the scene it belongs
+// to. Entity is nil when a scene is selected.
+func (c *Console) GetSelected() (engine.IEntity, engine.IScene) {
+	return c.selected, c.selectedScene
+}
+
+// GetTargetScene method returns the scene commands are applied to, which is
+// the scene selected or the last scene active when the console was opened.
+func (c *Console) GetTargetScene() engine.IScene {
+	if c.selectedScene != nil {
+		return c.selectedScene
+	}
+	if len(c.savedScenes) != 0 {
+		return c.savedScenes[len(c.savedScenes)-1]
+	}
+	return nil
+}
+
+// IsOpen method checks if the console is being displayed.
+func (c *Console) IsOpen() bool {
+	return c.GetEngine().GetSceneManager().IsSceneActive(c.scene)
+}
+
+// Open method opens the console on top of all visible scenes. All active
+// scenes are deactivated, so any input is handled by the console.
+func (c *Console) Open() {
+	if c.IsOpen() {
+		return
+	}
+	sceneManager := c.GetEngine().GetSceneManager()
+	if !sceneManager.IsSceneAvailable(c.scene) {
+		sceneManager.AddScene(c.scene)
+	}
+	c.savedScenes = []engine.IScene{}
+	for _, scene := range sceneManager.GetAllActiveScenes() {
+		if !isDevtoolsScene(scene) {
+			c.savedScenes = append(c.savedScenes, scene)
+		}
+	}
+	for _, scene := range c.savedScenes {
+		sceneManager.RemoveSceneAsActive(scene)
+	}
+	sceneManager.SetSceneAsActive(c.scene)
+	sceneManager.RemoveSceneAsVisible(c.scene)
+	sceneManager.PushVisibleSceneAsLast(c.scene)
+	c.Refresh()
+	sceneManager.UpdateFocus()
+}
+
+// Print method adds the given text to the console output.
+func (c *Console) Print(text string) {
+	if text == "" {
+		return
+	}
+	c.outputLines = append(c.outputLines, strings.Split(text, "\n")...)
+	if maxLines := c.output.GetSize().H; len(c.outputLines) > maxLines {
+		c.outputLines = c.outputLines[len(c.outputLines)-maxLines:]
+	}
+	c.output.SetText(fitLines(c.outputLines, c.output.GetSize()))
+}
+
+// Refresh method refreshes the tree and the inspector with latest values.
+func (c *Console) Refresh() {
+	c.updateTree()
+	c.inspector.SetText(fitLines(c.inspectorLines(), c.inspector.GetSize()))
+	c.output.SetText(fitLines(c.outputLines, c.output.GetSize()))
+}
+
+// RegisterCommand method registers the given command, replacing any command
+// with the same name.
+func (c *Console) RegisterCommand(command *Command) {
+	c.commands[command.Name] = command
+}
+
+// Run method runs the given command line and displays its output.
+func (c *Console) Run(line string) {
+	tools.Logger.WithField("module", "console").
+		WithField("method", "Run").
+		Debugf("%s", line)
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return
+	}
+	c.Print(consolePrompt + line)
+	command, ok := c.commands[fields[0]]
+	if !ok {
+		c.Print(fmt.Sprintf("error: unknown command %s", fields[0]))
+		return
+	}
+	output, err := command.Handler(c, fields[1:])
+	if err != nil {
+		c.Print(fmt.Sprintf("error: %s", err))
+	} else {
+		c.Print(output)
+	}
+	if c.IsOpen() {
+		c.Refresh()
+	}
+}
+
+// SetEngine method sets the engine the console belongs to, which is the
+// default engine if it is not set. It has to be called before the hot key is
+// bound.
+func (c *Console) SetEngine(e *engine.Engine) {
+	c.scene.SetEngine(e)
+	c.colliders.GetScene().SetEngine(e)
+	c.logs.GetScene().SetEngine(e)
+}
+
+// Select method selects the given entity in the given scene. When entity is
+// nil the scene is selected.
+func (c *Console) Select(scene engine.IScene, entity engine.IEntity) {
+	c.selectedScene = scene
+	c.selected = entity
+	c.Refresh()
+}
+
+// Toggle method opens the console if it is closed or closes it if it is
+// opened.
+func (c *Console) Toggle() {
+	if c.IsOpen() {
+		c.Close()
+		return
+	}
+	c.Open()
+}
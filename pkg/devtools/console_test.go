@@ -0,0 +1,144 @@
+package devtools_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/devtools"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+func TestConsole(t *testing.T) {
+	e := engine.GetEngine()
+	e.SetDryRun(true)
+	sceneManager := e.GetSceneManager()
+	scene := engine.NewScene("scene/test/1", engine.NewCamera(nil, api.NewSize(80, 24)))
+	entity := engine.NewEntity("entity/test/1", api.NewPoint(1, 1), api.NewSize(1, 1), nil)
+	scene.AddEntity(entity)
+	sceneManager.AddScene(scene)
+	sceneManager.SetSceneAsActive(scene)
+	sceneManager.SetSceneAsVisible(scene)
+
+	console := devtools.NewConsole(api.NewPoint(0, 12), api.NewSize(80, 12))
+	console.BindHotKey(devtools.ConsoleHotKey)
+	e.HandleHotKey(devtools.ConsoleHotKey)
+	if !console.IsOpen() {
+		t.Errorf("[1] IsOpen Error exp:%t got:%t", true, console.IsOpen())
+	}
+	if sceneManager.IsSceneActive(scene) {
+		t.Errorf("[1] IsSceneActive Error exp:%t got:%t", false, true)
+	}
+
+	console.Run("select entity/test/1")
+	if got, gotScene := console.GetSelected(); got != entity || gotScene != scene {
+		t.Errorf("[2] GetSelected Error exp:%s got:%v", entity.GetName(), got)
+	}
+
+	console.Run("teleport 5 6")
+	if got := entity.GetPosition(); !got.IsEqual(api.NewPoint(5, 6)) {
+		t.Errorf("[3] teleport Error exp:%s got:%s", "[5,6]", got.ToString())
+	}
+
+	console.Run("set solid true")
+	if !entity.IsSolid() {
+		t.Errorf("[4] set Error exp:%t got:%t", true, entity.IsSolid())
+	}
+
+	// levels are changed without adding the entity to the scene again.
+	added := 0
+	entity.SetBehaviorFor(engine.BehaviorAdded, func(engine.IScene) { added++ })
+	console.Run("set zlevel 3")
+	if got := entity.GetZLevel(); got != 3 {
+		t.Errorf("[5] set Error exp:%d got:%d", 3, got)
+	}
+	if added != 0 {
+		t.Errorf("[5] OnAdded Error exp:%d got:%d", 0, added)
+	}
+
+	console.Run("cache gold 10")
+	if got, _ := entity.GetCache().Get("gold"); got != 10 {
+		t.Errorf("[6] cache Error exp:%d got:%v", 10, got)
+	}
+
+	console.Run("spawn entity/test/2 2 2 x")
+	if got := scene.GetEntityByName("entity/test/2"); got == nil {
+		t.Errorf("[7] spawn Error exp:%s got:nil", "entity/test/2")
+	}
+
+	console.Run("colliders")
+	if !console.GetColliderOutlines().IsDisplayed() {
+		t.Errorf("[8] colliders Error exp:%t got:%t", true, false)
+	}
+
+	console.RegisterCommand(&devtools.Command{
+		Name:  "echo",
+		Usage: "echo <text>",
+		Handler: func(c *devtools.Console, args []string) (string, error) {
+			return args[0], nil
+		},
+	})
+	console.Run("echo hello")
+	output := console.GetOutput()
+	if got := output[len(output)-1]; got != "hello" {
+		t.Errorf("[9] Run Error exp:%s got:%s", "hello", got)
+	}
+	console.Run("unknown")
+	output = console.GetOutput()
+	if got := output[len(output)-1]; got != "error: unknown command unknown" {
+		t.Errorf("[10] Run Error exp:%s got:%s", "error: unknown command unknown", got)
+	}
+
+	e.HandleHotKey(devtools.ConsoleHotKey)
+	if console.IsOpen() {
+		t.Errorf("[11] IsOpen Error exp:%t got:%t", false, console.IsOpen())
+	}
+	if !sceneManager.IsSceneActive(scene) {
+		t.Errorf("[11] IsSceneActive Error exp:%t got:%t", true, false)
+	}
+}
+
+func TestLogViewer(t *testing.T) {
+	e := engine.GetEngine()
+	e.SetDryRun(true)
+	ring := tools.NewLogRing(10)
+	for _, line := range []string{"one module=engine", "two module=scene", "three module=engine"} {
+		ring.Write([]byte(`time="2024-01-01T00:00:00Z" level=debug msg="` + line + "\"\n"))
+	}
+	viewer := devtools.NewLogViewer(api.NewPoint(0, 0), api.NewSize(40, 2), ring)
+	lines := viewer.GetLines()
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "level=debug") {
+		t.Errorf("[1] GetLines Error exp:%d lines got:%v", 3, lines)
+	}
+
+	viewer.SetFilter("module=engine")
+	if got := len(viewer.GetLines()); got != 2 {
+		t.Errorf("[2] SetFilter Error exp:%d got:%d", 2, got)
+	}
+
+	viewer.Toggle()
+	if !viewer.IsDisplayed() {
+		t.Errorf("[3] Toggle Error exp:%t got:%t", true, false)
+	}
+	viewer.Toggle()
+	if viewer.IsDisplayed() {
+		t.Errorf("[4] Toggle Error exp:%t got:%t", false, true)
+	}
+
+	console := devtools.NewConsole(api.NewPoint(0, 12), api.NewSize(80, 12))
+	console.Run("logs")
+	if !console.GetLogViewer().IsDisplayed() {
+		t.Errorf("[5] logs Error exp:%t got:%t", true, false)
+	}
+	console.Run("logs filter engine")
+	if got := console.GetLogViewer().GetFilter(); got != "engine" {
+		t.Errorf("[6] logs Error exp:%s got:%s", "engine", got)
+	}
+	console.Run("loglevel engine verbose")
+	output := console.GetOutput()
+	if got := output[len(output)-1]; !strings.HasPrefix(got, "error:") {
+		t.Errorf("[7] loglevel Error exp:%s got:%s", "error:", got)
+	}
+	console.Run("logs")
+}
@@ -0,0 +1,215 @@
+// logviewer.go contains the log viewer overlay, which displays the last lines
+// written to the logger ring buffer while the application runs. Lines can be
+// filtered by any text, like a module name, and scrolled back.
+package devtools
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	LogViewerSceneName  = "devtools/logs"
+	LogViewerEntityName = "devtools/logs/viewer"
+)
+
+// -----------------------------------------------------------------------------
+// Package private constants
+// -----------------------------------------------------------------------------
+
+const (
+	logViewerNoRing = "logger is not configured with a ring buffer"
+)
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// compactLogLine function removes the timestamp from the given log line, so
+// the message is displayed at the start of the line.
+func compactLogLine(line string) string {
+	if !strings.HasPrefix(line, "time=\"") {
+		return line
+	}
+	if index := strings.Index(line[len("time=\""):], "\" "); index != -1 {
+		return line[len("time=\"")+index+2:]
+	}
+	return line
+}
+
+// -----------------------------------------------------------------------------
+//
+// LogViewer
+//
+// -----------------------------------------------------------------------------
+
+// LogViewer structure defines the entity that displays the last lines in the
+// logger ring buffer.
+// offset is the number of lines scrolled back from the newest line.
+// total is the number of lines written to the ring buffer the last time the
+// canvas was updated.
+type LogViewer struct {
+	*engine.Entity
+	scene  engine.IScene
+	ring   *tools.LogRing
+	filter string
+	offset int
+	total  int
+}
+
+// NewLogViewer function creates a new LogViewer instance with its own scene,
+// displayed at the given position and size in the screen and fed from the
+// given ring buffer.
+func NewLogViewer(position *api.Point, size *api.Size, ring *tools.LogRing) *LogViewer {
+	return newLogViewer(nil, position, size, ring)
+}
+
+// newLogViewer function creates a new LogViewer instance for the given
+// engine, or the default engine if it is nil.
+func newLogViewer(e *engine.Engine, position *api.Point, size *api.Size, ring *tools.LogRing) *LogViewer {
+	style := engine.NewStyle(tcell.ColorSilver, tcell.ColorBlack, tcell.AttrNone)
+	camera := engine.NewCamera(nil, api.NewSize(position.X+size.W, position.Y+size.H))
+	viewer := &LogViewer{
+		Entity: engine.NewEntity(LogViewerEntityName, position, size, style),
+		scene:  engine.NewScene(LogViewerSceneName, camera),
+		ring:   ring,
+		total:  -1,
+	}
+	viewer.SetZLevel(1)
+	if e != nil {
+		viewer.scene.SetEngine(e)
+	}
+	viewer.scene.AddEntity(viewer)
+	return viewer
+}
+
+// -----------------------------------------------------------------------------
+// LogViewer private methods
+// -----------------------------------------------------------------------------
+
+// lines method returns all lines in the ring buffer matching the filter.
+func (v *LogViewer) lines() []string {
+	if v.ring == nil {
+		return []string{logViewerNoRing}
+	}
+	var result []string
+	for _, line := range v.ring.GetLines() {
+		if v.filter == "" || strings.Contains(line, v.filter) {
+			result = append(result, compactLogLine(line))
+		}
+	}
+	return result
+}
+
+// updateCanvas method updates the viewer canvas with the lines to be
+// displayed for the scroll offset.
+func (v *LogViewer) updateCanvas() {
+	size := v.GetSize()
+	canvas := engine.NewCanvas(size)
+	canvas.FillWithCell(engine.NewCell(v.GetStyle(), ' '))
+	lines := v.lines()
+	v.offset = tools.Max(0, tools.Min(v.offset, len(lines)-size.H))
+	end := len(lines) - v.offset
+	start := tools.Max(0, end-size.H)
+	for row, line := range lines[start:end] {
+		if runes := []rune(line); len(runes) > size.W {
+			line = string(runes[:size.W])
+		}
+		canvas.WriteStringInCanvasAt(line, v.GetStyle(), api.NewPoint(0, row))
+	}
+	v.SetCanvas(canvas)
+	if v.ring != nil {
+		v.total = v.ring.GetTotal()
+	}
+}
+
+// -----------------------------------------------------------------------------
+// LogViewer public methods
+// -----------------------------------------------------------------------------
+
+// Draw method updates the viewer when new lines have been logged and renders
+// it.
+func (v *LogViewer) Draw(scene engine.IScene) {
+	if v.ring == nil || v.ring.GetTotal() != v.total {
+		v.updateCanvas()
+	}
+	v.Entity.Draw(scene)
+}
+
+// GetFilter method returns the text every line displayed has to contain.
+func (v *LogViewer) GetFilter() string {
+	return v.filter
+}
+
+// GetLines method returns all lines the viewer can display with the filter.
+func (v *LogViewer) GetLines() []string {
+	return v.lines()
+}
+
+// GetRing method returns the ring buffer the viewer is fed from.
+func (v *LogViewer) GetRing() *tools.LogRing {
+	return v.ring
+}
+
+// GetScene method returns the scene the viewer belongs to.
+func (v *LogViewer) GetScene() engine.IScene {
+	return v.scene
+}
+
+// IsDisplayed method checks if the viewer is being displayed.
+func (v *LogViewer) IsDisplayed() bool {
+	return v.scene.GetEngine().GetSceneManager().IsSceneVisible(v.scene)
+}
+
+// Refresh method updates the viewer with the latest lines.
+func (v *LogViewer) Refresh() {
+	v.updateCanvas()
+}
+
+// Scroll method scrolls the viewer back the given number of lines, or
+// forward if it is negative. The newest line is displayed at the bottom when
+// the offset is zero.
+func (v *LogViewer) Scroll(lines int) {
+	v.offset = tools.Max(0, v.offset+lines)
+	v.updateCanvas()
+}
+
+// SetFilter method sets the text every line displayed has to contain. An
+// empty filter displays all lines.
+func (v *LogViewer) SetFilter(filter string) {
+	v.filter = filter
+	v.offset = 0
+	v.updateCanvas()
+}
+
+// SetRing method sets the ring buffer the viewer is fed from.
+func (v *LogViewer) SetRing(ring *tools.LogRing) {
+	v.ring = ring
+	v.offset = 0
+	v.updateCanvas()
+}
+
+// Toggle method displays or hides the viewer. The viewer is drawn on top of
+// any other visible scene.
+func (v *LogViewer) Toggle() {
+	sceneManager := v.scene.GetEngine().GetSceneManager()
+	if !sceneManager.IsSceneAvailable(v.scene) {
+		sceneManager.AddScene(v.scene)
+	}
+	if sceneManager.IsSceneVisible(v.scene) {
+		sceneManager.RemoveSceneAsVisible(v.scene)
+		return
+	}
+	v.updateCanvas()
+	sceneManager.PushVisibleSceneAsLast(v.scene)
+}
+
+var _ engine.IEntity = (*LogViewer)(nil)
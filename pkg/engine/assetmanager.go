@@ -0,0 +1,329 @@
+// assetmanager.go contains the asset manager, which loads resources by logical
+// name from a file system, a root directory or an embedded file system, and
+// caches them, so repeated loads do not allocate them again. Assets are
+// reference counted and they are released from the cache when they are not
+// used anymore. Assets required by a scene can be preloaded at once.
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// AssetLoader type defines the function that loads an asset from the asset
+// manager file system.
+type AssetLoader func(fsys fs.FS) (any, error)
+
+// AssetCloner type defines the function that returns a copy of an asset, so
+// it can be modified without changing the cached asset.
+type AssetCloner func(any) any
+
+// AssetProgress type defines the function called for every asset preloaded,
+// with the number of assets already loaded and the total number of assets.
+type AssetProgress func(name string, loaded int, total int)
+
+// -----------------------------------------------------------------------------
+// Package private types
+// -----------------------------------------------------------------------------
+
+// assetEntry structure contains information for every registered asset.
+// refs is the number of times the asset has been acquired and not released.
+type assetEntry struct {
+	loader AssetLoader
+	cloner AssetCloner
+	value  any
+	loaded bool
+	refs   int
+}
+
+// -----------------------------------------------------------------------------
+//
+// AssetManager
+//
+// -----------------------------------------------------------------------------
+
+// AssetManager structure defines the manager for all assets registered by
+// logical name.
+// scenes contains the name of all assets to be preloaded for every scene.
+type AssetManager struct {
+	fsys   fs.FS
+	assets map[string]*assetEntry
+	scenes map[string][]string
+}
+
+// NewAssetManager function creates a new AssetManager instance that loads
+// assets from the given file system, like an embed.FS.
+func NewAssetManager(fsys fs.FS) *AssetManager {
+	return &AssetManager{
+		fsys:   fsys,
+		assets: make(map[string]*assetEntry),
+		scenes: make(map[string][]string),
+	}
+}
+
+// NewAssetManagerFromDir function creates a new AssetManager instance that
+// loads assets from the given root directory.
+func NewAssetManagerFromDir(root string) *AssetManager {
+	return NewAssetManager(os.DirFS(root))
+}
+
+// -----------------------------------------------------------------------------
+// AssetManager private methods
+// -----------------------------------------------------------------------------
+
+// getEntry method returns the entry for the given asset name.
+func (m *AssetManager) getEntry(name string) (*assetEntry, error) {
+	entry, ok := m.assets[name]
+	if !ok {
+		return nil, fmt.Errorf("asset %s not registered", name)
+	}
+	return entry, nil
+}
+
+// load method loads the given asset entry if it is not in the cache.
+func (m *AssetManager) load(name string, entry *assetEntry) error {
+	if entry.loaded {
+		return nil
+	}
+	value, err := m.read(name, entry)
+	if err != nil {
+		return err
+	}
+	entry.value = value
+	entry.loaded = true
+	return nil
+}
+
+// read method reads the given asset entry from the file system without
+// storing it in the cache.
+func (m *AssetManager) read(name string, entry *assetEntry) (any, error) {
+	tools.Logger.WithField("module", "assetmanager").
+		WithField("struct", "AssetManager").
+		WithField("method", "read").
+		Debugf("read asset %s", name)
+	value, err := entry.loader(m.fsys)
+	if err != nil {
+		return nil, fmt.Errorf("asset %s: %w", name, err)
+	}
+	return value, nil
+}
+
+// -----------------------------------------------------------------------------
+// AssetManager public methods
+// -----------------------------------------------------------------------------
+
+// Acquire method returns the given asset, loading it if it is not in the
+// cache, and increases its reference count. Every call has to be paired with
+// a Release call.
+func (m *AssetManager) Acquire(name string) (any, error) {
+	entry, err := m.getEntry(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.load(name, entry); err != nil {
+		return nil, err
+	}
+	entry.refs++
+	return entry.value, nil
+}
+
+// AddSceneAssets method adds the given assets to the list of assets to be
+// preloaded for the given scene.
+func (m *AssetManager) AddSceneAssets(sceneName string, names ...string) {
+	m.scenes[sceneName] = append(m.scenes[sceneName], names...)
+}
+
+// Clone method returns a copy of the given asset. The reference count is not
+// modified, so an asset not in the cache is read and returned without being
+// cached, because nobody would release it. Assets registered without any
+// cloner are returned as they are.
+func (m *AssetManager) Clone(name string) (any, error) {
+	entry, err := m.getEntry(name)
+	if err != nil {
+		return nil, err
+	}
+	if !entry.loaded {
+		return m.read(name, entry)
+	}
+	if entry.cloner == nil {
+		return entry.value, nil
+	}
+	return entry.cloner(entry.value), nil
+}
+
+// CloneCanvas method returns a copy of the given canvas asset.
+func (m *AssetManager) CloneCanvas(name string) (*Canvas, error) {
+	value, err := m.Clone(name)
+	if err != nil {
+		return nil, err
+	}
+	canvas, ok := value.(*Canvas)
+	if !ok {
+		return nil, fmt.Errorf("asset %s is not a canvas", name)
+	}
+	return canvas, nil
+}
+
+// CloneEntities method returns new instances for all entities in the given
+// entity list asset.
+func (m *AssetManager) CloneEntities(name string) ([]IEntity, error) {
+	value, err := m.Clone(name)
+	if err != nil {
+		return nil, err
+	}
+	entities, ok := value.([]IEntity)
+	if !ok {
+		return nil, fmt.Errorf("asset %s is not an entity list", name)
+	}
+	return entities, nil
+}
+
+// GetFS method returns the file system assets are loaded from.
+func (m *AssetManager) GetFS() fs.FS {
+	return m.fsys
+}
+
+// GetRefCount method returns the reference count for the given asset.
+func (m *AssetManager) GetRefCount(name string) int {
+	if entry, ok := m.assets[name]; ok {
+		return entry.refs
+	}
+	return 0
+}
+
+// GetSceneAssets method returns all assets to be preloaded for the given
+// scene.
+func (m *AssetManager) GetSceneAssets(sceneName string) []string {
+	return m.scenes[sceneName]
+}
+
+// IsLoaded method checks if the given asset is in the cache.
+func (m *AssetManager) IsLoaded(name string) bool {
+	entry, ok := m.assets[name]
+	return ok && entry.loaded
+}
+
+// IsRegistered method checks if the given asset has been registered.
+func (m *AssetManager) IsRegistered(name string) bool {
+	_, ok := m.assets[name]
+	return ok
+}
+
+// Preload method acquires all assets for the given scene. The progress
+// function, if any, is called after every asset is loaded. All assets
+// acquired are released if any asset fails to load.
+func (m *AssetManager) Preload(sceneName string, progress AssetProgress) error {
+	names := m.scenes[sceneName]
+	for i, name := range names {
+		if _, err := m.Acquire(name); err != nil {
+			for _, acquired := range names[:i] {
+				m.Release(acquired)
+			}
+			return err
+		}
+		if progress != nil {
+			progress(name, i+1, len(names))
+		}
+	}
+	return nil
+}
+
+// Register method registers an asset with the given logical name, loader and
+// cloner. Any asset with the same name is replaced.
+func (m *AssetManager) Register(name string, loader AssetLoader, cloner AssetCloner) {
+	m.assets[name] = &assetEntry{
+		loader: loader,
+		cloner: cloner,
+	}
+}
+
+// RegisterCanvas method registers a canvas asset with the content of the
+// given text file.
+func (m *AssetManager) RegisterCanvas(name string, path string, style *tcell.Style) {
+	loader := func(fsys fs.FS) (any, error) {
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		return NewCanvasFromString(strings.TrimRight(string(content), "\n"), style), nil
+	}
+	cloner := func(value any) any {
+		return CloneCanvas(value.(*Canvas))
+	}
+	m.Register(name, loader, cloner)
+}
+
+// RegisterEntities method registers an entity list asset with the content of
+// the given JSON file. Cloning the asset creates new entity instances.
+func (m *AssetManager) RegisterEntities(name string, path string, origin *api.Point, builtin IBuiltIn) {
+	var content []map[string]any
+	newEntities := func() (any, error) {
+		var result []IEntity
+		for _, mapEntity := range content {
+			entities, err := newEntitiesFromMap(GetPrefabManager(), mapEntity, origin, builtin)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, entities...)
+		}
+		return result, nil
+	}
+	loader := func(fsys fs.FS) (any, error) {
+		jsonContent, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(jsonContent, &content); err != nil {
+			return nil, err
+		}
+		return newEntities()
+	}
+	cloner := func(value any) any {
+		// content was already validated when the asset was loaded.
+		entities, _ := newEntities()
+		return entities
+	}
+	m.Register(name, loader, cloner)
+}
+
+// Release method decreases the reference count for the given asset. The
+// asset is removed from the cache when it is not referenced anymore.
+func (m *AssetManager) Release(name string) {
+	entry, ok := m.assets[name]
+	if !ok || entry.refs == 0 {
+		return
+	}
+	entry.refs--
+	if entry.refs == 0 {
+		tools.Logger.WithField("module", "assetmanager").
+			WithField("struct", "AssetManager").
+			WithField("method", "Release").
+			Debugf("unload asset %s", name)
+		entry.value = nil
+		entry.loaded = false
+	}
+}
+
+// SetFS method sets the file system assets are loaded from. Assets already
+// in the cache are not reloaded.
+func (m *AssetManager) SetFS(fsys fs.FS) {
+	m.fsys = fsys
+}
+
+// Unload method releases all assets preloaded for the given scene.
+func (m *AssetManager) Unload(sceneName string) {
+	for _, name := range m.scenes[sceneName] {
+		m.Release(name)
+	}
+}
@@ -0,0 +1,120 @@
+package engine_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+func TestAssetManager(t *testing.T) {
+	fsys := fstest.MapFS{
+		"canvas.txt": {Data: []byte("ab\ncd\n")},
+		"map.json": {Data: []byte(`[{"class": "Wall", "name": "wall/1", "position": [1, 1],
+			"size": [2, 1], "style": ["white", "black"], "ch": "#"}]`)},
+	}
+	manager := engine.NewAssetManager(fsys)
+	manager.RegisterCanvas("canvas/1", "canvas.txt", nil)
+	manager.RegisterEntities("map/1", "map.json", api.NewPoint(1, 1), &BuiltInTest{})
+	manager.RegisterCanvas("canvas/missing", "missing.txt", nil)
+
+	if _, err := manager.Acquire("unknown"); err == nil {
+		t.Errorf("[1] Acquire Error exp:error got:nil")
+	}
+
+	value, err := manager.Acquire("canvas/1")
+	if err != nil {
+		t.Errorf("[2] Acquire Error exp:nil got:%v", err)
+	}
+	canvas := value.(*engine.Canvas)
+	if got := canvas.GetRuneAt(api.NewPoint(1, 1)); got != 'd' {
+		t.Errorf("[2] Acquire Error exp:%c got:%c", 'd', got)
+	}
+	again, _ := manager.Acquire("canvas/1")
+	if again != value {
+		t.Errorf("[3] Acquire Error exp:%p got:%p", value, again)
+	}
+	if got := manager.GetRefCount("canvas/1"); got != 2 {
+		t.Errorf("[3] GetRefCount Error exp:%d got:%d", 2, got)
+	}
+	clone, _ := manager.CloneCanvas("canvas/1")
+	if clone == canvas || !clone.IsEqual(canvas) {
+		t.Errorf("[4] CloneCanvas Error exp:copy got:%p", clone)
+	}
+	manager.Release("canvas/1")
+	if !manager.IsLoaded("canvas/1") {
+		t.Errorf("[5] Release Error exp:%t got:%t", true, false)
+	}
+	manager.Release("canvas/1")
+	if manager.IsLoaded("canvas/1") {
+		t.Errorf("[5] Release Error exp:%t got:%t", false, true)
+	}
+
+	entities, err := manager.CloneEntities("map/1")
+	if err != nil || len(entities) != 1 {
+		t.Errorf("[6] CloneEntities Error exp:%d got:%d %v", 1, len(entities), err)
+	}
+	if got := entities[0].GetPosition(); !got.IsEqual(api.NewPoint(2, 2)) {
+		t.Errorf("[6] CloneEntities Error exp:%s got:%s", "[2,2]", got.ToString())
+	}
+	others, _ := manager.CloneEntities("map/1")
+	if others[0] == entities[0] {
+		t.Errorf("[6] CloneEntities Error exp:new instance got:%p", others[0])
+	}
+	// assets cloned without being acquired are not cached.
+	if manager.IsLoaded("map/1") {
+		t.Errorf("[6] IsLoaded Error exp:%t got:%t", false, true)
+	}
+
+	manager.AddSceneAssets("scene/1", "canvas/1", "map/1")
+	progress := []int{}
+	if err := manager.Preload("scene/1", func(name string, loaded int, total int) {
+		progress = append(progress, loaded)
+	}); err != nil {
+		t.Errorf("[7] Preload Error exp:nil got:%v", err)
+	}
+	if len(progress) != 2 || progress[1] != 2 {
+		t.Errorf("[7] Preload Error exp:%v got:%v", []int{1, 2}, progress)
+	}
+	manager.Unload("scene/1")
+	if manager.IsLoaded("canvas/1") || manager.IsLoaded("map/1") {
+		t.Errorf("[8] Unload Error exp:%t got:%t", false, true)
+	}
+
+	manager.AddSceneAssets("scene/2", "canvas/1", "canvas/missing")
+	if err := manager.Preload("scene/2", nil); err == nil {
+		t.Errorf("[9] Preload Error exp:error got:nil")
+	}
+	if got := manager.GetRefCount("canvas/1"); got != 0 {
+		t.Errorf("[9] GetRefCount Error exp:%d got:%d", 0, got)
+	}
+}
+
+func TestAssetManagerSceneActivation(t *testing.T) {
+	fsys := fstest.MapFS{
+		"canvas.txt": {Data: []byte("ab\ncd\n")},
+	}
+	manager := engine.NewAssetManager(fsys)
+	manager.RegisterCanvas("canvas/1", "canvas.txt", nil)
+	manager.AddSceneAssets("scene/assets/1", "canvas/1")
+	e := engine.NewEngine()
+	e.SetAssetManager(manager)
+	scene := e.NewScene("scene/assets/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(10, 10)))
+	e.GetSceneManager().AddScene(scene)
+
+	// scene assets are preloaded when the scene is activated and released
+	// when it is deactivated.
+	e.GetSceneManager().SetSceneAsActive(scene)
+	if got := manager.GetRefCount("canvas/1"); got != 1 || !manager.IsLoaded("canvas/1") {
+		t.Errorf("[1] SetSceneAsActive Error exp:%d got:%d", 1, got)
+	}
+	e.GetSceneManager().SetSceneAsActive(scene)
+	if got := manager.GetRefCount("canvas/1"); got != 1 {
+		t.Errorf("[2] SetSceneAsActive Error exp:%d got:%d", 1, got)
+	}
+	e.GetSceneManager().RemoveSceneAsActive(scene)
+	if manager.IsLoaded("canvas/1") {
+		t.Errorf("[3] RemoveSceneAsActive Error exp:%t got:%t", false, true)
+	}
+}
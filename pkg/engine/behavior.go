@@ -4,10 +4,12 @@ package engine
 // Package constants
 // -----------------------------------------------------------------------------
 const (
+	BehaviorAdded   string = "added"
 	BehaviorConsume string = "consume"
 	BehaviorDraw    string = "draw"
 	BehaviorInit    string = "init"
 	BehaviorNotify  string = "notify"
+	BehaviorRemoved string = "removed"
 	BehaviorStart   string = "start"
 	BehaviorStop    string = "stop"
 	BehaviorUpdate  string = "update"
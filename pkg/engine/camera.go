@@ -18,10 +18,16 @@ import (
 // ICamera interface defines all functions a Camera has to implement.
 type ICamera interface {
 	//Draw(bool, tcell.Screen)
+	GetLayout() *Layout
 	GetOrigin() *api.Point
+	GetSize() *api.Size
 	Init(tcell.Screen)
 	RenderCellAt(*api.Point, ICell) bool
 	SetDryRun(bool)
+	SetEngine(*Engine)
+	SetLayout(*Layout)
+	SetOrigin(*api.Point)
+	SetSize(*api.Size)
 }
 
 // -----------------------------------------------------------------------------
@@ -35,13 +41,19 @@ type ICamera interface {
 // oldCanvas Canvas instance contains the last canvas being flushed.
 // Canvas Canvas instance contains the latest canvas to be flushed.
 // DryRun bool flag is set true for testing where termbox is not called.
+// engine is the engine whose color mapper is used to render cells. Cameras
+// without any engine use the default engine.
+// layout places the camera in the screen when the screen is resized. Cameras
+// without any layout keep their origin and size.
 // TODO: Camera requires an origin point to be used as offset in the engine
 // display tcell.Screen.
 type Camera struct {
 	origin *api.Point
 	size   *api.Size
 	screen tcell.Screen
+	engine *Engine
 	dryRun bool
+	layout *Layout
 }
 
 // NewCamera function creates a new camera with the given width and height.
@@ -53,6 +65,8 @@ func NewCamera(origin *api.Point, size *api.Size) *Camera {
 		origin: origin,
 		size:   size,
 		screen: nil,
+		engine: nil,
+		layout: nil,
 	}
 }
 
@@ -64,6 +78,15 @@ func NewCamera(origin *api.Point, size *api.Size) *Camera {
 // Camera private methods
 // -----------------------------------------------------------------------------
 
+// getEngine method returns the engine the camera belongs to, or the default
+// engine if the camera does not belong to any engine.
+func (s *Camera) getEngine() *Engine {
+	if s.engine == nil {
+		return GetEngine()
+	}
+	return s.engine
+}
+
 // -----------------------------------------------------------------------------
 // Camera public methods
 // -----------------------------------------------------------------------------
@@ -77,6 +100,11 @@ func NewCamera(origin *api.Point, size *api.Size) *Camera {
 //    }
 //}
 
+// GetLayout method returns the layout used to place the camera in the screen.
+func (s *Camera) GetLayout() *Layout {
+	return s.layout
+}
+
 // GetOrigin method returns the origin point for the camera.
 func (s *Camera) GetOrigin() *api.Point {
 	return s.origin
@@ -101,6 +129,7 @@ func (s *Camera) RenderCellAt(point *api.Point, cell ICell) bool {
 		col, row := point.Get()
 		fg, bg, attrs := cell.GetStyle().Decompose()
 		style := tcell.StyleDefault.Background(bg).Foreground(fg).Attributes(attrs)
+		style = s.getEngine().GetColorMapper().MapStyle(style)
 		s.screen.SetContent(col+s.origin.X, row+s.origin.Y, cell.GetRune(), nil, style)
 	}
 	return true
@@ -112,4 +141,25 @@ func (s *Camera) SetDryRun(dryRun bool) {
 	s.dryRun = dryRun
 }
 
+// SetEngine method sets the engine the camera belongs to.
+func (s *Camera) SetEngine(engine *Engine) {
+	s.engine = engine
+}
+
+// SetLayout method sets the layout used to place the camera in the screen
+// every time the screen is resized.
+func (s *Camera) SetLayout(layout *Layout) {
+	s.layout = layout
+}
+
+// SetOrigin method sets the origin point for the camera.
+func (s *Camera) SetOrigin(origin *api.Point) {
+	s.origin = origin
+}
+
+// SetSize method sets the size for the camera.
+func (s *Camera) SetSize(size *api.Size) {
+	s.size = size
+}
+
 var _ ICamera = (*Camera)(nil)
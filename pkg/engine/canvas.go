@@ -6,6 +6,7 @@ package engine
 import (
 	"os"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/pkg/api"
@@ -119,12 +120,14 @@ func NewCanvasFromString(str string, style *tcell.Style) *Canvas {
 	lines := strings.Split(str, "\n")
 	width := 0
 	height := len(lines)
+	// every rune takes one column, so non-ASCII strings, like translated
+	// strings, are not spread out.
 	for _, line := range lines {
-		width = tools.Max(width, len(line))
+		width = tools.Max(width, utf8.RuneCountInString(line))
 	}
 	canvas := NewCanvas(api.NewSize(width, height))
 	for row, line := range lines {
-		for col, ch := range line {
+		for col, ch := range []rune(line) {
 			cell := NewCell(style, ch)
 			canvas.SetCellAt(api.NewPoint(col, row), cell)
 		}
@@ -459,13 +462,34 @@ func (c *Canvas) WriteStringInCanvasAt(str string, style *tcell.Style, position
 		if row >= c.Height() {
 			break
 		}
-		for colLine, ch := range line {
-			col := colLine + position.X
+		// columns are counted in runes, not in bytes.
+		col := position.X
+		for _, ch := range line {
 			if col >= c.Width() {
 				break
 			}
 			cell := NewCell(style, ch)
 			c.SetCellAt(api.NewPoint(col, row), cell)
+			col++
+		}
+	}
+}
+
+// WriteMarkupInCanvasAt method writes the given markup string in the canvas
+// at the given position, where the given style is the default style for any
+// text without tags. Any character exciding the canvas size is missed.
+func (c *Canvas) WriteMarkupInCanvasAt(str string, style *tcell.Style, position *api.Point) {
+	for rowLine, line := range ParseMarkup(str, style) {
+		row := rowLine + position.Y
+		if row >= c.Height() {
+			break
+		}
+		for colLine, cell := range line {
+			col := colLine + position.X
+			if col >= c.Width() {
+				break
+			}
+			c.SetCellAt(api.NewPoint(col, row), cell)
 		}
 	}
 }
@@ -0,0 +1,256 @@
+// color.go contains all data and methods required to detect the terminal
+// color capabilities and to down-map any style to the colors available in
+// the terminal.
+package engine
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// ColorDepth type defines the number of colors the terminal is able to
+// display.
+type ColorDepth int
+
+const (
+	ColorDepthMono ColorDepth = iota
+	ColorDepth8
+	ColorDepth16
+	ColorDepth256
+	ColorDepthTrueColor
+)
+
+// -----------------------------------------------------------------------------
+// Package private constants
+// -----------------------------------------------------------------------------
+
+const (
+	// monoChromaThreshold is the chroma value over which a color is
+	// considered highlighted when displayed in a monochrome terminal.
+	monoChromaThreshold = 0.3
+)
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// ColorDepthFromColors function returns the color depth for the given number
+// of colors, as returned by tcell.Screen.Colors().
+func ColorDepthFromColors(colors int) ColorDepth {
+	switch {
+	case colors >= 1<<24:
+		return ColorDepthTrueColor
+	case colors >= 256:
+		return ColorDepth256
+	case colors >= 16:
+		return ColorDepth16
+	case colors >= 8:
+		return ColorDepth8
+	}
+	return ColorDepthMono
+}
+
+// DetectColorDepth function returns the color depth for the given screen.
+func DetectColorDepth(screen tcell.Screen) ColorDepth {
+	if screen == nil {
+		return ColorDepthTrueColor
+	}
+	return ColorDepthFromColors(screen.Colors())
+}
+
+// -----------------------------------------------------------------------------
+// ColorDepth public methods
+// -----------------------------------------------------------------------------
+
+// NumberOfColors method returns the number of palette colors for the color
+// depth. True color returns zero because it is not limited to a palette.
+func (d ColorDepth) NumberOfColors() int {
+	switch d {
+	case ColorDepth8:
+		return 8
+	case ColorDepth16:
+		return 16
+	case ColorDepth256:
+		return 256
+	case ColorDepthMono:
+		return 2
+	}
+	return 0
+}
+
+// String method returns the color depth as a string.
+func (d ColorDepth) String() string {
+	switch d {
+	case ColorDepthMono:
+		return "mono"
+	case ColorDepth8:
+		return "8"
+	case ColorDepth16:
+		return "16"
+	case ColorDepth256:
+		return "256"
+	case ColorDepthTrueColor:
+		return "truecolor"
+	}
+	return fmt.Sprintf("ColorDepth(%d)", int(d))
+}
+
+// -----------------------------------------------------------------------------
+//
+// ColorMapper
+//
+// -----------------------------------------------------------------------------
+
+// ColorMapper structure maps any color to the nearest color available for
+// the given color depth, using the perceptual distance between colors.
+// In monochrome terminals colors are removed and the meaning is preserved
+// with attributes: bright colors are shown in bold and light backgrounds are
+// shown reversed.
+// cache stores every color already mapped.
+type ColorMapper struct {
+	depth   ColorDepth
+	palette []colorful.Color
+	cache   map[tcell.Color]tcell.Color
+}
+
+// NewColorMapper function creates a new ColorMapper instance for the given
+// color depth.
+func NewColorMapper(depth ColorDepth) *ColorMapper {
+	mapper := &ColorMapper{
+		depth:   depth,
+		palette: nil,
+		cache:   make(map[tcell.Color]tcell.Color),
+	}
+	if n := depth.NumberOfColors(); depth != ColorDepthMono && n != 0 {
+		mapper.palette = make([]colorful.Color, n)
+		for i := 0; i < n; i++ {
+			mapper.palette[i] = toColorful(tcell.PaletteColor(i))
+		}
+	}
+	return mapper
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// toColorful function converts a tcell.Color into a colorful.Color.
+func toColorful(color tcell.Color) colorful.Color {
+	r, g, b := color.RGB()
+	return colorful.Color{
+		R: float64(r) / 255.0,
+		G: float64(g) / 255.0,
+		B: float64(b) / 255.0,
+	}
+}
+
+// isMappable function returns if the color has a RGB value to be mapped.
+// Default and reset colors are never mapped.
+func isMappable(color tcell.Color) bool {
+	if !color.Valid() || color == tcell.ColorReset {
+		return false
+	}
+	r, _, _ := color.RGB()
+	return r >= 0
+}
+
+// -----------------------------------------------------------------------------
+// ColorMapper private methods
+// -----------------------------------------------------------------------------
+
+// isInPalette method checks if the color is already available in the palette
+// for the mapper color depth.
+func (m *ColorMapper) isInPalette(color tcell.Color) bool {
+	if color.IsRGB() {
+		return false
+	}
+	index := int(color - tcell.ColorValid)
+	return index >= 0 && index < len(m.palette)
+}
+
+// nearest method returns the palette color with the lowest perceptual
+// distance to the given color.
+func (m *ColorMapper) nearest(color tcell.Color) tcell.Color {
+	target := toColorful(color)
+	best := 0
+	bestDistance := -1.0
+	for index, c := range m.palette {
+		distance := target.DistanceCIEDE2000(c)
+		if bestDistance < 0 || distance < bestDistance {
+			best = index
+			bestDistance = distance
+		}
+	}
+	return tcell.PaletteColor(best)
+}
+
+// mapMono method removes colors from the style and set attributes to keep
+// the meaning of those colors.
+func (m *ColorMapper) mapMono(style tcell.Style) tcell.Style {
+	fg, bg, attrs := style.Decompose()
+	if isMappable(fg) {
+		if _, chroma, _ := toColorful(fg).Hcl(); chroma > monoChromaThreshold {
+			attrs |= tcell.AttrBold
+		}
+	}
+	if isMappable(bg) {
+		bgL, _, _ := toColorful(bg).Lab()
+		// the terminal default foreground is unknown, a mid lightness is
+		// assumed, so only light backgrounds are reversed.
+		fgL := 0.5
+		if isMappable(fg) {
+			fgL, _, _ = toColorful(fg).Lab()
+		}
+		if bgL > fgL {
+			attrs |= tcell.AttrReverse
+		}
+	}
+	return tcell.StyleDefault.Attributes(attrs)
+}
+
+// -----------------------------------------------------------------------------
+// ColorMapper public methods
+// -----------------------------------------------------------------------------
+
+// GetColorDepth method returns the color depth for the mapper.
+func (m *ColorMapper) GetColorDepth() ColorDepth {
+	return m.depth
+}
+
+// MapColor method returns the nearest color available for the given color.
+func (m *ColorMapper) MapColor(color tcell.Color) tcell.Color {
+	if m == nil || m.depth == ColorDepthTrueColor || m.depth == ColorDepthMono {
+		return color
+	}
+	if !isMappable(color) || m.isInPalette(color) {
+		return color
+	}
+	if mapped, ok := m.cache[color]; ok {
+		return mapped
+	}
+	mapped := m.nearest(color)
+	m.cache[color] = mapped
+	return mapped
+}
+
+// MapStyle method returns the given style with colors down-mapped to those
+// available for the mapper color depth.
+func (m *ColorMapper) MapStyle(style tcell.Style) tcell.Style {
+	if m == nil || m.depth == ColorDepthTrueColor {
+		return style
+	}
+	if m.depth == ColorDepthMono {
+		return m.mapMono(style)
+	}
+	fg, bg, attrs := style.Decompose()
+	return tcell.StyleDefault.
+		Foreground(m.MapColor(fg)).
+		Background(m.MapColor(bg)).
+		Attributes(attrs)
+}
@@ -0,0 +1,84 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+func TestColorDepthFromColors(t *testing.T) {
+	cases := []struct {
+		input int
+		exp   engine.ColorDepth
+	}{
+		{input: 0, exp: engine.ColorDepthMono},
+		{input: 2, exp: engine.ColorDepthMono},
+		{input: 8, exp: engine.ColorDepth8},
+		{input: 16, exp: engine.ColorDepth16},
+		{input: 256, exp: engine.ColorDepth256},
+		{input: 1 << 24, exp: engine.ColorDepthTrueColor},
+	}
+	for i, c := range cases {
+		got := engine.ColorDepthFromColors(c.input)
+		if got != c.exp {
+			t.Errorf("[%d] ColorDepthFromColors Error exp:%s got:%s", i, c.exp, got)
+		}
+	}
+}
+
+func TestColorMapperMapColor(t *testing.T) {
+	cases := []struct {
+		depth engine.ColorDepth
+		input tcell.Color
+		exp   tcell.Color
+	}{
+		{depth: engine.ColorDepthTrueColor, input: tcell.NewHexColor(0xFE0101), exp: tcell.NewHexColor(0xFE0101)},
+		{depth: engine.ColorDepth8, input: tcell.NewHexColor(0xFE0101), exp: tcell.ColorMaroon},
+		{depth: engine.ColorDepth8, input: tcell.ColorRed, exp: tcell.ColorMaroon},
+		{depth: engine.ColorDepth16, input: tcell.ColorRed, exp: tcell.ColorRed},
+		{depth: engine.ColorDepth16, input: tcell.NewHexColor(0xFE0101), exp: tcell.ColorRed},
+		{depth: engine.ColorDepth16, input: tcell.ColorDarkBlue, exp: tcell.ColorNavy},
+		{depth: engine.ColorDepth16, input: tcell.ColorDefault, exp: tcell.ColorDefault},
+		{depth: engine.ColorDepth256, input: tcell.NewHexColor(0x0000FF), exp: tcell.ColorBlue},
+		{depth: engine.ColorDepth256, input: tcell.Color208, exp: tcell.Color208},
+	}
+	for i, c := range cases {
+		mapper := engine.NewColorMapper(c.depth)
+		got := mapper.MapColor(c.input)
+		if got != c.exp {
+			t.Errorf("[%d] MapColor Error exp:%s got:%s", i, c.exp, got)
+		}
+	}
+}
+
+func TestColorMapperMapStyle(t *testing.T) {
+	mapper := engine.NewColorMapper(engine.ColorDepthMono)
+	cases := []struct {
+		input tcell.Style
+		exp   tcell.AttrMask
+	}{
+		{input: tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack), exp: tcell.AttrNone},
+		{input: tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlack), exp: tcell.AttrBold},
+		{input: tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite), exp: tcell.AttrReverse},
+		{input: tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack).Underline(true), exp: tcell.AttrUnderline},
+		{input: tcell.StyleDefault.Background(tcell.ColorWhite), exp: tcell.AttrReverse},
+		{input: tcell.StyleDefault.Background(tcell.ColorNavy), exp: tcell.AttrNone},
+	}
+	for i, c := range cases {
+		got := mapper.MapStyle(c.input)
+		fg, bg, attrs := got.Decompose()
+		if fg != tcell.ColorDefault || bg != tcell.ColorDefault {
+			t.Errorf("[%d] MapStyle Error.Colors exp:default got:%s/%s", i, fg, bg)
+		}
+		if attrs != c.exp {
+			t.Errorf("[%d] MapStyle Error.Attrs exp:%d got:%d", i, c.exp, attrs)
+		}
+	}
+
+	var nilMapper *engine.ColorMapper
+	style := tcell.StyleDefault.Foreground(tcell.NewHexColor(0x123456))
+	if got := nilMapper.MapStyle(style); got != style {
+		t.Errorf("[nil] MapStyle Error exp:%v got:%v", style, got)
+	}
+}
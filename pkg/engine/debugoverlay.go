@@ -0,0 +1,215 @@
+// debugoverlay.go contains the debug overlay owned by the engine. The overlay
+// displays runtime statistics on top of all scenes: actual FPS against the
+// target FPS, time spent in every phase of the tick, entities per scene,
+// focus owners, mailbox queue depths and any custom statistic registered.
+// A rolling graph with the time spent in the last frames flags spikes.
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	DebugOverlaySceneName  = "engine/debug"
+	DebugOverlayEntityName = "engine/debug/overlay"
+)
+
+// -----------------------------------------------------------------------------
+// Package private constants
+// -----------------------------------------------------------------------------
+
+const (
+	debugOverlayWidth = 48
+)
+
+var (
+	// debugGraphRunes contains runes used to draw the frame graph from the
+	// lowest to the highest value.
+	debugGraphRunes = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// DebugStat type defines a function that returns a custom statistic to be
+// displayed in the debug overlay.
+type DebugStat func() string
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// formatDuration function returns the given duration in milliseconds.
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
+
+// -----------------------------------------------------------------------------
+//
+// DebugOverlay
+//
+// -----------------------------------------------------------------------------
+
+// DebugOverlay structure defines the entity that displays all runtime
+// statistics collected by the engine.
+type DebugOverlay struct {
+	*Entity
+	engine     *Engine
+	spikeStyle *tcell.Style
+}
+
+// NewDebugOverlay function creates a new DebugOverlay instance for the given
+// engine.
+func NewDebugOverlay(engine *Engine, position *api.Point) *DebugOverlay {
+	style := NewStyle(tcell.ColorWhite, tcell.ColorNavy, tcell.AttrNone)
+	overlay := &DebugOverlay{
+		Entity:     NewEntity(DebugOverlayEntityName, position, api.NewSize(debugOverlayWidth, 1), style),
+		engine:     engine,
+		spikeStyle: NewStyle(tcell.ColorRed, tcell.ColorNavy, tcell.AttrBold),
+	}
+	overlay.SetZLevel(1 << 16)
+	return overlay
+}
+
+// -----------------------------------------------------------------------------
+// DebugOverlay private methods
+// -----------------------------------------------------------------------------
+
+// graph method returns the rolling graph with the time spent in the last
+// frames and the index for every spike in the graph.
+func (o *DebugOverlay) graph(width int) (string, []int) {
+	stats := o.engine.GetStats()
+	samples := stats.GetSamples()
+	if len(samples) > width {
+		samples = samples[len(samples)-width:]
+	}
+	// scale the graph to the frame budget, or to the highest frame when
+	// there is not any budget.
+	scale := stats.GetBudget()
+	for _, sample := range samples {
+		if sample.Work > scale {
+			scale = sample.Work
+		}
+	}
+	var graph strings.Builder
+	var spikes []int
+	for i, sample := range samples {
+		level := 0
+		if scale != 0 {
+			level = int(float64(sample.Work) / float64(scale) * float64(len(debugGraphRunes)-1))
+		}
+		graph.WriteRune(debugGraphRunes[level])
+		if stats.IsSpike(sample) {
+			spikes = append(spikes, i)
+		}
+	}
+	return graph.String(), spikes
+}
+
+// lines method returns all lines with statistics to be displayed.
+func (o *DebugOverlay) lines() []string {
+	stats := o.engine.GetStats()
+	lines := []string{
+		fmt.Sprintf("FPS %.1f/%.1f frame %s avg %s",
+			stats.GetFPS(), stats.GetTargetFPS(),
+			formatDuration(stats.GetBudget()), formatDuration(stats.GetAverageWork())),
+	}
+	phases := []string{}
+	for _, phase := range StatsPhases {
+		phases = append(phases, fmt.Sprintf("%s %s", phase, formatDuration(stats.GetPhaseTime(phase))))
+	}
+	// collision time is displayed as a part of the update phase.
+	phases[0] += fmt.Sprintf(" (%s %s)", StatsPhaseCollision, formatDuration(stats.GetPhaseTime(StatsPhaseCollision)))
+	lines = append(lines, phases[0], strings.Join(phases[1:], " "))
+
+	for _, scene := range o.engine.GetSceneManager().GetAllScenes() {
+		if scene.GetName() == DebugOverlaySceneName {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("scene %s: %d entities", scene.GetName(), len(scene.GetEntities())))
+	}
+
+	withFocus := o.engine.GetFocusManager().GetEntitiesWithFocus()
+	sceneNames := make([]string, 0, len(withFocus))
+	for sceneName := range withFocus {
+		sceneNames = append(sceneNames, sceneName)
+	}
+	sort.Strings(sceneNames)
+	for _, sceneName := range sceneNames {
+		for _, entity := range withFocus[sceneName] {
+			lines = append(lines, fmt.Sprintf("focus %s: %s", sceneName, entity.GetName()))
+		}
+	}
+
+	depths := o.engine.GetMailbox().GetQueueDepths()
+	queues := make([]string, 0, len(depths))
+	for queue := range depths {
+		queues = append(queues, queue)
+	}
+	sort.Strings(queues)
+	for _, queue := range queues {
+		lines = append(lines, fmt.Sprintf("mailbox %s: %d", queue, depths[queue]))
+	}
+
+	debugStats := o.engine.GetDebugStats()
+	names := make([]string, 0, len(debugStats))
+	for name := range debugStats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("%s: %s", name, debugStats[name]()))
+	}
+	return lines
+}
+
+// updateCanvas method updates the overlay canvas with latest statistics.
+func (o *DebugOverlay) updateCanvas() {
+	lines := o.lines()
+	graph, spikes := o.graph(debugOverlayWidth)
+	size := api.NewSize(debugOverlayWidth, len(lines)+1)
+	canvas := NewCanvas(size)
+	canvas.FillWithCell(NewCell(o.GetStyle(), ' '))
+	for row, line := range lines {
+		// lines are cut by runes, so multi-byte runes are not split.
+		if runes := []rune(line); len(runes) > debugOverlayWidth {
+			line = string(runes[:debugOverlayWidth])
+		}
+		canvas.WriteStringInCanvasAt(line, o.GetStyle(), api.NewPoint(0, row))
+	}
+	graphRow := len(lines)
+	canvas.WriteStringInCanvasAt(graph, o.GetStyle(), api.NewPoint(0, graphRow))
+	for _, col := range spikes {
+		canvas.SetStyleAt(api.NewPoint(col, graphRow), o.spikeStyle)
+	}
+	o.SetSize(size)
+	o.SetCanvas(canvas)
+}
+
+// -----------------------------------------------------------------------------
+// DebugOverlay public methods
+// -----------------------------------------------------------------------------
+
+// Draw method updates the overlay with latest statistics and renders it.
+func (o *DebugOverlay) Draw(scene IScene) {
+	o.updateCanvas()
+	o.Entity.Draw(scene)
+}
+
+// Refresh method refreshes the overlay with latest statistics.
+func (o *DebugOverlay) Refresh() {
+	o.updateCanvas()
+}
+
+var _ IEntity = (*DebugOverlay)(nil)
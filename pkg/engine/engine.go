@@ -21,36 +21,73 @@
 // - ObserverManager: Manages observer instances for handling event
 // notifications.
 // - FocusManager: Manages focus for interactive elements within the scenes.
+// - FrameStats: Collects time statistics for every frame, displayed in the
+//   debug overlay toggled with F12.
+// - AssetManager: Loads and caches assets by logical name from a directory or
+//   an embedded file system.
+// - AssetReloader: Reloads watched JSON entity files and canvas files when
+//   they change, displaying any error in an overlay.
+// - PrefabManager: Contains named JSON templates for entities, which can be
+//   instantiated many times and referenced from imported JSON files.
+// - Query: Finds entities in a scene by tag, class name, region, type or any
+//   other condition, using indexes the scene keeps by tag and class name.
+// - Layout: Anchors entities to the camera and cameras to the screen edges or
+//   center, with fixed or percentage sizes limited to a minimum and maximum
+//   size. All layouts are applied again when the screen is resized.
+// - Hot keys: Handlers registered with AddHotKey() are called before scenes
+//   are updated and the key is not passed to any entity.
+// - Mailbox: Every engine has its own mailbox, so engines running in the same
+//   process do not share topics or messages. Entities reach it with
+//   scene.GetEngine().GetMailbox().
 //
 // Global Constants and Variables:
 // - EngineMainSceneName: Default scene name for the main engine scene.
-// - EngineSingleton: Singleton instance of the Engine, accessible via
-// GetEngine().
+// - EngineSingleton: Default instance of the Engine, accessible via
+// GetEngine(). Several engines can run in the same process, every one
+// created with NewEngine() and displayed in its own screen. Scenes added to
+// an engine belong to that engine. GetEngines() returns all engines which
+// have not been shut down, like to refresh all of them when the theme
+// changes.
 //
 // Core Methods:
-// - Run(fps float64): Runs the engine in an infinite loop, processing events
-//   and updating scenes at the specified fps.
+// - Run(fps float64) error: Runs the engine in a loop, processing events
+//   and updating scenes at the specified fps, until Quit() is called or
+//   Ctrl+C is pressed. It returns any error found when shutting down.
+// - Quit(): Asks the engine for a clean quit, ending the loop after the
+//   current frame. It can be called from any goroutine.
+// - AddShutdownHook(func() error): Registers a function called when the
+//   engine shuts down, like saving the game.
 // - Start(), Stop(): Methods to initialize and terminate engine resources.
 // - CreateEngineScene(): Creates a main scene for the engine with the screen's
 //   size as its dimensions.
 // - Draw(), Update(), Consume(): Core rendering, updating, and message
-//   consumption methods, respectively.
+//   consumption methods, respectively. Entities added to or removed from a
+//   scene while the scene runs any of these phases are applied when the
+//   phase ends, calling entity OnAdded() and OnRemoved() hooks.
 //
 // Event Handling:
 // The engine listens for keyboard and mouse events using tcell, an ncurses
 // library for handling terminal-based graphical interfaces. Events like key
 // presses (Escape, Ctrl+C) and window resize events are captured and processed
-// during the event loop.
+// during the event loop. Resize events call Relayout(), which places every
+// camera and entity with a layout in the new screen size.
 //
 // Initialization and Resource Management:
 // - Init(): Initializes resources needed to run the engine, like the screen.
-// - InitResources(): Initializes low-level resources (like tcell screen).
+// - InitResources(): Initializes low-level resources (like tcell screen) and
+//   detects the terminal color depth used to down-map styles at render time.
+// - SetScreen(tcell.Screen): Sets a screen for the engine, like a screen for
+//   a network connection, instead of the terminal running the application.
 // - SetDryRun(bool): Configures the engine to run in dry mode, bypassing
 //   certain screen and input functionalities.
 //
 // Error Handling and Recovery:
-// The Run method includes a panic recovery mechanism, logging stack traces and
-// errors for debugging and safe application shutdown.
+// The Run method never exits the process. When the loop ends, or when any
+// panic is recovered, the engine shuts down: all scenes and entities are
+// stopped, shutdown hooks are called in reverse order and the screen is
+// finalized. A recovered panic is logged and returned as an error containing
+// the stack trace, joined with any error returned by shutdown hooks, so
+// callers can print it and set the exit status.
 //
 // Dependencies:
 // This package requires tcell for terminal rendering and an external tools
@@ -60,15 +97,20 @@
 // To create and run the engine in a new application:
 //     engine := engine.GetEngine()
 //     engine.Init()
-//     engine.Run(60.0) // Run at 60 FPS
+//     if err := engine.Run(60.0); err != nil { // Run at 60 FPS
+//         fmt.Println(err)
+//         os.Exit(1)
+//     }
 //
 
 package engine
 
 import (
+	"errors"
 	"fmt"
-	"os"
 	"runtime/debug"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
@@ -92,6 +134,21 @@ var (
 	EngineSingleton *Engine
 )
 
+// -----------------------------------------------------------------------------
+// Package private variables
+// -----------------------------------------------------------------------------
+
+var (
+	// engineSingletonMutex protects the default engine creation, because
+	// engines can run in different goroutines.
+	engineSingletonMutex sync.Mutex
+
+	// liveEngines contains all engines which have not been shut down,
+	// protected by liveEnginesMutex.
+	liveEngines      = make(map[*Engine]struct{})
+	liveEnginesMutex sync.Mutex
+)
+
 // -----------------------------------------------------------------------------
 // Package global functions
 // -----------------------------------------------------------------------------
@@ -99,12 +156,36 @@ var (
 // GetEngine funcion return the Engine instance singleton if it exists or
 // creates the singleton instance.
 func GetEngine() *Engine {
+	engineSingletonMutex.Lock()
+	defer engineSingletonMutex.Unlock()
 	if EngineSingleton == nil {
-		EngineSingleton = newEngine()
+		EngineSingleton = NewEngine()
 	}
 	return EngineSingleton
 }
 
+// GetEngines function returns all engines which have not been shut down, the
+// default engine and any engine created with NewEngine().
+func GetEngines() []*Engine {
+	liveEnginesMutex.Lock()
+	defer liveEnginesMutex.Unlock()
+	result := make([]*Engine, 0, len(liveEngines))
+	for engine := range liveEngines {
+		result = append(result, engine)
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// newPanicError function returns the given recovered panic value as an error
+// containing the stack trace.
+func newPanicError(r any) error {
+	return fmt.Errorf("panic %+v\n%s", r, string(debug.Stack()))
+}
+
 // -----------------------------------------------------------------------------
 //
 // Engine
@@ -114,27 +195,57 @@ func GetEngine() *Engine {
 // Engine struct contains all attributes required for handling the appplication
 // engine.
 // screen tcell.Screen instance used to display any application object.
+// looping is true while the engine loop is running, so any refresh requested
+// from other goroutines is done in the loop.
+// refreshPending is true when all entities have to be refreshed at the start
+// of the next tick.
 type Engine struct {
+	assetManager    *AssetManager
+	colorMapper     *ColorMapper
 	ctrlCh          chan bool
+	debugScene      IScene
+	debugStats      map[string]DebugStat
 	dryRun          bool
 	eventCh         chan tcell.Event
 	focusManager    *FocusManager
-	isRunning       bool
+	hotKeys         map[tcell.Key]func()
+	isRunning       atomic.Bool
+	looping         atomic.Bool
+	mailbox         *Mailbox
 	observerManager *ObserverManager
+	refreshPending  atomic.Bool
+	reloader        *AssetReloader
 	sceneManager    *SceneManager
 	screen          tcell.Screen
+	shutdownHooks   []func() error
+	stats           *FrameStats
 }
 
-// newEngine function creates a new Engine instance.
-func newEngine() *Engine {
+// NewEngine function creates a new Engine instance, independent of the
+// default engine returned by GetEngine().
+func NewEngine() *Engine {
 	engine := &Engine{
+		assetManager:    nil,
+		colorMapper:     nil,
 		ctrlCh:          make(chan bool, 2),
+		debugScene:      nil,
+		debugStats:      make(map[string]DebugStat),
 		eventCh:         make(chan tcell.Event),
 		focusManager:    NewFocusManager(),
-		isRunning:       true,
+		hotKeys:         make(map[tcell.Key]func()),
+		mailbox:         NewMailbox(),
 		observerManager: NewObserverManager(),
+		reloader:        nil,
 		sceneManager:    NewSceneManager(),
+		shutdownHooks:   nil,
+		stats:           NewFrameStats(StatsDefaultHistory),
 	}
+	engine.isRunning.Store(true)
+	engine.sceneManager.engine = engine
+	engine.AddHotKey(tcell.KeyF12, engine.ToggleDebugOverlay)
+	liveEnginesMutex.Lock()
+	liveEngines[engine] = struct{}{}
+	liveEnginesMutex.Unlock()
 	return engine
 }
 
@@ -142,17 +253,99 @@ func newEngine() *Engine {
 // Engine private methods
 // -----------------------------------------------------------------------------
 
-// eventPoll method polls the keyboard for any entry.
+// eventPoll method polls the keyboard for any entry. Polling stops when the
+// screen is finalized, which returns a nil event.
 func (e *Engine) eventPoll() {
-loop:
 	for {
+		event := e.screen.PollEvent()
+		if event == nil {
+			return
+		}
 		select {
 		case <-e.ctrlCh:
-			break loop
-		default:
-			e.eventCh <- e.screen.PollEvent()
+			return
+		case e.eventCh <- event:
+		}
+	}
+}
+
+// handleFocusKey method moves the focus in the last active scene for the
+// given key: Tab and Shift-Tab move it forward and backward in the tab order,
+// and arrow keys move it to the nearest entity when arrow navigation is
+// enabled. Keys used by the entity with focus, like tab completion or arrows
+// in a list, are not handled, and arrow keys are handled only when the focus
+// moved. It returns true if the key was handled.
+func (e *Engine) handleFocusKey(ev *tcell.EventKey) bool {
+	directions := map[tcell.Key]FocusDirection{
+		tcell.KeyUp:    FocusUp,
+		tcell.KeyDown:  FocusDown,
+		tcell.KeyLeft:  FocusLeft,
+		tcell.KeyRight: FocusRight,
+	}
+	switch key := ev.Key(); key {
+	case tcell.KeyTab:
+		if e.focusManager.HandlesTab() {
+			return false
+		}
+		tools.Logger.WithField("module", "engine").
+			WithField("struct", "Engine").
+			WithField("method", "handleFocusKey").
+			Debugf("tab focus next")
+		e.sceneManager.FocusNext()
+		return true
+	case tcell.KeyBacktab:
+		tools.Logger.WithField("module", "engine").
+			WithField("struct", "Engine").
+			WithField("method", "handleFocusKey").
+			Debugf("backtab focus previous")
+		e.sceneManager.FocusPrevious()
+		return true
+	case tcell.KeyUp, tcell.KeyDown, tcell.KeyLeft, tcell.KeyRight:
+		if !e.focusManager.IsArrowNavigation() || e.focusManager.HandlesArrows() {
+			return false
 		}
+		return e.sceneManager.FocusDirection(directions[key])
+	}
+	return false
+}
+
+// shutdown method stops all scenes and entities, calls all shutdown hooks in
+// reverse order and finalizes the screen. Any panic in a scene, entity or
+// hook is recovered and returned as an error, so every step is always run.
+func (e *Engine) shutdown() error {
+	var errs []error
+	if err := e.protect(e.Stop); err != nil {
+		errs = append(errs, err)
+	}
+	for i := len(e.shutdownHooks) - 1; i >= 0; i-- {
+		hook := e.shutdownHooks[i]
+		if err := e.protect(func() {
+			if err := hook(); err != nil {
+				errs = append(errs, err)
+			}
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if !e.dryRun && e.screen != nil {
+		e.screen.Fini()
 	}
+	liveEnginesMutex.Lock()
+	delete(liveEngines, e)
+	liveEnginesMutex.Unlock()
+	return errors.Join(errs...)
+}
+
+// protect method calls the given function and it returns any panic as an
+// error with the stack trace.
+func (e *Engine) protect(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = newPanicError(r)
+		}
+	}()
+	f()
+	return nil
 }
 
 // startEventPoll method starts the keyboard polling mechanism
@@ -169,6 +362,27 @@ func (e *Engine) stopEventPoll() {
 // Engine public methods
 // -----------------------------------------------------------------------------
 
+// AddDebugStat method registers a custom statistic to be displayed in the
+// debug overlay with the given name.
+func (e *Engine) AddDebugStat(name string, stat DebugStat) {
+	e.debugStats[name] = stat
+}
+
+// AddShutdownHook method registers the given function to be called when the
+// engine shuts down, after all scenes and entities are stopped and before the
+// screen is finalized. Hooks are called in reverse order they were added and
+// any error they return is returned by Run.
+func (e *Engine) AddShutdownHook(hook func() error) {
+	e.shutdownHooks = append(e.shutdownHooks, hook)
+}
+
+// AddHotKey method registers the handler to be called when the given key is
+// pressed. Hot keys are handled by the engine before any scene is updated and
+// the key event is not passed to any entity.
+func (e *Engine) AddHotKey(key tcell.Key, handler func()) {
+	e.hotKeys[key] = handler
+}
+
 // Consume method calls all underneath instances to consume all messages from
 // the mailbox.
 func (e *Engine) Consume() {
@@ -187,22 +401,27 @@ func (e *Engine) CreateEngineScene() (IScene, error) {
 	// the engine screen tcell.Screen.
 	width, height := e.screen.Size()
 	engineCamera := NewCamera(nil, api.NewSize(width, height))
+	engineCamera.SetLayout(NewLayout(LayoutAnchorTopLeft, nil).
+		WithSize(LayoutPercent(100), LayoutPercent(100)))
 
 	// Create a default scene for the engine that should be always present in
 	// all applications.
-	engineScene := NewScene(EngineMainSceneName, engineCamera)
+	engineScene := e.NewScene(EngineMainSceneName, engineCamera)
 	e.sceneManager.AddScene(engineScene)
 	return engineScene, nil
 }
 
 // Draw method proceeds to draws all entities in visible scenes.
 func (e *Engine) Draw() {
-	e.screen.Clear()
+	if !e.dryRun {
+		e.screen.Clear()
+	}
 	e.sceneManager.Draw(e.screen)
 }
 
+// End method ends the engine loop. It is the same as Quit.
 func (e *Engine) End() {
-	e.isRunning = false
+	e.Quit()
 }
 
 // EndTick methods calls any functionality required at the bottom of the tick.
@@ -210,16 +429,53 @@ func (e *Engine) EndTick() {
 	e.sceneManager.EndTick()
 }
 
+// GetAssetManager method returns the asset manager instance. It is created
+// the first time it is required, loading assets from the working directory.
+func (e *Engine) GetAssetManager() *AssetManager {
+	if e.assetManager == nil {
+		e.assetManager = NewAssetManagerFromDir(".")
+	}
+	return e.assetManager
+}
+
+// GetAssetReloader method returns the asset reloader instance, which is
+// created the first time it is required. Watched files are checked at the
+// start of every tick.
+func (e *Engine) GetAssetReloader() *AssetReloader {
+	if e.reloader == nil {
+		e.reloader = NewAssetReloader(e, AssetReloaderDefaultInterval)
+	}
+	return e.reloader
+}
+
+// GetColorMapper method returns the color mapper used to down-map styles to
+// the colors available in the terminal.
+func (e *Engine) GetColorMapper() *ColorMapper {
+	return e.colorMapper
+}
+
 // GetScreen method returns the tcell.Screen used by the engine.
 func (e *Engine) GetScreen() tcell.Screen {
 	return e.screen
 }
 
+// GetDebugStats method returns all custom statistics registered for the debug
+// overlay.
+func (e *Engine) GetDebugStats() map[string]DebugStat {
+	return e.debugStats
+}
+
 // GetFocusManager method returns the focus manager instance.
 func (e *Engine) GetFocusManager() *FocusManager {
 	return e.focusManager
 }
 
+// GetMailbox method returns the engine mailbox, used by all entities in the
+// engine scenes to publish and consume messages.
+func (e *Engine) GetMailbox() *Mailbox {
+	return e.mailbox
+}
+
 // GetObserverManager method returns the observer manager instance.
 func (e *Engine) GetObserverManager() *ObserverManager {
 	return e.observerManager
@@ -230,32 +486,91 @@ func (e *Engine) GetSceneManager() *SceneManager {
 	return e.sceneManager
 }
 
-// Init method initializes are resources required to run the engine.
+// GetStats method returns the frame statistics instance.
+func (e *Engine) GetStats() *FrameStats {
+	return e.stats
+}
+
+// HandleHotKey method calls the handler registered for the given key. It
+// returns true if there is any handler for the key.
+func (e *Engine) HandleHotKey(key tcell.Key) bool {
+	if handler, ok := e.hotKeys[key]; ok {
+		handler()
+		return true
+	}
+	return false
+}
+
+// Init method initializes are resources required to run the engine. All
+// scenes are placed in the screen using their layouts.
 func (e *Engine) Init() {
 	e.sceneManager.Init(e.screen)
+	e.Relayout()
+}
+
+// IsRunning method checks if the engine loop is running or it has not been
+// asked to quit.
+func (e *Engine) IsRunning() bool {
+	return e.isRunning.Load()
+}
+
+// IsDebugOverlayVisible method checks if the debug overlay is being displayed.
+func (e *Engine) IsDebugOverlayVisible() bool {
+	return e.debugScene != nil && e.sceneManager.IsSceneVisible(e.debugScene)
 }
 
 // InitResources methos initializes all engine low level resources (tcell).
 func (e *Engine) InitResources() {
-	// Initialize tcell Screen.
+	// Initialize tcell Screen, if it was not provided with SetScreen().
 	if !e.dryRun {
 		var err error
-		e.screen, err = tcell.NewScreen()
-		if err != nil {
-			panic(err)
+		if e.screen == nil {
+			if e.screen, err = tcell.NewScreen(); err != nil {
+				panic(err)
+			}
 		}
 		if err = e.screen.Init(); err != nil {
 			panic(err)
 		}
 		defaultStyle := tcell.StyleDefault.Background(tcell.ColorBlack).Foreground(tcell.ColorWhite)
 		e.screen.SetStyle(defaultStyle)
+		// Detect terminal color capabilities, so every style is down-mapped
+		// to the available colors at render time.
+		depth := DetectColorDepth(e.screen)
+		tools.Logger.WithField("module", "engine").
+			WithField("struct", "Engine").
+			WithField("method", "InitResources").
+			Infof("terminal colors %d depth %s", e.screen.Colors(), depth)
+		e.SetColorDepth(depth)
 	}
 }
 
-// Run method runs the engine in an infinite loop.
-func (e *Engine) Run(fps float64) {
+// Run method runs the engine in a loop until Quit is called or Ctrl+C is
+// pressed. The engine is always shut down when the loop ends, stopping all
+// scenes and entities, calling all shutdown hooks and finalizing the screen.
+// Any panic is recovered and returned as an error with the stack trace.
+func (e *Engine) Run(fps float64) (err error) {
 	var event tcell.Event
 
+	// panic handler and shutdown.
+	defer func() {
+		if r := recover(); r != nil {
+			err = newPanicError(r)
+			tools.Logger.WithField("module", "engine").
+				WithField("struct", "Engine").
+				WithField("method", "Run").
+				Errorf("%s", err.Error())
+		}
+		if shutdownErr := e.shutdown(); shutdownErr != nil {
+			tools.Logger.WithField("module", "engine").
+				WithField("struct", "Engine").
+				WithField("method", "Run").
+				Errorf("shutdown %s", shutdownErr.Error())
+			err = errors.Join(err, shutdownErr)
+		}
+	}()
+
+	e.stats.SetTargetFPS(fps)
 	if !e.dryRun {
 		e.startEventPoll()
 		defer e.stopEventPoll()
@@ -266,35 +581,13 @@ func (e *Engine) Run(fps float64) {
 
 		// Clear the screen.
 		e.screen.Clear()
-
-		// panic handler.
-		defer func() {
-			recoverStack := false
-			err := recover()
-			if err != nil {
-				tools.Logger.WithField("module", "engine").
-					WithField("struct", "Engine").
-					WithField("method", "Run").
-					Errorf("panic %+v", err)
-				tools.Logger.WithField("module", "engine").
-					WithField("struct", "Engine").
-					WithField("method", "Run").
-					Errorf("%s", string(debug.Stack()))
-				recoverStack = true
-			}
-			if !e.dryRun {
-				e.screen.Fini()
-			}
-			if recoverStack {
-				fmt.Printf("%+v", err)
-				fmt.Printf("%s", string(debug.Stack()))
-			}
-			os.Exit(0)
-		}()
 	}
 
-	for e.isRunning {
+	e.looping.Store(true)
+	defer e.looping.Store(false)
+	for e.isRunning.Load() {
 		nowTime := time.Now()
+		e.stats.StartFrame()
 		// proceed with any action at the very start of the tick before event
 		// is polled.
 		e.StartTick()
@@ -305,23 +598,30 @@ func (e *Engine) Run(fps float64) {
 			switch ev := event.(type) {
 			case *tcell.EventResize:
 				e.screen.Sync()
+				e.Relayout()
+			case *tcell.EventError:
+				// the screen can not be used anymore, like when a network
+				// connection is closed.
+				tools.Logger.WithField("module", "engine").
+					WithField("struct", "Engine").
+					WithField("method", "Run").
+					Errorf("screen error %s", ev.Error())
+				e.Quit()
 			case *tcell.EventMouse:
 				tools.Logger.WithField("module", "engine").
 					WithField("struct", "Engine").
 					WithField("method", "Run").
 					Debugf("mouse %+v", event)
 			case *tcell.EventKey:
+				if e.HandleHotKey(ev.Key()) || e.handleFocusKey(ev) {
+					event = nil
+					break
+				}
 				switch ev.Key() {
 				case tcell.KeyEscape:
-					//h.isRunning = false
+					//h.Quit()
 				case tcell.KeyCtrlC:
-					e.isRunning = false
-				case tcell.KeyTab:
-					tools.Logger.WithField("module", "engine").
-						WithField("struct", "Engine").
-						WithField("method", "Run").
-						Debugf("tab update focus")
-					e.sceneManager.UpdateFocus()
+					e.Quit()
 				case tcell.KeyRune:
 					tools.Logger.WithField("module", "engine").
 						WithField("struct", "Engine").
@@ -338,24 +638,97 @@ func (e *Engine) Run(fps float64) {
 		}
 
 		// update all engine resources.
+		phaseTime := time.Now()
 		e.Update(event)
+		e.stats.AddPhaseTime(StatsPhaseUpdate, time.Since(phaseTime))
 
 		// consume all message in the mailbox
+		phaseTime = time.Now()
 		e.Consume()
+		e.stats.AddPhaseTime(StatsPhaseConsume, time.Since(phaseTime))
 
 		// draw all engine resources.
+		phaseTime = time.Now()
 		e.Draw()
+		e.stats.AddPhaseTime(StatsPhaseDraw, time.Since(phaseTime))
 
 		// proceed with any action at the very end of the tick after everything
 		// has been processed.
 		e.EndTick()
+		e.stats.EndFrame()
 
 		timeToSleep := (time.Until(nowTime).Seconds() * 1000.0) + 1000.0/fps
 		time.Sleep(time.Duration(timeToSleep) * time.Millisecond)
 	}
+	return nil
+}
+
+// NewScene method creates a new scene that belongs to the engine.
+func (e *Engine) NewScene(name string, camera ICamera) *Scene {
+	scene := NewScene(name, camera)
+	scene.SetEngine(e)
+	return scene
+}
 
-	// stop all engine resources.
-	e.Stop()
+// Quit method asks the engine for a clean quit. The loop ends after the
+// current frame and Run shuts the engine down. It can be called from any
+// goroutine.
+func (e *Engine) Quit() {
+	e.isRunning.Store(false)
+}
+
+// Relayout method places all scenes and entities with a layout in the
+// screen. It is called every time the screen is resized.
+func (e *Engine) Relayout() {
+	if e.screen == nil {
+		return
+	}
+	e.sceneManager.Relayout(api.NewSize(e.screen.Size()))
+}
+
+// RefreshAll method refreshes all entities in all engine scenes, like when the
+// theme or the locale changes.
+func (e *Engine) RefreshAll() {
+	for _, scene := range e.sceneManager.GetAllScenes() {
+		for _, entity := range scene.GetEntities() {
+			entity.Refresh()
+		}
+	}
+}
+
+// RemoveDebugStat method removes the custom statistic with the given name
+// from the debug overlay.
+func (e *Engine) RemoveDebugStat(name string) {
+	delete(e.debugStats, name)
+}
+
+// RequestRefresh method refreshes all entities in all engine scenes. When the
+// engine loop is running, entities are refreshed at the start of the next
+// tick, so they are not refreshed from another goroutine, like another
+// session engine changing the locale.
+func (e *Engine) RequestRefresh() {
+	if e.looping.Load() {
+		e.refreshPending.Store(true)
+		return
+	}
+	e.RefreshAll()
+}
+
+// RemoveHotKey method removes the handler registered for the given key.
+func (e *Engine) RemoveHotKey(key tcell.Key) {
+	delete(e.hotKeys, key)
+}
+
+// SetAssetManager method sets the asset manager instance, which allows to
+// load assets from an embedded file system.
+func (e *Engine) SetAssetManager(manager *AssetManager) {
+	e.assetManager = manager
+}
+
+// SetColorDepth method sets the color depth used to down-map styles, which
+// overrides the color depth detected for the terminal.
+func (e *Engine) SetColorDepth(depth ColorDepth) {
+	e.colorMapper = NewColorMapper(depth)
 }
 
 // SetDryRun method sets the dryRun variable to set dryRun flag which avoid any
@@ -365,6 +738,14 @@ func (e *Engine) SetDryRun(dryRun bool) {
 	e.sceneManager.SetDryRun(dryRun)
 }
 
+// SetScreen method sets the tcell.Screen used by the engine. It has to be
+// called before InitResources() in order to display the engine in a screen
+// other than the terminal running the application, like a network
+// connection.
+func (e *Engine) SetScreen(screen tcell.Screen) {
+	e.screen = screen
+}
+
 // Start method starts any required functionality for running the engine.
 func (e *Engine) Start() {
 	e.sceneManager.Start()
@@ -372,6 +753,12 @@ func (e *Engine) Start() {
 
 // StartTick method calls any functionality required at the top of the tick.
 func (e *Engine) StartTick() {
+	if e.refreshPending.Swap(false) {
+		e.RefreshAll()
+	}
+	if e.reloader != nil {
+		e.reloader.Poll()
+	}
 	e.sceneManager.StartTick()
 }
 
@@ -380,6 +767,32 @@ func (e *Engine) Stop() {
 	e.sceneManager.Stop()
 }
 
+// ToggleDebugOverlay method displays or hides the debug overlay. The overlay
+// scene is created the first time it is displayed and it is always drawn on
+// top of any other visible scene.
+func (e *Engine) ToggleDebugOverlay() {
+	if e.debugScene == nil {
+		width, height := 80, 24
+		if e.screen != nil {
+			width, height = e.screen.Size()
+		}
+		camera := NewCamera(nil, api.NewSize(width, height))
+		camera.SetDryRun(e.dryRun)
+		camera.SetLayout(NewLayout(LayoutAnchorTopLeft, nil).
+			WithSize(LayoutPercent(100), LayoutPercent(100)))
+		e.debugScene = e.NewScene(DebugOverlaySceneName, camera)
+		overlay := NewDebugOverlay(e, api.NewPoint(0, 0))
+		overlay.SetLayout(NewLayout(LayoutAnchorTopRight, nil))
+		e.debugScene.AddEntity(overlay)
+		e.sceneManager.AddScene(e.debugScene)
+	}
+	if e.sceneManager.IsSceneVisible(e.debugScene) {
+		e.sceneManager.RemoveSceneAsVisible(e.debugScene)
+		return
+	}
+	e.sceneManager.PushVisibleSceneAsLast(e.debugScene)
+}
+
 // Update method proceeds to update all entities in active scenes.
 func (e *Engine) Update(event tcell.Event) {
 	e.sceneManager.Update(event)
@@ -1 +1,208 @@
 package engine_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+func TestNewEngine(t *testing.T) {
+	engineOne := engine.NewEngine()
+	engineTwo := engine.NewEngine()
+	if engineOne == engineTwo || engineOne == engine.GetEngine() {
+		t.Errorf("[0] NewEngine Error exp:new engine got:same engine")
+	}
+
+	// scenes created by an engine use that engine focus manager.
+	scene := engineOne.NewScene("scene/test/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(10, 10)))
+	entity := engine.NewEntity("entity/test/1", api.NewPoint(0, 0), api.NewSize(1, 1), nil)
+	entity.SetFocusType(engine.SingleFocus)
+	entity.SetFocusEnable(true)
+	scene.AddEntity(entity)
+	if got := scene.GetEngine(); got != engineOne {
+		t.Errorf("[1] GetEngine Error exp:%p got:%p", engineOne, got)
+	}
+	if got := len(engineOne.GetFocusManager().GetEntities()[scene.GetName()]); got != 1 {
+		t.Errorf("[1] GetEntities Error exp:1 got:%d", got)
+	}
+	if got := len(engineTwo.GetFocusManager().GetEntities()[scene.GetName()]); got != 0 {
+		t.Errorf("[1] GetEntities Error exp:0 got:%d", got)
+	}
+
+	// scenes added to another engine move to that engine.
+	engineTwo.GetSceneManager().AddScene(scene)
+	if got := scene.GetEngine(); got != engineTwo {
+		t.Errorf("[2] GetEngine Error exp:%p got:%p", engineTwo, got)
+	}
+	if got := len(engineOne.GetFocusManager().GetEntities()[scene.GetName()]); got != 0 {
+		t.Errorf("[2] GetEntities Error exp:0 got:%d", got)
+	}
+	if got := len(engineTwo.GetFocusManager().GetEntities()[scene.GetName()]); got != 1 {
+		t.Errorf("[2] GetEntities Error exp:1 got:%d", got)
+	}
+	if got := len(engineOne.GetSceneManager().GetAllScenes()); got != 0 {
+		t.Errorf("[2] GetAllScenes Error exp:0 got:%d", got)
+	}
+	// every engine has its own mailbox.
+	engineOne.GetMailbox().CreateTopic("topic/test/1")
+	if engineOne.GetMailbox() == engineTwo.GetMailbox() || engineTwo.GetMailbox().FindTopic("topic/test/1") != nil {
+		t.Errorf("[3] GetMailbox Error exp:own mailbox got:shared mailbox")
+	}
+	if engine.GetMailbox() != engine.GetEngine().GetMailbox() {
+		t.Errorf("[3] GetMailbox Error exp:default engine mailbox got:%p", engine.GetMailbox())
+	}
+
+	// all engines not shut down are live engines.
+	found := 0
+	for _, e := range engine.GetEngines() {
+		if e == engineOne || e == engineTwo {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Errorf("[4] GetEngines Error exp:2 got:%d", found)
+	}
+}
+
+func TestEngineRun(t *testing.T) {
+	steps := []string{}
+	newRunEngine := func(update func(*engine.Engine)) *engine.Engine {
+		e := engine.NewEngine()
+		scene := e.NewScene("scene/test/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(10, 10)))
+		entity := engine.NewEntity("entity/test/1", api.NewPoint(0, 0), api.NewSize(1, 1), nil)
+		entity.SetBehaviorFor(engine.BehaviorUpdate, func(tcell.Event, engine.IScene) { update(e) })
+		entity.SetBehaviorFor(engine.BehaviorStop, func() { steps = append(steps, "stop") })
+		scene.AddEntity(entity)
+		e.GetSceneManager().AddScene(scene)
+		e.GetSceneManager().SetSceneAsActive(scene)
+		e.GetSceneManager().SetSceneAsVisible(scene)
+		e.SetDryRun(true)
+		e.AddShutdownHook(func() error {
+			steps = append(steps, "hook 1")
+			return nil
+		})
+		e.AddShutdownHook(func() error {
+			steps = append(steps, "hook 2")
+			return errors.New("hook error")
+		})
+		return e
+	}
+
+	// quit ends the loop and the engine is shut down.
+	frames := 0
+	e := newRunEngine(func(e *engine.Engine) {
+		if frames++; frames == 3 {
+			e.Quit()
+		}
+	})
+	err := e.Run(1000.0)
+	if err == nil || !strings.Contains(err.Error(), "hook error") {
+		t.Errorf("[0] Run Error exp:hook error got:%v", err)
+	}
+	if frames != 3 || e.IsRunning() {
+		t.Errorf("[0] Run Error exp:3 frames got:%d %t", frames, e.IsRunning())
+	}
+	if got := strings.Join(steps, ","); got != "stop,hook 2,hook 1" {
+		t.Errorf("[0] Run Error exp:stop,hook 2,hook 1 got:%s", got)
+	}
+
+	// panics are returned as errors with the stack and the engine is shut
+	// down.
+	steps = []string{}
+	e = newRunEngine(func(*engine.Engine) { panic("update failed") })
+	err = e.Run(1000.0)
+	if err == nil || !strings.Contains(err.Error(), "panic update failed") || !strings.Contains(err.Error(), "goroutine") {
+		t.Errorf("[1] Run Error exp:panic error got:%v", err)
+	}
+	if got := strings.Join(steps, ","); got != "stop,hook 2,hook 1" {
+		t.Errorf("[1] Run Error exp:stop,hook 2,hook 1 got:%s", got)
+	}
+}
+
+type refreshEntity struct {
+	*engine.Entity
+	refreshed int
+}
+
+func (e *refreshEntity) Refresh() {
+	e.refreshed++
+}
+
+func TestEngineRequestRefresh(t *testing.T) {
+	e := engine.NewEngine()
+	e.SetDryRun(true)
+	scene := e.NewScene("scene/test/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(10, 10)))
+	entity := &refreshEntity{Entity: engine.NewEntity("entity/test/1", api.NewPoint(0, 0), api.NewSize(1, 1), nil)}
+	scene.AddEntity(entity)
+	e.GetSceneManager().AddScene(scene)
+	e.GetSceneManager().SetSceneAsActive(scene)
+
+	// entities are refreshed at once when the engine loop is not running.
+	e.RequestRefresh()
+	if entity.refreshed != 1 {
+		t.Errorf("[0] RequestRefresh Error exp:1 got:%d", entity.refreshed)
+	}
+
+	// entities are refreshed at the start of the next tick when the engine
+	// loop is running.
+	frames := 0
+	refreshed := []int{}
+	entity.SetBehaviorFor(engine.BehaviorUpdate, func(tcell.Event, engine.IScene) {
+		refreshed = append(refreshed, entity.refreshed)
+		if frames++; frames == 1 {
+			e.RequestRefresh()
+		} else if frames == 3 {
+			e.Quit()
+		}
+	})
+	_ = e.Run(1000.0)
+	if got := fmt.Sprint(refreshed); got != "[1 2 2]" {
+		t.Errorf("[1] RequestRefresh Error exp:[1 2 2] got:%s", got)
+	}
+	for _, live := range engine.GetEngines() {
+		if live == e {
+			t.Errorf("[2] GetEngines Error exp:engine removed got:%p", e)
+		}
+	}
+}
+
+func TestSceneManagerFocusDirection(t *testing.T) {
+	e := engine.NewEngine()
+	scene := e.NewScene("scene/test/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(20, 10)))
+	left := engine.NewEntity("entity/left", api.NewPoint(0, 0), api.NewSize(4, 1), nil)
+	right := engine.NewEntity("entity/right", api.NewPoint(10, 0), api.NewSize(4, 1), nil)
+	for _, entity := range []*engine.Entity{left, right} {
+		entity.SetFocusType(engine.SingleFocus)
+		entity.SetFocusEnable(true)
+		scene.AddEntity(entity)
+	}
+	e.GetSceneManager().AddScene(scene)
+	e.GetSceneManager().SetSceneAsActive(scene)
+	e.GetSceneManager().FocusNext()
+
+	// it returns true only when the focus moves, so the arrow key is passed
+	// to entities when there is not any entity in that direction.
+	cases := []struct {
+		direction engine.FocusDirection
+		exp       bool
+		focused   *engine.Entity
+	}{
+		{engine.FocusLeft, false, left},
+		{engine.FocusRight, true, right},
+		{engine.FocusDown, false, right},
+		{engine.FocusLeft, true, left},
+	}
+	for i, c := range cases {
+		if got := e.GetSceneManager().FocusDirection(c.direction); got != c.exp {
+			t.Errorf("[%d] FocusDirection Error exp:%t got:%t", i, c.exp, got)
+		}
+		if !c.focused.HasFocus() {
+			t.Errorf("[%d] HasFocus Error exp:%s got:false", i, c.focused.GetName())
+		}
+	}
+}
@@ -5,6 +5,7 @@ package engine
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/gdamore/tcell/v2"
@@ -22,24 +23,33 @@ type IEntity interface {
 	IObjectUI
 	IFocus
 	IObserver
+	AddTag(...string)
 	Consume()
 	Draw(IScene)
 	EndTick(IScene)
 	GetCache() api.ICache
 	GetCanvas() *Canvas
 	GetCollider() *Collider
+	GetLayout() *Layout
 	GetPLevel() int
+	GetTags() []string
 	GetValidator() IValidator
 	GetZLevel() int
+	HasAnyTag(...string) bool
+	HasTag(string) bool
 	Init(tcell.Screen)
 	IsSolid() bool
 	MarshalJSON() ([]byte, error)
 	MarshalMap(*api.Point) (map[string]any, error)
 	MarshalCode(*api.Point) (string, error)
+	OnAdded(IScene)
+	OnRemoved(IScene)
 	Refresh()
+	RemoveTag(...string)
 	SetBehaviorFor(string, any)
 	SetCache(api.ICache)
 	SetCanvas(*Canvas)
+	SetLayout(*Layout)
 	SetPLevel(int)
 	SetSolid(bool)
 	SetValidator(IValidator)
@@ -64,15 +74,19 @@ type IEntity interface {
 // displayed before.
 // pLevel represents the update priority of the entity which allows to update
 // entities before.
+// tags are labels used to group and to find entities in a scene.
+// layout places the entity in the scene camera when the screen is resized.
 type Entity struct {
 	*ObjectUI
 	*Focus
 	behavior  IBehavior
 	cache     api.ICache
 	canvas    *Canvas
+	layout    *Layout
 	pLevel    int
 	screen    tcell.Screen
 	solid     bool
+	tags      map[string]bool
 	validator IValidator
 	zLevel    int
 }
@@ -89,9 +103,11 @@ func NewEntity(name string, position *api.Point, size *api.Size, style *tcell.St
 		behavior:  NewBehavior(),
 		cache:     api.NewCache(),
 		canvas:    NewCanvas(size),
+		layout:    nil,
 		pLevel:    0,
 		screen:    nil,
 		solid:     false,
+		tags:      make(map[string]bool),
 		validator: nil,
 		zLevel:    0,
 	}
@@ -107,9 +123,11 @@ func NewEmptyEntity() *Entity {
 		behavior:  NewBehavior(),
 		cache:     api.NewCache(),
 		canvas:    nil,
+		layout:    nil,
 		pLevel:    0,
 		screen:    nil,
 		solid:     false,
+		tags:      make(map[string]bool),
 		validator: nil,
 		zLevel:    0,
 	}
@@ -124,9 +142,11 @@ func NewNamedEntity(name string) *Entity {
 		behavior:  NewBehavior(),
 		cache:     api.NewCache(),
 		canvas:    nil,
+		layout:    nil,
 		pLevel:    0,
 		screen:    nil,
 		solid:     false,
+		tags:      make(map[string]bool),
 		validator: nil,
 		zLevel:    0,
 	}
@@ -144,6 +164,19 @@ func NewHandler(name string) *Entity {
 // Entity public methods
 // -----------------------------------------------------------------------------
 
+// AddTag method adds the given tags to the entity.
+func (e *Entity) AddTag(tags ...string) {
+	if e.tags == nil {
+		e.tags = make(map[string]bool)
+	}
+	for _, tag := range tags {
+		if !e.tags[tag] {
+			e.tags[tag] = true
+			updateEntityIndexVersion()
+		}
+	}
+}
+
 // CanHaveFocus method checks if the entity can receive and have focus.
 func (e *Entity) CanHaveFocus() bool {
 	//tools.Logger.WithField("module", "entity").
@@ -217,6 +250,22 @@ func (e *Entity) GetPLevel() int {
 //    return rect
 //}
 
+// GetLayout method returns the layout used to place the entity in the scene
+// camera.
+func (e *Entity) GetLayout() *Layout {
+	return e.layout
+}
+
+// GetTags method returns all entity tags sorted.
+func (e *Entity) GetTags() []string {
+	result := make([]string, 0, len(e.tags))
+	for tag := range e.tags {
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result
+}
+
 func (e *Entity) GetValidator() IValidator {
 	return e.validator
 }
@@ -226,6 +275,21 @@ func (e *Entity) GetZLevel() int {
 	return e.zLevel
 }
 
+// HasAnyTag method checks if the entity has any of the given tags.
+func (e *Entity) HasAnyTag(tags ...string) bool {
+	for _, tag := range tags {
+		if e.tags[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTag method checks if the entity has the given tag.
+func (e *Entity) HasTag(tag string) bool {
+	return e.tags[tag]
+}
+
 // Init methos initialize the entity instance.
 func (e *Entity) Init(screen tcell.Screen) {
 	if b := e.behavior.GetBehaviorFor(BehaviorInit); b != nil {
@@ -268,6 +332,12 @@ func (e *Entity) MarshalMap(origin *api.Point) (map[string]any, error) {
 		"style":    []string{fg.String(), bg.String(), strconv.Itoa(int(attrs))},
 		"ch":       string(cell.GetRune()),
 	}
+	if len(e.tags) != 0 {
+		content["tags"] = e.GetTags()
+	}
+	if e.layout != nil {
+		content["layout"] = e.layout.MarshalMap()
+	}
 	return content, nil
 }
 
@@ -299,10 +369,39 @@ func (e *Entity) Notify(subjectID any, message any) {
 	}
 }
 
+// OnAdded method is called when the entity has been added to the given scene.
+func (e *Entity) OnAdded(scene IScene) {
+	if b := e.behavior.GetBehaviorFor(BehaviorAdded); b != nil {
+		if behavior, ok := b.(func(IScene)); ok {
+			behavior(scene)
+		}
+	}
+}
+
+// OnRemoved method is called when the entity has been removed from the given
+// scene.
+func (e *Entity) OnRemoved(scene IScene) {
+	if b := e.behavior.GetBehaviorFor(BehaviorRemoved); b != nil {
+		if behavior, ok := b.(func(IScene)); ok {
+			behavior(scene)
+		}
+	}
+}
+
 // Refresh method refreshes the entity instance.
 func (e *Entity) Refresh() {
 }
 
+// RemoveTag method removes the given tags from the entity.
+func (e *Entity) RemoveTag(tags ...string) {
+	for _, tag := range tags {
+		if e.tags[tag] {
+			delete(e.tags, tag)
+			updateEntityIndexVersion()
+		}
+	}
+}
+
 func (e *Entity) SetCache(cache api.ICache) {
 	e.cache = cache
 }
@@ -316,6 +415,14 @@ func (e *Entity) SetCanvas(canvas *Canvas) {
 	e.canvas = canvas
 }
 
+// SetLayout method sets the layout used to place the entity in the scene
+// camera. The layout is applied when the entity is added to a scene and
+// every time the screen is resized, and the entity is refreshed when its
+// size changes.
+func (e *Entity) SetLayout(layout *Layout) {
+	e.layout = layout
+}
+
 // SetPLevel method sets a new value for the entity p-level.
 func (e *Entity) SetPLevel(level int) {
 	e.pLevel = level
@@ -402,6 +509,20 @@ func (e *Entity) UnmarshalMap(content map[string]any, origin *api.Point) error {
 			Background(tcell.GetColor(style[1].(string)))
 		e.style = &tcellStyle
 	}
+	if tags, ok := content["tags"].([]any); ok {
+		for _, tag := range tags {
+			if str, ok := tag.(string); ok {
+				e.AddTag(str)
+			}
+		}
+	}
+	if content, ok := content["layout"].(map[string]any); ok {
+		layout, err := NewLayoutFromMap(content)
+		if err != nil {
+			return err
+		}
+		e.layout = layout
+	}
 	return nil
 }
 
@@ -105,7 +105,10 @@ func (o *EObject) SetActive(active bool) {
 
 // SetClassName method sets the instance class name attribute.
 func (e *EObject) SetClassName(className string) {
-	e.className = className
+	if e.className != className {
+		e.className = className
+		updateEntityIndexVersion()
+	}
 }
 
 // SetName method sets the instance name with the given value.
@@ -21,6 +21,17 @@ const (
 	MultiFocus
 )
 
+// FocusDirection type defines the direction used to move the focus to the
+// nearest entity.
+type FocusDirection int
+
+const (
+	FocusUp FocusDirection = iota
+	FocusDown
+	FocusLeft
+	FocusRight
+)
+
 // -----------------------------------------------------------------------------
 //
 // IFocus
@@ -33,11 +44,53 @@ type IFocus interface {
 	AcquireFocus() (bool, error)
 	CanHaveFocus() bool
 	GetFocusType() FocusType
+	GetTabIndex() int
 	HasFocus() bool
 	IsFocusEnable() bool
 	ReleaseFocus() (bool, error)
 	SetFocusEnable(bool)
 	SetFocusType(FocusType)
+	SetTabIndex(int)
+}
+
+// -----------------------------------------------------------------------------
+//
+// IArrowHandler
+//
+// -----------------------------------------------------------------------------
+
+// IArrowHandler interface defines entities which use the arrow keys while they
+// have the focus, like a list box moving its selection. The focus is not moved
+// to the nearest entity in the arrow direction when the entity with focus
+// handles arrow keys, so the same key does not move the selection and the
+// focus at once. Widgets provide it with the SetHandlesArrows method.
+type IArrowHandler interface {
+	HandlesArrows() bool
+}
+
+// -----------------------------------------------------------------------------
+//
+// IFocusScope
+//
+// -----------------------------------------------------------------------------
+
+// IFocusScope interface defines a group of entities the focus can be trapped
+// in, like all widgets in a container or in a modal window.
+type IFocusScope interface {
+	ContainsEntity(IEntity) bool
+}
+
+// -----------------------------------------------------------------------------
+//
+// ITabHandler
+//
+// -----------------------------------------------------------------------------
+
+// ITabHandler interface defines entities which can use the tab key while they
+// have the focus, like a text input with completion. The focus is not moved
+// to the next entity when the entity with focus handles the tab key.
+type ITabHandler interface {
+	HandlesTab() bool
 }
 
 // -----------------------------------------------------------------------------
@@ -47,10 +100,15 @@ type IFocus interface {
 // -----------------------------------------------------------------------------
 
 // Focus structure defines a baseline for any entity with focus.
+// tabIndex is the position for the entity when the focus is moved with the
+// tab key. Entities with a positive tab index take the focus first, in
+// ascending order, and then entities with zero tab index, in the order they
+// were added.
 type Focus struct {
 	focus     bool
 	enable    bool
 	focusType FocusType
+	tabIndex  int
 }
 
 // -----------------------------------------------------------------------------
@@ -63,6 +121,7 @@ func NewFocus(focusType FocusType) *Focus {
 		focus:     false,
 		enable:    true,
 		focusType: focusType,
+		tabIndex:  0,
 	}
 }
 
@@ -72,6 +131,7 @@ func NewDisableFocus() *Focus {
 		focus:     false,
 		enable:    false,
 		focusType: NoFocus,
+		tabIndex:  0,
 	}
 }
 
@@ -103,6 +163,12 @@ func (f *Focus) GetFocusType() FocusType {
 	return f.focusType
 }
 
+// GetTabIndex method returns the position for the entity when the focus is
+// moved with the tab key.
+func (f *Focus) GetTabIndex() int {
+	return f.tabIndex
+}
+
 // HasFocus method checks if the entity has the focus.
 func (f *Focus) HasFocus() bool {
 	return f.focus
@@ -131,3 +197,49 @@ func (f *Focus) SetFocusEnable(enable bool) {
 func (f *Focus) SetFocusType(focusType FocusType) {
 	f.focusType = focusType
 }
+
+// SetTabIndex method sets the position for the entity when the focus is moved
+// with the tab key. Zero keeps the order the entity was added in.
+func (f *Focus) SetTabIndex(index int) {
+	f.tabIndex = index
+}
+
+// -----------------------------------------------------------------------------
+//
+// FocusGroup
+//
+// -----------------------------------------------------------------------------
+
+// FocusGroup structure defines a focus scope for a list of entities.
+type FocusGroup struct {
+	entities []IEntity
+}
+
+// NewFocusGroup function creates a new FocusGroup instance with the given
+// entities.
+func NewFocusGroup(entities ...IEntity) *FocusGroup {
+	return &FocusGroup{
+		entities: entities,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// FocusGroup public methods
+// -----------------------------------------------------------------------------
+
+// AddEntity method adds the given entity to the group.
+func (g *FocusGroup) AddEntity(entity IEntity) {
+	g.entities = append(g.entities, entity)
+}
+
+// ContainsEntity method checks if the given entity is in the group.
+func (g *FocusGroup) ContainsEntity(entity IEntity) bool {
+	for _, ent := range g.entities {
+		if ent == entity {
+			return true
+		}
+	}
+	return false
+}
+
+var _ IFocusScope = (*FocusGroup)(nil)
@@ -4,6 +4,7 @@ package engine
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/jrecuero/thengine/pkg/tools"
 )
@@ -16,6 +17,71 @@ const (
 	entityNotInScene int = -1
 )
 
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// focusCenter function returns the center for the given entity, scaled by two
+// so it does not have any fraction.
+func focusCenter(entity IEntity) (int, int) {
+	x, y, w, h := 0, 0, 0, 0
+	if position := entity.GetPosition(); position != nil {
+		x, y = position.X, position.Y
+	}
+	if size := entity.GetSize(); size != nil {
+		w, h = size.W, size.H
+	}
+	return 2*x + w, 2*y + h
+}
+
+// focusDistance function returns the distance from the given center to the
+// other center in the given direction, and the distance in the cross axis.
+func focusDistance(direction FocusDirection, fromX, fromY, toX, toY int) (int, int) {
+	switch direction {
+	case FocusUp:
+		return fromY - toY, toX - fromX
+	case FocusDown:
+		return toY - fromY, toX - fromX
+	case FocusLeft:
+		return fromX - toX, toY - fromY
+	default:
+		return toX - fromX, toY - fromY
+	}
+}
+
+// -----------------------------------------------------------------------------
+//
+// FocusEvent
+//
+// -----------------------------------------------------------------------------
+
+// FocusEvent structure defines a change in the focus for an entity, which is
+// notified to all focus listeners, like widgets styling the entity with the
+// focus.
+type FocusEvent struct {
+	Scene   string
+	Entity  IEntity
+	Focused bool
+}
+
+// FocusListener type defines functions called every time any entity acquires
+// or releases the focus.
+type FocusListener func(*FocusEvent)
+
+// -----------------------------------------------------------------------------
+//
+// focusScope
+//
+// -----------------------------------------------------------------------------
+
+// focusScope structure defines a scope the focus is trapped in, and the
+// entity with the focus when the scope was pushed, which gets the focus back
+// when the scope is popped.
+type focusScope struct {
+	scope    IFocusScope
+	previous IEntity
+}
+
 // -----------------------------------------------------------------------------
 //
 // FocusManager
@@ -24,10 +90,22 @@ const (
 
 // FocusManager struct contains all attributes and methods required for
 // handling focus between all application entities.
+// order contains the order every entity was added in, used to move the focus
+// with the tab key.
+// scopes contains the focus scopes for every scene. Only entities in the last
+// scope pushed for a scene can take the focus, like the focus manager being
+// locked for any other entity.
+// arrowNavigation is true when arrow keys move the focus to the nearest
+// entity in the arrow direction.
 type FocusManager struct {
-	entities  map[string][]IEntity
-	withFocus map[string][]IEntity
-	locked    bool
+	entities        map[string][]IEntity
+	withFocus       map[string][]IEntity
+	locked          bool
+	order           map[IEntity]int
+	sequence        int
+	scopes          map[string][]*focusScope
+	listeners       []FocusListener
+	arrowNavigation bool
 }
 
 // -----------------------------------------------------------------------------
@@ -37,9 +115,14 @@ type FocusManager struct {
 // NewFocusManager function creates a new FocusManager instance.
 func NewFocusManager() *FocusManager {
 	return &FocusManager{
-		locked:    false,
-		entities:  make(map[string][]IEntity),
-		withFocus: make(map[string][]IEntity),
+		locked:          false,
+		entities:        make(map[string][]IEntity),
+		withFocus:       make(map[string][]IEntity),
+		order:           make(map[IEntity]int),
+		sequence:        0,
+		scopes:          make(map[string][]*focusScope),
+		listeners:       nil,
+		arrowNavigation: false,
 	}
 }
 
@@ -65,6 +148,62 @@ func (m *FocusManager) acquireFocusToEntityInScene(sceneName string, entity IEnt
 	// Remove the entity from the list of entities so it can not take focus
 	// again.
 	m.entities[sceneName] = append(m.entities[sceneName][:index], m.entities[sceneName][index+1:]...)
+	m.notify(sceneName, entity, true)
+	return nil
+}
+
+// acquireMultiFocusInScene method acquires the focus for all multi-focus
+// entities in the given scene which can have focus.
+func (m *FocusManager) acquireMultiFocusInScene(sceneName string) {
+	candidates := []IEntity{}
+	for _, entity := range m.entities[sceneName] {
+		if entity.CanHaveFocus() && (entity.GetFocusType() == MultiFocus) {
+			candidates = append(candidates, entity)
+		}
+	}
+	for _, entity := range candidates {
+		for index, ent := range m.entities[sceneName] {
+			if ent == entity {
+				m.acquireFocusToEntityInScene(sceneName, entity, index)
+				break
+			}
+		}
+	}
+}
+
+// candidatesInScene method returns all single-focus entities in the given
+// scene which can have focus, sorted by their tab index and the order they
+// were added in.
+func (m *FocusManager) candidatesInScene(sceneName string) []IEntity {
+	result := []IEntity{}
+	for _, entities := range [][]IEntity{m.entities[sceneName], m.withFocus[sceneName]} {
+		for _, entity := range entities {
+			if entity.GetFocusType() == SingleFocus && entity.CanHaveFocus() && !m.isLockedFor(sceneName, entity) {
+				result = append(result, entity)
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		indexI, indexJ := result[i].GetTabIndex(), result[j].GetTabIndex()
+		if (indexI > 0) != (indexJ > 0) {
+			return indexI > 0
+		}
+		if indexI != indexJ {
+			return indexI < indexJ
+		}
+		return m.order[result[i]] < m.order[result[j]]
+	})
+	return result
+}
+
+// focusedInScene method returns the single-focus entity with the focus in the
+// given scene.
+func (m *FocusManager) focusedInScene(sceneName string) IEntity {
+	for _, entity := range m.withFocus[sceneName] {
+		if entity.GetFocusType() == SingleFocus {
+			return entity
+		}
+	}
 	return nil
 }
 
@@ -120,6 +259,61 @@ func (m *FocusManager) indexForEntityInWithFocus(entity IEntity) (string, int) {
 	return "", entityNotInScene
 }
 
+// isLockedFor method checks if the focus can not be given to the given entity
+// in the given scene, because the focus manager is locked or the entity is
+// outside the focus scope for the scene.
+func (m *FocusManager) isLockedFor(sceneName string, entity IEntity) bool {
+	if m.locked {
+		return true
+	}
+	if scopes := m.scopes[sceneName]; len(scopes) != 0 {
+		return !scopes[len(scopes)-1].scope.ContainsEntity(entity)
+	}
+	return false
+}
+
+// moveFocus method moves the focus in the given scene to the entity at the
+// given step from the entity with focus, in the tab order.
+func (m *FocusManager) moveFocus(scene IScene, step int) error {
+	if m.IsLocked() {
+		return nil
+	}
+	sceneName := scene.GetName()
+	m.acquireMultiFocusInScene(sceneName)
+	candidates := m.candidatesInScene(sceneName)
+	if len(candidates) == 0 {
+		return nil
+	}
+	next := 0
+	if step < 0 {
+		next = len(candidates) - 1
+	}
+	current := m.focusedInScene(sceneName)
+	for index, entity := range candidates {
+		if entity == current {
+			next = (index + step + len(candidates)) % len(candidates)
+			break
+		}
+	}
+	return m.AcquireFocusToEntity(candidates[next])
+}
+
+// notify method calls all focus listeners with the focus change for the
+// given entity.
+func (m *FocusManager) notify(sceneName string, entity IEntity, focused bool) {
+	if len(m.listeners) == 0 {
+		return
+	}
+	event := &FocusEvent{
+		Scene:   sceneName,
+		Entity:  entity,
+		Focused: focused,
+	}
+	for _, listener := range m.listeners {
+		listener(event)
+	}
+}
+
 // releaseFocusFromEntityInScene method release the focus for the given entity
 // in the given scene with the given index.
 func (m *FocusManager) releaseFocusFromEntityInScene(sceneName string, entity IEntity, index int) error {
@@ -131,6 +325,7 @@ func (m *FocusManager) releaseFocusFromEntityInScene(sceneName string, entity IE
 	entity.ReleaseFocus()
 	// Add the entity to the end of the entities list.
 	m.entities[sceneName] = append(m.entities[sceneName], entity)
+	m.notify(sceneName, entity, false)
 	return nil
 }
 
@@ -149,6 +344,9 @@ func (m *FocusManager) AcquireFocusToEntity(entity IEntity) error {
 		return nil
 	}
 	if sceneName, index := m.indexForEntityInEntities(entity); index != entityNotInScene {
+		if m.isLockedFor(sceneName, entity) {
+			return fmt.Errorf("entity %s is outside the focus scope", entity.GetName())
+		}
 		// look for any single-focus entity in the list of entities with focus
 		// for the given scene and remove the focus for that entity.
 		for index, entity := range m.withFocus[sceneName] {
@@ -176,9 +374,68 @@ func (m *FocusManager) AddEntity(scene IScene, entity IEntity) error {
 		WithField("method", "UpdateFocusForScene").
 		Debugf("add entity %s", scene.GetName())
 	m.entities[scene.GetName()] = append(m.entities[scene.GetName()], entity)
+	m.order[entity] = m.sequence
+	m.sequence++
+	return nil
+}
+
+// AddFocusListener method adds a function to be called every time any entity
+// acquires or releases the focus.
+func (m *FocusManager) AddFocusListener(listener FocusListener) {
+	m.listeners = append(m.listeners, listener)
+}
+
+// FocusDirectionInScene method moves the focus in the given scene to the
+// nearest entity in the given direction from the entity with focus. Entities
+// far from the line in that direction are farther than entities close to it.
+func (m *FocusManager) FocusDirectionInScene(scene IScene, direction FocusDirection) error {
+	if m.IsLocked() {
+		return nil
+	}
+	sceneName := scene.GetName()
+	candidates := m.candidatesInScene(sceneName)
+	current := m.focusedInScene(sceneName)
+	if current == nil {
+		if len(candidates) != 0 {
+			return m.AcquireFocusToEntity(candidates[0])
+		}
+		return nil
+	}
+	fromX, fromY := focusCenter(current)
+	var nearest IEntity
+	nearestScore := 0
+	for _, entity := range candidates {
+		if entity == current {
+			continue
+		}
+		toX, toY := focusCenter(entity)
+		main, cross := focusDistance(direction, fromX, fromY, toX, toY)
+		if main <= 0 {
+			continue
+		}
+		score := main + 2*tools.Abs(cross)
+		if nearest == nil || score < nearestScore {
+			nearest, nearestScore = entity, score
+		}
+	}
+	if nearest != nil {
+		return m.AcquireFocusToEntity(nearest)
+	}
 	return nil
 }
 
+// FocusNextInScene method moves the focus in the given scene to the next
+// entity in the tab order.
+func (m *FocusManager) FocusNextInScene(scene IScene) error {
+	return m.moveFocus(scene, 1)
+}
+
+// FocusPreviousInScene method moves the focus in the given scene to the
+// previous entity in the tab order.
+func (m *FocusManager) FocusPreviousInScene(scene IScene) error {
+	return m.moveFocus(scene, -1)
+}
+
 // GetEntities method returns all entities in the focus manager.
 func (m *FocusManager) GetEntities() map[string][]IEntity {
 	return m.entities
@@ -189,6 +446,47 @@ func (m *FocusManager) GetEntitiesWithFocus() map[string][]IEntity {
 	return m.withFocus
 }
 
+// GetFocusScope method returns the focus scope the focus is trapped in for the
+// given scene, nil if there is not any.
+func (m *FocusManager) GetFocusScope(scene IScene) IFocusScope {
+	if scopes := m.scopes[scene.GetName()]; len(scopes) != 0 {
+		return scopes[len(scopes)-1].scope
+	}
+	return nil
+}
+
+// HandlesArrows method checks if any entity with focus handles the arrow
+// keys, so the focus should not be moved to the nearest entity.
+func (m *FocusManager) HandlesArrows() bool {
+	for _, entities := range m.withFocus {
+		for _, entity := range entities {
+			if handler, ok := entity.(IArrowHandler); ok && entity.HasFocus() && handler.HandlesArrows() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HandlesTab method checks if any entity with focus handles the tab key, so
+// the focus should not be moved to the next entity.
+func (m *FocusManager) HandlesTab() bool {
+	for _, entities := range m.withFocus {
+		for _, entity := range entities {
+			if handler, ok := entity.(ITabHandler); ok && entity.HasFocus() && handler.HandlesTab() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsArrowNavigation method checks if arrow keys move the focus to the
+// nearest entity in the arrow direction.
+func (m *FocusManager) IsArrowNavigation() bool {
+	return m.arrowNavigation
+}
+
 // IsLocked method checks if the focus manager is locked for switching focus to
 // other entities.
 func (m *FocusManager) IsLocked() bool {
@@ -200,7 +498,7 @@ func (m *FocusManager) IsLocked() bool {
 func (m *FocusManager) NextEntityWithFocusInScene(scene IScene) (IEntity, int) {
 	if entities, ok := m.entities[scene.GetName()]; ok {
 		for index, entity := range entities {
-			if entity.CanHaveFocus() {
+			if entity.CanHaveFocus() && !m.isLockedFor(scene.GetName(), entity) {
 				return entity, index
 			}
 		}
@@ -208,6 +506,42 @@ func (m *FocusManager) NextEntityWithFocusInScene(scene IScene) (IEntity, int) {
 	return nil, 0
 }
 
+// PopFocusScope method removes the last focus scope pushed for the given
+// scene. The entity with the focus when the scope was pushed gets the focus
+// back.
+func (m *FocusManager) PopFocusScope(scene IScene) IFocusScope {
+	sceneName := scene.GetName()
+	scopes := m.scopes[sceneName]
+	if len(scopes) == 0 {
+		return nil
+	}
+	last := scopes[len(scopes)-1]
+	if m.scopes[sceneName] = scopes[:len(scopes)-1]; len(m.scopes[sceneName]) == 0 {
+		delete(m.scopes, sceneName)
+	}
+	if last.previous != nil && last.previous.CanHaveFocus() {
+		m.AcquireFocusToEntity(last.previous)
+	}
+	return last.scope
+}
+
+// PushFocusScope method traps the focus for the given scene in the given
+// scope, so only entities in the scope can take the focus, until the scope is
+// popped. The focus is moved to the first entity in the scope if the entity
+// with the focus is outside it.
+func (m *FocusManager) PushFocusScope(scene IScene, scope IFocusScope) {
+	sceneName := scene.GetName()
+	previous := m.focusedInScene(sceneName)
+	m.scopes[sceneName] = append(m.scopes[sceneName], &focusScope{scope: scope, previous: previous})
+	if previous == nil || !scope.ContainsEntity(previous) {
+		m.moveFocus(scene, 1)
+		// entities outside the scope do not keep the focus.
+		if current := m.focusedInScene(sceneName); current != nil && current == previous {
+			m.ReleaseFocusFromEntity(current)
+		}
+	}
+}
+
 // ReleaseFocusFromEntity method release the focus from the given entity.
 func (m *FocusManager) ReleaseFocusFromEntity(entity IEntity) error {
 	// if focus manager is locked, return.
@@ -250,7 +584,9 @@ func (m *FocusManager) RemoveEntity(scene IScene, entity IEntity) error {
 		if len(m.withFocus[sceneName]) == 0 {
 			delete(m.withFocus, sceneName)
 		}
+		m.notify(sceneName, entity, false)
 	}
+	delete(m.order, entity)
 	// after entity has been removed from all list, update the focus for the
 	// scene for the next available entity.
 	m.UpdateFocusForScene(scene)
@@ -260,6 +596,11 @@ func (m *FocusManager) RemoveEntity(scene IScene, entity IEntity) error {
 // RemoveEntitiesInScene method removes all entities from the given scene in
 // the focus manager.
 func (m *FocusManager) RemoveEntitiesInScene(scene IScene) error {
+	for _, entities := range [][]IEntity{m.entities[scene.GetName()], m.withFocus[scene.GetName()]} {
+		for _, entity := range entities {
+			delete(m.order, entity)
+		}
+	}
 	m.entities[scene.GetName()] = []IEntity{}
 	m.withFocus[scene.GetName()] = []IEntity{}
 	return nil
@@ -267,8 +608,21 @@ func (m *FocusManager) RemoveEntitiesInScene(scene IScene) error {
 
 // RemoveScene method removes all entities for the given scene.
 func (m *FocusManager) RemoveScene(scene IScene) {
+	for _, entities := range [][]IEntity{m.entities[scene.GetName()], m.withFocus[scene.GetName()]} {
+		for _, entity := range entities {
+			delete(m.order, entity)
+		}
+	}
 	delete(m.entities, scene.GetName())
 	delete(m.withFocus, scene.GetName())
+	delete(m.scopes, scene.GetName())
+}
+
+// SetArrowNavigation method sets if arrow keys move the focus to the nearest
+// entity in the arrow direction. Entities with focus handling arrow keys keep
+// using them.
+func (m *FocusManager) SetArrowNavigation(enable bool) {
+	m.arrowNavigation = enable
 }
 
 // SetLocked method locks or unlocks focus manager in order to select a new
@@ -289,18 +643,14 @@ func (m *FocusManager) UpdateFocusForScene(scene IScene) error {
 	}
 
 	sceneName := scene.GetName()
-	if entities, ok := m.entities[sceneName]; ok {
+	if _, ok := m.entities[sceneName]; ok {
 		// ensure that there is a list for entities with focus for the given
 		// scene.
 		if _, ok := m.withFocus[sceneName]; !ok {
 			m.withFocus[sceneName] = []IEntity{}
 		}
 		// look for all multi-focus entities in the given scene.
-		for index, entity := range entities {
-			if entity.CanHaveFocus() && (entity.GetFocusType() == MultiFocus) {
-				m.acquireFocusToEntityInScene(sceneName, entity, index)
-			}
-		}
+		m.acquireMultiFocusInScene(sceneName)
 		// look for any single-focus entity in the list of entities with focus
 		// for the given scene and remove the focus for that entity.
 		for index, entity := range m.withFocus[sceneName] {
@@ -1,8 +1,10 @@
 package engine_test
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/engine"
 )
 
@@ -590,3 +592,252 @@ func TestFocusReleaseFocusFromEntity(t *testing.T) {
 		t.Errorf("[1] ReleaseFocusFromEntity Error.Entity exp:%s got:%s", entity11.GetName(), entity.GetName())
 	}
 }
+
+type tabEntity struct {
+	*engine.Entity
+	tab bool
+}
+
+func (e *tabEntity) HandlesTab() bool {
+	return e.tab
+}
+
+func TestFocusManagerHandlesTab(t *testing.T) {
+	entity := &tabEntity{Entity: engine.NewNamedEntity("entity/tab"), tab: true}
+	entity.SetFocusEnable(true)
+	entity.SetFocusType(engine.SingleFocus)
+
+	got := engine.NewFocusManager()
+	_ = got.AddEntity(scene1, entity)
+	if got.HandlesTab() {
+		t.Errorf("[0] HandlesTab Error exp:false got:true")
+	}
+	_ = got.AcquireFocusToEntity(entity)
+	if !got.HandlesTab() {
+		t.Errorf("[1] HandlesTab Error exp:true got:false")
+	}
+	entity.tab = false
+	if got.HandlesTab() {
+		t.Errorf("[2] HandlesTab Error exp:false got:true")
+	}
+}
+
+func newFocusEntity(name string, x int, y int) *engine.Entity {
+	entity := engine.NewEntity(name, api.NewPoint(x, y), api.NewSize(4, 1), nil)
+	entity.SetFocusEnable(true)
+	entity.SetFocusType(engine.SingleFocus)
+	return entity
+}
+
+func focusedName(manager *engine.FocusManager, scene engine.IScene) string {
+	for _, entity := range manager.GetEntitiesWithFocus()[scene.GetName()] {
+		if entity.GetFocusType() == engine.SingleFocus {
+			return entity.GetName()
+		}
+	}
+	return ""
+}
+
+func TestFocusManagerFocusNextPrevious(t *testing.T) {
+	scene := engine.NewScene("scene/tab-order", nil)
+	first := newFocusEntity("entity/first", 0, 0)
+	second := newFocusEntity("entity/second", 0, 1)
+	third := newFocusEntity("entity/third", 0, 2)
+	got := engine.NewFocusManager()
+	for _, entity := range []engine.IEntity{first, second, third} {
+		_ = got.AddEntity(scene, entity)
+	}
+
+	cases := []struct {
+		next bool
+		exp  string
+	}{
+		{true, "entity/first"},
+		{true, "entity/second"},
+		{true, "entity/third"},
+		{true, "entity/first"},
+		{false, "entity/third"},
+		{false, "entity/second"},
+	}
+	for i, c := range cases {
+		if c.next {
+			_ = got.FocusNextInScene(scene)
+		} else {
+			_ = got.FocusPreviousInScene(scene)
+		}
+		if name := focusedName(got, scene); name != c.exp {
+			t.Errorf("[%d] FocusNextPrevious Error exp:%s got:%s", i, c.exp, name)
+		}
+	}
+
+	// entities with a tab index go first, in tab index order, so the order
+	// is third, second and first.
+	third.SetTabIndex(1)
+	second.SetTabIndex(2)
+	exps := []string{"entity/first", "entity/third", "entity/second", "entity/first"}
+	for i, exp := range exps {
+		_ = got.FocusNextInScene(scene)
+		if name := focusedName(got, scene); name != exp {
+			t.Errorf("[%d] FocusNext TabIndex Error exp:%s got:%s", i, exp, name)
+		}
+	}
+
+	// disabled entities are skipped.
+	first.SetFocusEnable(false)
+	_ = got.FocusPreviousInScene(scene)
+	if name := focusedName(got, scene); name != "entity/second" {
+		t.Errorf("[0] FocusPrevious Disabled Error exp:%s got:%s", "entity/second", name)
+	}
+	_ = got.FocusPreviousInScene(scene)
+	if name := focusedName(got, scene); name != "entity/third" {
+		t.Errorf("[1] FocusPrevious Disabled Error exp:%s got:%s", "entity/third", name)
+	}
+}
+
+func TestFocusManagerFocusDirection(t *testing.T) {
+	// entities in a grid:
+	//  topLeft     topRight
+	//  bottomLeft  bottomRight
+	//              lower
+	scene := engine.NewScene("scene/direction", nil)
+	topLeft := newFocusEntity("entity/top-left", 0, 0)
+	topRight := newFocusEntity("entity/top-right", 10, 0)
+	bottomLeft := newFocusEntity("entity/bottom-left", 0, 2)
+	bottomRight := newFocusEntity("entity/bottom-right", 10, 2)
+	lower := newFocusEntity("entity/lower", 11, 4)
+	got := engine.NewFocusManager()
+	for _, entity := range []engine.IEntity{topLeft, topRight, bottomLeft, bottomRight, lower} {
+		_ = got.AddEntity(scene, entity)
+	}
+
+	cases := []struct {
+		direction engine.FocusDirection
+		exp       string
+	}{
+		{engine.FocusDown, "entity/top-left"},
+		{engine.FocusUp, "entity/top-left"},
+		{engine.FocusLeft, "entity/top-left"},
+		{engine.FocusRight, "entity/top-right"},
+		{engine.FocusDown, "entity/bottom-right"},
+		{engine.FocusLeft, "entity/bottom-left"},
+		{engine.FocusDown, "entity/lower"},
+		{engine.FocusUp, "entity/bottom-right"},
+		{engine.FocusUp, "entity/top-right"},
+	}
+	for i, c := range cases {
+		_ = got.FocusDirectionInScene(scene, c.direction)
+		if name := focusedName(got, scene); name != c.exp {
+			t.Errorf("[%d] FocusDirection Error exp:%s got:%s", i, c.exp, name)
+		}
+	}
+}
+
+func TestFocusManagerFocusScope(t *testing.T) {
+	scene := engine.NewScene("scene/scope", nil)
+	outside := newFocusEntity("entity/outside", 0, 0)
+	ok := newFocusEntity("entity/ok", 0, 1)
+	cancel := newFocusEntity("entity/cancel", 6, 1)
+	got := engine.NewFocusManager()
+	for _, entity := range []engine.IEntity{outside, ok, cancel} {
+		_ = got.AddEntity(scene, entity)
+	}
+	_ = got.AcquireFocusToEntity(outside)
+
+	dialog := engine.NewFocusGroup(ok, cancel)
+	got.PushFocusScope(scene, dialog)
+	if got.GetFocusScope(scene) != dialog {
+		t.Errorf("[0] FocusScope Error.Scope exp:%v got:%v", dialog, got.GetFocusScope(scene))
+	}
+	if name := focusedName(got, scene); name != "entity/ok" {
+		t.Errorf("[0] FocusScope Error exp:%s got:%s", "entity/ok", name)
+	}
+	exps := []string{"entity/cancel", "entity/ok", "entity/cancel"}
+	for i, exp := range exps {
+		_ = got.FocusNextInScene(scene)
+		if name := focusedName(got, scene); name != exp {
+			t.Errorf("[%d] FocusScope Next Error exp:%s got:%s", i, exp, name)
+		}
+	}
+	if err := got.AcquireFocusToEntity(outside); err == nil {
+		t.Errorf("[1] FocusScope Error.Acquire exp:error got:nil")
+	}
+	_ = got.UpdateFocusForScene(scene)
+	if name := focusedName(got, scene); name != "entity/ok" {
+		t.Errorf("[2] FocusScope Error.Update exp:%s got:%s", "entity/ok", name)
+	}
+
+	if scope := got.PopFocusScope(scene); scope != dialog {
+		t.Errorf("[3] FocusScope Error.Pop exp:%v got:%v", dialog, scope)
+	}
+	if got.GetFocusScope(scene) != nil {
+		t.Errorf("[3] FocusScope Error.Scope exp:nil got:%v", got.GetFocusScope(scene))
+	}
+	if name := focusedName(got, scene); name != "entity/outside" {
+		t.Errorf("[3] FocusScope Error exp:%s got:%s", "entity/outside", name)
+	}
+	if scope := got.PopFocusScope(scene); scope != nil {
+		t.Errorf("[4] FocusScope Error.Pop exp:nil got:%v", scope)
+	}
+}
+
+func TestFocusManagerFocusListener(t *testing.T) {
+	scene := engine.NewScene("scene/listener", nil)
+	first := newFocusEntity("entity/first", 0, 0)
+	second := newFocusEntity("entity/second", 0, 1)
+	got := engine.NewFocusManager()
+	_ = got.AddEntity(scene, first)
+	_ = got.AddEntity(scene, second)
+	events := []string{}
+	got.AddFocusListener(func(event *engine.FocusEvent) {
+		events = append(events, fmt.Sprintf("%s:%s:%t", event.Scene, event.Entity.GetName(), event.Focused))
+	})
+
+	_ = got.FocusNextInScene(scene)
+	_ = got.FocusNextInScene(scene)
+	_ = got.RemoveEntity(scene, second)
+	exps := []string{
+		"scene/listener:entity/first:true",
+		"scene/listener:entity/first:false",
+		"scene/listener:entity/second:true",
+		"scene/listener:entity/second:false",
+		// the focus is updated when the entity with focus is removed.
+		"scene/listener:entity/first:true",
+	}
+	if len(events) != len(exps) {
+		t.Errorf("[0] FocusListener Error.Len exp:%d got:%d %v", len(exps), len(events), events)
+		return
+	}
+	for i, exp := range exps {
+		if events[i] != exp {
+			t.Errorf("[%d] FocusListener Error exp:%s got:%s", i, exp, events[i])
+		}
+	}
+}
+
+type arrowEntity struct {
+	*engine.Entity
+}
+
+func (e *arrowEntity) HandlesArrows() bool {
+	return true
+}
+
+func TestFocusManagerHandlesArrows(t *testing.T) {
+	entity := &arrowEntity{Entity: newFocusEntity("entity/arrow", 0, 0)}
+	got := engine.NewFocusManager()
+	if got.IsArrowNavigation() {
+		t.Errorf("[0] IsArrowNavigation Error exp:false got:true")
+	}
+	got.SetArrowNavigation(true)
+	if !got.IsArrowNavigation() {
+		t.Errorf("[1] IsArrowNavigation Error exp:true got:false")
+	}
+	_ = got.AddEntity(scene1, entity)
+	if got.HandlesArrows() {
+		t.Errorf("[0] HandlesArrows Error exp:false got:true")
+	}
+	_ = got.AcquireFocusToEntity(entity)
+	if !got.HandlesArrows() {
+		t.Errorf("[1] HandlesArrows Error exp:true got:false")
+	}
+}
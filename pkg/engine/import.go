@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/tools"
@@ -24,52 +25,160 @@ type IBuiltIn interface {
 }
 
 // -----------------------------------------------------------------------------
-// Module public methods
+// Module private methods
 // -----------------------------------------------------------------------------
 
-// ImportEntitiesFromJSON function reads all entities in the given JSON file
-// and it returns an array of IEntity instances.
-func ImportEntitiesFromJSON(filename string, origin *api.Point, builtin IBuiltIn) []IEntity {
-	var result []IEntity
-
+// readEntityMapsFromJSON function reads the given JSON file with a list of
+// entities and it returns every entity as a map.
+func readEntityMapsFromJSON(filename string) ([]map[string]any, error) {
 	jsonContent, err := os.ReadFile(filename)
 	if err != nil {
-		panic(fmt.Sprintf("Error reading %s:%s", filename, err.Error()))
+		return nil, fmt.Errorf("Error reading %s:%s", filename, err.Error())
 	}
 	var content []map[string]any
 	if err := json.Unmarshal(jsonContent, &content); err != nil {
-		panic(fmt.Sprintf("Error unmarshaling %s:%s", filename, err.Error()))
+		return nil, fmt.Errorf("Error unmarshaling %s:%s", filename, err.Error())
 	}
-	tools.Logger.WithField("module", "import").
-		WithField("function", "ImportEntitiesToJSON").
-		Debugf("importing content %+#v", content)
-	for _, mapEntity := range content {
-		var entity IEntity
-		if builtin == nil {
-			entity = NewEmptyEntity()
+	return content, nil
+}
+
+// newEntitiesFromMap function creates the entity for the given map, using the
+// prefab it references in the given prefab manager, and all its child
+// entities. The entity is the first one returned.
+func newEntitiesFromMap(prefabs *PrefabManager, mapEntity map[string]any, origin *api.Point, builtin IBuiltIn) ([]IEntity, error) {
+	content, err := prefabs.resolve(mapEntity)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := newEntityFromMap(prefabs, content, origin, builtin)
+	if err != nil {
+		return nil, err
+	}
+	result := []IEntity{entity}
+	if children, ok := content[PrefabChildrenKey].([]any); ok {
+		for _, tmp := range children {
+			child, ok := tmp.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("entity %s: child %+v is not an entity", entity.GetName(), tmp)
+			}
+			// child positions are relative to the parent entity.
+			childOrigin := api.NewPoint(0, 0)
+			if entity.GetPosition() != nil {
+				childOrigin = api.ClonePoint(entity.GetPosition())
+			}
+			entities, err := newEntitiesFromMap(prefabs, child, childOrigin, builtin)
+			if err != nil {
+				return nil, fmt.Errorf("entity %s: %s", entity.GetName(), err.Error())
+			}
+			result = append(result, entities...)
+		}
+	}
+	return result, nil
+}
+
+// newEntityFromMap function creates a new entity with the content of the
+// given map. The builtin instance creates the entity for the class in the map
+// and behaviors are found by name in the given prefab manager. Any malformed
+// content is returned as an error.
+func newEntityFromMap(prefabs *PrefabManager, mapEntity map[string]any, origin *api.Point, builtin IBuiltIn) (entity IEntity, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("entity %+v: %v", mapEntity["name"], r)
+		}
+	}()
+	className, _ := mapEntity["class"].(string)
+	if builtin == nil {
+		entity = NewEmptyEntity()
+	} else {
+		entity = builtin.GetClassFromString(className)
+	}
+	if className != "" {
+		entity.SetClassName(className)
+	}
+	if err := entity.UnmarshalMap(mapEntity, origin); err != nil {
+		return nil, err
+	}
+	if lines, ok := mapEntity["canvas"].([]any); ok {
+		rows := make([]string, len(lines))
+		for i, line := range lines {
+			rows[i] = line.(string)
+		}
+		canvas := NewCanvasFromString(strings.Join(rows, "\n"), entity.GetStyle())
+		if entity.GetSize() == nil {
+			entity.SetSize(canvas.Size())
+		}
+		entity.SetCanvas(canvas)
+	} else if entity.GetSize() != nil {
+		canvas := NewCanvas(entity.GetSize())
+		ch, _ := mapEntity["ch"].(string)
+		if len(ch) != 1 {
+			canvas.WriteStringInCanvas(ch, entity.GetStyle())
 		} else {
-			entity = builtin.GetClassFromString(mapEntity["class"].(string))
+			cell := NewCell(entity.GetStyle(), rune(ch[0]))
+			canvas.FillWithCell(cell)
 		}
-		if err := entity.UnmarshalMap(mapEntity, origin); err != nil {
-			panic(fmt.Sprintf("Error unmarshaling entitys %s:%s", filename, err.Error()))
+		entity.SetCanvas(canvas)
+	}
+	if solid, ok := mapEntity["solid"].(bool); ok {
+		entity.SetSolid(solid)
+	}
+	if cache, ok := mapEntity["cache"].(map[string]any); ok {
+		for key, value := range cache {
+			entity.GetCache().Set(key, value)
 		}
-		if entity.GetSize() != nil {
-			canvas := NewCanvas(entity.GetSize())
-			ch := mapEntity["ch"].(string)
-			if len(ch) != 1 {
-				canvas.WriteStringInCanvas(mapEntity["ch"].(string), entity.GetStyle())
-			} else {
-				cell := NewCell(entity.GetStyle(), rune(ch[0]))
-				canvas.FillWithCell(cell)
+	}
+	if behaviors, ok := mapEntity["behaviors"].(map[string]any); ok {
+		for behaviorType, tmp := range behaviors {
+			name, _ := tmp.(string)
+			behavior, ok := prefabs.getBehavior(name)
+			if !ok {
+				return nil, fmt.Errorf("entity %s: behavior %+v not found", entity.GetName(), tmp)
 			}
-			entity.SetCanvas(canvas)
+			entity.SetBehaviorFor(behaviorType, behavior)
 		}
-		result = append(result, entity)
 	}
+	return entity, nil
+}
 
+// -----------------------------------------------------------------------------
+// Module public methods
+// -----------------------------------------------------------------------------
+
+// ImportEntitiesFromJSON function reads all entities in the given JSON file
+// and it returns an array of IEntity instances. Entities can reference any
+// prefab in the prefab manager returned by GetPrefabManager(). It panics if
+// the file can not be imported, LoadEntitiesFromJSON function returns the
+// error instead.
+func ImportEntitiesFromJSON(filename string, origin *api.Point, builtin IBuiltIn) []IEntity {
+	result, err := LoadEntitiesFromJSON(filename, origin, builtin)
+	if err != nil {
+		panic(err.Error())
+	}
 	return result
 }
 
+// LoadEntitiesFromJSON function reads all entities in the given JSON file and
+// it returns an array of IEntity instances or the error found.
+func LoadEntitiesFromJSON(filename string, origin *api.Point, builtin IBuiltIn) ([]IEntity, error) {
+	var result []IEntity
+
+	content, err := readEntityMapsFromJSON(filename)
+	if err != nil {
+		return nil, err
+	}
+	tools.Logger.WithField("module", "import").
+		WithField("function", "LoadEntitiesFromJSON").
+		Debugf("importing content %+#v", content)
+	for _, mapEntity := range content {
+		entities, err := newEntitiesFromMap(GetPrefabManager(), mapEntity, origin, builtin)
+		if err != nil {
+			return nil, fmt.Errorf("Error unmarshaling entitys %s:%s", filename, err.Error())
+		}
+		result = append(result, entities...)
+	}
+	return result, nil
+}
+
 // ExportEntitiesToJSON function exports given entites to the given JSON file
 // in JSON format.
 func ExportEntitiesToJSON(filename string, entities []IEntity, origin *api.Point, builtin IBuiltIn) error {
@@ -0,0 +1,389 @@
+// layout.go contains the layout constraints used to place entities and
+// cameras when the screen is resized. A layout anchors a rectangle to the
+// edges or to the center of its container, which is the camera for entities
+// and the screen for cameras, and it sets its size in cells or as a
+// percentage of the container size, limited to a minimum and a maximum size.
+// Example:
+//
+//	// status bar at the bottom of the screen using the whole width.
+//	entity.SetLayout(engine.NewLayout(engine.LayoutAnchorBottom, nil).
+//	    WithSize(engine.LayoutPercent(100), engine.LayoutCells(3)))
+//
+// Layouts can be imported from JSON files as well:
+//
+//	"layout": {"anchor": "top-right", "offset": [1, 0], "width": "30%", "min": [20, 0]}
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// LayoutAnchor type defines the point of the container a layout rectangle is
+// anchored to.
+type LayoutAnchor int
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	// LayoutAnchorNone keeps the position, only the size is changed.
+	LayoutAnchorNone LayoutAnchor = iota
+	LayoutAnchorTopLeft
+	LayoutAnchorTop
+	LayoutAnchorTopRight
+	LayoutAnchorLeft
+	LayoutAnchorCenter
+	LayoutAnchorRight
+	LayoutAnchorBottomLeft
+	LayoutAnchorBottom
+	LayoutAnchorBottomRight
+)
+
+// -----------------------------------------------------------------------------
+// Package private variables
+// -----------------------------------------------------------------------------
+
+var (
+	// layoutAnchorNames contains the name used in JSON files for every
+	// anchor.
+	layoutAnchorNames = map[LayoutAnchor]string{
+		LayoutAnchorNone:        "none",
+		LayoutAnchorTopLeft:     "top-left",
+		LayoutAnchorTop:         "top",
+		LayoutAnchorTopRight:    "top-right",
+		LayoutAnchorLeft:        "left",
+		LayoutAnchorCenter:      "center",
+		LayoutAnchorRight:       "right",
+		LayoutAnchorBottomLeft:  "bottom-left",
+		LayoutAnchorBottom:      "bottom",
+		LayoutAnchorBottomRight: "bottom-right",
+	}
+)
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// GetLayoutAnchorFromString function returns the anchor with the given name.
+func GetLayoutAnchorFromString(name string) (LayoutAnchor, error) {
+	for anchor, anchorName := range layoutAnchorNames {
+		if anchorName == name {
+			return anchor, nil
+		}
+	}
+	return LayoutAnchorNone, fmt.Errorf("unknown layout anchor %s", name)
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// layoutAxis function returns the position in one axis for a rectangle with
+// the given length anchored to the given side, where -1 is the start, 0 is
+// the center and 1 is the end of the container. The offset is the margin to
+// the anchored edge.
+func layoutAxis(side int, start int, length int, containerLength int, offset int) int {
+	switch side {
+	case -1:
+		return start + offset
+	case 1:
+		return start + containerLength - length - offset
+	default:
+		return start + (containerLength-length)/2 + offset
+	}
+}
+
+// newPointFromAny function returns a point from the given JSON value, which
+// is a list with two numbers.
+func newPointFromAny(value any) (*api.Point, error) {
+	list, ok := value.([]any)
+	if !ok || len(list) != 2 {
+		return nil, fmt.Errorf("invalid point %v", value)
+	}
+	x, okX := list[0].(float64)
+	y, okY := list[1].(float64)
+	if !okX || !okY {
+		return nil, fmt.Errorf("invalid point %v", value)
+	}
+	return api.NewPoint(int(x), int(y)), nil
+}
+
+// -----------------------------------------------------------------------------
+//
+// LayoutDimension
+//
+// -----------------------------------------------------------------------------
+
+// LayoutDimension structure defines a width or a height in cells or as a
+// percentage of the container. The zero value keeps the current length.
+type LayoutDimension struct {
+	Value   int
+	Percent bool
+}
+
+// LayoutCells function returns a dimension with the given number of cells.
+func LayoutCells(cells int) LayoutDimension {
+	return LayoutDimension{Value: cells}
+}
+
+// LayoutPercent function returns a dimension with the given percentage of
+// the container.
+func LayoutPercent(percent int) LayoutDimension {
+	return LayoutDimension{Value: percent, Percent: true}
+}
+
+// NewLayoutDimensionFromAny function returns the dimension for the given JSON
+// value, which is a number of cells or a string with a percentage, like
+// "50%".
+func NewLayoutDimensionFromAny(value any) (LayoutDimension, error) {
+	switch v := value.(type) {
+	case float64:
+		return LayoutCells(int(v)), nil
+	case string:
+		if percent, ok := strings.CutSuffix(v, "%"); ok {
+			if number, err := strconv.Atoi(percent); err == nil {
+				return LayoutPercent(number), nil
+			}
+		} else if number, err := strconv.Atoi(v); err == nil {
+			return LayoutCells(number), nil
+		}
+	}
+	return LayoutDimension{}, fmt.Errorf("invalid layout dimension %v", value)
+}
+
+// -----------------------------------------------------------------------------
+// LayoutDimension public methods
+// -----------------------------------------------------------------------------
+
+// IsZero method checks if the dimension keeps the current length.
+func (d LayoutDimension) IsZero() bool {
+	return d.Value == 0 && !d.Percent
+}
+
+// Resolve method returns the length for the given container length, or the
+// given current length if the dimension is zero.
+func (d LayoutDimension) Resolve(containerLength int, length int) int {
+	if d.IsZero() {
+		return length
+	}
+	if d.Percent {
+		return containerLength * d.Value / 100
+	}
+	return d.Value
+}
+
+// ToAny method returns the dimension as a JSON value.
+func (d LayoutDimension) ToAny() any {
+	if d.Percent {
+		return strconv.Itoa(d.Value) + "%"
+	}
+	return d.Value
+}
+
+// -----------------------------------------------------------------------------
+//
+// Layout
+//
+// -----------------------------------------------------------------------------
+
+// Layout structure defines the constraints to place a rectangle in its
+// container.
+// anchor is the container point the rectangle is anchored to.
+// offset is the margin between the anchored container edges and the
+// rectangle, or the displacement from the container center.
+// width and height are the rectangle size.
+// minSize and maxSize limit the size, a zero length does not limit it.
+type Layout struct {
+	anchor  LayoutAnchor
+	offset  *api.Point
+	width   LayoutDimension
+	height  LayoutDimension
+	minSize *api.Size
+	maxSize *api.Size
+}
+
+// NewLayout function creates a new Layout instance anchored to the given
+// anchor with the given offset, keeping the current size.
+func NewLayout(anchor LayoutAnchor, offset *api.Point) *Layout {
+	if offset == nil {
+		offset = api.NewPoint(0, 0)
+	}
+	return &Layout{
+		anchor:  anchor,
+		offset:  offset,
+		minSize: nil,
+		maxSize: nil,
+	}
+}
+
+// NewLayoutFromMap function creates a new Layout instance from the given map
+// read from a JSON file, with keys "anchor", "offset", "width", "height",
+// "min" and "max".
+func NewLayoutFromMap(content map[string]any) (*Layout, error) {
+	layout := NewLayout(LayoutAnchorNone, nil)
+	if name, ok := content["anchor"].(string); ok {
+		anchor, err := GetLayoutAnchorFromString(name)
+		if err != nil {
+			return nil, err
+		}
+		layout.anchor = anchor
+	}
+	if value, ok := content["offset"]; ok {
+		offset, err := newPointFromAny(value)
+		if err != nil {
+			return nil, fmt.Errorf("layout offset: %s", err.Error())
+		}
+		layout.offset = offset
+	}
+	for key, dimension := range map[string]*LayoutDimension{"width": &layout.width, "height": &layout.height} {
+		if value, ok := content[key]; ok {
+			result, err := NewLayoutDimensionFromAny(value)
+			if err != nil {
+				return nil, fmt.Errorf("layout %s: %s", key, err.Error())
+			}
+			*dimension = result
+		}
+	}
+	for key, size := range map[string]**api.Size{"min": &layout.minSize, "max": &layout.maxSize} {
+		if value, ok := content[key]; ok {
+			point, err := newPointFromAny(value)
+			if err != nil {
+				return nil, fmt.Errorf("layout %s: %s", key, err.Error())
+			}
+			*size = api.NewSize(point.X, point.Y)
+		}
+	}
+	return layout, nil
+}
+
+// -----------------------------------------------------------------------------
+// Layout public methods
+// -----------------------------------------------------------------------------
+
+// Apply method returns the position and the size for a rectangle with the
+// given position and size placed in the given container.
+func (l *Layout) Apply(position *api.Point, size *api.Size, container *api.Rect) (*api.Point, *api.Size) {
+	if position == nil {
+		position = api.NewPoint(0, 0)
+	}
+	if size == nil {
+		size = api.NewSize(0, 0)
+	}
+	width := l.width.Resolve(container.Size.W, size.W)
+	height := l.height.Resolve(container.Size.H, size.H)
+	if l.minSize != nil {
+		width = tools.Max(width, l.minSize.W)
+		height = tools.Max(height, l.minSize.H)
+	}
+	if l.maxSize != nil {
+		if l.maxSize.W != 0 {
+			width = tools.Min(width, l.maxSize.W)
+		}
+		if l.maxSize.H != 0 {
+			height = tools.Min(height, l.maxSize.H)
+		}
+	}
+	result := api.ClonePoint(position)
+	if l.anchor != LayoutAnchorNone {
+		// sides for every anchor, -1 is the start, 0 is the center and 1 is
+		// the end of the container.
+		sideX := (int(l.anchor)-1)%3 - 1
+		sideY := (int(l.anchor)-1)/3 - 1
+		result.X = layoutAxis(sideX, container.Origin.X, width, container.Size.W, l.offset.X)
+		result.Y = layoutAxis(sideY, container.Origin.Y, height, container.Size.H, l.offset.Y)
+	}
+	return result, api.NewSize(width, height)
+}
+
+// GetAnchor method returns the layout anchor.
+func (l *Layout) GetAnchor() LayoutAnchor {
+	return l.anchor
+}
+
+// GetHeight method returns the layout height.
+func (l *Layout) GetHeight() LayoutDimension {
+	return l.height
+}
+
+// GetMaxSize method returns the layout maximum size.
+func (l *Layout) GetMaxSize() *api.Size {
+	return l.maxSize
+}
+
+// GetMinSize method returns the layout minimum size.
+func (l *Layout) GetMinSize() *api.Size {
+	return l.minSize
+}
+
+// GetOffset method returns the layout offset.
+func (l *Layout) GetOffset() *api.Point {
+	return l.offset
+}
+
+// GetWidth method returns the layout width.
+func (l *Layout) GetWidth() LayoutDimension {
+	return l.width
+}
+
+// MarshalMap method returns the layout as a map to be saved in a JSON file.
+func (l *Layout) MarshalMap() map[string]any {
+	content := map[string]any{
+		"anchor": layoutAnchorNames[l.anchor],
+		"offset": []int{l.offset.X, l.offset.Y},
+	}
+	if !l.width.IsZero() {
+		content["width"] = l.width.ToAny()
+	}
+	if !l.height.IsZero() {
+		content["height"] = l.height.ToAny()
+	}
+	if l.minSize != nil {
+		content["min"] = []int{l.minSize.W, l.minSize.H}
+	}
+	if l.maxSize != nil {
+		content["max"] = []int{l.maxSize.W, l.maxSize.H}
+	}
+	return content
+}
+
+// WithHeight method sets the layout height.
+func (l *Layout) WithHeight(height LayoutDimension) *Layout {
+	l.height = height
+	return l
+}
+
+// WithMaxSize method sets the layout maximum size. A zero length does not
+// limit it.
+func (l *Layout) WithMaxSize(size *api.Size) *Layout {
+	l.maxSize = size
+	return l
+}
+
+// WithMinSize method sets the layout minimum size.
+func (l *Layout) WithMinSize(size *api.Size) *Layout {
+	l.minSize = size
+	return l
+}
+
+// WithSize method sets the layout width and height.
+func (l *Layout) WithSize(width LayoutDimension, height LayoutDimension) *Layout {
+	l.width = width
+	l.height = height
+	return l
+}
+
+// WithWidth method sets the layout width.
+func (l *Layout) WithWidth(width LayoutDimension) *Layout {
+	l.width = width
+	return l
+}
@@ -0,0 +1,151 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+func TestLayoutApply(t *testing.T) {
+	container := api.NewRect(api.NewPoint(0, 0), api.NewSize(80, 24))
+	cases := []struct {
+		layout   *engine.Layout
+		position *api.Point
+		size     *api.Size
+		exp      *api.Rect
+	}{
+		{
+			layout:   engine.NewLayout(engine.LayoutAnchorNone, nil),
+			position: api.NewPoint(3, 4),
+			size:     api.NewSize(10, 2),
+			exp:      api.NewRect(api.NewPoint(3, 4), api.NewSize(10, 2)),
+		},
+		{
+			layout:   engine.NewLayout(engine.LayoutAnchorTopLeft, api.NewPoint(1, 1)),
+			position: api.NewPoint(3, 4),
+			size:     api.NewSize(10, 2),
+			exp:      api.NewRect(api.NewPoint(1, 1), api.NewSize(10, 2)),
+		},
+		{
+			layout:   engine.NewLayout(engine.LayoutAnchorBottomRight, api.NewPoint(1, 1)),
+			position: api.NewPoint(0, 0),
+			size:     api.NewSize(10, 2),
+			exp:      api.NewRect(api.NewPoint(69, 21), api.NewSize(10, 2)),
+		},
+		{
+			layout:   engine.NewLayout(engine.LayoutAnchorCenter, nil),
+			position: api.NewPoint(0, 0),
+			size:     api.NewSize(20, 4),
+			exp:      api.NewRect(api.NewPoint(30, 10), api.NewSize(20, 4)),
+		},
+		{
+			layout: engine.NewLayout(engine.LayoutAnchorBottom, nil).
+				WithSize(engine.LayoutPercent(100), engine.LayoutCells(3)),
+			position: api.NewPoint(0, 0),
+			size:     api.NewSize(10, 1),
+			exp:      api.NewRect(api.NewPoint(0, 21), api.NewSize(80, 3)),
+		},
+		{
+			layout: engine.NewLayout(engine.LayoutAnchorRight, nil).
+				WithSize(engine.LayoutPercent(10), engine.LayoutPercent(50)).
+				WithMinSize(api.NewSize(20, 0)).
+				WithMaxSize(api.NewSize(0, 10)),
+			position: api.NewPoint(0, 0),
+			size:     api.NewSize(10, 1),
+			exp:      api.NewRect(api.NewPoint(60, 7), api.NewSize(20, 10)),
+		},
+	}
+	for i, c := range cases {
+		position, size := c.layout.Apply(c.position, c.size, container)
+		if got := api.NewRect(position, size); !got.IsEqual(c.exp) {
+			t.Errorf("[%d] Apply Error exp:%s got:%s", i, c.exp.ToString(), got.ToString())
+		}
+	}
+
+	// layouts can be read from and written to maps.
+	layout, err := engine.NewLayoutFromMap(map[string]any{
+		"anchor": "top-right",
+		"offset": []any{1.0, 0.0},
+		"width":  "30%",
+		"height": 5.0,
+		"min":    []any{20.0, 0.0},
+	})
+	if err != nil {
+		t.Fatalf("[0] NewLayoutFromMap Error exp:nil got:%s", err)
+	}
+	position, size := layout.Apply(nil, nil, container)
+	if got := api.NewRect(position, size); !got.IsEqual(api.NewRect(api.NewPoint(55, 0), api.NewSize(24, 5))) {
+		t.Errorf("[0] Apply Error exp:[55,0][24,5] got:%s", got.ToString())
+	}
+	content := layout.MarshalMap()
+	if content["anchor"] != "top-right" || content["width"] != "30%" || content["height"] != 5 {
+		t.Errorf("[0] MarshalMap Error exp:top-right 30%% 5 got:%v", content)
+	}
+	for i, content := range []map[string]any{
+		{"anchor": "middle"},
+		{"width": "many"},
+		{"offset": []any{1.0}},
+	} {
+		if _, err := engine.NewLayoutFromMap(content); err == nil {
+			t.Errorf("[1:%d] NewLayoutFromMap Error exp:error got:nil", i)
+		}
+	}
+}
+
+func TestEngineRelayout(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	screen.Init()
+	screen.SetSize(80, 24)
+	e := engine.NewEngine()
+	e.SetScreen(screen)
+	e.SetDryRun(true)
+	camera := engine.NewCamera(api.NewPoint(0, 0), api.NewSize(80, 24))
+	camera.SetLayout(engine.NewLayout(engine.LayoutAnchorTopLeft, nil).
+		WithSize(engine.LayoutPercent(100), engine.LayoutPercent(100)))
+	scene := e.NewScene("scene/test/1", camera)
+	statusBar := engine.NewEntity("status-bar", api.NewPoint(0, 0), api.NewSize(1, 1), nil)
+	statusBar.SetLayout(engine.NewLayout(engine.LayoutAnchorBottom, nil).
+		WithSize(engine.LayoutPercent(100), engine.LayoutCells(1)))
+	refreshed := 0
+	panel := &TestRefreshEntity{Entity: engine.NewEntity("panel", api.NewPoint(0, 0), api.NewSize(20, 5), nil), refreshed: &refreshed}
+	panel.SetLayout(engine.NewLayout(engine.LayoutAnchorTopRight, nil))
+	scene.AddEntity(statusBar)
+	scene.AddEntity(panel)
+	e.GetSceneManager().AddScene(scene)
+	e.Init()
+	if got := statusBar.GetRect(); !got.IsEqual(api.NewRect(api.NewPoint(0, 23), api.NewSize(80, 1))) {
+		t.Errorf("[0] Relayout Error exp:[0,23][80,1] got:%s", got.ToString())
+	}
+	if got := panel.GetRect(); !got.IsEqual(api.NewRect(api.NewPoint(60, 0), api.NewSize(20, 5))) {
+		t.Errorf("[0] Relayout Error exp:[60,0][20,5] got:%s", got.ToString())
+	}
+
+	// cameras and entities are placed again when the screen is resized, and
+	// only entities changing their size are refreshed.
+	screen.SetSize(120, 40)
+	e.Relayout()
+	if got := camera.GetSize(); !got.IsEqual(api.NewSize(120, 40)) {
+		t.Errorf("[1] Relayout Error exp:[120,40] got:%s", got.ToString())
+	}
+	if got := statusBar.GetRect(); !got.IsEqual(api.NewRect(api.NewPoint(0, 39), api.NewSize(120, 1))) {
+		t.Errorf("[1] Relayout Error exp:[0,39][120,1] got:%s", got.ToString())
+	}
+	if got := panel.GetRect(); !got.IsEqual(api.NewRect(api.NewPoint(100, 0), api.NewSize(20, 5))) {
+		t.Errorf("[1] Relayout Error exp:[100,0][20,5] got:%s", got.ToString())
+	}
+	if refreshed != 0 {
+		t.Errorf("[1] Refresh Error exp:0 got:%d", refreshed)
+	}
+}
+
+// TestRefreshEntity is an entity counting how many times it is refreshed.
+type TestRefreshEntity struct {
+	*engine.Entity
+	refreshed *int
+}
+
+func (e *TestRefreshEntity) Refresh() {
+	*e.refreshed++
+}
@@ -33,10 +33,12 @@
 //
 // Main Functions:
 //
-// - `GetMailbox()`: Returns a singleton instance of the Mailbox, providing
+// - `Engine.GetMailbox()`: Returns the mailbox for the engine, providing
 // access to the central
 //
-//	messaging hub.
+//	messaging hub. Every engine has its own mailbox, so several engines in
+//	the same process do not share topics. `GetMailbox()` returns the
+//	mailbox for the default engine.
 //
 // - `NewMessage()`: Creates a new message instance, initialized with the
 // topic, source, destination,
@@ -59,7 +61,7 @@
 //
 // Usage Example:
 //
-//	mailbox := engine.GetMailbox()                 // Retrieve the singleton
+//	mailbox := scene.GetEngine().GetMailbox()      // Retrieve the engine
 //	Mailbox instance
 //	topic := mailbox.CreateTopic("orders")         // Create a new topic
 //	"orders"
@@ -82,29 +84,15 @@ import (
 	"time"
 )
 
-// -----------------------------------------------------------------------------
-// Package private variables
-// -----------------------------------------------------------------------------
-
-var (
-	eMailbox *Mailbox
-)
-
-// -----------------------------------------------------------------------------
-// Init Package method
-// -----------------------------------------------------------------------------
-
-func init() {
-	eMailbox = NewMailbox()
-}
-
 // -----------------------------------------------------------------------------
 // Package public functions
 // -----------------------------------------------------------------------------
 
-// GetMailbox function returns the singleton mailbox instance.
+// GetMailbox function returns the mailbox for the default engine. Entities
+// should use the mailbox for the engine their scene belongs to, with
+// scene.GetEngine().GetMailbox().
 func GetMailbox() *Mailbox {
-	return eMailbox
+	return GetEngine().GetMailbox()
 }
 
 // -----------------------------------------------------------------------------
@@ -176,6 +164,12 @@ func (c *Consumer) Consume() *Message {
 	return nil
 }
 
+// Len method returns the number of messages in the pool waiting to be
+// consumed.
+func (c *Consumer) Len() int {
+	return len(c.pool)
+}
+
 // Publish method publishes a new message at the end of the pool of messages.
 func (c *Consumer) Publish(message *Message) error {
 	c.pool = append(c.pool, message)
@@ -277,13 +271,10 @@ type Mailbox struct {
 
 // NewMailbox function creates a new Mailbox instance.
 func NewMailbox() *Mailbox {
-	if eMailbox == nil {
-		return &Mailbox{
-			topics:    make(map[string]*Topic),
-			consumers: make(map[string][]string),
-		}
+	return &Mailbox{
+		topics:    make(map[string]*Topic),
+		consumers: make(map[string][]string),
 	}
-	return eMailbox
 }
 
 // -----------------------------------------------------------------------------
@@ -353,6 +344,18 @@ func (m *Mailbox) FindTopic(name string) *Topic {
 	return m.topics[name]
 }
 
+// GetQueueDepths method returns the number of messages waiting to be consumed
+// for every topic and consumer, where the key is "topic/consumer".
+func (m *Mailbox) GetQueueDepths() map[string]int {
+	result := make(map[string]int)
+	for topicName, topic := range m.topics {
+		for _, consumer := range topic.consumers {
+			result[topicName+"/"+consumer.Name] = consumer.Len()
+		}
+	}
+	return result
+}
+
 // IsTopicInConsumer method finds the given consumer in the list of consumers
 // for the given topic.
 func (m *Mailbox) IsTopicInConsumer(topicName string, consumerName string) bool {
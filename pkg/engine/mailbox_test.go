@@ -185,13 +185,13 @@ func TestMailboxFaults(t *testing.T) {
 	}
 	mailbox.Clean()
 
-	// re-create mailbox
+	// new mailboxes do not share topics with the engine mailbox.
 	mailbox2 := engine.NewMailbox()
 	if mailbox2 == nil {
 		t.Errorf("[3] NewMailbox Error exp:*Mailbox got:nil")
 	}
-	if mailbox != mailbox2 {
-		t.Errorf("[3] NewMailbox Error exp:%v got:%v", mailbox, mailbox2)
+	if mailbox == mailbox2 {
+		t.Errorf("[3] NewMailbox Error exp:new mailbox got:%p", mailbox2)
 	}
 
 	// subscribe to a not created topic
@@ -0,0 +1,239 @@
+// markup.go contains all functions required to handle inline markup in any
+// string to be displayed. Markup tags change the style for the text that
+// follows them, like "[red]Goblin[/] hits you for [b]5[/]".
+//
+// Tag format is "[fg:bg:attrs]" where every field is optional and it inherits
+// the value from the enclosing style when empty:
+//   - "[red]" or "[#ff0000]" sets the foreground color.
+//   - "[:blue]" sets the background color.
+//   - "[b]" sets attributes: b(old), i(talic), u(nderline), r(everse),
+//     d(im), l(blink) and s(trikethrough).
+//   - "[red:blue:bu]" sets all of them at once.
+//   - "[/]" restores the style before the last tag, so tags can be nested.
+//   - "[[" is an escaped "[" character.
+//
+// Any bracketed string that is not a valid tag, like "[INFO]", is displayed
+// as it is.
+package engine
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package private constants
+// -----------------------------------------------------------------------------
+
+const (
+	markupOpen      = '['
+	markupClose     = ']'
+	markupSeparator = ":"
+	markupPop       = "/"
+)
+
+var (
+	markupAttrs = map[rune]tcell.AttrMask{
+		'b': tcell.AttrBold,
+		'd': tcell.AttrDim,
+		'i': tcell.AttrItalic,
+		'l': tcell.AttrBlink,
+		'r': tcell.AttrReverse,
+		's': tcell.AttrStrikeThrough,
+		'u': tcell.AttrUnderline,
+	}
+)
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// indexMarkupClose function returns the index for the closing bracket in the
+// given runes starting at the given index, or -1 if the tag is not closed in
+// the same line.
+func indexMarkupClose(runes []rune, start int) int {
+	for i := start; i < len(runes); i++ {
+		switch runes[i] {
+		case markupClose:
+			return i
+		case markupOpen, '\n':
+			return -1
+		}
+	}
+	return -1
+}
+
+// parseMarkupAttrs function returns the attribute mask for the given
+// attribute letters.
+func parseMarkupAttrs(str string) (tcell.AttrMask, bool) {
+	var attrs tcell.AttrMask
+	for _, ch := range str {
+		attr, ok := markupAttrs[ch]
+		if !ok {
+			return tcell.AttrNone, false
+		}
+		attrs |= attr
+	}
+	return attrs, true
+}
+
+// parseMarkupColor function returns the color for the given color name or
+// hexadecimal value.
+func parseMarkupColor(str string) (tcell.Color, bool) {
+	str = strings.ToLower(str)
+	if str == "default" {
+		return tcell.ColorDefault, true
+	}
+	if color, ok := tcell.ColorNames[str]; ok {
+		return color, true
+	}
+	if len(str) == 7 && str[0] == '#' {
+		if color := tcell.GetColor(str); color != tcell.ColorDefault {
+			return color, true
+		}
+	}
+	return tcell.ColorDefault, false
+}
+
+// parseMarkupTag function returns the style resulting from applying the given
+// tag content to the given style. It returns false if the content is not a
+// valid tag.
+func parseMarkupTag(tag string, style *tcell.Style) (*tcell.Style, bool) {
+	if tag == "" {
+		return nil, false
+	}
+	fg, bg, attrs := style.Decompose()
+	fields := strings.Split(tag, markupSeparator)
+	if len(fields) > 3 {
+		return nil, false
+	}
+	// a single field is a foreground color or a set of attributes.
+	if len(fields) == 1 {
+		if color, ok := parseMarkupColor(tag); ok {
+			return NewStyle(color, bg, attrs), true
+		}
+		if newAttrs, ok := parseMarkupAttrs(tag); ok {
+			return NewStyle(fg, bg, attrs|newAttrs), true
+		}
+		return nil, false
+	}
+	var ok bool
+	if fields[0] != "" {
+		if fg, ok = parseMarkupColor(fields[0]); !ok {
+			return nil, false
+		}
+	}
+	if fields[1] != "" {
+		if bg, ok = parseMarkupColor(fields[1]); !ok {
+			return nil, false
+		}
+	}
+	if len(fields) == 3 && fields[2] != "" {
+		newAttrs, ok := parseMarkupAttrs(fields[2])
+		if !ok {
+			return nil, false
+		}
+		attrs |= newAttrs
+	}
+	return NewStyle(fg, bg, attrs), true
+}
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// EscapeMarkup function escapes the given string so any bracket in it is
+// displayed as it is and never handled as a markup tag.
+func EscapeMarkup(str string) string {
+	return strings.ReplaceAll(str, string(markupOpen), string(markupOpen)+string(markupOpen))
+}
+
+// MarkupWidth function returns the number of visible columns required to
+// display the given markup string, which is the width of the longest line.
+func MarkupWidth(str string) int {
+	width := 0
+	for _, line := range ParseMarkup(str, nil) {
+		width = tools.Max(width, len(line))
+	}
+	return width
+}
+
+// NewCanvasFromMarkup function creates a new canvas where the content is the
+// given markup string (multi-line is allowed) with the given style as the
+// default style.
+func NewCanvasFromMarkup(str string, style *tcell.Style) *Canvas {
+	lines := ParseMarkup(str, style)
+	width := 0
+	for _, line := range lines {
+		width = tools.Max(width, len(line))
+	}
+	canvas := NewCanvas(api.NewSize(width, len(lines)))
+	for row, line := range lines {
+		for col, cell := range line {
+			canvas.SetCellAt(api.NewPoint(col, row), cell)
+		}
+	}
+	return canvas
+}
+
+// ParseMarkup function parses the given markup string and returns all cells
+// to be displayed for every line, where every cell contains the visible rune
+// and the style resulting from all tags enclosing it. The given style is used
+// as the default style.
+func ParseMarkup(str string, style *tcell.Style) [][]*Cell {
+	if style == nil {
+		style = &tcell.StyleDefault
+	}
+	stack := []*tcell.Style{style}
+	lines := [][]*Cell{{}}
+	runes := []rune(str)
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		current := stack[len(stack)-1]
+		switch {
+		case ch == '\n':
+			lines = append(lines, []*Cell{})
+			continue
+		case ch == markupOpen && i+1 < len(runes) && runes[i+1] == markupOpen:
+			// escaped bracket.
+			i++
+		case ch == markupOpen:
+			if end := indexMarkupClose(runes, i+1); end != -1 {
+				tag := string(runes[i+1 : end])
+				if tag == markupPop {
+					if len(stack) > 1 {
+						stack = stack[:len(stack)-1]
+					}
+					i = end
+					continue
+				}
+				if newStyle, ok := parseMarkupTag(tag, current); ok {
+					stack = append(stack, newStyle)
+					i = end
+					continue
+				}
+			}
+		}
+		row := len(lines) - 1
+		lines[row] = append(lines[row], NewCell(current, ch))
+	}
+	return lines
+}
+
+// StripMarkup function returns the given markup string without any tag, as it
+// would be displayed.
+func StripMarkup(str string) string {
+	var builder strings.Builder
+	for row, line := range ParseMarkup(str, nil) {
+		if row != 0 {
+			builder.WriteRune('\n')
+		}
+		for _, cell := range line {
+			builder.WriteRune(cell.GetRune())
+		}
+	}
+	return builder.String()
+}
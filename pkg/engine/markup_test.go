@@ -0,0 +1,75 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+func TestMarkupStripAndWidth(t *testing.T) {
+	cases := []struct {
+		input string
+		exp   string
+		width int
+	}{
+		{"[red]Goblin[/] hits you for [b]5[/]", "Goblin hits you for 5", 21},
+		{"[red:blue:bu]nested [yellow]tags[/][/]", "nested tags", 11},
+		{"[[red] is escaped", "[red] is escaped", 16},
+		{"[INFO] [ STORY ] [x] are not tags", "[INFO] [ STORY ] [x] are not tags", 33},
+		{"unclosed [red tag", "unclosed [red tag", 17},
+		{"first line\n[#ff0000]2nd[/]", "first line\n2nd", 10},
+		{"[/]extra pop", "extra pop", 9},
+	}
+	for i, c := range cases {
+		if got := engine.StripMarkup(c.input); got != c.exp {
+			t.Errorf("[%d] StripMarkup Error exp:%q got:%q", i, c.exp, got)
+		}
+		if got := engine.MarkupWidth(c.input); got != c.width {
+			t.Errorf("[%d] MarkupWidth Error exp:%d got:%d", i, c.width, got)
+		}
+	}
+	str := "[unit] [b]"
+	if got := engine.StripMarkup(engine.EscapeMarkup(str)); got != str {
+		t.Errorf("EscapeMarkup Error exp:%q got:%q", str, got)
+	}
+}
+
+func TestMarkupStyles(t *testing.T) {
+	style := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack)
+	lines := engine.ParseMarkup("a[red]b[:blue:b]c[/]d[/]e", &style)
+	if len(lines) != 1 || len(lines[0]) != 5 {
+		t.Errorf("ParseMarkup Error exp:1x5 got:%+v", lines)
+		return
+	}
+	exps := []tcell.Style{
+		style,
+		style.Foreground(tcell.ColorRed),
+		style.Foreground(tcell.ColorRed).Background(tcell.ColorBlue).Bold(true),
+		style.Foreground(tcell.ColorRed),
+		style,
+	}
+	for i, exp := range exps {
+		if got := lines[0][i].GetStyle(); !engine.CompareStyle(got, &exp) {
+			t.Errorf("[%d] ParseMarkup Error.Style exp:%s got:%s", i, engine.StyleToString(&exp), engine.StyleToString(got))
+		}
+	}
+
+	canvas := engine.NewCanvasFromMarkup("[green]ok[/]\nlonger", &style)
+	if got := canvas.Size(); !got.IsEqual(api.NewSize(6, 2)) {
+		t.Errorf("NewCanvasFromMarkup Error.Size exp:6x2 got:%s", got.ToString())
+	}
+	if got := canvas.GetStyleAt(api.NewPoint(1, 0)); engine.GetForegroundFromStyle(got) != tcell.ColorGreen {
+		t.Errorf("NewCanvasFromMarkup Error.Style exp:green got:%s", engine.StyleToString(got))
+	}
+
+	canvas = engine.NewCanvas(api.NewSize(4, 1))
+	canvas.WriteMarkupInCanvasAt("[b]xy[/]z!", &style, api.NewPoint(1, 0))
+	if got := canvas.GetRuneAt(api.NewPoint(3, 0)); got != 'z' {
+		t.Errorf("WriteMarkupInCanvasAt Error.Rune exp:z got:%c", got)
+	}
+	if got := canvas.GetStyleAt(api.NewPoint(1, 0)); engine.GetAttrsFromStyle(got) != tcell.AttrBold {
+		t.Errorf("WriteMarkupInCanvasAt Error.Attrs exp:%d got:%d", tcell.AttrBold, engine.GetAttrsFromStyle(got))
+	}
+}
@@ -135,6 +135,16 @@ func (m *ObserverManager) RegisterObserver(subjectID any, observer IObserver) {
 	}
 }
 
+// RemoveObserver method removes the given observer from all subjects.
+func (m *ObserverManager) RemoveObserver(observer IObserver) {
+	for subjectID := range m.observers {
+		m.UnregisterObserver(subjectID, observer)
+		if len(m.observers[subjectID]) == 0 {
+			delete(m.observers, subjectID)
+		}
+	}
+}
+
 // UnregisterObserver method removes an observer for a specific subject.
 func (m *ObserverManager) UnregisterObserver(subjectID any, observer IObserver) {
 	if index, found := m.findObserver(subjectID, observer); found {
@@ -0,0 +1,294 @@
+// prefab.go contains the prefab manager. A prefab is a named JSON template
+// describing an entity which can be instantiated many times. Any entity map,
+// like every entry in a JSON file imported with ImportEntitiesFromJSON, can
+// reference a prefab with the "prefab" key, and any other key in the map
+// overrides the value in the prefab.
+//
+// Besides keys handled by the entity class UnmarshalMap method, like "name",
+// "position", "size", "style" or "tags", an entity map can contain:
+//   - "class": class created by the IBuiltIn instance.
+//   - "ch": character used to fill the canvas.
+//   - "canvas": list of strings with the canvas content.
+//   - "solid": true if the entity can collide with other solid entities.
+//   - "cache": values set in the entity cache.
+//   - "behaviors": behavior name registered in the prefab manager for every
+//     behavior type, like {"update": "patrol"}.
+//   - "children": list of child entity maps, which can reference prefabs too,
+//     with positions relative to the parent entity.
+//   - "prefab": prefab the entity is based on. Prefabs can be based on other
+//     prefabs.
+//   - "suffix": suffix added to the prefab name when the entity map does not
+//     have any name. A counter is used when there is not any suffix either.
+//
+// Example:
+//
+//	{"goblin": {"class": "Enemy", "ch": "g", "solid": true, "hp": 7}}
+//	[{"prefab": "goblin", "position": [5, 5], "suffix": "/boss", "hp": 12}]
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/jrecuero/thengine/pkg/api"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	PrefabKey         = "prefab"
+	PrefabSuffixKey   = "suffix"
+	PrefabChildrenKey = "children"
+)
+
+// -----------------------------------------------------------------------------
+// Package private constants
+// -----------------------------------------------------------------------------
+
+const (
+	// prefabMaxDepth is the maximum number of prefabs an entity map can be
+	// based on, so cyclic prefabs are detected.
+	prefabMaxDepth = 16
+)
+
+// -----------------------------------------------------------------------------
+// Package private variables
+// -----------------------------------------------------------------------------
+
+var (
+	// thePrefabManager is the prefab manager used by import functions.
+	thePrefabManager *PrefabManager = NewPrefabManager()
+)
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// GetPrefabManager function returns the prefab manager used to import
+// entities from JSON files.
+func GetPrefabManager() *PrefabManager {
+	return thePrefabManager
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// copyPrefabContent function returns a copy of the given map. Values are not
+// copied, because they are never modified.
+func copyPrefabContent(content map[string]any) map[string]any {
+	result := make(map[string]any, len(content))
+	for key, value := range content {
+		result[key] = value
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+//
+// PrefabManager
+//
+// -----------------------------------------------------------------------------
+
+// PrefabManager structure defines the manager for all prefabs and all
+// behaviors prefabs can reference by name. It is safe to be used from
+// multiple goroutines.
+// counters contains the number of entities created for every prefab, used to
+// generate entity names.
+type PrefabManager struct {
+	mutex     sync.Mutex
+	prefabs   map[string]map[string]any
+	behaviors map[string]any
+	counters  map[string]int
+}
+
+// NewPrefabManager function creates a new PrefabManager instance.
+func NewPrefabManager() *PrefabManager {
+	return &PrefabManager{
+		prefabs:   make(map[string]map[string]any),
+		behaviors: make(map[string]any),
+		counters:  make(map[string]int),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// PrefabManager private methods
+// -----------------------------------------------------------------------------
+
+// getBehavior method returns the behavior registered with the given name.
+func (m *PrefabManager) getBehavior(name string) (any, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	behavior, ok := m.behaviors[name]
+	return behavior, ok
+}
+
+// loadFromJSON method adds all prefabs in the given JSON content.
+func (m *PrefabManager) loadFromJSON(filename string, jsonContent []byte) error {
+	var content map[string]map[string]any
+	if err := json.Unmarshal(jsonContent, &content); err != nil {
+		return fmt.Errorf("Error unmarshaling %s:%s", filename, err.Error())
+	}
+	for name, prefab := range content {
+		m.AddPrefab(name, prefab)
+	}
+	return nil
+}
+
+// merge method returns a new map with the content of the prefab the given
+// map references overridden by the given map content. The mutex has to be
+// locked.
+func (m *PrefabManager) merge(mapEntity map[string]any, depth int) (map[string]any, error) {
+	prefabName, ok := mapEntity[PrefabKey].(string)
+	if !ok {
+		return copyPrefabContent(mapEntity), nil
+	}
+	if depth >= prefabMaxDepth {
+		return nil, fmt.Errorf("prefab %s is based on too many prefabs", prefabName)
+	}
+	prefab, ok := m.prefabs[prefabName]
+	if !ok {
+		return nil, fmt.Errorf("prefab %s not found", prefabName)
+	}
+	result, err := m.merge(prefab, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range mapEntity {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// resolve method returns the given entity map with the content of the prefab
+// it references, if any, and with the entity name.
+func (m *PrefabManager) resolve(mapEntity map[string]any) (map[string]any, error) {
+	prefabName, ok := mapEntity[PrefabKey].(string)
+	if !ok {
+		return mapEntity, nil
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	result, err := m.merge(mapEntity, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := mapEntity["name"]; !ok {
+		name, ok := result["name"].(string)
+		if !ok {
+			name = prefabName
+		}
+		if suffix, ok := mapEntity[PrefabSuffixKey].(string); ok {
+			name += suffix
+		} else {
+			m.counters[prefabName]++
+			name = fmt.Sprintf("%s/%d", name, m.counters[prefabName])
+		}
+		result["name"] = name
+	}
+	delete(result, PrefabKey)
+	delete(result, PrefabSuffixKey)
+	return result, nil
+}
+
+// -----------------------------------------------------------------------------
+// PrefabManager public methods
+// -----------------------------------------------------------------------------
+
+// AddPrefab method adds a prefab with the given name and content, replacing
+// any prefab with the same name.
+func (m *PrefabManager) AddPrefab(name string, content map[string]any) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.prefabs[name] = copyPrefabContent(content)
+}
+
+// GetPrefab method returns a copy of the content for the prefab with the
+// given name.
+func (m *PrefabManager) GetPrefab(name string) (map[string]any, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	prefab, ok := m.prefabs[name]
+	if !ok {
+		return nil, false
+	}
+	return copyPrefabContent(prefab), true
+}
+
+// GetPrefabNames method returns the name for all prefabs sorted.
+func (m *PrefabManager) GetPrefabNames() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	result := make([]string, 0, len(m.prefabs))
+	for name := range m.prefabs {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Instantiate method creates a new entity from the prefab with the given name
+// and all its child entities. The entity is the first one returned. Any value
+// in the given overrides replaces the value in the prefab, like "name",
+// "suffix" or "position". Positions are relative to the given origin.
+// Overrides can use any Go value with the same JSON encoding as the value in
+// the prefab, like []int for "position".
+func (m *PrefabManager) Instantiate(name string, overrides map[string]any, origin *api.Point, builtin IBuiltIn) ([]IEntity, error) {
+	// overrides are converted to the values read from a JSON file.
+	mapEntity := make(map[string]any)
+	if overrides != nil {
+		jsonContent, err := json.Marshal(overrides)
+		if err != nil {
+			return nil, fmt.Errorf("prefab %s: %s", name, err.Error())
+		}
+		if err := json.Unmarshal(jsonContent, &mapEntity); err != nil {
+			return nil, fmt.Errorf("prefab %s: %s", name, err.Error())
+		}
+	}
+	mapEntity[PrefabKey] = name
+	return newEntitiesFromMap(m, mapEntity, origin, builtin)
+}
+
+// LoadFromFS method adds all prefabs in the given JSON file from the given
+// file system. The file contains a JSON object with the content for every
+// prefab by name.
+func (m *PrefabManager) LoadFromFS(fsys fs.FS, path string) error {
+	jsonContent, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return fmt.Errorf("Error reading %s:%s", path, err.Error())
+	}
+	return m.loadFromJSON(path, jsonContent)
+}
+
+// LoadFromJSON method adds all prefabs in the given JSON file.
+func (m *PrefabManager) LoadFromJSON(filename string) error {
+	jsonContent, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("Error reading %s:%s", filename, err.Error())
+	}
+	return m.loadFromJSON(filename, jsonContent)
+}
+
+// RegisterBehavior method registers the given behavior with the given name,
+// so prefabs can reference it in the "behaviors" key. The behavior has to be
+// the function required by the behavior type it is used for, like
+// func(tcell.Event, IScene) for "update".
+func (m *PrefabManager) RegisterBehavior(name string, behavior any) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.behaviors[name] = behavior
+}
+
+// RemovePrefab method removes the prefab with the given name.
+func (m *PrefabManager) RemovePrefab(name string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.prefabs, name)
+	delete(m.counters, name)
+}
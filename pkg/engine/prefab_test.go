@@ -0,0 +1,126 @@
+package engine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+func TestPrefabInstantiate(t *testing.T) {
+	prefabs := engine.NewPrefabManager()
+	prefabs.AddPrefab("wall", map[string]any{
+		"class": "Wall",
+		"size":  []any{2.0, 1.0},
+		"style": []any{"blue", "black", "0"},
+		"ch":    "#",
+		"solid": true,
+		"tags":  []any{"wall"},
+		"cache": map[string]any{"hardness": 3.0},
+	})
+	prefabs.AddPrefab("tower", map[string]any{
+		"prefab":    "wall",
+		"name":      "tower",
+		"canvas":    []any{"/\\", "||"},
+		"size":      nil,
+		"behaviors": map[string]any{"update": "blink"},
+		"children": []any{
+			map[string]any{"prefab": "wall", "position": []any{0.0, 2.0}, "suffix": "/base"},
+		},
+	})
+	prefabs.AddPrefab("loop", map[string]any{"prefab": "loop"})
+	blinked := false
+	prefabs.RegisterBehavior("blink", func(tcell.Event, engine.IScene) { blinked = true })
+
+	// overrides replace prefab values and names are generated.
+	for i, exp := range []string{"wall/1", "wall/2"} {
+		entities, err := prefabs.Instantiate("wall", map[string]any{"position": []int{i, 3}}, nil, &BuiltInTest{})
+		if err != nil || len(entities) != 1 {
+			t.Fatalf("[%d] Instantiate Error exp:1 entity got:%d %v", i, len(entities), err)
+		}
+		wall := entities[0]
+		if _, ok := wall.(*Wall); !ok {
+			t.Errorf("[%d] Instantiate Error exp:*Wall got:%T", i, wall)
+		}
+		if wall.GetName() != exp {
+			t.Errorf("[%d] GetName Error exp:%s got:%s", i, exp, wall.GetName())
+		}
+		if got := wall.GetPosition(); !got.IsEqual(api.NewPoint(i, 3)) {
+			t.Errorf("[%d] GetPosition Error exp:[%d,3] got:%s", i, i, got.ToString())
+		}
+		if !wall.IsSolid() || !wall.HasTag("wall") || wall.GetClassName() != "Wall" {
+			t.Errorf("[%d] Instantiate Error exp:solid wall got:%t %v %s", i, wall.IsSolid(), wall.GetTags(), wall.GetClassName())
+		}
+		if got, _ := wall.GetCache().Get("hardness"); got != 3.0 {
+			t.Errorf("[%d] GetCache Error exp:3 got:%v", i, got)
+		}
+		if got := wall.GetCanvas().GetCellAt(api.NewPoint(1, 0)).GetRune(); got != '#' {
+			t.Errorf("[%d] GetCanvas Error exp:# got:%c", i, got)
+		}
+	}
+
+	// prefabs can be based on other prefabs and contain child entities.
+	entities, err := prefabs.Instantiate("tower", map[string]any{"position": []int{5, 5}}, api.NewPoint(1, 1), &BuiltInTest{})
+	if err != nil || len(entities) != 2 {
+		t.Fatalf("[2] Instantiate Error exp:2 entities got:%d %v", len(entities), err)
+	}
+	tower, base := entities[0], entities[1]
+	if tower.GetName() != "tower/1" || base.GetName() != "wall/base" {
+		t.Errorf("[2] GetName Error exp:tower/1,wall/base got:%s,%s", tower.GetName(), base.GetName())
+	}
+	if got := tower.GetSize(); !got.IsEqual(api.NewSize(2, 2)) {
+		t.Errorf("[2] GetSize Error exp:2x2 got:%dx%d", got.W, got.H)
+	}
+	if got := tower.GetCanvas().GetCellAt(api.NewPoint(0, 1)).GetRune(); got != '|' {
+		t.Errorf("[2] GetCanvas Error exp:| got:%c", got)
+	}
+	if got := base.GetPosition(); !got.IsEqual(api.NewPoint(6, 8)) {
+		t.Errorf("[2] GetPosition Error exp:[6,8] got:%s", got.ToString())
+	}
+	tower.Update(nil, nil)
+	if !blinked {
+		t.Errorf("[2] Update Error exp:behavior called got:not called")
+	}
+
+	// errors.
+	for i, name := range []string{"unknown", "loop"} {
+		if _, err := prefabs.Instantiate(name, nil, nil, nil); err == nil {
+			t.Errorf("[3:%d] Instantiate Error exp:error got:nil", i)
+		}
+	}
+	prefabs.AddPrefab("broken", map[string]any{"behaviors": map[string]any{"update": "unknown"}})
+	if _, err := prefabs.Instantiate("broken", nil, nil, nil); err == nil {
+		t.Errorf("[3:2] Instantiate Error exp:error got:nil")
+	}
+}
+
+func TestPrefabImport(t *testing.T) {
+	dir := t.TempDir()
+	prefabsFile := filepath.Join(dir, "prefabs.json")
+	os.WriteFile(prefabsFile, []byte(`{"goblin": {"class": "Wall", "size": [1, 1], "ch": "g", "solid": true}}`), 0644)
+	mapFile := filepath.Join(dir, "map.json")
+	os.WriteFile(mapFile, []byte(`[
+		{"prefab": "goblin", "name": "goblin/boss", "position": [1, 2], "ch": "G"},
+		{"class": "Wall", "name": "wall", "position": [0, 0], "size": [1, 1], "ch": "#"}
+	]`), 0644)
+
+	prefabs := engine.GetPrefabManager()
+	if err := prefabs.LoadFromJSON(prefabsFile); err != nil {
+		t.Fatalf("[0] LoadFromJSON Error exp:nil got:%s", err)
+	}
+	defer prefabs.RemovePrefab("goblin")
+	entities := engine.ImportEntitiesFromJSON(mapFile, nil, &BuiltInTest{})
+	if len(entities) != 2 {
+		t.Fatalf("[1] ImportEntitiesFromJSON Error exp:2 got:%d", len(entities))
+	}
+	goblin := entities[0]
+	if goblin.GetName() != "goblin/boss" || !goblin.IsSolid() {
+		t.Errorf("[1] ImportEntitiesFromJSON Error exp:solid goblin/boss got:%s %t", goblin.GetName(), goblin.IsSolid())
+	}
+	if got := goblin.GetCanvas().GetCellAt(nil).GetRune(); got != 'G' {
+		t.Errorf("[1] GetCanvas Error exp:G got:%c", got)
+	}
+}
@@ -0,0 +1,275 @@
+// query.go contains the query to find entities in a scene by tags, class name,
+// region or any other condition, and generic functions to find and iterate
+// entities with a given type or interface.
+// Example:
+//
+//	query := engine.NewQuery().WithTag("enemy").WithoutTag("boss")
+//	for _, enemy := range engine.FindEntities[*Enemy](scene, query) {
+//	    ...
+//	}
+package engine
+
+import (
+	"sync/atomic"
+
+	"github.com/jrecuero/thengine/pkg/api"
+)
+
+// -----------------------------------------------------------------------------
+// Package private variables
+// -----------------------------------------------------------------------------
+
+var (
+	// entityIndexVersion is updated every time any entity tag or class name
+	// changes, so scenes know when their indexes have to be built again.
+	entityIndexVersion atomic.Int64
+)
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// FindEntities function returns all entities in the given scene matching the
+// given query which are of type T, where T can be any entity type or
+// interface. A nil query matches all entities.
+func FindEntities[T any](scene IScene, query *Query) []T {
+	var result []T
+	for _, entity := range scene.Find(query) {
+		if value, ok := entity.(T); ok {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// FindEntity function returns the first entity in the given scene matching
+// the given query which is of type T.
+func FindEntity[T any](scene IScene, query *Query) (T, bool) {
+	for _, entity := range scene.Find(query) {
+		if value, ok := entity.(T); ok {
+			return value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// ForEachEntity function calls the given function for every entity in the
+// given scene matching the given query which is of type T. Entities can be
+// added to or removed from the scene in the given function.
+func ForEachEntity[T any](scene IScene, query *Query, f func(T)) {
+	for _, entity := range FindEntities[T](scene, query) {
+		f(entity)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// isEntityInRegion function checks if the given entity is in the given
+// region. Entities without any size are checked by their position.
+func isEntityInRegion(entity IEntity, region *api.Rect) bool {
+	position := entity.GetPosition()
+	if position == nil {
+		return false
+	}
+	if size := entity.GetSize(); size == nil || size.W == 0 || size.H == 0 {
+		return region.IsIn(position)
+	}
+	return region.IsRectIntersect(entity.GetRect())
+}
+
+// updateEntityIndexVersion function tells all scenes their indexes have to be
+// built again.
+func updateEntityIndexVersion() {
+	entityIndexVersion.Add(1)
+}
+
+// -----------------------------------------------------------------------------
+//
+// entityIndex
+//
+// -----------------------------------------------------------------------------
+
+// entityIndex structure contains scene entities by tag and by class name in
+// the same order they are in the scene.
+type entityIndex struct {
+	version int64
+	tags    map[string][]IEntity
+	classes map[string][]IEntity
+}
+
+// newEntityIndex function creates a new entityIndex instance for the given
+// entities.
+func newEntityIndex(entities []IEntity) *entityIndex {
+	index := &entityIndex{
+		version: entityIndexVersion.Load(),
+		tags:    make(map[string][]IEntity),
+		classes: make(map[string][]IEntity),
+	}
+	for _, entity := range entities {
+		for _, tag := range entity.GetTags() {
+			index.tags[tag] = append(index.tags[tag], entity)
+		}
+		className := entity.GetClassName()
+		index.classes[className] = append(index.classes[className], entity)
+	}
+	return index
+}
+
+// isValid method checks if the index is up to date with all tags and class
+// names.
+func (i *entityIndex) isValid() bool {
+	return i.version == entityIndexVersion.Load()
+}
+
+// -----------------------------------------------------------------------------
+//
+// Query
+//
+// -----------------------------------------------------------------------------
+
+// Query structure defines the conditions an entity has to match. All
+// conditions have to be matched.
+// tags are tags the entity has to have all of them.
+// anyTags are tags the entity has to have at least one of them.
+// noTags are tags the entity can not have any of them.
+// classes are class names the entity has to have one of them.
+// region is the rectangle the entity has to be in.
+// filters are functions the entity has to return true for all of them.
+type Query struct {
+	tags    []string
+	anyTags []string
+	noTags  []string
+	classes []string
+	region  *api.Rect
+	filters []func(IEntity) bool
+}
+
+// NewQuery function creates a new Query instance which matches all entities.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// -----------------------------------------------------------------------------
+// Query private methods
+// -----------------------------------------------------------------------------
+
+// candidates method returns the entities in the given index the query has to
+// check, or nil if all entities have to be checked.
+func (q *Query) candidates(index *entityIndex) ([]IEntity, bool) {
+	var result []IEntity
+	found := false
+	for _, tag := range q.tags {
+		if entities := index.tags[tag]; !found || len(entities) < len(result) {
+			result = entities
+			found = true
+		}
+	}
+	if !found && len(q.classes) == 1 {
+		return index.classes[q.classes[0]], true
+	}
+	if !found && len(q.anyTags) == 1 {
+		return index.tags[q.anyTags[0]], true
+	}
+	return result, found
+}
+
+// -----------------------------------------------------------------------------
+// Query public methods
+// -----------------------------------------------------------------------------
+
+// InRegion method sets the region the entity has to be in. Entities with any
+// cell in the region match.
+func (q *Query) InRegion(region *api.Rect) *Query {
+	q.region = region
+	return q
+}
+
+// Match method checks if the given entity matches all query conditions.
+func (q *Query) Match(entity IEntity) bool {
+	for _, tag := range q.tags {
+		if !entity.HasTag(tag) {
+			return false
+		}
+	}
+	if len(q.anyTags) != 0 && !entity.HasAnyTag(q.anyTags...) {
+		return false
+	}
+	if len(q.noTags) != 0 && entity.HasAnyTag(q.noTags...) {
+		return false
+	}
+	if len(q.classes) != 0 {
+		found := false
+		for _, className := range q.classes {
+			if entity.GetClassName() == className {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if q.region != nil && !isEntityInRegion(entity, q.region) {
+		return false
+	}
+	for _, filter := range q.filters {
+		if !filter(entity) {
+			return false
+		}
+	}
+	return true
+}
+
+// Not method adds the condition the entity can not match the given query.
+func (q *Query) Not(query *Query) *Query {
+	return q.Where(func(entity IEntity) bool {
+		return !query.Match(entity)
+	})
+}
+
+// Or method adds the condition the entity has to match at least one of the
+// given queries.
+func (q *Query) Or(queries ...*Query) *Query {
+	return q.Where(func(entity IEntity) bool {
+		for _, query := range queries {
+			if query.Match(entity) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Where method adds the given function as a condition the entity has to
+// match.
+func (q *Query) Where(filter func(IEntity) bool) *Query {
+	q.filters = append(q.filters, filter)
+	return q
+}
+
+// WithAnyTag method adds tags the entity has to have at least one of them.
+func (q *Query) WithAnyTag(tags ...string) *Query {
+	q.anyTags = append(q.anyTags, tags...)
+	return q
+}
+
+// WithClass method adds class names the entity has to have one of them.
+func (q *Query) WithClass(classNames ...string) *Query {
+	q.classes = append(q.classes, classNames...)
+	return q
+}
+
+// WithTag method adds tags the entity has to have all of them.
+func (q *Query) WithTag(tags ...string) *Query {
+	q.tags = append(q.tags, tags...)
+	return q
+}
+
+// WithoutTag method adds tags the entity can not have any of them.
+func (q *Query) WithoutTag(tags ...string) *Query {
+	q.noTags = append(q.noTags, tags...)
+	return q
+}
@@ -0,0 +1,96 @@
+package engine_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+// TestMarker is an entity implementing a custom interface used to find
+// entities by interface.
+type TestMarker struct {
+	*engine.Entity
+}
+
+func (m *TestMarker) Mark() string {
+	return m.GetName()
+}
+
+// names function returns the names for all given entities joined.
+func names(entities []engine.IEntity) string {
+	result := []string{}
+	for _, entity := range entities {
+		result = append(result, entity.GetName())
+	}
+	return strings.Join(result, ",")
+}
+
+func TestSceneQuery(t *testing.T) {
+	scene := engine.NewEngine().NewScene("scene/test/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(20, 20)))
+	goblin := engine.NewEntity("goblin", api.NewPoint(1, 1), api.NewSize(1, 1), nil)
+	goblin.AddTag("enemy", "melee")
+	archer := engine.NewEntity("archer", api.NewPoint(10, 10), api.NewSize(1, 1), nil)
+	archer.AddTag("enemy", "ranged")
+	trap := engine.NewEntity("trap", api.NewPoint(2, 2), api.NewSize(2, 2), nil)
+	trap.AddTag("hazard")
+	trap.SetClassName("Trap")
+	marker := &TestMarker{Entity: engine.NewEntity("marker", api.NewPoint(15, 15), api.NewSize(1, 1), nil)}
+	for _, entity := range []engine.IEntity{goblin, archer, trap, marker} {
+		scene.AddEntity(entity)
+	}
+
+	cases := []struct {
+		query *engine.Query
+		exp   string
+	}{
+		{query: nil, exp: "goblin,archer,trap,marker"},
+		{query: engine.NewQuery().WithTag("enemy"), exp: "goblin,archer"},
+		{query: engine.NewQuery().WithTag("enemy", "ranged"), exp: "archer"},
+		{query: engine.NewQuery().WithAnyTag("melee", "hazard"), exp: "goblin,trap"},
+		{query: engine.NewQuery().WithTag("enemy").WithoutTag("melee"), exp: "archer"},
+		{query: engine.NewQuery().WithClass("Trap"), exp: "trap"},
+		{query: engine.NewQuery().InRegion(api.NewRect(api.NewPoint(0, 0), api.NewSize(3, 3))), exp: "goblin,trap"},
+		{query: engine.NewQuery().Or(engine.NewQuery().WithTag("ranged"), engine.NewQuery().WithClass("Trap")), exp: "archer,trap"},
+		{query: engine.NewQuery().Not(engine.NewQuery().WithTag("enemy")), exp: "trap,marker"},
+		{query: engine.NewQuery().Where(func(e engine.IEntity) bool { return e.GetPosition().X > 5 }), exp: "archer,marker"},
+		{query: engine.NewQuery().WithTag("unknown"), exp: ""},
+	}
+	for i, c := range cases {
+		if got := names(scene.Find(c.query)); got != c.exp {
+			t.Errorf("[%d] Find Error exp:%s got:%s", i, c.exp, got)
+		}
+	}
+
+	// indexes are updated when tags change or entities are removed.
+	goblin.RemoveTag("enemy")
+	marker.AddTag("enemy")
+	if got := names(scene.GetEntitiesWithTag("enemy")); got != "archer,marker" {
+		t.Errorf("[0] GetEntitiesWithTag Error exp:archer,marker got:%s", got)
+	}
+	scene.RemoveEntity(archer)
+	if got := names(scene.GetEntitiesWithTag("enemy")); got != "marker" {
+		t.Errorf("[1] GetEntitiesWithTag Error exp:marker got:%s", got)
+	}
+
+	// generic functions find entities by type or interface.
+	markers := engine.FindEntities[interface{ Mark() string }](scene, nil)
+	if len(markers) != 1 || markers[0].Mark() != "marker" {
+		t.Errorf("[2] FindEntities Error exp:[marker] got:%v", markers)
+	}
+	if got, ok := engine.FindEntity[*TestMarker](scene, engine.NewQuery().WithTag("enemy")); !ok || got != marker {
+		t.Errorf("[2] FindEntity Error exp:marker got:%v", got)
+	}
+	visited := []string{}
+	engine.ForEachEntity(scene, engine.NewQuery().WithAnyTag("melee", "hazard"), func(entity *engine.Entity) {
+		visited = append(visited, entity.GetName())
+		scene.RemoveEntity(entity)
+	})
+	if got := strings.Join(visited, ","); got != "goblin,trap" {
+		t.Errorf("[3] ForEachEntity Error exp:goblin,trap got:%s", got)
+	}
+	if got := names(scene.GetEntities()); got != "marker" {
+		t.Errorf("[3] GetEntities Error exp:marker got:%s", got)
+	}
+}
@@ -0,0 +1,367 @@
+// reloader.go contains the asset reloader, which polls JSON entity files and
+// canvas text files while the engine runs and applies any change to the live
+// scene. Errors found reloading any file are displayed in an overlay instead
+// of stopping the application.
+package engine
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	AssetReloaderSceneName  = "engine/reloader"
+	AssetReloaderEntityName = "engine/reloader/errors"
+
+	// AssetReloaderDefaultInterval is the default time between two checks
+	// for changes in watched files.
+	AssetReloaderDefaultInterval = 500 * time.Millisecond
+)
+
+// -----------------------------------------------------------------------------
+// Package private types
+// -----------------------------------------------------------------------------
+
+// assetWatch structure contains information for every file being watched.
+// modTime and size are used to detect any change in the file.
+type assetWatch struct {
+	filename string
+	modTime  time.Time
+	size     int64
+	reload   func() error
+}
+
+// loadedEntity structure contains an entity loaded from a JSON file, the
+// class it was created from and the behaviors it was loaded with.
+type loadedEntity struct {
+	entity    IEntity
+	className string
+	behaviors string
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// updateLoadedEntity function copies all attributes that can be loaded from a
+// JSON file from the fresh entity into the old one.
+func updateLoadedEntity(old IEntity, fresh IEntity) {
+	old.SetPosition(fresh.GetPosition())
+	old.SetSize(fresh.GetSize())
+	old.SetStyle(fresh.GetStyle())
+	old.SetCanvas(fresh.GetCanvas())
+	old.SetVisible(fresh.IsVisible())
+	old.SetSolid(fresh.IsSolid())
+	old.SetZLevel(fresh.GetZLevel())
+	old.SetPLevel(fresh.GetPLevel())
+	old.SetLayout(fresh.GetLayout())
+	old.RemoveTag(old.GetTags()...)
+	old.AddTag(fresh.GetTags()...)
+}
+
+// -----------------------------------------------------------------------------
+//
+// AssetReloader
+//
+// -----------------------------------------------------------------------------
+
+// AssetReloader structure defines the reloader for all watched asset files.
+// The entity displays errors found reloading any file.
+// errors contains the last error for every file.
+type AssetReloader struct {
+	*Entity
+	engine   *Engine
+	interval time.Duration
+	lastPoll time.Time
+	watches  map[string]*assetWatch
+	errors   map[string]error
+	scene    IScene
+}
+
+// NewAssetReloader function creates a new AssetReloader instance that checks
+// watched files at the given interval.
+func NewAssetReloader(engine *Engine, interval time.Duration) *AssetReloader {
+	style := NewStyle(tcell.ColorWhite, tcell.ColorMaroon, tcell.AttrBold)
+	reloader := &AssetReloader{
+		Entity:   NewEntity(AssetReloaderEntityName, api.NewPoint(0, 0), api.NewSize(0, 0), style),
+		engine:   engine,
+		interval: interval,
+		watches:  make(map[string]*assetWatch),
+		errors:   make(map[string]error),
+		scene:    nil,
+	}
+	reloader.SetZLevel(1 << 16)
+	return reloader
+}
+
+// -----------------------------------------------------------------------------
+// AssetReloader private methods
+// -----------------------------------------------------------------------------
+
+// reloadWatch method reloads the given watched file. Any panic while the
+// file is reloaded is returned as an error.
+func (r *AssetReloader) reloadWatch(watch *assetWatch) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%v", p)
+		}
+	}()
+	return watch.reload()
+}
+
+// setError method sets or clears the error for the given file.
+func (r *AssetReloader) setError(filename string, err error) {
+	if err == nil {
+		delete(r.errors, filename)
+		return
+	}
+	tools.Logger.WithField("module", "reloader").
+		WithField("struct", "AssetReloader").
+		WithField("method", "setError").
+		Errorf("reload %s: %s", filename, err.Error())
+	r.errors[filename] = err
+}
+
+// updateOverlay method displays the overlay scene when there are errors and
+// hides it when there is not any.
+func (r *AssetReloader) updateOverlay() {
+	sceneManager := r.engine.GetSceneManager()
+	if len(r.errors) == 0 {
+		if r.scene != nil && sceneManager.IsSceneVisible(r.scene) {
+			sceneManager.RemoveSceneAsVisible(r.scene)
+		}
+		return
+	}
+	if r.scene == nil {
+		width, height := 80, 24
+		if screen := r.engine.GetScreen(); screen != nil {
+			width, height = screen.Size()
+		}
+		camera := NewCamera(nil, api.NewSize(width, height))
+		camera.SetDryRun(r.engine.dryRun)
+		r.scene = r.engine.NewScene(AssetReloaderSceneName, camera)
+		r.scene.AddEntity(r)
+		sceneManager.AddScene(r.scene)
+	}
+	r.updateCanvas()
+	if !sceneManager.IsSceneVisible(r.scene) {
+		sceneManager.PushVisibleSceneAsLast(r.scene)
+	}
+}
+
+// updateCanvas method updates the overlay canvas with all errors.
+func (r *AssetReloader) updateCanvas() {
+	lines := []string{}
+	for _, filename := range r.GetWatchedFiles() {
+		if err, ok := r.errors[filename]; ok {
+			lines = append(lines, fmt.Sprintf("reload %s: %s", filename, err.Error()))
+		}
+	}
+	width := 0
+	for _, line := range lines {
+		width = tools.Max(width, len(line))
+	}
+	size := api.NewSize(width, len(lines))
+	canvas := NewCanvas(size)
+	canvas.FillWithCell(NewCell(r.GetStyle(), ' '))
+	for row, line := range lines {
+		canvas.WriteStringInCanvasAt(line, r.GetStyle(), api.NewPoint(0, row))
+	}
+	r.SetSize(size)
+	r.SetCanvas(canvas)
+}
+
+// watch method starts watching the given file and loads it for the first
+// time.
+func (r *AssetReloader) watch(filename string, reload func() error) error {
+	watch := &assetWatch{
+		filename: filename,
+		reload:   reload,
+	}
+	r.watches[filename] = watch
+	if info, err := os.Stat(filename); err == nil {
+		watch.modTime = info.ModTime()
+		watch.size = info.Size()
+	}
+	err := r.reloadWatch(watch)
+	r.setError(filename, err)
+	r.updateOverlay()
+	return err
+}
+
+// -----------------------------------------------------------------------------
+// AssetReloader public methods
+// -----------------------------------------------------------------------------
+
+// Check method checks all watched files and reloads any file that changed
+// since last time it was loaded.
+func (r *AssetReloader) Check() {
+	for _, filename := range r.GetWatchedFiles() {
+		watch := r.watches[filename]
+		info, err := os.Stat(filename)
+		if err != nil {
+			// reload the file as soon as it is available again.
+			watch.modTime = time.Time{}
+			r.setError(filename, err)
+			continue
+		}
+		if info.ModTime().Equal(watch.modTime) && info.Size() == watch.size {
+			continue
+		}
+		watch.modTime = info.ModTime()
+		watch.size = info.Size()
+		tools.Logger.WithField("module", "reloader").
+			WithField("struct", "AssetReloader").
+			WithField("method", "Check").
+			Infof("reload %s", filename)
+		r.setError(filename, r.reloadWatch(watch))
+	}
+	r.updateOverlay()
+}
+
+// GetErrors method returns the last error found for every watched file.
+func (r *AssetReloader) GetErrors() map[string]error {
+	return r.errors
+}
+
+// GetInterval method returns the time between two checks.
+func (r *AssetReloader) GetInterval() time.Duration {
+	return r.interval
+}
+
+// GetWatchedFiles method returns all files being watched sorted by name.
+func (r *AssetReloader) GetWatchedFiles() []string {
+	result := make([]string, 0, len(r.watches))
+	for filename := range r.watches {
+		result = append(result, filename)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Poll method checks all watched files if the interval since the last check
+// has expired. It is called by the engine at the start of every tick.
+func (r *AssetReloader) Poll() {
+	if time.Since(r.lastPoll) < r.interval {
+		return
+	}
+	r.lastPoll = time.Now()
+	r.Check()
+}
+
+// Reload method reloads the given watched file, even if it did not change.
+func (r *AssetReloader) Reload(filename string) error {
+	watch, ok := r.watches[filename]
+	if !ok {
+		return fmt.Errorf("file %s is not being watched", filename)
+	}
+	err := r.reloadWatch(watch)
+	r.setError(filename, err)
+	r.updateOverlay()
+	return err
+}
+
+// SetInterval method sets the time between two checks.
+func (r *AssetReloader) SetInterval(interval time.Duration) {
+	r.interval = interval
+}
+
+// Unwatch method stops watching the given file.
+func (r *AssetReloader) Unwatch(filename string) {
+	delete(r.watches, filename)
+	delete(r.errors, filename)
+	r.updateOverlay()
+}
+
+// WatchCanvas method loads the given canvas text file into the given entity
+// and reloads it every time the file changes.
+func (r *AssetReloader) WatchCanvas(filename string, entity IEntity) error {
+	return r.watch(filename, func() error {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		str := strings.TrimRight(string(content), "\n")
+		entity.SetCanvas(NewCanvasFromString(str, entity.GetStyle()))
+		return nil
+	})
+}
+
+// WatchEntities method loads all entities in the given JSON file into the
+// given scene and reloads them every time the file changes. Entities are
+// matched by name: new entities are added to the scene, entities no longer
+// in the file are removed and any other entity is updated in place, unless
+// its class or its behaviors changed, then it is replaced. The scene is not
+// modified if the file contains any error. Entities based on a prefab need a
+// name or a suffix to be matched, because generated names change every time
+// they are loaded.
+func (r *AssetReloader) WatchEntities(filename string, scene IScene, origin *api.Point, builtin IBuiltIn) error {
+	loaded := make(map[string]*loadedEntity)
+	return r.watch(filename, func() error {
+		content, err := readEntityMapsFromJSON(filename)
+		if err != nil {
+			return err
+		}
+		// create all entities first, so any error does not leave the scene
+		// partially updated.
+		names := []string{}
+		entities := make(map[string]*loadedEntity)
+		for _, mapEntity := range content {
+			resolved, err := GetPrefabManager().resolve(mapEntity)
+			if err != nil {
+				return err
+			}
+			created, err := newEntitiesFromMap(GetPrefabManager(), mapEntity, origin, builtin)
+			if err != nil {
+				return err
+			}
+			// behaviors can not be compared once they are set in the entity,
+			// so their names in the file are used instead.
+			behaviors := fmt.Sprint(resolved["behaviors"])
+			for _, entity := range created {
+				name := entity.GetName()
+				if _, ok := entities[name]; ok {
+					return fmt.Errorf("entity %s is duplicated", name)
+				}
+				entities[name] = &loadedEntity{entity: entity, className: entity.GetClassName(), behaviors: behaviors}
+				names = append(names, name)
+			}
+		}
+		for name, old := range loaded {
+			if _, ok := entities[name]; !ok {
+				scene.RemoveEntity(old.entity)
+				delete(loaded, name)
+			}
+		}
+		for _, name := range names {
+			fresh := entities[name]
+			old, ok := loaded[name]
+			if ok && old.className == fresh.className && old.behaviors == fresh.behaviors {
+				updateLoadedEntity(old.entity, fresh.entity)
+				continue
+			}
+			if ok {
+				scene.RemoveEntity(old.entity)
+			}
+			scene.AddEntity(fresh.entity)
+			loaded[name] = fresh
+		}
+		// z-level and p-level could have changed for entities updated in
+		// place.
+		scene.SortEntities()
+		return nil
+	})
+}
+
+var _ IEntity = (*AssetReloader)(nil)
@@ -0,0 +1,134 @@
+package engine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+// writeAsset function writes the given content into the file and moves its
+// modification time forward, so the change is always detected.
+func writeAsset(t *testing.T, filename string, content string, delta time.Duration) {
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(delta)
+	if err := os.Chtimes(filename, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAssetReloaderEntities(t *testing.T) {
+	e := engine.GetEngine()
+	e.SetDryRun(true)
+	filename := filepath.Join(t.TempDir(), "map.json")
+	writeAsset(t, filename, `[
+		{"class": "Wall", "name": "wall/1", "position": [1, 1], "size": [2, 1], "style": ["white", "black"], "ch": "#"},
+		{"class": "Wall", "name": "wall/2", "position": [5, 5], "size": [1, 1], "style": ["white", "black"], "ch": "#"}
+	]`, time.Second)
+	scene := engine.NewScene("scene/reloader/1", engine.NewCamera(nil, api.NewSize(80, 24)))
+	reloader := engine.NewAssetReloader(e, 0)
+	if err := reloader.WatchEntities(filename, scene, nil, &BuiltInTest{}); err != nil {
+		t.Errorf("[1] WatchEntities Error exp:nil got:%v", err)
+	}
+	if got := len(scene.GetEntities()); got != 2 {
+		t.Errorf("[1] WatchEntities Error exp:%d got:%d", 2, got)
+	}
+	wall := scene.GetEntityByName("wall/1")
+
+	// wall/1 is updated, wall/2 is removed and wall/3 is added.
+	writeAsset(t, filename, `[
+		{"class": "Wall", "name": "wall/1", "position": [3, 4], "size": [2, 1], "style": ["white", "black"], "ch": "#"},
+		{"class": "Wall", "name": "wall/3", "position": [7, 7], "size": [1, 1], "style": ["white", "black"], "ch": "#"}
+	]`, 2*time.Second)
+	reloader.Check()
+	if got := len(scene.GetEntities()); got != 2 {
+		t.Errorf("[2] Check Error exp:%d got:%d", 2, got)
+	}
+	if got := scene.GetEntityByName("wall/1"); got != wall {
+		t.Errorf("[2] Check Error exp:%p got:%p", wall, got)
+	}
+	if got := wall.GetPosition(); !got.IsEqual(api.NewPoint(3, 4)) {
+		t.Errorf("[2] Check Error exp:%s got:%s", "[3,4]", got.ToString())
+	}
+	if got := scene.GetEntityByName("wall/2"); got != nil {
+		t.Errorf("[2] Check Error exp:nil got:%s", got.GetName())
+	}
+	if got := scene.GetEntityByName("wall/3"); got == nil {
+		t.Errorf("[2] Check Error exp:%s got:nil", "wall/3")
+	}
+
+	// wall/1 attributes are updated in place and wall/3 is replaced because
+	// its behaviors changed.
+	engine.GetPrefabManager().RegisterBehavior("reloader/still", func(tcell.Event, engine.IScene) {})
+	other := scene.GetEntityByName("wall/3")
+	writeAsset(t, filename, `[
+		{"class": "Wall", "name": "wall/1", "position": [3, 4], "size": [2, 1], "style": ["white", "black"], "ch": "#", "solid": false, "tags": ["door"]},
+		{"class": "Wall", "name": "wall/3", "position": [7, 7], "size": [1, 1], "style": ["white", "black"], "ch": "#", "behaviors": {"update": "reloader/still"}}
+	]`, 5*time.Second/2)
+	reloader.Check()
+	if got := scene.GetEntityByName("wall/1"); got != wall {
+		t.Errorf("[3] Check Error exp:%p got:%p", wall, got)
+	}
+	if wall.IsSolid() || !wall.HasTag("door") {
+		t.Errorf("[3] Check Error exp:%v/%v got:%v/%v", false, true, wall.IsSolid(), wall.HasTag("door"))
+	}
+	if got := scene.GetEntityByName("wall/3"); got == nil || got == other {
+		t.Errorf("[3] Check Error exp:new entity got:%v", got)
+	}
+
+	// malformed file does not modify the scene and it is displayed in the
+	// overlay.
+	writeAsset(t, filename, `[{"class": "Wall", "name": "wall/1", "position": ["x"]}]`, 3*time.Second)
+	reloader.Check()
+	if got := len(reloader.GetErrors()); got != 1 {
+		t.Errorf("[4] GetErrors Error exp:%d got:%d", 1, got)
+	}
+	if got := len(scene.GetEntities()); got != 2 {
+		t.Errorf("[4] Check Error exp:%d got:%d", 2, got)
+	}
+	overlay := e.GetSceneManager().GetSceneByName(engine.AssetReloaderSceneName)
+	if overlay == nil || !e.GetSceneManager().IsSceneVisible(overlay) {
+		t.Errorf("[4] Overlay Error exp:visible got:%v", overlay)
+	}
+
+	writeAsset(t, filename, `[]`, 4*time.Second)
+	reloader.Check()
+	if got := len(reloader.GetErrors()); got != 0 {
+		t.Errorf("[5] GetErrors Error exp:%d got:%d", 0, got)
+	}
+	if got := len(scene.GetEntities()); got != 0 {
+		t.Errorf("[5] Check Error exp:%d got:%d", 0, got)
+	}
+	if e.GetSceneManager().IsSceneVisible(overlay) {
+		t.Errorf("[5] Overlay Error exp:hidden got:visible")
+	}
+}
+
+func TestAssetReloaderCanvas(t *testing.T) {
+	e := engine.GetEngine()
+	e.SetDryRun(true)
+	filename := filepath.Join(t.TempDir(), "canvas.txt")
+	writeAsset(t, filename, "ab\ncd\n", time.Second)
+	entity := engine.NewEntity("entity/canvas/1", api.NewPoint(0, 0), api.NewSize(2, 2), nil)
+	reloader := engine.NewAssetReloader(e, 0)
+	if err := reloader.WatchCanvas(filename, entity); err != nil {
+		t.Errorf("[1] WatchCanvas Error exp:nil got:%v", err)
+	}
+	if got := entity.GetCanvas().GetRuneAt(api.NewPoint(1, 1)); got != 'd' {
+		t.Errorf("[1] WatchCanvas Error exp:%c got:%c", 'd', got)
+	}
+	writeAsset(t, filename, "xyz", 2*time.Second)
+	reloader.Check()
+	if got := entity.GetCanvas().Size(); !got.IsEqual(api.NewSize(3, 1)) {
+		t.Errorf("[2] Check Error exp:%s got:%s", "(3,1)", got.ToString())
+	}
+	if got := entity.GetCanvas().GetRuneAt(api.NewPoint(2, 0)); got != 'z' {
+		t.Errorf("[2] Check Error exp:%c got:%c", 'z', got)
+	}
+}
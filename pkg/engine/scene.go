@@ -1,11 +1,19 @@
 // scene.go contains all attributes and methods required to ahdnle a single
 // scene in the application.
+// Entities added or removed while the scene is iterating its entities, like
+// an entity removing another one in its Update method, are queued. Queued
+// changes are applied in the same order they were requested when the scene
+// phase (Update, Consume, Draw, StartTick, EndTick, Init, Start or Stop) ends,
+// so the next phase already sees them. Entities queued to be removed are not
+// called anymore in the phase running.
 package engine
 
 import (
 	"sort"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/tools"
 )
 
@@ -26,22 +34,45 @@ const (
 type IScene interface {
 	IObject
 	AddEntity(IEntity) error
+	ApplyChanges()
 	CheckCollisionWith(IEntity) []IEntity
 	Clean()
 	Consume()
 	Draw()
 	EndTick()
+	Find(*Query) []IEntity
 	GetEntities() []IEntity
+	GetEntitiesInRegion(*api.Rect) []IEntity
+	GetEntitiesWithClass(string) []IEntity
+	GetEntitiesWithTag(string) []IEntity
 	GetEntityByName(string) IEntity
 	GetCamera() ICamera
+	GetEngine() *Engine
+	HasPendingChanges() bool
 	Init(tcell.Screen)
+	Relayout(*api.Size)
 	RemoveEntity(IEntity) error
+	SetEngine(*Engine)
+	SortEntities()
 	Update(tcell.Event)
 	Start()
 	StartTick()
 	Stop()
 }
 
+// -----------------------------------------------------------------------------
+//
+// sceneChange
+//
+// -----------------------------------------------------------------------------
+
+// sceneChange structure defines an entity change queued while the scene is
+// iterating its entities. A change without any entity removes all entities.
+type sceneChange struct {
+	entity IEntity
+	add    bool
+}
+
 // -----------------------------------------------------------------------------
 //
 // Scene
@@ -50,14 +81,25 @@ type IScene interface {
 
 // Scene struct contains all attribute required for handling an application
 // scene.
+// engine is the engine the scene belongs to. Scenes without any engine use
+// the default engine returned by GetEngine().
+// iterating is the number of loops over entities running, because a loop can
+// be nested in another one, like an entity drawing the scene.
+// changes are entities added or removed while iterating.
+// index contains entities by tag and class name, and it is built when any
+// query requires it.
 type Scene struct {
 	*EObject
 	entities       []IEntity
 	zLevelEntities []IEntity
 	pLevelEntities []IEntity
 	camera         ICamera
+	engine         *Engine
 	initialized    bool
 	started        bool
+	iterating      int
+	changes        []*sceneChange
+	index          *entityIndex
 }
 
 // NewCamera function creates a new Scene instance.
@@ -68,6 +110,7 @@ func NewScene(name string, camera ICamera) *Scene {
 		zLevelEntities: []IEntity{},
 		pLevelEntities: []IEntity{},
 		camera:         camera,
+		engine:         nil,
 		initialized:    false,
 		started:        false,
 	}
@@ -81,6 +124,46 @@ func NewScene(name string, camera ICamera) *Scene {
 // Scene private methods
 // -----------------------------------------------------------------------------
 
+// addEntity method adds the given entity to the scene right away.
+func (s *Scene) addEntity(entity IEntity) {
+	s.entities = append(s.entities, entity)
+	s.sortEntities()
+	s.layoutEntity(entity)
+	focusManager := s.GetEngine().GetFocusManager()
+	focusManager.AddEntity(s, entity)
+	if s.initialized {
+		screen := s.GetEngine().GetScreen()
+		entity.Init(screen)
+	}
+	if s.started {
+		entity.Start()
+	}
+	entity.OnAdded(s)
+}
+
+// clean method removes all entities from the scene right away.
+func (s *Scene) clean() {
+	entities := s.entities
+	s.entities = []IEntity{}
+	s.zLevelEntities = []IEntity{}
+	s.pLevelEntities = []IEntity{}
+	s.index = nil
+	s.GetEngine().GetFocusManager().RemoveScene(s)
+	for _, entity := range entities {
+		s.GetEngine().GetObserverManager().RemoveObserver(entity)
+		entity.OnRemoved(s)
+	}
+}
+
+// endIteration method ends a loop over entities. Queued changes are applied
+// when there is not any other loop running.
+func (s *Scene) endIteration() {
+	s.iterating--
+	if s.iterating == 0 {
+		s.ApplyChanges()
+	}
+}
+
 // findEntity methods finds the given entity in the list of entities.
 func (s *Scene) findEntity(entity IEntity) int {
 	for index, ent := range s.entities {
@@ -91,8 +174,74 @@ func (s *Scene) findEntity(entity IEntity) int {
 	return InvalidEntityIndex
 }
 
+// forEachEntity method calls the given function for every entity in the given
+// slice which is not queued to be removed. Entities added or removed while
+// iterating are queued.
+func (s *Scene) forEachEntity(entities []IEntity, f func(IEntity)) {
+	s.iterating++
+	defer s.endIteration()
+	for _, entity := range entities {
+		if !s.isRemovalQueued(entity) {
+			f(entity)
+		}
+	}
+}
+
+// getIndex method returns the entity index, building it if the scene or any
+// entity tag or class name has changed.
+func (s *Scene) getIndex() *entityIndex {
+	if s.index == nil || !s.index.isValid() {
+		s.index = newEntityIndex(s.entities)
+	}
+	return s.index
+}
+
+// isRemovalQueued method checks if the last change queued for the given
+// entity removes it from the scene.
+func (s *Scene) isRemovalQueued(entity IEntity) bool {
+	for i := len(s.changes) - 1; i >= 0; i-- {
+		if change := s.changes[i]; change.entity == nil {
+			return true
+		} else if change.entity == entity {
+			return !change.add
+		}
+	}
+	return false
+}
+
+// layoutEntity method places the given entity in the scene camera using the
+// entity layout, if any. The entity is refreshed when its size changes.
+func (s *Scene) layoutEntity(entity IEntity) {
+	layout := entity.GetLayout()
+	if layout == nil || s.camera == nil || s.camera.GetSize() == nil {
+		return
+	}
+	container := api.NewRect(api.NewPoint(0, 0), s.camera.GetSize())
+	oldSize := entity.GetSize()
+	position, size := layout.Apply(entity.GetPosition(), oldSize, container)
+	entity.SetPosition(position)
+	entity.SetSize(size)
+	if oldSize == nil || !oldSize.IsEqual(size) {
+		entity.Refresh()
+	}
+}
+
+// removeEntity method removes the given entity from the scene right away.
+func (s *Scene) removeEntity(entity IEntity) {
+	if index := s.findEntity(entity); index != InvalidEntityIndex {
+		s.entities = append(s.entities[:index], s.entities[index+1:]...)
+		s.sortEntities()
+		focusManager := s.GetEngine().GetFocusManager()
+		focusManager.RemoveEntity(s, entity)
+		s.GetEngine().GetObserverManager().RemoveObserver(entity)
+		entity.OnRemoved(s)
+	}
+}
+
 // sortEntities method sorts zLevelEntites and pLevelEntities.
 func (s *Scene) sortEntities() {
+	s.index = nil
+
 	// copy and sort zLevelEntities. Entities with lower zLevel are drawed
 	// first.
 	s.zLevelEntities = make([]IEntity, len(s.entities))
@@ -118,28 +267,47 @@ func (s *Scene) sortEntities() {
 // Scene public methods
 // -----------------------------------------------------------------------------
 
-// AddEntity methods adds a new entity to the scene.
+// AddEntity methods adds a new entity to the scene. The entity is queued if
+// the scene is iterating its entities and it is added when the iteration
+// ends.
 func (s *Scene) AddEntity(entity IEntity) error {
-	s.entities = append(s.entities, entity)
-	s.sortEntities()
-	focusManager := GetEngine().GetFocusManager()
-	focusManager.AddEntity(s, entity)
-	if s.initialized {
-		screen := GetEngine().GetScreen()
-		entity.Init(screen)
-	}
-	if s.started {
-		entity.Start()
+	if s.iterating != 0 {
+		s.changes = append(s.changes, &sceneChange{entity: entity, add: true})
+		return nil
 	}
+	s.addEntity(entity)
 	return nil
 }
 
+// ApplyChanges method applies all queued changes in the order they were
+// requested.
+func (s *Scene) ApplyChanges() {
+	// changes requested by OnAdded and OnRemoved hooks are applied right
+	// away, because the scene is not iterating.
+	changes := s.changes
+	s.changes = nil
+	for _, change := range changes {
+		switch {
+		case change.entity == nil:
+			s.clean()
+		case change.add:
+			s.addEntity(change.entity)
+		default:
+			s.removeEntity(change.entity)
+		}
+	}
+}
+
 // CheckCollisionWith method checks if the given entity has a collision with
-// any other solid entity in the scene.
+// any other solid entity in the scene. Entities queued to be removed are not
+// checked.
 func (s *Scene) CheckCollisionWith(entity IEntity) []IEntity {
+	defer func(start time.Time) {
+		s.GetEngine().GetStats().AddPhaseTime(StatsPhaseCollision, time.Since(start))
+	}(time.Now())
 	solidEntities := []IEntity{}
 	for _, ent := range s.entities {
-		if ent.IsActive() && ent.IsSolid() {
+		if ent.IsActive() && ent.IsSolid() && !s.isRemovalQueued(ent) {
 			solidEntities = append(solidEntities, ent)
 		}
 	}
@@ -147,19 +315,22 @@ func (s *Scene) CheckCollisionWith(entity IEntity) []IEntity {
 }
 
 // Clean method cleans all resources for the scene in order to set it up as a
-// brand new screen.
+// brand new screen. All entities are removed, and they are queued if the
+// scene is iterating its entities.
 func (s *Scene) Clean() {
-	s.entities = []IEntity{}
-	s.zLevelEntities = []IEntity{}
-	s.pLevelEntities = []IEntity{}
+	if s.iterating != 0 {
+		s.changes = append(s.changes, &sceneChange{entity: nil})
+		return
+	}
+	s.clean()
 }
 
 // Consume method calls all entity instances to consume all messages from
 // the mailbox.
 func (s *Scene) Consume() {
-	for _, entity := range s.pLevelEntities {
+	s.forEachEntity(s.pLevelEntities, func(entity IEntity) {
 		entity.Consume()
-	}
+	})
 }
 
 // Draw method proceeds to draw all entities registered and visible in the
@@ -169,22 +340,61 @@ func (s *Scene) Draw() {
 		return
 	}
 	// Draw entites by its zLevel.
-	for _, entity := range s.zLevelEntities {
+	s.forEachEntity(s.zLevelEntities, func(entity IEntity) {
 		entity.Draw(s)
-	}
+	})
 }
 
 func (s *Scene) EndTick() {
-	for _, entity := range s.pLevelEntities {
+	s.forEachEntity(s.pLevelEntities, func(entity IEntity) {
 		entity.EndTick(s)
+	})
+}
+
+// Find method returns all entities matching the given query in the same order
+// they were added to the scene. A nil query matches all entities. Entities
+// queued to be removed are not returned.
+func (s *Scene) Find(query *Query) []IEntity {
+	candidates := s.entities
+	if query != nil {
+		if entities, ok := query.candidates(s.getIndex()); ok {
+			candidates = entities
+		}
+	}
+	result := []IEntity{}
+	for _, entity := range candidates {
+		if s.isRemovalQueued(entity) {
+			continue
+		}
+		if query == nil || query.Match(entity) {
+			result = append(result, entity)
+		}
 	}
+	return result
 }
 
-// GetEntities method returns all entities in the scene.
+// GetEntities method returns all entities in the scene. Queued changes are
+// not applied yet to the entities returned.
 func (s *Scene) GetEntities() []IEntity {
 	return s.entities
 }
 
+// GetEntitiesInRegion method returns all entities with any cell in the given
+// region.
+func (s *Scene) GetEntitiesInRegion(region *api.Rect) []IEntity {
+	return s.Find(NewQuery().InRegion(region))
+}
+
+// GetEntitiesWithClass method returns all entities with the given class name.
+func (s *Scene) GetEntitiesWithClass(className string) []IEntity {
+	return s.Find(NewQuery().WithClass(className))
+}
+
+// GetEntitiesWithTag method returns all entities with the given tag.
+func (s *Scene) GetEntitiesWithTag(tag string) []IEntity {
+	return s.Find(NewQuery().WithTag(tag))
+}
+
 // GetEntityByName method returns the entity with the given name in the scene.
 func (s *Scene) GetEntityByName(name string) IEntity {
 	for _, entity := range s.entities {
@@ -200,53 +410,117 @@ func (s *Scene) GetCamera() ICamera {
 	return s.camera
 }
 
-// Init method proceeds to initialize all scene resources.
+// GetEngine method returns the engine the scene belongs to, or the default
+// engine if the scene does not belong to any engine.
+func (s *Scene) GetEngine() *Engine {
+	if s.engine == nil {
+		return GetEngine()
+	}
+	return s.engine
+}
+
+// HasPendingChanges method checks if there are entities queued to be added
+// or removed.
+func (s *Scene) HasPendingChanges() bool {
+	return len(s.changes) != 0
+}
+
+// Init method proceeds to initialize all scene resources. Entities added
+// while being initialized are initialized when they are added.
 func (s *Scene) Init(display tcell.Screen) {
 	s.camera.Init(display)
-	for _, entity := range s.entities {
-		entity.Init(display)
-	}
 	s.initialized = true
+	s.forEachEntity(s.entities, func(entity IEntity) {
+		entity.Init(display)
+	})
 }
 
-// Start method proceeds to start all scene resources.
+// Start method proceeds to start all scene resources. Entities added while
+// being started are started when they are added.
 func (s *Scene) Start() {
-	for _, entity := range s.entities {
-		entity.Start()
-	}
 	s.started = true
+	s.forEachEntity(s.entities, func(entity IEntity) {
+		entity.Start()
+	})
 }
 
 func (s *Scene) StartTick() {
-	for _, entity := range s.pLevelEntities {
+	s.forEachEntity(s.pLevelEntities, func(entity IEntity) {
 		entity.StartTick(s)
-	}
+	})
 }
 
 // Stop method proceeds to stop all scene resources.
 func (s *Scene) Stop() {
-	for _, entity := range s.entities {
+	s.forEachEntity(s.entities, func(entity IEntity) {
 		entity.Stop()
+	})
+}
+
+// Relayout method places the scene camera in a screen with the given size
+// using the camera layout, if any, and then it places every entity with a
+// layout in the camera. It is called by the engine every time the screen is
+// resized.
+func (s *Scene) Relayout(screenSize *api.Size) {
+	if s.camera == nil {
+		return
+	}
+	if layout := s.camera.GetLayout(); layout != nil && screenSize != nil {
+		container := api.NewRect(api.NewPoint(0, 0), screenSize)
+		origin, size := layout.Apply(s.camera.GetOrigin(), s.camera.GetSize(), container)
+		s.camera.SetOrigin(origin)
+		s.camera.SetSize(size)
 	}
+	s.forEachEntity(s.entities, func(entity IEntity) {
+		s.layoutEntity(entity)
+	})
 }
 
-// RemoveEntity method proceeds to remove the given entity from the scene.
+// RemoveEntity method proceeds to remove the given entity from the scene. The
+// entity is queued if the scene is iterating its entities and it is removed
+// when the iteration ends, but it is not called anymore in the iteration.
+// The entity is removed from the focus manager and from all subjects it
+// observes.
 func (s *Scene) RemoveEntity(entity IEntity) error {
-	if index := s.findEntity(entity); index != InvalidEntityIndex {
-		s.entities = append(s.entities[:index], s.entities[index+1:]...)
-		s.sortEntities()
-		focusManager := GetEngine().GetFocusManager()
-		focusManager.RemoveEntity(s, entity)
+	if s.iterating != 0 {
+		s.changes = append(s.changes, &sceneChange{entity: entity, add: false})
+		return nil
 	}
+	s.removeEntity(entity)
 	return nil
 }
 
+// SetEngine method sets the engine the scene belongs to. Entities already in
+// the scene are moved to the focus manager for the new engine.
+func (s *Scene) SetEngine(engine *Engine) {
+	if s.engine == engine {
+		return
+	}
+	if len(s.entities) != 0 {
+		s.GetEngine().GetFocusManager().RemoveScene(s)
+		for _, entity := range s.entities {
+			engine.GetFocusManager().AddEntity(s, entity)
+		}
+	}
+	s.engine = engine
+	if s.camera != nil {
+		s.camera.SetEngine(engine)
+	}
+}
+
+// SortEntities method sorts all entities again by their z-level and p-level.
+// It has to be called when any entity in the scene changes its z-level or
+// p-level, like a window being raised over other windows.
+func (s *Scene) SortEntities() {
+	s.sortEntities()
+}
+
 // Update method proceeds to updates all scene resources.
 func (s *Scene) Update(event tcell.Event) {
 	// update entities by its pLevel.
-	for _, entity := range s.pLevelEntities {
+	s.forEachEntity(s.pLevelEntities, func(entity IEntity) {
 		entity.Update(event, s)
-	}
+	})
 }
 
 var _ IObject = (*Scene)(nil)
@@ -1,8 +1,10 @@
 package engine_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/engine"
 )
@@ -29,3 +31,84 @@ func TestScene(t *testing.T) {
 		}
 	}
 }
+
+func TestSceneDeferredChanges(t *testing.T) {
+	e := engine.NewEngine()
+	scene := e.NewScene("scene/test/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(10, 10)))
+	hooks := []string{}
+	newEntity := func(name string) *engine.Entity {
+		entity := engine.NewEntity(name, api.NewPoint(0, 0), api.NewSize(1, 1), nil)
+		entity.SetFocusType(engine.SingleFocus)
+		entity.SetFocusEnable(true)
+		entity.SetBehaviorFor(engine.BehaviorAdded, func(engine.IScene) { hooks = append(hooks, "added "+name) })
+		entity.SetBehaviorFor(engine.BehaviorRemoved, func(engine.IScene) { hooks = append(hooks, "removed "+name) })
+		return entity
+	}
+	spawner := newEntity("entity/spawner")
+	target := newEntity("entity/target")
+	bullet := newEntity("entity/bullet")
+	spawner.SetPLevel(1)
+	updated := []string{}
+	spawner.SetBehaviorFor(engine.BehaviorUpdate, func(_ tcell.Event, s engine.IScene) {
+		s.RemoveEntity(target)
+		s.AddEntity(bullet)
+		if got := len(s.GetEntities()); got != 2 {
+			t.Errorf("[1] GetEntities Error exp:2 got:%d", got)
+		}
+		if !s.HasPendingChanges() {
+			t.Errorf("[1] HasPendingChanges Error exp:true got:false")
+		}
+	})
+	target.SetBehaviorFor(engine.BehaviorUpdate, func(tcell.Event, engine.IScene) {
+		updated = append(updated, "entity/target")
+	})
+	scene.AddEntity(spawner)
+	scene.AddEntity(target)
+	e.GetObserverManager().RegisterObserver("subject/test", target)
+	if exp, got := "added entity/spawner,added entity/target", strings.Join(hooks, ","); got != exp {
+		t.Errorf("[0] OnAdded Error exp:%s got:%s", exp, got)
+	}
+
+	// changes are applied when the update ends, and the entity removed is not
+	// updated anymore.
+	hooks = []string{}
+	scene.Update(nil)
+	if len(updated) != 0 {
+		t.Errorf("[2] Update Error exp:[] got:%v", updated)
+	}
+	if scene.HasPendingChanges() {
+		t.Errorf("[2] HasPendingChanges Error exp:false got:true")
+	}
+	if exp, got := "removed entity/target,added entity/bullet", strings.Join(hooks, ","); got != exp {
+		t.Errorf("[2] Hooks Error exp:%s got:%s", exp, got)
+	}
+	if scene.GetEntityByName("entity/target") != nil || scene.GetEntityByName("entity/bullet") == nil {
+		t.Errorf("[2] GetEntityByName Error exp:bullet without target got:%v", scene.GetEntities())
+	}
+
+	// registrations for the removed entity are cleaned up.
+	for _, entity := range e.GetFocusManager().GetEntities()[scene.GetName()] {
+		if entity == target {
+			t.Errorf("[3] FocusManager Error exp:no target got:target")
+		}
+	}
+	target.SetBehaviorFor(engine.BehaviorNotify, func(any, any) { updated = append(updated, "notified") })
+	e.GetObserverManager().NotifyObservers("subject/test", "message")
+	if len(updated) != 0 {
+		t.Errorf("[3] NotifyObservers Error exp:[] got:%v", updated)
+	}
+
+	// cleaning the scene while updating removes all entities at the end.
+	hooks = []string{}
+	spawner.SetBehaviorFor(engine.BehaviorUpdate, func(_ tcell.Event, s engine.IScene) {
+		s.Clean()
+		s.AddEntity(target)
+	})
+	scene.Update(nil)
+	if got := scene.GetEntities(); len(got) != 1 || got[0] != target {
+		t.Errorf("[4] Clean Error exp:[target] got:%v", got)
+	}
+	if exp, got := "removed entity/spawner,removed entity/bullet,added entity/target", strings.Join(hooks, ","); got != exp {
+		t.Errorf("[4] Hooks Error exp:%s got:%s", exp, got)
+	}
+}
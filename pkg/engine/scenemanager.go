@@ -4,6 +4,7 @@ package engine
 
 import (
 	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/tools"
 )
 
@@ -23,10 +24,13 @@ const (
 
 // SceneManager structure defines attributes and functions to handle multiple
 // scenes in the application.
+// engine is the engine the scene manager belongs to. Every scene added is set
+// to belong to the same engine.
 type SceneManager struct {
 	scenes        []IScene
 	activeScenes  []IScene
 	visibleScenes []IScene
+	engine        *Engine
 	initialized   bool
 	started       bool
 }
@@ -37,6 +41,7 @@ func NewSceneManager() *SceneManager {
 		scenes:        make([]IScene, 0),
 		activeScenes:  make([]IScene, 0),
 		visibleScenes: make([]IScene, 0),
+		engine:        nil,
 		initialized:   false,
 		started:       false,
 	}
@@ -58,6 +63,19 @@ func getSceneInSlice(scene IScene, slice []IScene) int {
 	return InvalidSceneIndex
 }
 
+// -----------------------------------------------------------------------------
+// SceneManager private methods
+// -----------------------------------------------------------------------------
+
+// lastActiveScene method returns the last active scene, which is the scene
+// handling the focus, nil if there are not any active scenes.
+func (m *SceneManager) lastActiveScene() IScene {
+	if lenActiveScenes := len(m.activeScenes); lenActiveScenes != 0 {
+		return m.activeScenes[lenActiveScenes-1]
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // SceneManager public methods
 // -----------------------------------------------------------------------------
@@ -78,11 +96,15 @@ func (m *SceneManager) AddScene(scene IScene) bool {
 		Debugf("add scene %s", scene.GetName())
 
 	m.scenes = append(m.scenes, scene)
+	scene.SetEngine(m.GetEngine())
 	// if the scene manager has been already initialized or started, call
 	// related function for the added scene.
 	if m.initialized {
-		screen := GetEngine().GetScreen()
+		screen := m.GetEngine().GetScreen()
 		scene.Init(screen)
+		if screen != nil {
+			scene.Relayout(api.NewSize(screen.Size()))
+		}
 	}
 	if m.started {
 		scene.Start()
@@ -108,13 +130,15 @@ func (m *SceneManager) DeactivateScene(scene IScene) bool {
 }
 
 // Draw method is called by the engine to draw all visible scenes in the scene
-// manager.
+// manager. The screen is not shown when it is nil, like in dry-run mode.
 func (m *SceneManager) Draw(screen tcell.Screen) {
 	for _, scene := range m.visibleScenes {
 		scene.Draw()
 		//scene.GetCamera().Draw(true, screen)
 	}
-	GetEngine().GetScreen().Show()
+	if screen := m.GetEngine().GetScreen(); screen != nil {
+		screen.Show()
+	}
 }
 
 func (m *SceneManager) EndTick() {
@@ -123,6 +147,45 @@ func (m *SceneManager) EndTick() {
 	}
 }
 
+// FocusDirection method moves the focus in the last active scene to the
+// nearest entity in the given direction. It returns true if the focus moved.
+func (m *SceneManager) FocusDirection(direction FocusDirection) bool {
+	if lastActiveScene := m.lastActiveScene(); lastActiveScene != nil {
+		tools.Logger.WithField("module", "scenemanager").
+			WithField("method", "FocusDirection").
+			Debugf("scene %s direction %d", lastActiveScene.GetName(), direction)
+		focusManager := m.GetEngine().GetFocusManager()
+		focused := focusManager.focusedInScene(lastActiveScene.GetName())
+		focusManager.FocusDirectionInScene(lastActiveScene, direction)
+		return focusManager.focusedInScene(lastActiveScene.GetName()) != focused
+	}
+	return false
+}
+
+// FocusNext method moves the focus in the last active scene to the next
+// entity in the tab order.
+func (m *SceneManager) FocusNext() {
+	if lastActiveScene := m.lastActiveScene(); lastActiveScene != nil {
+		tools.Logger.WithField("module", "scenemanager").
+			WithField("method", "FocusNext").
+			Debugf("scene %s", lastActiveScene.GetName())
+		focusManager := m.GetEngine().GetFocusManager()
+		focusManager.FocusNextInScene(lastActiveScene)
+	}
+}
+
+// FocusPrevious method moves the focus in the last active scene to the
+// previous entity in the tab order.
+func (m *SceneManager) FocusPrevious() {
+	if lastActiveScene := m.lastActiveScene(); lastActiveScene != nil {
+		tools.Logger.WithField("module", "scenemanager").
+			WithField("method", "FocusPrevious").
+			Debugf("scene %s", lastActiveScene.GetName())
+		focusManager := m.GetEngine().GetFocusManager()
+		focusManager.FocusPreviousInScene(lastActiveScene)
+	}
+}
+
 // GetSceneByIndex method finds a scene with the given index. If the index is
 // -1 it retreive the last scene.
 func (m *SceneManager) GetSceneByIndex(index int) IScene {
@@ -150,6 +213,15 @@ func (m *SceneManager) GetAllVisibleScenes() []IScene {
 	return m.visibleScenes
 }
 
+// GetEngine method returns the engine the scene manager belongs to, or the
+// default engine if the scene manager does not belong to any engine.
+func (m *SceneManager) GetEngine() *Engine {
+	if m.engine == nil {
+		return GetEngine()
+	}
+	return m.engine
+}
+
 // GetSceneByName method finds a scene with the given name.
 func (m *SceneManager) GetSceneByName(name string) IScene {
 	for _, scene := range m.scenes {
@@ -252,6 +324,13 @@ func (m *SceneManager) PushVisibleSceneAsLast(scene IScene) bool {
 	return false
 }
 
+// Relayout method places all scenes in a screen with the given size.
+func (m *SceneManager) Relayout(screenSize *api.Size) {
+	for _, scene := range m.scenes {
+		scene.Relayout(screenSize)
+	}
+}
+
 // RemoveScene method removes the given scene from all scene slices.
 func (m *SceneManager) RemoveScene(scene IScene) bool {
 	tools.Logger.WithField("module", "scenemanager").
@@ -259,7 +338,7 @@ func (m *SceneManager) RemoveScene(scene IScene) bool {
 		Debugf("remove scene %s", scene.GetName())
 
 	// Remove the scene from the focus manager
-	focusManager := GetEngine().GetFocusManager()
+	focusManager := m.GetEngine().GetFocusManager()
 	focusManager.RemoveScene(scene)
 
 	// Remove scene from all scene manager lists: active, visible and
@@ -269,10 +348,14 @@ func (m *SceneManager) RemoveScene(scene IScene) bool {
 	return m.RemoveSceneAsAvailable(scene)
 }
 
-// RemoveSceneAsActive method removes the given scene as an active scene.
+// RemoveSceneAsActive method removes the given scene as an active scene and
+// releases all assets preloaded for the scene by the engine asset manager.
 func (m *SceneManager) RemoveSceneAsActive(scene IScene) bool {
 	if index := m.GetActiveSceneIndex(scene); index != InvalidSceneIndex {
 		m.activeScenes = append(m.activeScenes[:index], m.activeScenes[index+1:]...)
+		if manager := m.GetEngine().assetManager; manager != nil {
+			manager.Unload(scene.GetName())
+		}
 		return true
 	}
 	return false
@@ -305,7 +388,9 @@ func (m *SceneManager) SetDryRun(dryRun bool) {
 	}
 }
 
-// SetSceneAsActive methos sets the given scene as an active scene.
+// SetSceneAsActive methos sets the given scene as an active scene and
+// preloads all assets for the scene with the engine asset manager, if the
+// engine has any.
 func (m *SceneManager) SetSceneAsActive(scene IScene) bool {
 	if !m.IsSceneAvailable(scene) {
 		return false
@@ -313,6 +398,13 @@ func (m *SceneManager) SetSceneAsActive(scene IScene) bool {
 	if m.IsSceneActive(scene) {
 		return true
 	}
+	if manager := m.GetEngine().assetManager; manager != nil {
+		if err := manager.Preload(scene.GetName(), nil); err != nil {
+			tools.Logger.WithField("module", "scenemanager").
+				WithField("method", "SetSceneAsActive").
+				Errorf("preload scene %s: %s", scene.GetName(), err.Error())
+		}
+	}
 	m.activeScenes = append(m.activeScenes, scene)
 	return true
 }
@@ -362,12 +454,11 @@ func (m *SceneManager) Update(event tcell.Event) {
 
 // UpdateFocus method updates focus in the last active scenes.
 func (m *SceneManager) UpdateFocus() {
-	if lenActiveScenes := len(m.activeScenes); lenActiveScenes != 0 {
-		lastActiveScene := m.activeScenes[lenActiveScenes-1]
+	if lastActiveScene := m.lastActiveScene(); lastActiveScene != nil {
 		tools.Logger.WithField("module", "scenemanager").
 			WithField("method", "UpdateFocus").
 			Debugf("scene %s", lastActiveScene.GetName())
-		focusManager := GetEngine().GetFocusManager()
+		focusManager := m.GetEngine().GetFocusManager()
 		focusManager.UpdateFocusForScene(lastActiveScene)
 	}
 }
@@ -0,0 +1,199 @@
+// stats.go contains all data and methods required to collect runtime
+// statistics for every engine frame: actual frames per second, time spent in
+// every phase of the tick and frame spikes.
+package engine
+
+import (
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	// StatsPhaseCollision is a sub-phase measured while entities check
+	// collisions, mostly in the update phase, so its time is already part
+	// of the phase it runs in.
+	StatsPhaseCollision = "collision"
+	StatsPhaseConsume   = "consume"
+	StatsPhaseDraw      = "draw"
+	StatsPhaseUpdate    = "update"
+
+	// StatsDefaultHistory is the default number of frames stored.
+	StatsDefaultHistory = 60
+)
+
+var (
+	// StatsPhases contains all phases measured for every frame in the
+	// order they are executed. Sub-phases are not included.
+	StatsPhases = []string{StatsPhaseUpdate, StatsPhaseConsume, StatsPhaseDraw}
+)
+
+// -----------------------------------------------------------------------------
+//
+// FrameSample
+//
+// -----------------------------------------------------------------------------
+
+// FrameSample structure contains the time information for a single frame.
+// Period: time between the start of the frame and the start of the previous
+// frame, sleep time included.
+// Work: time spent processing the frame, sleep time not included.
+type FrameSample struct {
+	Period time.Duration
+	Work   time.Duration
+}
+
+// -----------------------------------------------------------------------------
+//
+// FrameStats
+//
+// -----------------------------------------------------------------------------
+
+// FrameStats structure collects time statistics for the last frames being
+// processed by the engine.
+// samples is a ring buffer with the information for the last frames.
+// phases contains time spent in every phase for the frame being processed and
+// lastPhases for the last frame completed.
+type FrameStats struct {
+	targetFPS  float64
+	frameStart time.Time
+	samples    []FrameSample
+	index      int
+	count      int
+	total      int
+	phases     map[string]time.Duration
+	lastPhases map[string]time.Duration
+}
+
+// NewFrameStats function creates a new FrameStats instance that stores
+// information for the given number of frames.
+func NewFrameStats(history int) *FrameStats {
+	if history <= 0 {
+		history = StatsDefaultHistory
+	}
+	return &FrameStats{
+		targetFPS:  0,
+		samples:    make([]FrameSample, history),
+		index:      0,
+		count:      0,
+		total:      0,
+		phases:     make(map[string]time.Duration),
+		lastPhases: make(map[string]time.Duration),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// FrameStats public methods
+// -----------------------------------------------------------------------------
+
+// AddPhaseTime method adds the given duration to the given phase for the
+// frame being processed.
+func (s *FrameStats) AddPhaseTime(phase string, duration time.Duration) {
+	s.phases[phase] += duration
+}
+
+// EndFrame method completes the frame being processed and stores its sample.
+func (s *FrameStats) EndFrame() {
+	s.samples[s.index].Work = time.Since(s.frameStart)
+	s.index = (s.index + 1) % len(s.samples)
+	if s.count < len(s.samples) {
+		s.count++
+	}
+	s.total++
+	s.lastPhases = s.phases
+	s.phases = make(map[string]time.Duration)
+}
+
+// GetAverageWork method returns the average time spent processing every
+// frame.
+func (s *FrameStats) GetAverageWork() time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, sample := range s.GetSamples() {
+		total += sample.Work
+	}
+	return total / time.Duration(s.count)
+}
+
+// GetBudget method returns the time available for every frame at the target
+// frames per second.
+func (s *FrameStats) GetBudget() time.Duration {
+	if s.targetFPS <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / s.targetFPS)
+}
+
+// GetFPS method returns the actual frames per second based on the period of
+// the last frames.
+func (s *FrameStats) GetFPS() float64 {
+	var total time.Duration
+	var count int
+	for _, sample := range s.GetSamples() {
+		if sample.Period != 0 {
+			total += sample.Period
+			count++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / total.Seconds()
+}
+
+// GetPhaseTime method returns the time spent in the given phase in the last
+// frame completed.
+func (s *FrameStats) GetPhaseTime(phase string) time.Duration {
+	return s.lastPhases[phase]
+}
+
+// GetSamples method returns samples for the last frames in chronological
+// order.
+func (s *FrameStats) GetSamples() []FrameSample {
+	result := make([]FrameSample, 0, s.count)
+	start := (s.index - s.count + len(s.samples)) % len(s.samples)
+	for i := 0; i < s.count; i++ {
+		result = append(result, s.samples[(start+i)%len(s.samples)])
+	}
+	return result
+}
+
+// GetTargetFPS method returns the target frames per second.
+func (s *FrameStats) GetTargetFPS() float64 {
+	return s.targetFPS
+}
+
+// GetTotalFrames method returns the total number of frames processed.
+func (s *FrameStats) GetTotalFrames() int {
+	return s.total
+}
+
+// IsSpike method checks if the given frame sample is a spike, which means
+// the frame took longer than the frame budget, or when there is not any
+// target, longer than twice the average.
+func (s *FrameStats) IsSpike(sample FrameSample) bool {
+	if budget := s.GetBudget(); budget != 0 {
+		return sample.Work > budget
+	}
+	return sample.Work > 2*s.GetAverageWork()
+}
+
+// SetTargetFPS method sets the target frames per second.
+func (s *FrameStats) SetTargetFPS(fps float64) {
+	s.targetFPS = fps
+}
+
+// StartFrame method starts a new frame to be processed.
+func (s *FrameStats) StartFrame() {
+	now := time.Now()
+	if !s.frameStart.IsZero() {
+		s.samples[s.index].Period = now.Sub(s.frameStart)
+	} else {
+		s.samples[s.index].Period = 0
+	}
+	s.frameStart = now
+}
@@ -0,0 +1,88 @@
+package engine_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+func TestFrameStats(t *testing.T) {
+	got := engine.NewFrameStats(4)
+	if got == nil {
+		t.Errorf("[1] NewFrameStats Error exp:*FrameStats got:nil")
+		return
+	}
+	got.SetTargetFPS(100)
+	if budget := got.GetBudget(); budget != 10*time.Millisecond {
+		t.Errorf("[1] GetBudget Error exp:%s got:%s", 10*time.Millisecond, budget)
+	}
+	for i := 0; i < 6; i++ {
+		got.StartFrame()
+		got.AddPhaseTime(engine.StatsPhaseUpdate, time.Duration(i)*time.Millisecond)
+		got.AddPhaseTime(engine.StatsPhaseUpdate, time.Millisecond)
+		got.EndFrame()
+	}
+	if total := got.GetTotalFrames(); total != 6 {
+		t.Errorf("[2] GetTotalFrames Error exp:%d got:%d", 6, total)
+	}
+	if samples := got.GetSamples(); len(samples) != 4 {
+		t.Errorf("[2] GetSamples Error exp:%d got:%d", 4, len(samples))
+	}
+	if phase := got.GetPhaseTime(engine.StatsPhaseUpdate); phase != 6*time.Millisecond {
+		t.Errorf("[2] GetPhaseTime Error exp:%s got:%s", 6*time.Millisecond, phase)
+	}
+	if phase := got.GetPhaseTime(engine.StatsPhaseDraw); phase != 0 {
+		t.Errorf("[2] GetPhaseTime Error exp:0 got:%s", phase)
+	}
+	if fps := got.GetFPS(); fps <= 0 {
+		t.Errorf("[2] GetFPS Error exp:>0 got:%f", fps)
+	}
+	if got.IsSpike(engine.FrameSample{Work: time.Millisecond}) {
+		t.Errorf("[3] IsSpike Error exp:false got:true")
+	}
+	if !got.IsSpike(engine.FrameSample{Work: 20 * time.Millisecond}) {
+		t.Errorf("[3] IsSpike Error exp:true got:false")
+	}
+}
+
+func TestDebugOverlay(t *testing.T) {
+	e := engine.GetEngine()
+	e.SetDryRun(true)
+	e.AddDebugStat("tasks", func() string { return "3" })
+	defer e.RemoveDebugStat("tasks")
+	e.AddDebugStat("wide", func() string { return strings.Repeat("é", 80) })
+	defer e.RemoveDebugStat("wide")
+
+	e.ToggleDebugOverlay()
+	if !e.IsDebugOverlayVisible() {
+		t.Errorf("[1] IsDebugOverlayVisible Error exp:true got:false")
+	}
+	scene := e.GetSceneManager().GetSceneByName(engine.DebugOverlaySceneName)
+	if scene == nil {
+		t.Errorf("[1] GetSceneByName Error exp:IScene got:nil")
+		return
+	}
+	overlay := scene.GetEntityByName(engine.DebugOverlayEntityName)
+	if overlay == nil {
+		t.Errorf("[1] GetEntityByName Error exp:IEntity got:nil")
+		return
+	}
+	overlay.Refresh()
+	canvas := overlay.GetCanvas()
+	if canvas == nil || canvas.Height() < 4 {
+		t.Errorf("[1] Refresh Error exp:canvas got:%+v", canvas)
+		return
+	}
+	// long lines are cut by runes, the last stat is above the graph.
+	if got := canvas.GetRuneAt(api.NewPoint(canvas.Width()-1, canvas.Height()-2)); got != 'é' {
+		t.Errorf("[1] Refresh Error exp:%c got:%c", 'é', got)
+	}
+
+	e.ToggleDebugOverlay()
+	if e.IsDebugOverlayVisible() {
+		t.Errorf("[2] IsDebugOverlayVisible Error exp:false got:true")
+	}
+}
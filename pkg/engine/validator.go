@@ -2,7 +2,13 @@
 // to implement input validators to be used in any Entity.
 package engine
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 // -----------------------------------------------------------------------------
 // Module public types
@@ -43,6 +49,62 @@ func NewValidator(name string, validation Validation, errorStyle *tcell.Style) *
 	return v
 }
 
+// NewIntegerValidator function creates a new Validator instance for strings
+// with an integer number. An empty string is valid.
+func NewIntegerValidator(name string, errorStyle *tcell.Style) *Validator {
+	return NewValidator(name, func(data any, args ...any) error {
+		str, ok := data.(string)
+		if !ok {
+			return fmt.Errorf("%v is not a string", data)
+		}
+		if str == "" {
+			return nil
+		}
+		if _, err := strconv.Atoi(str); err != nil {
+			return fmt.Errorf("%s is not an integer", str)
+		}
+		return nil
+	}, errorStyle)
+}
+
+// NewNumericValidator function creates a new Validator instance for strings
+// with an integer or a decimal number. An empty string is valid.
+func NewNumericValidator(name string, errorStyle *tcell.Style) *Validator {
+	return NewValidator(name, func(data any, args ...any) error {
+		str, ok := data.(string)
+		if !ok {
+			return fmt.Errorf("%v is not a string", data)
+		}
+		if str == "" {
+			return nil
+		}
+		if _, err := strconv.ParseFloat(str, 64); err != nil {
+			return fmt.Errorf("%s is not a number", str)
+		}
+		return nil
+	}, errorStyle)
+}
+
+// NewRegexpValidator function creates a new Validator instance for strings
+// matching the given regular expression. It returns an error if the regular
+// expression is not valid.
+func NewRegexpValidator(name string, pattern string, errorStyle *tcell.Style) (*Validator, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return NewValidator(name, func(data any, args ...any) error {
+		str, ok := data.(string)
+		if !ok {
+			return fmt.Errorf("%v is not a string", data)
+		}
+		if !re.MatchString(str) {
+			return fmt.Errorf("%s does not match %s", str, pattern)
+		}
+		return nil
+	}, errorStyle), nil
+}
+
 // -----------------------------------------------------------------------------
 // Validator public methods
 // -----------------------------------------------------------------------------
@@ -0,0 +1,457 @@
+// catalog.go contains the string catalog used to localize all user-facing
+// text. Every message is identified by an ID and it is defined for every
+// locale in a JSON file. Messages support parameter substitution with the
+// "{name}" format and pluralization by count. A message ID not found in the
+// catalog is used as the text itself, so any hard-coded string is displayed
+// as it is.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// Params type defines parameters to be substituted in a message. Every
+// "{name}" in the message is replaced by the value for the "name" key.
+type Params map[string]any
+
+// PluralRule type defines the function that returns the plural form to be
+// used for the given count.
+type PluralRule func(count int) string
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	DefaultLocale = "en"
+
+	// CountParam is the parameter name for the count in plural messages.
+	CountParam = "count"
+)
+
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// -----------------------------------------------------------------------------
+// Package private variables
+// -----------------------------------------------------------------------------
+
+var (
+	// activeCatalog is the catalog used by all package functions.
+	activeCatalog *Catalog = NewCatalog(DefaultLocale)
+
+	// localeListeners contains all functions called when the locale
+	// changes.
+	localeListeners []func(string)
+
+	// activeMutex protects activeCatalog and localeListeners, because
+	// engines can run in different goroutines.
+	activeMutex sync.RWMutex
+
+	// pluralRules contains plural rules for every language. Any language
+	// not defined uses the default plural rule.
+	pluralRules = map[string]PluralRule{
+		"fr": FrenchPluralRule,
+		"ja": NoPluralRule,
+		"ko": NoPluralRule,
+		"pl": SlavicPluralRule,
+		"ru": SlavicPluralRule,
+		"uk": SlavicPluralRule,
+		"zh": NoPluralRule,
+	}
+)
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// AddLocaleListener function registers a function to be called every time
+// the locale changes with SetLocale function.
+func AddLocaleListener(listener func(string)) {
+	activeMutex.Lock()
+	defer activeMutex.Unlock()
+	localeListeners = append(localeListeners, listener)
+}
+
+// DefaultPluralRule function returns the plural form for languages with one
+// singular form and one plural form.
+func DefaultPluralRule(count int) string {
+	if count == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// FrenchPluralRule function returns the plural form for languages where zero
+// is singular.
+func FrenchPluralRule(count int) string {
+	if count == 0 || count == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// GetCatalog function returns the active catalog.
+func GetCatalog() *Catalog {
+	activeMutex.RLock()
+	defer activeMutex.RUnlock()
+	return activeCatalog
+}
+
+// GetLocale function returns the locale for the active catalog.
+func GetLocale() string {
+	return GetCatalog().GetLocale()
+}
+
+// NoPluralRule function returns the plural form for languages without plural
+// forms.
+func NoPluralRule(count int) string {
+	return PluralOther
+}
+
+// SetCatalog function sets the active catalog and notifies all locale
+// listeners.
+func SetCatalog(catalog *Catalog) {
+	activeMutex.Lock()
+	activeCatalog = catalog
+	activeMutex.Unlock()
+	notifyLocaleListeners(catalog.GetLocale())
+}
+
+// SetLocale function sets the locale for the active catalog and notifies all
+// locale listeners, so any text displayed can be refreshed.
+func SetLocale(locale string) {
+	tools.Logger.WithField("module", "i18n").
+		WithField("function", "SetLocale").
+		Debugf("locale %s", locale)
+	GetCatalog().SetLocale(locale)
+	notifyLocaleListeners(locale)
+}
+
+// SlavicPluralRule function returns the plural form for slavic languages,
+// with different forms for few and many.
+func SlavicPluralRule(count int) string {
+	mod10, mod100 := count%10, count%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	}
+	return PluralMany
+}
+
+// T function returns the message for the given ID in the active locale.
+func T(id string) string {
+	return GetCatalog().Translate(id, nil)
+}
+
+// Tf function returns the message for the given ID in the active locale with
+// all parameters substituted.
+func Tf(id string, params Params) string {
+	return GetCatalog().Translate(id, params)
+}
+
+// Tn function returns the plural form of the message for the given ID and
+// count in the active locale with all parameters substituted.
+func Tn(id string, count int, params Params) string {
+	return GetCatalog().TranslatePlural(id, count, params)
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// language function returns the language for the given locale, "es" for
+// "es-MX" or "es_MX".
+func language(locale string) string {
+	if index := strings.IndexAny(locale, "-_"); index != -1 {
+		return locale[:index]
+	}
+	return locale
+}
+
+// notifyLocaleListeners function calls all locale listeners. Listeners are
+// called without holding the lock, so they can translate any text.
+func notifyLocaleListeners(locale string) {
+	activeMutex.RLock()
+	listeners := append([]func(string){}, localeListeners...)
+	activeMutex.RUnlock()
+	for _, listener := range listeners {
+		listener(locale)
+	}
+}
+
+// substitute function replaces every parameter in the given text.
+func substitute(text string, params Params) string {
+	if len(params) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for key, value := range params {
+		pairs = append(pairs, "{"+key+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// -----------------------------------------------------------------------------
+//
+// Catalog
+//
+// -----------------------------------------------------------------------------
+
+// Catalog structure contains all messages for every locale.
+// messages maps every locale to every message ID, and every message ID to
+// the text for every plural form. Messages without plural forms use the
+// PluralOther form.
+// fallback is the locale used when a message is not defined for the locale.
+// mutex protects all attributes, because the locale can be changed while
+// messages are being translated.
+type Catalog struct {
+	locale   string
+	fallback string
+	messages map[string]map[string]map[string]string
+	plurals  map[string]PluralRule
+	mutex    sync.RWMutex
+}
+
+// NewCatalog function creates a new empty Catalog instance for the given
+// locale.
+func NewCatalog(locale string) *Catalog {
+	return &Catalog{
+		locale:   locale,
+		fallback: DefaultLocale,
+		messages: make(map[string]map[string]map[string]string),
+		plurals:  make(map[string]PluralRule),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Catalog private methods
+// -----------------------------------------------------------------------------
+
+// getPluralRule method returns the plural rule for the given locale.
+func (c *Catalog) getPluralRule(locale string) PluralRule {
+	if rule, ok := c.plurals[locale]; ok {
+		return rule
+	}
+	if rule, ok := c.plurals[language(locale)]; ok {
+		return rule
+	}
+	if rule, ok := pluralRules[language(locale)]; ok {
+		return rule
+	}
+	return DefaultPluralRule
+}
+
+// lookup method returns the plural forms for the given message ID and the
+// locale where they were found. The locale is looked up, then its language
+// and then the fallback locale.
+func (c *Catalog) lookup(id string) (map[string]string, string) {
+	for _, locale := range []string{c.locale, language(c.locale), c.fallback} {
+		if forms, ok := c.messages[locale][id]; ok {
+			return forms, locale
+		}
+	}
+	return nil, ""
+}
+
+// -----------------------------------------------------------------------------
+// Catalog public methods
+// -----------------------------------------------------------------------------
+
+// AddMessage method adds the message for the given locale and ID.
+func (c *Catalog) AddMessage(locale string, id string, text string) {
+	c.AddPluralMessage(locale, id, map[string]string{PluralOther: text})
+}
+
+// AddPluralMessage method adds the message for the given locale and ID with
+// the text for every plural form.
+func (c *Catalog) AddPluralMessage(locale string, id string, forms map[string]string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.messages[locale]; !ok {
+		c.messages[locale] = make(map[string]map[string]string)
+	}
+	c.messages[locale][id] = forms
+}
+
+// GetFallback method returns the fallback locale.
+func (c *Catalog) GetFallback() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.fallback
+}
+
+// GetLocale method returns the catalog locale.
+func (c *Catalog) GetLocale() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.locale
+}
+
+// GetLocales method returns all locales with any message sorted by name.
+func (c *Catalog) GetLocales() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	result := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		result = append(result, locale)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// HasMessage method checks if the given message ID is defined for the
+// catalog locale or the fallback locale.
+func (c *Catalog) HasMessage(id string) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	forms, _ := c.lookup(id)
+	return forms != nil
+}
+
+// LoadFile method loads all messages for the given locale from the given
+// JSON file.
+func (c *Catalog) LoadFile(locale string, filename string) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return c.LoadJSON(locale, content)
+}
+
+// LoadFS method loads all messages from every "<locale>.json" file in the
+// given directory of the given file system, like an embed.FS.
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err := c.LoadJSON(strings.TrimSuffix(entry.Name(), ".json"), content); err != nil {
+			return fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// LoadJSON method loads all messages for the given locale from the given JSON
+// content. Every message is a string or an object with the text for every
+// plural form:
+//
+//	{
+//	    "menu.file": "File",
+//	    "items": {"one": "{count} item", "other": "{count} items"}
+//	}
+func (c *Catalog) LoadJSON(locale string, data []byte) error {
+	var content map[string]json.RawMessage
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+	for id, raw := range content {
+		var text string
+		if err := json.Unmarshal(raw, &text); err == nil {
+			c.AddMessage(locale, id, text)
+			continue
+		}
+		var forms map[string]string
+		if err := json.Unmarshal(raw, &forms); err != nil {
+			return fmt.Errorf("message %s: %w", id, err)
+		}
+		c.AddPluralMessage(locale, id, forms)
+	}
+	return nil
+}
+
+// SetFallback method sets the fallback locale.
+func (c *Catalog) SetFallback(locale string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.fallback = locale
+}
+
+// SetLocale method sets the catalog locale.
+func (c *Catalog) SetLocale(locale string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.locale = locale
+}
+
+// SetPluralRule method sets the plural rule for the given locale or
+// language.
+func (c *Catalog) SetPluralRule(locale string, rule PluralRule) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.plurals[locale] = rule
+}
+
+// Translate method returns the message for the given ID with all parameters
+// substituted. The ID is used as the message if it is not defined.
+func (c *Catalog) Translate(id string, params Params) string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	text := id
+	if forms, _ := c.lookup(id); forms != nil {
+		if other, ok := forms[PluralOther]; ok {
+			text = other
+		} else if one, ok := forms[PluralOne]; ok {
+			text = one
+		}
+	}
+	return substitute(text, params)
+}
+
+// TranslatePlural method returns the plural form of the message for the
+// given ID and count with all parameters substituted. The count is available
+// as the "count" parameter. The "zero" form is used for zero if it is
+// defined.
+func (c *Catalog) TranslatePlural(id string, count int, params Params) string {
+	allParams := Params{CountParam: count}
+	for key, value := range params {
+		allParams[key] = value
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	forms, locale := c.lookup(id)
+	if forms == nil {
+		return substitute(id, allParams)
+	}
+	text, ok := "", false
+	if count == 0 {
+		text, ok = forms[PluralZero]
+	}
+	if !ok {
+		text, ok = forms[c.getPluralRule(locale)(count)]
+	}
+	if !ok {
+		text = forms[PluralOther]
+	}
+	return substitute(text, allParams)
+}
@@ -0,0 +1,165 @@
+package i18n_test
+
+import (
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jrecuero/thengine/pkg/i18n"
+)
+
+func TestCatalogTranslate(t *testing.T) {
+	catalog := i18n.NewCatalog("es-MX")
+	catalog.AddMessage("en", "menu.file", "File")
+	catalog.AddMessage("en", "greeting", "Hello {name}!")
+	catalog.AddMessage("es", "menu.file", "Archivo")
+	catalog.AddMessage("es-MX", "greeting", "¡Qué onda {name}!")
+
+	cases := []struct {
+		id     string
+		params i18n.Params
+		exp    string
+	}{
+		{"menu.file", nil, "Archivo"},
+		{"greeting", i18n.Params{"name": "Ana"}, "¡Qué onda Ana!"},
+		{"Not Translated", nil, "Not Translated"},
+		{"{count} left", i18n.Params{"count": 3}, "3 left"},
+	}
+	for i, c := range cases {
+		if got := catalog.Translate(c.id, c.params); got != c.exp {
+			t.Errorf("[%d] Translate Error exp:%s got:%s", i, c.exp, got)
+		}
+	}
+
+	catalog.SetLocale("fr")
+	if got := catalog.Translate("menu.file", nil); got != "File" {
+		t.Errorf("[4] Translate Error exp:%s got:%s", "File", got)
+	}
+	if !catalog.HasMessage("menu.file") || catalog.HasMessage("menu.edit") {
+		t.Errorf("[5] HasMessage Error")
+	}
+}
+
+func TestCatalogTranslatePlural(t *testing.T) {
+	catalog := i18n.NewCatalog("en")
+	catalog.AddPluralMessage("en", "items", map[string]string{
+		i18n.PluralZero:  "no items",
+		i18n.PluralOne:   "{count} item",
+		i18n.PluralOther: "{count} items in {place}",
+	})
+	catalog.AddPluralMessage("ru", "items", map[string]string{
+		i18n.PluralOne:   "{count} предмет",
+		i18n.PluralFew:   "{count} предмета",
+		i18n.PluralMany:  "{count} предметов",
+		i18n.PluralOther: "{count} предмета",
+	})
+	params := i18n.Params{"place": "bag"}
+	cases := []struct {
+		locale string
+		count  int
+		exp    string
+	}{
+		{"en", 0, "no items"},
+		{"en", 1, "1 item"},
+		{"en", 5, "5 items in bag"},
+		{"ru", 1, "1 предмет"},
+		{"ru", 3, "3 предмета"},
+		{"ru", 11, "11 предметов"},
+		{"ru", 21, "21 предмет"},
+		{"ru", 0, "0 предметов"},
+	}
+	for i, c := range cases {
+		catalog.SetLocale(c.locale)
+		if got := catalog.TranslatePlural("items", c.count, params); got != c.exp {
+			t.Errorf("[%d] TranslatePlural Error exp:%s got:%s", i, c.exp, got)
+		}
+	}
+
+	catalog.SetPluralRule("en", func(int) string { return i18n.PluralOne })
+	catalog.SetLocale("en")
+	if got := catalog.TranslatePlural("items", 7, nil); got != "7 item" {
+		t.Errorf("[%d] TranslatePlural Error exp:%s got:%s", len(cases), "7 item", got)
+	}
+}
+
+func TestCatalogLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json":   {Data: []byte(`{"yes": "Yes", "apples": {"one": "an apple", "other": "{count} apples"}}`)},
+		"locales/es.json":   {Data: []byte(`{"yes": "Sí", "apples": {"one": "una manzana", "other": "{count} manzanas"}}`)},
+		"locales/notes.txt": {Data: []byte(`not a locale`)},
+	}
+	catalog := i18n.NewCatalog("es")
+	if err := catalog.LoadFS(fsys, "locales"); err != nil {
+		t.Errorf("[1] LoadFS Error exp:nil got:%s", err)
+		return
+	}
+	if got := catalog.GetLocales(); len(got) != 2 || got[0] != "en" || got[1] != "es" {
+		t.Errorf("[1] GetLocales Error exp:[en es] got:%v", got)
+	}
+	if got := catalog.Translate("yes", nil); got != "Sí" {
+		t.Errorf("[1] Translate Error exp:%s got:%s", "Sí", got)
+	}
+	if got := catalog.TranslatePlural("apples", 2, nil); got != "2 manzanas" {
+		t.Errorf("[1] TranslatePlural Error exp:%s got:%s", "2 manzanas", got)
+	}
+
+	if err := catalog.LoadJSON("de", []byte(`{"yes": ["Ja"]}`)); err == nil {
+		t.Errorf("[2] LoadJSON Error exp:error got:nil")
+	}
+	if err := catalog.LoadJSON("de", []byte(`not json`)); err == nil {
+		t.Errorf("[3] LoadJSON Error exp:error got:nil")
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	previous := i18n.GetCatalog()
+	defer i18n.SetCatalog(previous)
+
+	catalog := i18n.NewCatalog("en")
+	catalog.AddMessage("en", "ok", "OK")
+	catalog.AddMessage("es", "ok", "Vale")
+	i18n.SetCatalog(catalog)
+
+	locales := []string{}
+	i18n.AddLocaleListener(func(locale string) {
+		locales = append(locales, locale)
+	})
+	i18n.SetLocale("es")
+	if got := i18n.T("ok"); got != "Vale" {
+		t.Errorf("[1] T Error exp:%s got:%s", "Vale", got)
+	}
+	if got := i18n.GetLocale(); got != "es" {
+		t.Errorf("[1] GetLocale Error exp:%s got:%s", "es", got)
+	}
+	if len(locales) != 1 || locales[0] != "es" {
+		t.Errorf("[1] AddLocaleListener Error exp:[es] got:%v", locales)
+	}
+	if got := i18n.Tn("ok", 2, nil); got != "Vale" {
+		t.Errorf("[2] Tn Error exp:%s got:%s", "Vale", got)
+	}
+}
+
+func TestSetLocaleConcurrent(t *testing.T) {
+	previous := i18n.GetCatalog()
+	defer i18n.SetCatalog(previous)
+
+	catalog := i18n.NewCatalog("en")
+	catalog.AddMessage("en", "ok", "OK")
+	catalog.AddMessage("es", "ok", "Vale")
+	i18n.SetCatalog(catalog)
+
+	// engines running in different goroutines can change the locale while
+	// other engines translate messages.
+	var wg sync.WaitGroup
+	for i, locale := range []string{"en", "es", "en", "es"} {
+		wg.Add(1)
+		go func(i int, locale string) {
+			defer wg.Done()
+			i18n.SetLocale(locale)
+			if got := i18n.T("ok"); got != "OK" && got != "Vale" {
+				t.Errorf("[%d] T Error exp:OK|Vale got:%s", i, got)
+			}
+		}(i, locale)
+	}
+	wg.Wait()
+}
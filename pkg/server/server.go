@@ -0,0 +1,342 @@
+// server.go contains the server used to play any engine based application
+// from multiple terminal clients over TCP, telnet style. Every connection
+// gets its own tcell screen, engine instance and scene set, so several
+// engines run in the same process, every one in its own goroutine.
+//
+// Some state is process-wide and it is shared by all sessions: the i18n
+// catalog and locale, the widgets theme and the prefab manager. They are safe
+// to be used from every session goroutine, but they have to be loaded before
+// serving and handled as read-only while serving, because any change, like a
+// new locale or theme, is seen by every connected client.
+package server
+
+import (
+	"fmt"
+	"net"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/terminfo"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// SessionHandler type defines the function called for every new session,
+// which creates all scenes for the session engine. The engine is run by the
+// server after the function returns without any error. The handler must not
+// change any process-wide state, like the locale or the theme.
+type SessionHandler func(*Session) error
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	// DefaultTerminalType is the terminal type used when the client does not
+	// negotiate any terminal type or it is not known.
+	DefaultTerminalType = "xterm-256color"
+
+	// DefaultNegotiationTimeout is the time to wait for the client to send
+	// the window size and the terminal type.
+	DefaultNegotiationTimeout = 2 * time.Second
+)
+
+// -----------------------------------------------------------------------------
+//
+// Session
+//
+// -----------------------------------------------------------------------------
+
+// Session structure contains all resources for every client connected.
+type Session struct {
+	id     int
+	conn   net.Conn
+	tty    *TelnetTty
+	screen tcell.Screen
+	engine *engine.Engine
+}
+
+// -----------------------------------------------------------------------------
+// Session public methods
+// -----------------------------------------------------------------------------
+
+// Close method closes the session connection, which ends the session engine.
+func (s *Session) Close() error {
+	return s.conn.Close()
+}
+
+// GetEngine method returns the session engine. All session scenes have to be
+// created with this engine.
+func (s *Session) GetEngine() *engine.Engine {
+	return s.engine
+}
+
+// GetID method returns the session identifier.
+func (s *Session) GetID() int {
+	return s.id
+}
+
+// GetRemoteAddr method returns the client address.
+func (s *Session) GetRemoteAddr() net.Addr {
+	return s.conn.RemoteAddr()
+}
+
+// GetScreen method returns the session screen.
+func (s *Session) GetScreen() tcell.Screen {
+	return s.screen
+}
+
+// GetTty method returns the session terminal.
+func (s *Session) GetTty() *TelnetTty {
+	return s.tty
+}
+
+// -----------------------------------------------------------------------------
+//
+// Server
+//
+// -----------------------------------------------------------------------------
+
+// Server structure defines the server for multiple terminal clients.
+// fps is the frames per second every session engine runs at.
+type Server struct {
+	address            string
+	fps                float64
+	handler            SessionHandler
+	listener           net.Listener
+	sessions           map[int]*Session
+	nextID             int
+	negotiationTimeout time.Duration
+	terminalType       string
+	closed             bool
+	mutex              sync.Mutex
+	wg                 sync.WaitGroup
+}
+
+// NewServer function creates a new Server instance listening at the given
+// address. The given handler is called for every new session.
+func NewServer(address string, fps float64, handler SessionHandler) *Server {
+	return &Server{
+		address:            address,
+		fps:                fps,
+		handler:            handler,
+		sessions:           make(map[int]*Session),
+		nextID:             1,
+		negotiationTimeout: DefaultNegotiationTimeout,
+		terminalType:       DefaultTerminalType,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Server private methods
+// -----------------------------------------------------------------------------
+
+// addSession method adds a new session for the given connection. It returns
+// nil if the server is closed.
+func (s *Server) addSession(conn net.Conn) *Session {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return nil
+	}
+	session := &Session{
+		id:   s.nextID,
+		conn: conn,
+		tty:  NewTelnetTty(conn),
+	}
+	s.sessions[session.id] = session
+	s.nextID++
+	s.wg.Add(1)
+	return session
+}
+
+// lookupTerminfo method returns the terminal description for the terminal
+// type negotiated by the client or the server default terminal type.
+func (s *Server) lookupTerminfo(tty *TelnetTty) (*terminfo.Terminfo, error) {
+	if termType := tty.GetTerminalType(); termType != "" {
+		if ti, err := tcell.LookupTerminfo(termType); err == nil {
+			return ti, nil
+		}
+	}
+	return tcell.LookupTerminfo(s.terminalType)
+}
+
+// removeSession method removes the given session.
+func (s *Server) removeSession(session *Session) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.sessions, session.id)
+}
+
+// runSession method runs the given session until the client disconnects or
+// the session engine ends. Any panic is logged and it only ends the session.
+func (s *Server) runSession(session *Session) {
+	defer s.wg.Done()
+	defer s.removeSession(session)
+	defer session.conn.Close()
+	defer func() {
+		if err := recover(); err != nil {
+			tools.Logger.WithField("module", "server").
+				WithField("struct", "Server").
+				WithField("method", "runSession").
+				Errorf("session %d panic %+v\n%s", session.id, err, string(debug.Stack()))
+		}
+	}()
+	if err := s.startSession(session); err != nil {
+		tools.Logger.WithField("module", "server").
+			WithField("struct", "Server").
+			WithField("method", "runSession").
+			Errorf("session %d: %s", session.id, err.Error())
+		return
+	}
+	tools.Logger.WithField("module", "server").
+		WithField("struct", "Server").
+		WithField("method", "runSession").
+		Infof("session %d started from %s", session.id, session.GetRemoteAddr())
+	session.engine.Init()
+	session.engine.Start()
+	if err := session.engine.Run(s.fps); err != nil {
+		tools.Logger.WithField("module", "server").
+			WithField("struct", "Server").
+			WithField("method", "runSession").
+			Errorf("session %d: %s", session.id, err.Error())
+	}
+	tools.Logger.WithField("module", "server").
+		WithField("struct", "Server").
+		WithField("method", "runSession").
+		Infof("session %d ended", session.id)
+}
+
+// startSession method negotiates the terminal with the client and creates
+// the screen and the engine for the given session.
+func (s *Server) startSession(session *Session) error {
+	if err := session.tty.Negotiate(s.negotiationTimeout); err != nil {
+		return err
+	}
+	ti, err := s.lookupTerminfo(session.tty)
+	if err != nil {
+		return err
+	}
+	session.screen, err = tcell.NewTerminfoScreenFromTtyTerminfo(session.tty, ti)
+	if err != nil {
+		return err
+	}
+	session.engine = engine.NewEngine()
+	session.engine.SetScreen(session.screen)
+	session.engine.InitResources()
+	if err := s.handler(session); err != nil {
+		session.screen.Fini()
+		return err
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Server public methods
+// -----------------------------------------------------------------------------
+
+// Close method stops accepting new connections and closes all sessions,
+// waiting for all of them to end.
+func (s *Server) Close() error {
+	s.mutex.Lock()
+	s.closed = true
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	for _, session := range s.sessions {
+		session.Close()
+	}
+	s.mutex.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+// GetAddr method returns the address the server is listening at, or nil if
+// it is not listening.
+func (s *Server) GetAddr() net.Addr {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// GetSessions method returns all sessions sorted by identifier.
+func (s *Server) GetSessions() []*Session {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	result := make([]*Session, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		result = append(result, session)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+// ListenAndServe method listens at the server address and serves every
+// connection.
+func (s *Server) ListenAndServe() error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve method accepts connections from the given listener, running a new
+// session for every one. It returns when the server is closed.
+func (s *Server) Serve(listener net.Listener) error {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		listener.Close()
+		return fmt.Errorf("server is closed")
+	}
+	s.listener = listener
+	s.mutex.Unlock()
+	tools.Logger.WithField("module", "server").
+		WithField("struct", "Server").
+		WithField("method", "Serve").
+		Infof("listening at %s", listener.Addr())
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			s.mutex.Lock()
+			closed := s.closed
+			s.mutex.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		session := s.addSession(conn)
+		if session == nil {
+			conn.Close()
+			return nil
+		}
+		go s.runSession(session)
+	}
+}
+
+// SetNegotiationTimeout method sets the time to wait for the client to send
+// the window size and the terminal type.
+func (s *Server) SetNegotiationTimeout(timeout time.Duration) {
+	s.negotiationTimeout = timeout
+}
+
+// SetTerminalType method sets the terminal type used when the client does not
+// negotiate any known terminal type.
+func (s *Server) SetTerminalType(terminalType string) {
+	s.terminalType = terminalType
+}
@@ -0,0 +1,135 @@
+package server_test
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/server"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+// telnet commands sent by the test client.
+var (
+	clientWillNAWS  = []byte{255, 251, 31}
+	clientNAWS      = []byte{255, 250, 31, 0, 40, 0, 10, 255, 240}
+	clientWillTType = []byte{255, 251, 24}
+	clientTType     = append(append([]byte{255, 250, 24, 0}, []byte("XTERM")...), 255, 240)
+)
+
+// readUntil function reads from the given connection until the given string
+// is received or the timeout expires.
+func readUntil(conn net.Conn, str string, timeout time.Duration) bool {
+	received := []byte{}
+	buffer := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	defer conn.SetReadDeadline(time.Time{})
+	for {
+		n, err := conn.Read(buffer)
+		received = append(received, buffer[:n]...)
+		if bytes.Contains(received, []byte(str)) {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
+
+// waitFor function waits until the given condition is true or the timeout
+// expires.
+func waitFor(condition func() bool, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return condition()
+}
+
+func TestServerSessions(t *testing.T) {
+	sessions := make(chan *server.Session, 2)
+	handler := func(session *server.Session) error {
+		e := session.GetEngine()
+		width, height := session.GetScreen().Size()
+		scene := e.NewScene("scene/main/1", engine.NewCamera(nil, api.NewSize(width, height)))
+		label := strings.Repeat("#", session.GetID())
+		scene.AddEntity(widgets.NewText("text/1", api.NewPoint(0, 0), api.NewSize(10, 1), nil, "hello"+label))
+		e.GetSceneManager().AddScene(scene)
+		e.GetSceneManager().SetSceneAsActive(scene)
+		e.GetSceneManager().SetSceneAsVisible(scene)
+		sessions <- session
+		return nil
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("[0] Listen Error exp:nil got:%s", err)
+	}
+	srv := server.NewServer("", 30, handler)
+	srv.SetNegotiationTimeout(time.Second)
+	go srv.Serve(listener)
+	defer srv.Close()
+
+	// first client negotiates the window size and the terminal type.
+	conn1, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("[1] Dial Error exp:nil got:%s", err)
+	}
+	defer conn1.Close()
+	for _, command := range [][]byte{clientWillNAWS, clientNAWS, clientWillTType, clientTType} {
+		conn1.Write(command)
+	}
+	session1 := <-sessions
+	if width, height := session1.GetScreen().Size(); width != 40 || height != 10 {
+		t.Errorf("[1] Size Error exp:40x10 got:%dx%d", width, height)
+	}
+	if got := session1.GetTty().GetTerminalType(); got != "xterm" {
+		t.Errorf("[1] GetTerminalType Error exp:xterm got:%s", got)
+	}
+	if !readUntil(conn1, "hello#", 2*time.Second) {
+		t.Errorf("[1] Read Error exp:hello# got:nothing")
+	}
+
+	// second client does not negotiate anything, so default values are used
+	// after the negotiation timeout.
+	conn2, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("[2] Dial Error exp:nil got:%s", err)
+	}
+	session2 := <-sessions
+	if width, height := session2.GetScreen().Size(); width != server.TelnetDefaultWidth || height != server.TelnetDefaultHeight {
+		t.Errorf("[2] Size Error exp:%dx%d got:%dx%d", server.TelnetDefaultWidth,
+			server.TelnetDefaultHeight, width, height)
+	}
+	if !readUntil(conn2, "hello##", 2*time.Second) {
+		t.Errorf("[2] Read Error exp:hello## got:nothing")
+	}
+	if session1.GetEngine() == session2.GetEngine() {
+		t.Errorf("[2] GetEngine Error exp:different engines got:same engine")
+	}
+	if got := len(srv.GetSessions()); got != 2 {
+		t.Errorf("[2] GetSessions Error exp:2 got:%d", got)
+	}
+
+	// disconnecting a client ends only its session.
+	conn2.Close()
+	if !waitFor(func() bool { return len(srv.GetSessions()) == 1 }, 2*time.Second) {
+		t.Errorf("[3] GetSessions Error exp:1 got:%d", len(srv.GetSessions()))
+	}
+	if got := srv.GetSessions(); len(got) == 1 && got[0] != session1 {
+		t.Errorf("[3] GetSessions Error exp:session %d got:session %d", session1.GetID(), got[0].GetID())
+	}
+
+	if err := srv.Close(); err != nil {
+		t.Errorf("[4] Close Error exp:nil got:%s", err)
+	}
+	if got := len(srv.GetSessions()); got != 0 {
+		t.Errorf("[4] GetSessions Error exp:0 got:%d", got)
+	}
+}
@@ -0,0 +1,376 @@
+// telnet.go contains the telnet terminal used by every connection to the
+// server. It implements the tcell.Tty interface on top of a network
+// connection, negotiating the terminal size (NAWS) and the terminal type
+// (TTYPE) with the client and removing any telnet command from the input.
+package server
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	// TelnetDefaultWidth and TelnetDefaultHeight are the terminal size used
+	// when the client does not negotiate the window size.
+	TelnetDefaultWidth  = 80
+	TelnetDefaultHeight = 24
+)
+
+// -----------------------------------------------------------------------------
+// Package private constants
+// -----------------------------------------------------------------------------
+
+// telnet commands.
+const (
+	telnetSE   byte = 240
+	telnetSB   byte = 250
+	telnetWILL byte = 251
+	telnetWONT byte = 252
+	telnetDO   byte = 253
+	telnetDONT byte = 254
+	telnetIAC  byte = 255
+)
+
+// telnet options.
+const (
+	telnetOptionEcho  byte = 1
+	telnetOptionSGA   byte = 3
+	telnetOptionTType byte = 24
+	telnetOptionNAWS  byte = 31
+)
+
+// telnet terminal type subnegotiation commands.
+const (
+	telnetTTypeIS   byte = 0
+	telnetTTypeSEND byte = 1
+)
+
+// telnet parser states.
+const (
+	telnetStateData = iota
+	telnetStateIAC
+	telnetStateOption
+	telnetStateSB
+	telnetStateSBIAC
+)
+
+// -----------------------------------------------------------------------------
+//
+// TelnetTty
+//
+// -----------------------------------------------------------------------------
+
+// TelnetTty structure defines a terminal for a telnet connection.
+// pending contains input data already parsed and not read yet.
+// verb is the command (WILL, WONT, DO or DONT) for the option being parsed.
+// lastCR is set when the last data byte was a carriage return, so the NUL
+// or LF sent by telnet clients after it is removed.
+type TelnetTty struct {
+	conn     net.Conn
+	mutex    sync.Mutex
+	width    int
+	height   int
+	termType string
+	resizeCb func()
+	pending  []byte
+	state    int
+	verb     byte
+	sbData   []byte
+	lastCR   bool
+	gotNAWS  bool
+	gotTType bool
+	noNAWS   bool
+	noTType  bool
+}
+
+// NewTelnetTty function creates a new TelnetTty instance for the given
+// connection.
+func NewTelnetTty(conn net.Conn) *TelnetTty {
+	return &TelnetTty{
+		conn:   conn,
+		width:  TelnetDefaultWidth,
+		height: TelnetDefaultHeight,
+		state:  telnetStateData,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TelnetTty private methods
+// -----------------------------------------------------------------------------
+
+// handleOption method answers any option the client wants to enable or asks
+// the server to enable.
+func (t *TelnetTty) handleOption(verb byte, option byte) {
+	switch verb {
+	case telnetWILL:
+		switch option {
+		case telnetOptionNAWS:
+			// the size is received in the following subnegotiation.
+		case telnetOptionTType:
+			t.send(telnetIAC, telnetSB, telnetOptionTType, telnetTTypeSEND, telnetIAC, telnetSE)
+		default:
+			t.send(telnetIAC, telnetDONT, option)
+		}
+	case telnetWONT:
+		t.mutex.Lock()
+		switch option {
+		case telnetOptionNAWS:
+			t.noNAWS = true
+		case telnetOptionTType:
+			t.noTType = true
+		}
+		t.mutex.Unlock()
+	case telnetDO:
+		if option != telnetOptionEcho && option != telnetOptionSGA {
+			t.send(telnetIAC, telnetWONT, option)
+		}
+	case telnetDONT:
+	}
+}
+
+// handleSubnegotiation method handles the window size and the terminal type
+// sent by the client.
+func (t *TelnetTty) handleSubnegotiation(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	switch data[0] {
+	case telnetOptionNAWS:
+		if len(data) < 5 {
+			return
+		}
+		width := int(data[1])<<8 | int(data[2])
+		height := int(data[3])<<8 | int(data[4])
+		t.mutex.Lock()
+		if width != 0 && height != 0 {
+			t.width, t.height = width, height
+		}
+		t.gotNAWS = true
+		resizeCb := t.resizeCb
+		t.mutex.Unlock()
+		tools.Logger.WithField("module", "telnet").
+			WithField("struct", "TelnetTty").
+			WithField("method", "handleSubnegotiation").
+			Debugf("window size %dx%d", width, height)
+		if resizeCb != nil {
+			resizeCb()
+		}
+	case telnetOptionTType:
+		if len(data) < 2 || data[1] != telnetTTypeIS {
+			return
+		}
+		t.mutex.Lock()
+		t.termType = strings.ToLower(string(data[2:]))
+		t.gotTType = true
+		t.mutex.Unlock()
+	}
+}
+
+// isNegotiated method checks if the window size and the terminal type have
+// been received or refused by the client.
+func (t *TelnetTty) isNegotiated() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return (t.gotNAWS || t.noNAWS) && (t.gotTType || t.noTType)
+}
+
+// parse method parses the given input and returns only data bytes, handling
+// every telnet command found.
+func (t *TelnetTty) parse(input []byte) []byte {
+	result := make([]byte, 0, len(input))
+	for _, b := range input {
+		switch t.state {
+		case telnetStateData:
+			if b == telnetIAC {
+				t.state = telnetStateIAC
+				continue
+			}
+			if t.lastCR && (b == 0 || b == '\n') {
+				t.lastCR = false
+				continue
+			}
+			t.lastCR = b == '\r'
+			result = append(result, b)
+		case telnetStateIAC:
+			switch b {
+			case telnetIAC:
+				result = append(result, b)
+				t.state = telnetStateData
+			case telnetWILL, telnetWONT, telnetDO, telnetDONT:
+				t.verb = b
+				t.state = telnetStateOption
+			case telnetSB:
+				t.sbData = t.sbData[:0]
+				t.state = telnetStateSB
+			default:
+				t.state = telnetStateData
+			}
+		case telnetStateOption:
+			t.handleOption(t.verb, b)
+			t.state = telnetStateData
+		case telnetStateSB:
+			if b == telnetIAC {
+				t.state = telnetStateSBIAC
+				continue
+			}
+			t.sbData = append(t.sbData, b)
+		case telnetStateSBIAC:
+			switch b {
+			case telnetSE:
+				t.handleSubnegotiation(t.sbData)
+				t.state = telnetStateData
+			case telnetIAC:
+				t.sbData = append(t.sbData, b)
+				t.state = telnetStateSB
+			default:
+				t.state = telnetStateSB
+			}
+		}
+	}
+	return result
+}
+
+// readInput method reads from the connection and parses the input.
+func (t *TelnetTty) readInput() error {
+	buffer := make([]byte, 512)
+	n, err := t.conn.Read(buffer)
+	if n > 0 {
+		data := t.parse(buffer[:n])
+		t.mutex.Lock()
+		t.pending = append(t.pending, data...)
+		t.mutex.Unlock()
+	}
+	return err
+}
+
+// send method sends the given telnet command to the client.
+func (t *TelnetTty) send(command ...byte) {
+	t.conn.Write(command)
+}
+
+// -----------------------------------------------------------------------------
+// TelnetTty public methods
+// -----------------------------------------------------------------------------
+
+// Close method closes the connection.
+func (t *TelnetTty) Close() error {
+	return t.conn.Close()
+}
+
+// Drain method wakes up any blocked Read call.
+func (t *TelnetTty) Drain() error {
+	return t.conn.SetReadDeadline(time.Now())
+}
+
+// GetTerminalType method returns the terminal type sent by the client, in
+// lower case, or an empty string if it was not negotiated.
+func (t *TelnetTty) GetTerminalType() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.termType
+}
+
+// Negotiate method asks the client to operate in character mode and to send
+// its window size and terminal type. It waits for the answer until the
+// given timeout expires, in which case default values are used.
+func (t *TelnetTty) Negotiate(timeout time.Duration) error {
+	t.send(telnetIAC, telnetWILL, telnetOptionEcho,
+		telnetIAC, telnetWILL, telnetOptionSGA,
+		telnetIAC, telnetDO, telnetOptionSGA,
+		telnetIAC, telnetDO, telnetOptionNAWS,
+		telnetIAC, telnetDO, telnetOptionTType)
+	deadline := time.Now().Add(timeout)
+	defer t.conn.SetReadDeadline(time.Time{})
+	for !t.isNegotiated() {
+		if err := t.conn.SetReadDeadline(deadline); err != nil {
+			return err
+		}
+		if err := t.readInput(); err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyResize method registers the function called when the client sends a
+// new window size.
+func (t *TelnetTty) NotifyResize(cb func()) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.resizeCb = cb
+}
+
+// Read method reads data from the connection without any telnet command.
+func (t *TelnetTty) Read(p []byte) (int, error) {
+	for {
+		t.mutex.Lock()
+		if len(t.pending) != 0 {
+			n := copy(p, t.pending)
+			t.pending = t.pending[n:]
+			t.mutex.Unlock()
+			return n, nil
+		}
+		t.mutex.Unlock()
+		if err := t.readInput(); err != nil {
+			t.mutex.Lock()
+			empty := len(t.pending) == 0
+			t.mutex.Unlock()
+			if empty {
+				return 0, err
+			}
+		}
+	}
+}
+
+// Start method prepares the connection to be read.
+func (t *TelnetTty) Start() error {
+	return t.conn.SetReadDeadline(time.Time{})
+}
+
+// Stop method stops using the connection. The connection is not closed.
+func (t *TelnetTty) Stop() error {
+	return nil
+}
+
+// WindowSize method returns the window size sent by the client.
+func (t *TelnetTty) WindowSize() (tcell.WindowSize, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return tcell.WindowSize{Width: t.width, Height: t.height}, nil
+}
+
+// Write method writes the given data to the connection, escaping any byte
+// that could be taken as a telnet command.
+func (t *TelnetTty) Write(p []byte) (int, error) {
+	for i, b := range p {
+		if b == telnetIAC {
+			escaped := make([]byte, 0, len(p)+1)
+			escaped = append(escaped, p[:i]...)
+			for _, b := range p[i:] {
+				if b == telnetIAC {
+					escaped = append(escaped, telnetIAC)
+				}
+				escaped = append(escaped, b)
+			}
+			if _, err := t.conn.Write(escaped); err != nil {
+				return 0, err
+			}
+			return len(p), nil
+		}
+	}
+	return t.conn.Write(p)
+}
+
+var _ tcell.Tty = (*TelnetTty)(nil)
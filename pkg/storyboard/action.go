@@ -2,6 +2,8 @@
 // storyboard event.
 package storyboard
 
+import "github.com/jrecuero/thengine/pkg/i18n"
+
 // -----------------------------------------------------------------------------
 //
 // IAction
@@ -38,7 +40,7 @@ func (a *Action) GetConsequences() map[string]any {
 }
 
 func (a *Action) GetDescription() string {
-	return a.description
+	return i18n.T(a.description)
 }
 
 func (a *Action) GetName() string {
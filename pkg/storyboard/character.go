@@ -2,6 +2,8 @@
 // involved in the storyboard.
 package storyboard
 
+import "github.com/jrecuero/thengine/pkg/i18n"
+
 // -----------------------------------------------------------------------------
 //
 // ICharacter
@@ -40,7 +42,7 @@ func (c *Character) GetAge() int {
 }
 
 func (c *Character) GetDescription() string {
-	return c.description
+	return i18n.T(c.description)
 }
 
 func (c *Character) GetName() string {
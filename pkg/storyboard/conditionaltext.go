@@ -2,6 +2,8 @@
 // related condition when text has to be applied.
 package storyboard
 
+import "github.com/jrecuero/thengine/pkg/i18n"
+
 // -----------------------------------------------------------------------------
 //
 // IConditionalText
@@ -50,7 +52,7 @@ func (c *ConditionalText) GetCondition() ICondition {
 }
 
 func (c *ConditionalText) GetText() string {
-	return c.text
+	return i18n.T(c.text)
 }
 
 func (c *ConditionalText) SetCondition(condition ICondition) {
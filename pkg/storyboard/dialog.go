@@ -2,6 +2,8 @@
 // included in any event in the storyboard.
 package storyboard
 
+import "github.com/jrecuero/thengine/pkg/i18n"
+
 // -----------------------------------------------------------------------------
 //
 // IDialog
@@ -35,7 +37,7 @@ func (d *Dialog) GetSpeaker() ICharacter {
 }
 
 func (d *Dialog) GetText() string {
-	return d.text
+	return i18n.T(d.text)
 }
 
 func (d *Dialog) SetSpeaker(speaker ICharacter) {
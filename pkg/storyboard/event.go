@@ -2,6 +2,8 @@
 // storyboard.
 package storyboard
 
+import "github.com/jrecuero/thengine/pkg/i18n"
+
 // -----------------------------------------------------------------------------
 //
 // IEvent
@@ -41,7 +43,7 @@ func (e *Event) GetActions() []IAction {
 }
 
 func (e *Event) GetDesription() string {
-	return e.desription
+	return i18n.T(e.desription)
 }
 
 func (e *Event) GetDialogs() []IDialog {
@@ -49,7 +51,7 @@ func (e *Event) GetDialogs() []IDialog {
 }
 
 func (e *Event) GetTitle() string {
-	return e.title
+	return i18n.T(e.title)
 }
 
 func (e *Event) SetActions(actions []IAction) {
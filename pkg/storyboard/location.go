@@ -2,6 +2,8 @@
 // the storyboard.
 package storyboard
 
+import "github.com/jrecuero/thengine/pkg/i18n"
+
 // -----------------------------------------------------------------------------
 //
 // ILocation
@@ -34,7 +36,7 @@ type Location struct {
 // -----------------------------------------------------------------------------
 
 func (l *Location) GetDescription() string {
-	return l.description
+	return i18n.T(l.description)
 }
 
 func (l *Location) GetKeyEvents() []IEvent {
@@ -1,20 +1,40 @@
 // logger.go contains everything required for the logger used in the
-// application.
+// application. The logger does not write anywhere until it is configured
+// with ConfigureLogger function, which sets the level, the outputs (file,
+// stderr, ring buffer or none), the file rotation and the level for every
+// module, using the "module" field every package logs with.
 package tools
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 )
 
 // -----------------------------------------------------------------------------
-// Private constants
+// Package public constants
 // -----------------------------------------------------------------------------
 
 const (
-	logFilename = "logfile.log"
+	LogOutputNone   = "none"
+	LogOutputFile   = "file"
+	LogOutputStderr = "stderr"
+	LogOutputRing   = "ring"
+)
+
+const (
+	// LogLevelOff is the level used to disable all logs for a module.
+	LogLevelOff = "off"
+
+	// LogModuleField is the field used to filter logs by module.
+	LogModuleField = "module"
+
+	DefaultLogFilename = "logfile.log"
+	DefaultLogRingSize = 1000
 )
 
 // -----------------------------------------------------------------------------
@@ -22,42 +42,504 @@ const (
 // -----------------------------------------------------------------------------
 
 var (
-	Logger *logrus.Logger
+	Logger *logrus.Logger = newLogger()
 )
 
+// -----------------------------------------------------------------------------
+// Package private variables
+// -----------------------------------------------------------------------------
+
+var (
+	// logMutex protects the logger configuration.
+	logMutex sync.Mutex
+
+	// logCloser is the file opened by the last configuration, if any.
+	logCloser io.Closer
+
+	// logRing is the ring buffer for the last configuration, if any.
+	logRing *LogRing
+
+	// logFilter is the filter used by the logger formatter.
+	logFilter *moduleFilter = newModuleFilter(logrus.PanicLevel)
+
+	// logEnabled is set when the logger writes to any output.
+	logEnabled bool
+)
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// ConfigureLogger function configures the logger with the given
+// configuration. Any file opened by a previous configuration is closed.
+func ConfigureLogger(config *LogConfig) error {
+	level, err := logrus.ParseLevel(config.Level)
+	if err != nil {
+		return err
+	}
+	filter := newModuleFilter(level)
+	for module, moduleLevel := range config.Modules {
+		if err := filter.setModuleLevel(module, moduleLevel); err != nil {
+			return err
+		}
+	}
+
+	var writers []io.Writer
+	var closer io.Closer
+	var ring *LogRing
+	for _, output := range config.Outputs {
+		switch output {
+		case LogOutputNone:
+		case LogOutputFile:
+			if closer != nil {
+				continue
+			}
+			file, err := NewRotatingFile(config.Filename, config.MaxSize, config.MaxBackups)
+			if err != nil {
+				return err
+			}
+			writers = append(writers, file)
+			closer = file
+		case LogOutputStderr:
+			writers = append(writers, os.Stderr)
+		case LogOutputRing:
+			if ring != nil {
+				continue
+			}
+			ring = NewLogRing(config.RingSize)
+			writers = append(writers, ring)
+		default:
+			if closer != nil {
+				closer.Close()
+			}
+			return fmt.Errorf("unknown log output %s", output)
+		}
+	}
+
+	logMutex.Lock()
+	defer logMutex.Unlock()
+	if logCloser != nil {
+		logCloser.Close()
+	}
+	logCloser = closer
+	logRing = ring
+	logFilter = filter
+	Logger.SetFormatter(newModuleFormatter(filter))
+	logEnabled = len(writers) != 0
+	if !logEnabled {
+		Logger.SetOutput(io.Discard)
+		Logger.SetLevel(logrus.PanicLevel)
+		return nil
+	}
+	Logger.SetOutput(io.MultiWriter(writers...))
+	Logger.SetLevel(filter.maxLevel())
+	return nil
+}
+
+// GetLogRing function returns the ring buffer configured for the logger, or
+// nil if the logger does not use any ring buffer.
+func GetLogRing() *LogRing {
+	logMutex.Lock()
+	defer logMutex.Unlock()
+	return logRing
+}
+
+// ParseLogModules function parses the level for every module from the given
+// string with the "module=level,module=level" format.
+func ParseLogModules(str string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, field := range strings.Split(str, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		module, level, ok := strings.Cut(field, "=")
+		if !ok || module == "" {
+			return nil, fmt.Errorf("invalid log module %s", field)
+		}
+		result[module] = level
+	}
+	return result, nil
+}
+
+// SetModuleLogLevel function sets the level for the given module in the
+// configured logger. An empty level removes the module level, so the logger
+// level is used for the module.
+func SetModuleLogLevel(module string, level string) error {
+	logMutex.Lock()
+	defer logMutex.Unlock()
+	filter := logFilter.clone()
+	if level == "" {
+		delete(filter.modules, module)
+	} else if err := filter.setModuleLevel(module, level); err != nil {
+		return err
+	}
+	logFilter = filter
+	Logger.SetFormatter(newModuleFormatter(filter))
+	if logEnabled {
+		Logger.SetLevel(filter.maxLevel())
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // Package private functions
 // -----------------------------------------------------------------------------
 
-// createLogFile function creates a new logrus.Logger instance from the given
-// filename.
-func createLogFile(filename string) *logrus.Logger {
-	if logFile, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err == nil {
-		logger := logrus.New()
+// newLogger function creates the logger used by all packages. It does not
+// write anywhere until it is configured.
+func newLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetFormatter(newModuleFormatter(newModuleFilter(logrus.PanicLevel)))
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.PanicLevel)
+	return logger
+}
+
+// newTextFormatter function creates the formatter used for every log entry.
+func newTextFormatter() *logrus.TextFormatter {
+	formatter := new(logrus.TextFormatter)
+	formatter.TimestampFormat = "2006-01-02T15:04:05.999999Z07:00"
+	formatter.FullTimestamp = true
+	formatter.DisableColors = true
+	return formatter
+}
+
+// -----------------------------------------------------------------------------
+//
+// LogConfig
+//
+// -----------------------------------------------------------------------------
+
+// LogConfig structure contains the logger configuration.
+// Level is the level for all modules without their own level.
+// Outputs contains every output to write logs to: LogOutputFile,
+// LogOutputStderr, LogOutputRing or LogOutputNone.
+// MaxSize is the size in bytes for the log file to be rotated, zero to never
+// rotate it, and MaxBackups is the number of rotated files to keep.
+// RingSize is the number of lines kept by the ring buffer.
+// Modules contains the level for every module, LogLevelOff disables the
+// module.
+type LogConfig struct {
+	Level      string
+	Outputs    []string
+	Filename   string
+	MaxSize    int64
+	MaxBackups int
+	RingSize   int
+	Modules    map[string]string
+}
 
-		Formatter := new(logrus.TextFormatter)
-		Formatter.TimestampFormat = "2006-01-02T15:04:05.999999Z07:00"
-		Formatter.FullTimestamp = true
-		//Formatter.ForceColors = true
-		logger.SetFormatter(Formatter)
-		logger.SetOutput(logFile)
-		logger.SetLevel(logrus.TraceLevel)
-		return logger
-	} else {
-		panic(fmt.Sprintf("Error creating logfile %s Err=%+v", logFilename, err))
+// NewLogConfig function creates a new LogConfig instance that writes every
+// log at the given level to the default log file.
+func NewLogConfig(level string) *LogConfig {
+	return &LogConfig{
+		Level:      level,
+		Outputs:    []string{LogOutputFile},
+		Filename:   DefaultLogFilename,
+		MaxSize:    0,
+		MaxBackups: 0,
+		RingSize:   DefaultLogRingSize,
+		Modules:    make(map[string]string),
 	}
 }
 
 // -----------------------------------------------------------------------------
-// Init package
+//
+// moduleFilter
+//
 // -----------------------------------------------------------------------------
 
-// init function provides all initialization required for the logger module.
-// It initializes logging file configuration.
-func init() {
-	if _, err := os.Stat(logFilename); err == nil {
-		fmt.Println("removing ", logFilename)
-		os.Remove(logFilename)
+// moduleFilter structure defines the level for all log entries and for every
+// module. disabled contains all modules without any log.
+type moduleFilter struct {
+	level    logrus.Level
+	modules  map[string]logrus.Level
+	disabled map[string]bool
+}
+
+// newModuleFilter function creates a new moduleFilter instance for the given
+// level.
+func newModuleFilter(level logrus.Level) *moduleFilter {
+	return &moduleFilter{
+		level:    level,
+		modules:  make(map[string]logrus.Level),
+		disabled: make(map[string]bool),
+	}
+}
+
+// allows method checks if the given entry has to be logged.
+func (f *moduleFilter) allows(entry *logrus.Entry) bool {
+	module, _ := entry.Data[LogModuleField].(string)
+	if f.disabled[module] {
+		return false
+	}
+	if level, ok := f.modules[module]; ok {
+		return entry.Level <= level
+	}
+	return entry.Level <= f.level
+}
+
+// clone method returns a copy of the filter.
+func (f *moduleFilter) clone() *moduleFilter {
+	result := newModuleFilter(f.level)
+	for module, level := range f.modules {
+		result.modules[module] = level
+	}
+	for module := range f.disabled {
+		result.disabled[module] = true
+	}
+	return result
+}
+
+// maxLevel method returns the most verbose level for all modules, which is
+// the level the logger has to be set to.
+func (f *moduleFilter) maxLevel() logrus.Level {
+	result := f.level
+	for _, level := range f.modules {
+		if level > result {
+			result = level
+		}
+	}
+	return result
+}
+
+// setModuleLevel method sets the level for the given module.
+func (f *moduleFilter) setModuleLevel(module string, level string) error {
+	if level == LogLevelOff {
+		delete(f.modules, module)
+		f.disabled[module] = true
+		return nil
+	}
+	moduleLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	delete(f.disabled, module)
+	f.modules[module] = moduleLevel
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+//
+// moduleFormatter
+//
+// -----------------------------------------------------------------------------
+
+// moduleFormatter structure defines the logger formatter that drops every
+// entry not allowed by the module filter.
+type moduleFormatter struct {
+	formatter logrus.Formatter
+	filter    *moduleFilter
+}
+
+// newModuleFormatter function creates a new moduleFormatter instance for the
+// given filter.
+func newModuleFormatter(filter *moduleFilter) *moduleFormatter {
+	return &moduleFormatter{
+		formatter: newTextFormatter(),
+		filter:    filter,
+	}
+}
+
+// Format method formats the given entry, or returns nothing if the entry is
+// not allowed.
+func (f *moduleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !f.filter.allows(entry) {
+		return nil, nil
+	}
+	return f.formatter.Format(entry)
+}
+
+// -----------------------------------------------------------------------------
+//
+// LogRing
+//
+// -----------------------------------------------------------------------------
+
+// LogRing structure defines a ring buffer with the last lines logged.
+// total is the number of lines ever written, so readers can check if there
+// are new lines.
+type LogRing struct {
+	mutex sync.Mutex
+	lines []string
+	start int
+	total int
+}
+
+// NewLogRing function creates a new LogRing instance that keeps the given
+// number of lines.
+func NewLogRing(size int) *LogRing {
+	if size <= 0 {
+		size = DefaultLogRingSize
+	}
+	return &LogRing{
+		lines: make([]string, 0, size),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// LogRing public methods
+// -----------------------------------------------------------------------------
+
+// Clear method removes all lines.
+func (r *LogRing) Clear() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.lines = r.lines[:0]
+	r.start = 0
+}
+
+// GetLines method returns all lines in the ring buffer from the oldest to the
+// newest one.
+func (r *LogRing) GetLines() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	result := make([]string, 0, len(r.lines))
+	result = append(result, r.lines[r.start:]...)
+	return append(result, r.lines[:r.start]...)
+}
+
+// GetSize method returns the number of lines the ring buffer can keep.
+func (r *LogRing) GetSize() int {
+	return cap(r.lines)
+}
+
+// GetTotal method returns the number of lines ever written.
+func (r *LogRing) GetTotal() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.total
+}
+
+// Write method adds every line in the given data to the ring buffer,
+// replacing the oldest lines when it is full.
+func (r *LogRing) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if len(r.lines) < cap(r.lines) {
+			r.lines = append(r.lines, line)
+		} else {
+			r.lines[r.start] = line
+			r.start = (r.start + 1) % len(r.lines)
+		}
+		r.total++
+	}
+	return len(p), nil
+}
+
+// -----------------------------------------------------------------------------
+//
+// RotatingFile
+//
+// -----------------------------------------------------------------------------
+
+// RotatingFile structure defines a log file that is rotated when it reaches
+// the maximum size. Rotated files are named "<filename>.1" for the newest to
+// "<filename>.<maxBackups>" for the oldest one.
+type RotatingFile struct {
+	mutex      sync.Mutex
+	filename   string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewRotatingFile function creates a new RotatingFile instance. Any existing
+// file with the given filename is truncated. A zero maximum size never
+// rotates the file.
+func NewRotatingFile(filename string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	r := &RotatingFile{
+		filename:   filename,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// -----------------------------------------------------------------------------
+// RotatingFile private methods
+// -----------------------------------------------------------------------------
+
+// backupName method returns the filename for the given backup index.
+func (r *RotatingFile) backupName(index int) string {
+	return fmt.Sprintf("%s.%d", r.filename, index)
+}
+
+// open method creates the log file.
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	r.file = file
+	r.size = 0
+	return nil
+}
+
+// rotate method moves every backup file to the next index, removing the
+// oldest one, and creates a new log file.
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	if r.maxBackups > 0 {
+		os.Remove(r.backupName(r.maxBackups))
+		for index := r.maxBackups - 1; index > 0; index-- {
+			os.Rename(r.backupName(index), r.backupName(index+1))
+		}
+		if err := os.Rename(r.filename, r.backupName(1)); err != nil {
+			return err
+		}
+	}
+	return r.open()
+}
+
+// -----------------------------------------------------------------------------
+// RotatingFile public methods
+// -----------------------------------------------------------------------------
+
+// Close method closes the log file.
+func (r *RotatingFile) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.file.Close()
+}
+
+// GetBackups method returns all backup files that exist, from the newest to
+// the oldest one.
+func (r *RotatingFile) GetBackups() []string {
+	var result []string
+	for index := 1; index <= r.maxBackups; index++ {
+		if _, err := os.Stat(r.backupName(index)); err == nil {
+			result = append(result, r.backupName(index))
+		}
+	}
+	return result
+}
+
+// Write method writes the given data to the log file, rotating it before if
+// it would be bigger than the maximum size.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
 	}
-	Logger = createLogFile(logFilename)
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
 }
@@ -0,0 +1,156 @@
+package tools_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+func TestLogRing(t *testing.T) {
+	cases := []struct {
+		size   int
+		writes []string
+		exp    []string
+		total  int
+	}{
+		{
+			size:   3,
+			writes: []string{"one\n", "two\nthree\n"},
+			exp:    []string{"one", "two", "three"},
+			total:  3,
+		},
+		{
+			size:   3,
+			writes: []string{"one\n", "two\n", "three\n", "four\nfive\n"},
+			exp:    []string{"three", "four", "five"},
+			total:  5,
+		},
+		{
+			size:   2,
+			writes: []string{"", "one\n"},
+			exp:    []string{"one"},
+			total:  1,
+		},
+	}
+	for i, c := range cases {
+		ring := tools.NewLogRing(c.size)
+		for _, str := range c.writes {
+			ring.Write([]byte(str))
+		}
+		if got := ring.GetLines(); strings.Join(got, ",") != strings.Join(c.exp, ",") {
+			t.Errorf("[%d] GetLines Error exp:%v got:%v", i, c.exp, got)
+		}
+		if got := ring.GetTotal(); got != c.total {
+			t.Errorf("[%d] GetTotal Error exp:%d got:%d", i, c.total, got)
+		}
+	}
+}
+
+func TestParseLogModules(t *testing.T) {
+	cases := []struct {
+		input string
+		exp   map[string]string
+		err   bool
+	}{
+		{
+			input: "engine=debug, focusmanager=off",
+			exp:   map[string]string{"engine": "debug", "focusmanager": "off"},
+		},
+		{
+			input: "",
+			exp:   map[string]string{},
+		},
+		{
+			input: "engine",
+			err:   true,
+		},
+	}
+	for i, c := range cases {
+		got, err := tools.ParseLogModules(c.input)
+		if (err != nil) != c.err {
+			t.Errorf("[%d] ParseLogModules Error exp:%t got:%v", i, c.err, err)
+			continue
+		}
+		if len(got) != len(c.exp) {
+			t.Errorf("[%d] ParseLogModules Error exp:%v got:%v", i, c.exp, got)
+		}
+		for module, level := range c.exp {
+			if got[module] != level {
+				t.Errorf("[%d] ParseLogModules Error exp:%s got:%s", i, level, got[module])
+			}
+		}
+	}
+}
+
+func TestConfigureLogger(t *testing.T) {
+	defer tools.ConfigureLogger(&tools.LogConfig{Level: "panic"})
+
+	config := tools.NewLogConfig("info")
+	config.Outputs = []string{tools.LogOutputRing}
+	config.Modules = map[string]string{"engine": "debug", "scene": tools.LogLevelOff}
+	if err := tools.ConfigureLogger(config); err != nil {
+		t.Fatalf("[0] ConfigureLogger Error exp:nil got:%s", err)
+	}
+	ring := tools.GetLogRing()
+	if ring == nil {
+		t.Fatalf("[0] GetLogRing Error exp:*LogRing got:nil")
+	}
+	tools.Logger.WithField("module", "engine").Debugf("engine debug")
+	tools.Logger.WithField("module", "scene").Errorf("scene error")
+	tools.Logger.WithField("module", "camera").Infof("camera info")
+	tools.Logger.WithField("module", "camera").Debugf("camera debug")
+	lines := strings.Join(ring.GetLines(), "\n")
+	for _, exp := range []string{"engine debug", "camera info"} {
+		if !strings.Contains(lines, exp) {
+			t.Errorf("[1] Logger Error exp:%s got:%s", exp, lines)
+		}
+	}
+	for _, notExp := range []string{"scene error", "camera debug"} {
+		if strings.Contains(lines, notExp) {
+			t.Errorf("[1] Logger Error not exp:%s got:%s", notExp, lines)
+		}
+	}
+
+	if err := tools.SetModuleLogLevel("camera", "debug"); err != nil {
+		t.Errorf("[2] SetModuleLogLevel Error exp:nil got:%s", err)
+	}
+	tools.Logger.WithField("module", "camera").Debugf("camera debug")
+	if got := strings.Join(ring.GetLines(), "\n"); !strings.Contains(got, "camera debug") {
+		t.Errorf("[2] Logger Error exp:camera debug got:%s", got)
+	}
+
+	if err := tools.ConfigureLogger(&tools.LogConfig{Level: "info", Outputs: []string{"printer"}}); err == nil {
+		t.Errorf("[3] ConfigureLogger Error exp:error got:nil")
+	}
+	if err := tools.ConfigureLogger(&tools.LogConfig{Level: "verbose"}); err == nil {
+		t.Errorf("[3] ConfigureLogger Error exp:error got:nil")
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "test.log")
+	file, err := tools.NewRotatingFile(filename, 10, 2)
+	if err != nil {
+		t.Fatalf("[0] NewRotatingFile Error exp:nil got:%s", err)
+	}
+	defer file.Close()
+	for _, str := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		file.Write([]byte(str))
+	}
+	exps := map[string]string{
+		filename:        "fourth\n",
+		filename + ".1": "third\n",
+		filename + ".2": "second\n",
+	}
+	for name, exp := range exps {
+		if got, _ := os.ReadFile(name); string(got) != exp {
+			t.Errorf("[1] Write Error %s exp:%q got:%q", name, exp, string(got))
+		}
+	}
+	if got := len(file.GetBackups()); got != 2 {
+		t.Errorf("[2] GetBackups Error exp:2 got:%d", got)
+	}
+}
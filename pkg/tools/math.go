@@ -9,13 +9,11 @@ import (
 )
 
 var (
-	RandomRing *rand.Rand
+	// RandomRing is the generator for the default random stream. New code
+	// should use a named stream returned by GetRandomStream function.
+	RandomRing *rand.Rand = GetRandomStream(RandomStreamDefault).GetRand()
 )
 
-func init() {
-	InitRandom()
-}
-
 // -----------------------------------------------------------------------------
 // Public functions
 // -----------------------------------------------------------------------------
@@ -33,10 +31,9 @@ func Sign(a int) int {
 	return 1
 }
 
+// InitRandom function seeds the random number service from the clock.
 func InitRandom() {
-	seed := time.Now().UnixNano()
-	source := rand.NewSource(seed)
-	RandomRing = rand.New(source)
+	SeedRandom(time.Now().UnixNano())
 }
 
 // Max function returns the maximum integer in a sequence of integers.
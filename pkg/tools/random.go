@@ -0,0 +1,337 @@
+// random.go contains the random number service. The service is explicitly
+// seeded and it provides named streams that are independent from each other,
+// so the numbers drawn from one stream, like loot, do not change numbers
+// drawn from any other stream, like combat. Every stream seed is derived from
+// the service seed and the stream name, so streams do not depend on the order
+// they are created. The state for all streams can be saved and restored.
+package tools
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	RandomStreamDefault = "default"
+	RandomStreamCombat  = "combat"
+	RandomStreamLoot    = "loot"
+	RandomStreamDungeon = "dungeon"
+	RandomStreamAI      = "ai"
+)
+
+// -----------------------------------------------------------------------------
+// Package private variables
+// -----------------------------------------------------------------------------
+
+var (
+	// theRandom is the random number service used by all package functions.
+	theRandom *Random = NewRandom(time.Now().UnixNano())
+)
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// GetRandom function returns the random number service.
+func GetRandom() *Random {
+	return theRandom
+}
+
+// GetRandomStream function returns the stream with the given name from the
+// random number service.
+func GetRandomStream(name string) *RandomStream {
+	return theRandom.GetStream(name)
+}
+
+// SeedRandom function seeds the random number service with the given seed.
+func SeedRandom(seed int64) {
+	theRandom.Seed(seed)
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// streamSeed function returns the seed for the stream with the given name
+// derived from the given service seed.
+func streamSeed(seed int64, name string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	source := &randomSource{state: uint64(seed) ^ hash.Sum64()}
+	return int64(source.next())
+}
+
+// -----------------------------------------------------------------------------
+//
+// randomSource
+//
+// -----------------------------------------------------------------------------
+
+// randomSource structure defines a splitmix64 generator. Its whole state is
+// one number, so it can be saved and restored. It is safe to be used from
+// multiple goroutines.
+type randomSource struct {
+	mutex sync.Mutex
+	state uint64
+}
+
+// next method returns the next number. The mutex has to be locked.
+func (s *randomSource) next() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// getState method returns the generator state.
+func (s *randomSource) getState() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.state
+}
+
+// setState method sets the generator state.
+func (s *randomSource) setState(state uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.state = state
+}
+
+// Int63 method returns a non-negative 63-bit integer.
+func (s *randomSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed method sets the generator state to the given seed.
+func (s *randomSource) Seed(seed int64) {
+	s.setState(uint64(seed))
+}
+
+// Uint64 method returns a 64-bit integer.
+func (s *randomSource) Uint64() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.next()
+}
+
+var _ rand.Source64 = (*randomSource)(nil)
+
+// -----------------------------------------------------------------------------
+//
+// RandomStream
+//
+// -----------------------------------------------------------------------------
+
+// RandomStream structure defines a named stream of random numbers. It is safe
+// to be used from multiple goroutines.
+type RandomStream struct {
+	name   string
+	seed   int64
+	source *randomSource
+	rand   *rand.Rand
+}
+
+// NewRandomStream function creates a new RandomStream instance with the given
+// name and seed.
+func NewRandomStream(name string, seed int64) *RandomStream {
+	source := &randomSource{state: uint64(seed)}
+	return &RandomStream{
+		name:   name,
+		seed:   seed,
+		source: source,
+		rand:   rand.New(source),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// RandomStream public methods
+// -----------------------------------------------------------------------------
+
+// Float64 method returns a number in [0.0,1.0).
+func (s *RandomStream) Float64() float64 {
+	return s.rand.Float64()
+}
+
+// GetName method returns the stream name.
+func (s *RandomStream) GetName() string {
+	return s.name
+}
+
+// GetRand method returns a math/rand generator using the stream. Its Read
+// method is not safe to be used from multiple goroutines.
+func (s *RandomStream) GetRand() *rand.Rand {
+	return s.rand
+}
+
+// GetSeed method returns the seed the stream was created or seeded with.
+func (s *RandomStream) GetSeed() int64 {
+	return s.seed
+}
+
+// GetState method returns the stream state, which can be saved and restored
+// with SetState.
+func (s *RandomStream) GetState() uint64 {
+	return s.source.getState()
+}
+
+// Int63 method returns a non-negative 63-bit integer.
+func (s *RandomStream) Int63() int64 {
+	return s.rand.Int63()
+}
+
+// Intn method returns a number in [0,n). It panics if n <= 0.
+func (s *RandomStream) Intn(n int) int {
+	return s.rand.Intn(n)
+}
+
+// Perm method returns a permutation of the integers [0,n).
+func (s *RandomStream) Perm(n int) []int {
+	return s.rand.Perm(n)
+}
+
+// Seed method seeds the stream with the given seed.
+func (s *RandomStream) Seed(seed int64) {
+	s.seed = seed
+	s.source.Seed(seed)
+}
+
+// SetState method sets the stream state returned by GetState.
+func (s *RandomStream) SetState(state uint64) {
+	s.source.setState(state)
+}
+
+// Shuffle method shuffles n elements using the given swap function.
+func (s *RandomStream) Shuffle(n int, swap func(i, j int)) {
+	s.rand.Shuffle(n, swap)
+}
+
+// -----------------------------------------------------------------------------
+//
+// RandomState
+//
+// -----------------------------------------------------------------------------
+
+// RandomState structure contains the state of the random number service, so
+// it can be saved in a save game and restored.
+type RandomState struct {
+	Seed    int64             `json:"seed"`
+	Streams map[string]uint64 `json:"streams"`
+}
+
+// -----------------------------------------------------------------------------
+//
+// Random
+//
+// -----------------------------------------------------------------------------
+
+// Random structure defines the random number service with all named streams.
+type Random struct {
+	mutex   sync.Mutex
+	seed    int64
+	streams map[string]*RandomStream
+}
+
+// NewRandom function creates a new Random instance with the given seed.
+func NewRandom(seed int64) *Random {
+	return &Random{
+		seed:    seed,
+		streams: make(map[string]*RandomStream),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Random public methods
+// -----------------------------------------------------------------------------
+
+// GetSeed method returns the service seed.
+func (r *Random) GetSeed() int64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.seed
+}
+
+// GetState method returns the state for the service and all streams.
+func (r *Random) GetState() *RandomState {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	state := &RandomState{
+		Seed:    r.seed,
+		Streams: make(map[string]uint64),
+	}
+	for name, stream := range r.streams {
+		state.Streams[name] = stream.GetState()
+	}
+	return state
+}
+
+// GetStream method returns the stream with the given name, creating it if it
+// does not exist.
+func (r *Random) GetStream(name string) *RandomStream {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	stream, ok := r.streams[name]
+	if !ok {
+		stream = NewRandomStream(name, streamSeed(r.seed, name))
+		r.streams[name] = stream
+	}
+	return stream
+}
+
+// GetStreamNames method returns the name for all streams sorted.
+func (r *Random) GetStreamNames() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	result := make([]string, 0, len(r.streams))
+	for name := range r.streams {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// MarshalJSON method returns the service state as JSON.
+func (r *Random) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.GetState())
+}
+
+// Seed method seeds the service with the given seed. All streams are seeded
+// again from the new seed.
+func (r *Random) Seed(seed int64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.seed = seed
+	for name, stream := range r.streams {
+		stream.Seed(streamSeed(seed, name))
+	}
+}
+
+// SetState method restores the state for the service and all streams.
+// Streams not in the state are seeded again from the state seed.
+func (r *Random) SetState(state *RandomState) {
+	r.Seed(state.Seed)
+	for name, streamState := range state.Streams {
+		r.GetStream(name).SetState(streamState)
+	}
+}
+
+// UnmarshalJSON method restores the service state from JSON.
+func (r *Random) UnmarshalJSON(data []byte) error {
+	state := &RandomState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return err
+	}
+	if r.streams == nil {
+		r.streams = make(map[string]*RandomStream)
+	}
+	r.SetState(state)
+	return nil
+}
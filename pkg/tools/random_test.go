@@ -0,0 +1,104 @@
+package tools_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// draw function returns the given number of values drawn from the given
+// stream.
+func draw(stream *tools.RandomStream, count int) []int {
+	result := make([]int, count)
+	for i := range result {
+		result[i] = stream.Intn(1000)
+	}
+	return result
+}
+
+// equal function checks if both slices contain the same values.
+func equal(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRandomSeed(t *testing.T) {
+	r1 := tools.NewRandom(42)
+	r2 := tools.NewRandom(42)
+	exp := draw(r1.GetStream(tools.RandomStreamCombat), 10)
+	if got := draw(r2.GetStream(tools.RandomStreamCombat), 10); !equal(exp, got) {
+		t.Errorf("[0] Intn Error exp:%v got:%v", exp, got)
+	}
+
+	r1.Seed(42)
+	if got := draw(r1.GetStream(tools.RandomStreamCombat), 10); !equal(exp, got) {
+		t.Errorf("[1] Seed Error exp:%v got:%v", exp, got)
+	}
+
+	r1.Seed(7)
+	if got := draw(r1.GetStream(tools.RandomStreamCombat), 10); equal(exp, got) {
+		t.Errorf("[2] Seed Error exp:different values got:%v", got)
+	}
+}
+
+func TestRandomStreams(t *testing.T) {
+	// drawing from the loot stream does not change the combat stream, and
+	// the order streams are created does not matter.
+	r1 := tools.NewRandom(42)
+	r2 := tools.NewRandom(42)
+	draw(r2.GetStream(tools.RandomStreamLoot), 25)
+	exp := draw(r1.GetStream(tools.RandomStreamCombat), 10)
+	if got := draw(r2.GetStream(tools.RandomStreamCombat), 10); !equal(exp, got) {
+		t.Errorf("[0] Intn Error exp:%v got:%v", exp, got)
+	}
+
+	loot := draw(r1.GetStream(tools.RandomStreamLoot), 10)
+	if equal(exp, loot) {
+		t.Errorf("[1] Intn Error exp:different values got:%v", loot)
+	}
+
+	names := r1.GetStreamNames()
+	if len(names) != 2 || names[0] != tools.RandomStreamCombat || names[1] != tools.RandomStreamLoot {
+		t.Errorf("[2] GetStreamNames Error exp:[combat loot] got:%v", names)
+	}
+}
+
+func TestRandomState(t *testing.T) {
+	r := tools.NewRandom(42)
+	draw(r.GetStream(tools.RandomStreamCombat), 5)
+	draw(r.GetStream(tools.RandomStreamDungeon), 3)
+	state := r.GetState()
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("[0] MarshalJSON Error exp:nil got:%s", err)
+	}
+	combat := draw(r.GetStream(tools.RandomStreamCombat), 10)
+	dungeon := draw(r.GetStream(tools.RandomStreamDungeon), 10)
+
+	r.SetState(state)
+	if got := draw(r.GetStream(tools.RandomStreamCombat), 10); !equal(combat, got) {
+		t.Errorf("[1] SetState Error exp:%v got:%v", combat, got)
+	}
+
+	restored := &tools.Random{}
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("[2] UnmarshalJSON Error exp:nil got:%s", err)
+	}
+	if got := restored.GetSeed(); got != 42 {
+		t.Errorf("[2] GetSeed Error exp:42 got:%d", got)
+	}
+	if got := draw(restored.GetStream(tools.RandomStreamCombat), 10); !equal(combat, got) {
+		t.Errorf("[2] UnmarshalJSON Error exp:%v got:%v", combat, got)
+	}
+	if got := draw(restored.GetStream(tools.RandomStreamDungeon), 10); !equal(dungeon, got) {
+		t.Errorf("[2] UnmarshalJSON Error exp:%v got:%v", dungeon, got)
+	}
+}
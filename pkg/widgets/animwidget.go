@@ -96,7 +96,7 @@ func (w *AnimWidget) Update(event tcell.Event, scene engine.IScene) {
 		if frame.Inc() {
 			frame.Reset()
 			if w.isshuffle {
-				w.frameTraverse = tools.RandomRing.Intn(len(w.frames))
+				w.frameTraverse = tools.GetRandomStream(tools.RandomStreamDefault).Intn(len(w.frames))
 			} else {
 				w.frameTraverse = (w.frameTraverse + 1) % len(w.frames)
 			}
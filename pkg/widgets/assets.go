@@ -0,0 +1,101 @@
+// assets.go contains helpers to register and create widget resources, like
+// sprites and frame sets, with the engine asset manager.
+package widgets
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+)
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// cloneCells function returns a copy of all given cells.
+func cloneCells(cells engine.CellGroup) engine.CellGroup {
+	result := make(engine.CellGroup, len(cells))
+	for i, cell := range cells {
+		result[i] = engine.CloneCell(cell)
+	}
+	return result
+}
+
+// cloneFrames function returns a copy of all given frames. Frames that can
+// not be cloned are shared.
+func cloneFrames(frames []IFrame) []IFrame {
+	result := make([]IFrame, len(frames))
+	for i, frame := range frames {
+		if f, ok := frame.(*Frame); ok {
+			result[i] = f.Clone()
+		} else {
+			result[i] = frame
+		}
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// CloneFramesAsset function returns a copy of the given frame set asset.
+func CloneFramesAsset(manager *engine.AssetManager, name string) ([]IFrame, error) {
+	value, err := manager.Clone(name)
+	if err != nil {
+		return nil, err
+	}
+	frames, ok := value.([]IFrame)
+	if !ok {
+		return nil, fmt.Errorf("asset %s is not a frame set", name)
+	}
+	return frames, nil
+}
+
+// NewSpriteFromAsset function creates a new Sprite instance with a copy of
+// the cells in the given sprite asset.
+func NewSpriteFromAsset(manager *engine.AssetManager, assetName string, name string, position *api.Point) (*Sprite, error) {
+	value, err := manager.Clone(assetName)
+	if err != nil {
+		return nil, err
+	}
+	cells, ok := value.(engine.CellGroup)
+	if !ok {
+		return nil, fmt.Errorf("asset %s is not a sprite", assetName)
+	}
+	return NewSprite(name, position, cells), nil
+}
+
+// RegisterFramesAsset function registers a frame set asset created by the
+// given builder, like any function creating animation frames.
+func RegisterFramesAsset(manager *engine.AssetManager, name string, builder func() []IFrame) {
+	loader := func(fs.FS) (any, error) {
+		return builder(), nil
+	}
+	cloner := func(value any) any {
+		return cloneFrames(value.([]IFrame))
+	}
+	manager.Register(name, loader, cloner)
+}
+
+// RegisterSpriteAsset function registers a sprite asset with the content of
+// the given text file. Spaces in the file are skipped.
+func RegisterSpriteAsset(manager *engine.AssetManager, name string, path string, style *tcell.Style) {
+	loader := func(fsys fs.FS) (any, error) {
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		sprite := NewSprite(name, nil, nil)
+		sprite.StringToSprite(strings.TrimRight(string(content), "\n"), style)
+		return sprite.GetCells(), nil
+	}
+	cloner := func(value any) any {
+		return cloneCells(value.(engine.CellGroup))
+	}
+	manager.Register(name, loader, cloner)
+}
@@ -60,7 +60,7 @@ func (b *Box) updateBox() {
 		return
 	}
 	w, h := b.GetSize().Get()
-	style := b.GetStyle()
+	style := GetThemeStyleFor("box", ThemeRoleBorder, b.GetStyle())
 	for col := 1; col < (w - 1); col++ {
 		cell = engine.NewCellAt(style, hl, api.NewPoint(col, 0))
 		b.AddCellAt(-1, cell)
@@ -83,3 +83,10 @@ func (b *Box) updateBox() {
 	cell = engine.NewCellAt(style, lr, api.NewPoint(w-1, h-1))
 	b.AddCellAt(-1, cell)
 }
+
+// Refresh method draws the box again with the latest size, like when the box
+// layout changes its size.
+func (b *Box) Refresh() {
+	b.SetCells(nil)
+	b.updateBox()
+}
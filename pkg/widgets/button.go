@@ -6,6 +6,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/i18n"
 	"github.com/jrecuero/thengine/pkg/tools"
 )
 
@@ -36,6 +37,7 @@ func NewButton(name string, position *api.Point, size *api.Size, style *tcell.St
 		label:   label,
 		clicked: false,
 	}
+	button.SetThemeClass("button")
 	button.SetFocusType(engine.SingleFocus)
 	button.SetFocusEnable(true)
 	button.updateCanvas()
@@ -58,7 +60,11 @@ func (b *Button) execute(args ...any) {
 // updateCanvas method updates the buttonwidget canvas with the label
 // information.
 func (b *Button) updateCanvas() {
-	canvas := engine.NewCanvasFromString(b.label, b.GetStyle())
+	style := b.GetThemeStyle(ThemeRoleNormal)
+	if b.HasFocus() {
+		style = b.GetThemeStyle(ThemeRoleFocused)
+	}
+	canvas := b.newDisplayCanvas(b.GetDisplayLabel(), style)
 	b.SetCanvas(canvas)
 }
 
@@ -73,17 +79,29 @@ func (b *Button) AcquireFocus() (bool, error) {
 		Debugf("%s", b.GetName())
 	ok, err := b.Entity.AcquireFocus()
 	if err == nil {
-		reverseStyle := tools.ReverseStyle(b.GetStyle())
-		b.SetStyle(reverseStyle)
+		b.updateCanvas()
 	}
 	return ok, err
 }
 
+// GetDisplayLabel method returns the Button instance string translated to
+// the active locale.
+func (t *Button) GetDisplayLabel() string {
+	return i18n.T(t.label)
+}
+
 // GetLabel method returns the Button instance string.
 func (t *Button) GetLabel() string {
 	return t.label
 }
 
+// SetMarkup method sets if the button label handles inline markup tags.
+// Markup is disabled by default, so any label is displayed as it is.
+func (b *Button) SetMarkup(markup bool) {
+	b.markup = markup
+	b.updateCanvas()
+}
+
 // SetLabel method sets the Button instance string.
 func (t *Button) SetLabel(label string) {
 	t.label = label
@@ -98,8 +116,7 @@ func (b *Button) Refresh() {
 func (b *Button) ReleaseFocus() (bool, error) {
 	ok, err := b.Entity.ReleaseFocus()
 	if err == nil {
-		reverseStyle := tools.ReverseStyle(b.GetStyle())
-		b.SetStyle(reverseStyle)
+		b.updateCanvas()
 	}
 	return ok, err
 }
@@ -49,6 +49,8 @@ func NewCheckBox(name string, position *api.Point, size *api.Size, style *tcell.
 		selectionIndex: selectionIndex,
 	}
 	checkBox.scroller = NewVerticalScroller(selectionsLength, size.H-2)
+	checkBox.SetThemeClass("checkbox")
+	checkBox.SetHandlesArrows(true)
 	checkBox.SetFocusType(engine.SingleFocus)
 	checkBox.SetFocusEnable(true)
 	checkBox.updateCanvas()
@@ -87,7 +89,7 @@ func (c *CheckBox) updateCanvas() {
 	// update the scroller with the selection index.
 	c.scroller.Update(c.selectionIndex)
 	canvas := c.GetCanvas()
-	canvas.WriteRectangleInCanvasAt(nil, nil, c.GetStyle(), engine.CanvasRectSingleLine)
+	canvas.WriteRectangleInCanvasAt(nil, nil, c.GetThemeStyle(ThemeRoleBorder), engine.CanvasRectSingleLine)
 	c.scroller.CreateIter()
 	for x, y := 1, 1; c.scroller.IterHasNext(); y++ {
 		index, _ := c.scroller.IterGetNext()
@@ -98,10 +100,9 @@ func (c *CheckBox) updateCanvas() {
 			selection = "- " + selection
 		}
 		if index == c.selectionIndex {
-			reverseStyle := tools.ReverseStyle(c.GetStyle())
-			canvas.WriteStringInCanvasAt(selection, reverseStyle, api.NewPoint(x, y))
+			canvas.WriteStringInCanvasAt(selection, c.GetThemeStyle(ThemeRoleSelected), api.NewPoint(x, y))
 		} else {
-			canvas.WriteStringInCanvasAt(selection, c.GetStyle(), api.NewPoint(x, y))
+			canvas.WriteStringInCanvasAt(selection, c.GetThemeStyle(ThemeRoleNormal), api.NewPoint(x, y))
 		}
 	}
 }
@@ -122,6 +123,11 @@ func (c *CheckBox) GetSelection() []string {
 	return result
 }
 
+// Refresh method refreshes the check box canvas with latest attribute values.
+func (c *CheckBox) Refresh() {
+	c.updateCanvas()
+}
+
 // SetSelection method update the list of selected selections in the check box
 // widget.
 func (c *CheckBox) SetSelection(indexes ...int) {
@@ -162,3 +168,4 @@ func (c *CheckBox) Update(event tcell.Event, scene engine.IScene) {
 var _ engine.IObject = (*CheckBox)(nil)
 var _ engine.IFocus = (*CheckBox)(nil)
 var _ engine.IEntity = (*CheckBox)(nil)
+var _ engine.IArrowHandler = (*CheckBox)(nil)
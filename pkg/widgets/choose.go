@@ -49,6 +49,8 @@ func NewChoose(name string, position *api.Point, size *api.Size, style *tcell.St
 		selectionIndex: selectionIndex,
 	}
 	choose.scroller = NewVerticalScroller(selectionsLength, size.H-2)
+	choose.SetThemeClass("choose")
+	choose.SetHandlesArrows(true)
 	choose.SetFocusType(engine.SingleFocus)
 	choose.SetFocusEnable(true)
 	choose.updateCanvas()
@@ -87,7 +89,7 @@ func (c *Choose) updateCanvas() {
 	// update the scroller with the selection index.
 	c.scroller.Update(c.selectionIndex)
 	canvas := c.GetCanvas()
-	canvas.WriteRectangleInCanvasAt(nil, nil, c.GetStyle(), engine.CanvasRectSingleLine)
+	canvas.WriteRectangleInCanvasAt(nil, nil, c.GetThemeStyle(ThemeRoleBorder), engine.CanvasRectSingleLine)
 	c.scroller.CreateIter()
 	for x, y := 1, 1; c.scroller.IterHasNext(); y++ {
 		index, _ := c.scroller.IterGetNext()
@@ -98,10 +100,9 @@ func (c *Choose) updateCanvas() {
 			selection = "- " + selection
 		}
 		if index == c.selectionIndex {
-			reverseStyle := tools.ReverseStyle(c.GetStyle())
-			canvas.WriteStringInCanvasAt(selection, reverseStyle, api.NewPoint(x, y))
+			canvas.WriteStringInCanvasAt(selection, c.GetThemeStyle(ThemeRoleSelected), api.NewPoint(x, y))
 		} else {
-			canvas.WriteStringInCanvasAt(selection, c.GetStyle(), api.NewPoint(x, y))
+			canvas.WriteStringInCanvasAt(selection, c.GetThemeStyle(ThemeRoleNormal), api.NewPoint(x, y))
 		}
 	}
 }
@@ -115,6 +116,11 @@ func (c *Choose) GetSelected() int {
 	return c.selected
 }
 
+// Refresh method refreshes the choose canvas with latest attribute values.
+func (c *Choose) Refresh() {
+	c.updateCanvas()
+}
+
 // SetSelection method sets the selected option.
 func (c *Choose) SetSelected(index int) {
 	c.selected = index
@@ -152,3 +158,4 @@ func (c *Choose) Update(event tcell.Event, scene engine.IScene) {
 var _ engine.IObject = (*Choose)(nil)
 var _ engine.IFocus = (*Choose)(nil)
 var _ engine.IEntity = (*Choose)(nil)
+var _ engine.IArrowHandler = (*Choose)(nil)
@@ -54,6 +54,8 @@ func NewComboBox(name string, position *api.Point, size *api.Size, style *tcell.
 	//tools.Logger.WithField("module", "combobox").
 	//    WithField("function", "NewComboBox").
 	//    Debugf("scroller %s", comboBox.scroller.ToString())
+	comboBox.SetThemeClass("combobox")
+	comboBox.SetHandlesArrows(true)
 	comboBox.SetFocusType(engine.SingleFocus)
 	comboBox.SetFocusEnable(true)
 	comboBox.updateCanvas()
@@ -92,8 +94,8 @@ func (c *ComboBox) updateCanvas() {
 	c.scroller.Update(c.selectionIndex)
 	canvas := c.GetCanvas()
 	c.scroller.CreateIter()
-	canvas.WriteRectangleInCanvasAt(nil, nil, c.GetStyle(), engine.CanvasRectSingleLine)
-	canvas.WriteStringInCanvasAt(c.inputStr, c.GetStyle(), api.NewPoint(1, 1))
+	canvas.WriteRectangleInCanvasAt(nil, nil, c.GetThemeStyle(ThemeRoleBorder), engine.CanvasRectSingleLine)
+	canvas.WriteStringInCanvasAt(c.inputStr, c.GetThemeStyle(ThemeRoleNormal), api.NewPoint(1, 1))
 	isEmptyFilter := (len(c.filtered) == 0)
 	for i, x, y := 0, 1, 2; c.scroller.IterHasNext() || i < c.GetSize().H-3; i, y = i+1, y+1 {
 		index, _ := c.scroller.IterGetNext()
@@ -107,10 +109,9 @@ func (c *ComboBox) updateCanvas() {
 		//    WithField("method", "updateCanvas").
 		//    Debugf("%d:%d '%s'", index, i, selection)
 		if !isEmptyFilter && index == c.selectionIndex {
-			reverseStyle := tools.ReverseStyle(c.GetStyle())
-			canvas.WriteStringInCanvasAt(selection, reverseStyle, api.NewPoint(x, y))
+			canvas.WriteStringInCanvasAt(selection, c.GetThemeStyle(ThemeRoleSelected), api.NewPoint(x, y))
 		} else {
-			canvas.WriteStringInCanvasAt(selection, c.GetStyle(), api.NewPoint(x, y))
+			canvas.WriteStringInCanvasAt(selection, c.GetThemeStyle(ThemeRoleNormal), api.NewPoint(x, y))
 		}
 	}
 }
@@ -153,6 +154,11 @@ func (c *ComboBox) GetSelection() string {
 	return strings.TrimSpace(c.selections[c.selectionIndex])
 }
 
+// Refresh method refreshes the combo box canvas with latest attribute values.
+func (c *ComboBox) Refresh() {
+	c.updateCanvas()
+}
+
 // Update method executes all combobox functionality every tick time. Keyboard
 // inut is scanned in order to move the selection index and proceed to select
 // any option.
@@ -190,3 +196,4 @@ func (c *ComboBox) Update(event tcell.Event, scene engine.IScene) {
 var _ engine.IObject = (*ComboBox)(nil)
 var _ engine.IFocus = (*ComboBox)(nil)
 var _ engine.IEntity = (*ComboBox)(nil)
+var _ engine.IArrowHandler = (*ComboBox)(nil)
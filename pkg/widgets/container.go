@@ -0,0 +1,498 @@
+// container.go contains the base for all container widgets. A container
+// arranges its child widgets automatically using their preferred sizes, and
+// it arranges them again every time their content or the container position
+// or size change. Child widgets are added to the scene when the container is
+// added to the scene, so they are updated, drawn and focused like any other
+// widget.
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// Align type defines how a child widget is aligned in the space a container
+// gives to it.
+type Align int
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	AlignStart Align = iota
+	AlignCenter
+	AlignEnd
+	AlignStretch
+)
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// GetPreferredSize function returns the size the given entity prefers to be
+// displayed with. Entities implementing IMeasurable return their own
+// preferred size, any other entity prefers its current size.
+func GetPreferredSize(entity engine.IEntity) *api.Size {
+	if measurable, ok := entity.(IMeasurable); ok {
+		return measurable.GetPreferredSize()
+	}
+	if size := entity.GetSize(); size != nil {
+		return api.CloneSize(size)
+	}
+	return api.NewSize(0, 0)
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// alignLength function returns the offset and the length for a child with
+// the given preferred length aligned in the given available length.
+func alignLength(align Align, length int, available int) (int, int) {
+	switch align {
+	case AlignCenter:
+		return (available - length) / 2, length
+	case AlignEnd:
+		return available - length, length
+	case AlignStretch:
+		return 0, available
+	default:
+		return 0, length
+	}
+}
+
+// distributeLength function adds the given extra length to the given lengths
+// proportionally to the given weights. The last weighted length gets any
+// remainder.
+func distributeLength(lengths []int, weights []int, extra int) {
+	total := 0
+	last := -1
+	for i, weight := range weights {
+		if weight > 0 {
+			total += weight
+			last = i
+		}
+	}
+	if total == 0 || extra <= 0 {
+		return
+	}
+	remaining := extra
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		share := extra * weight / total
+		if i == last {
+			share = remaining
+		}
+		lengths[i] += share
+		remaining -= share
+	}
+}
+
+// walkEntity function calls the given function for the given entity and for
+// all children in it, when it is a container.
+func walkEntity(entity engine.IEntity, f func(engine.IEntity)) {
+	if entity == nil {
+		return
+	}
+	f(entity)
+	if container, ok := entity.(IContainer); ok {
+		for _, item := range container.GetItems() {
+			walkEntity(item.GetWidget(), f)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+//
+// IMeasurable
+//
+// -----------------------------------------------------------------------------
+
+// IMeasurable interface defines widgets which know the size they prefer to be
+// displayed with, like a text widget with the size of its text.
+type IMeasurable interface {
+	GetPreferredSize() *api.Size
+}
+
+// -----------------------------------------------------------------------------
+//
+// IContainer
+//
+// -----------------------------------------------------------------------------
+
+// IContainer interface defines all methods any container widget has to
+// implement.
+type IContainer interface {
+	IWidget
+	IMeasurable
+	GetItems() []*ContainerItem
+	Reflow()
+	Remove(engine.IEntity) bool
+}
+
+// -----------------------------------------------------------------------------
+//
+// ContainerItem
+//
+// -----------------------------------------------------------------------------
+
+// ContainerItem structure contains a child widget in a container and how it
+// is arranged.
+// widget is the child widget, or nil for an empty space.
+// space is the preferred size for an empty space.
+// weight is the share of the extra space the child gets in boxes, where zero
+// gives the child its preferred size.
+// align is how the child is aligned in the space it gets.
+// row, column, rowSpan and columnSpan are the grid cells the child uses.
+// container is the container the item belongs to, which arranges its
+// children again when the item changes.
+type ContainerItem struct {
+	container  *Container
+	widget     engine.IEntity
+	space      *api.Size
+	weight     int
+	align      Align
+	row        int
+	column     int
+	rowSpan    int
+	columnSpan int
+}
+
+// NewContainerItem function creates a new ContainerItem instance for the
+// given widget.
+func NewContainerItem(widget engine.IEntity) *ContainerItem {
+	return &ContainerItem{
+		container:  nil,
+		widget:     widget,
+		space:      nil,
+		weight:     0,
+		align:      AlignStart,
+		row:        0,
+		column:     0,
+		rowSpan:    1,
+		columnSpan: 1,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// ContainerItem private methods
+// -----------------------------------------------------------------------------
+
+// reflow method arranges all children in the container again, if the item
+// belongs to any container.
+func (i *ContainerItem) reflow() *ContainerItem {
+	if i.container != nil {
+		i.container.Reflow()
+	}
+	return i
+}
+
+// -----------------------------------------------------------------------------
+// ContainerItem public methods
+// -----------------------------------------------------------------------------
+
+// GetAlign method returns how the child is aligned.
+func (i *ContainerItem) GetAlign() Align {
+	return i.align
+}
+
+// GetCell method returns the grid row and column for the child.
+func (i *ContainerItem) GetCell() (int, int) {
+	return i.row, i.column
+}
+
+// GetPreferredSize method returns the preferred size for the child.
+func (i *ContainerItem) GetPreferredSize() *api.Size {
+	if i.widget == nil {
+		return api.CloneSize(i.space)
+	}
+	return GetPreferredSize(i.widget)
+}
+
+// GetSpan method returns the number of grid rows and columns the child uses.
+func (i *ContainerItem) GetSpan() (int, int) {
+	return i.rowSpan, i.columnSpan
+}
+
+// GetWeight method returns the share of the extra space the child gets.
+func (i *ContainerItem) GetWeight() int {
+	return i.weight
+}
+
+// GetWidget method returns the child widget, nil for an empty space.
+func (i *ContainerItem) GetWidget() engine.IEntity {
+	return i.widget
+}
+
+// WithAlign method sets how the child is aligned in the space it gets.
+func (i *ContainerItem) WithAlign(align Align) *ContainerItem {
+	i.align = align
+	return i.reflow()
+}
+
+// WithSpan method sets the number of grid rows and columns the child uses.
+func (i *ContainerItem) WithSpan(rows int, columns int) *ContainerItem {
+	i.rowSpan = tools.Max(1, rows)
+	i.columnSpan = tools.Max(1, columns)
+	return i.reflow()
+}
+
+// WithWeight method sets the share of the extra space the child gets in a
+// box.
+func (i *ContainerItem) WithWeight(weight int) *ContainerItem {
+	i.weight = weight
+	return i.reflow()
+}
+
+// -----------------------------------------------------------------------------
+//
+// containerArranger
+//
+// -----------------------------------------------------------------------------
+
+// containerArranger interface defines how every container type measures and
+// arranges its children.
+type containerArranger interface {
+	// measure returns the container preferred size for the given children
+	// preferred sizes.
+	measure(items []*ContainerItem, sizes []*api.Size) *api.Size
+
+	// arrange returns the rectangle for every child in the given container
+	// rectangle.
+	arrange(items []*ContainerItem, sizes []*api.Size, rect *api.Rect) []*api.Rect
+}
+
+// -----------------------------------------------------------------------------
+//
+// Container
+//
+// -----------------------------------------------------------------------------
+
+// Container structure defines the base for all container widgets.
+// arranger measures and arranges children for the container type.
+// autoSize is true when the container does not have any size and it takes
+// its preferred size.
+// scene is the scene the container has been added to.
+// lastRect and lastSizes are the container rectangle and children preferred
+// sizes when children were arranged, used to know when they have to be
+// arranged again.
+type Container struct {
+	*Widget
+	items     []*ContainerItem
+	arranger  containerArranger
+	autoSize  bool
+	scene     engine.IScene
+	lastRect  *api.Rect
+	lastSizes []*api.Size
+}
+
+// newContainer function creates a new Container instance at the given
+// position with the given size. A nil size makes the container to take its
+// preferred size.
+func newContainer(name string, position *api.Point, size *api.Size, arranger containerArranger) *Container {
+	if position == nil {
+		position = api.NewPoint(0, 0)
+	}
+	container := &Container{
+		Widget:    NewNamedWidget(name),
+		items:     nil,
+		arranger:  arranger,
+		autoSize:  size == nil,
+		scene:     nil,
+		lastRect:  nil,
+		lastSizes: nil,
+	}
+	if size == nil {
+		size = api.NewSize(0, 0)
+	}
+	container.SetPosition(position)
+	container.Widget.SetSize(size)
+	return container
+}
+
+// -----------------------------------------------------------------------------
+// Container private methods
+// -----------------------------------------------------------------------------
+
+// addItem method adds the given item to the container. The child widget is
+// added to the scene if the container is already in a scene.
+func (c *Container) addItem(item *ContainerItem) *ContainerItem {
+	item.container = c
+	c.items = append(c.items, item)
+	if c.scene != nil && item.widget != nil {
+		c.scene.AddEntity(item.widget)
+	}
+	c.Reflow()
+	return item
+}
+
+// measureItems method returns the preferred size for every child.
+func (c *Container) measureItems() []*api.Size {
+	sizes := make([]*api.Size, len(c.items))
+	for i, item := range c.items {
+		sizes[i] = item.GetPreferredSize()
+	}
+	return sizes
+}
+
+// needsReflow method checks if children have to be arranged again, because
+// the container rectangle or any child preferred size changed.
+func (c *Container) needsReflow() bool {
+	if c.lastRect == nil || !c.lastRect.IsEqual(c.GetRect()) {
+		return true
+	}
+	sizes := c.measureItems()
+	if len(sizes) != len(c.lastSizes) {
+		return true
+	}
+	for i, size := range sizes {
+		if !size.IsEqual(c.lastSizes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// Container public methods
+// -----------------------------------------------------------------------------
+
+// ContainsEntity method checks if the given entity is any child in the
+// container, or in any container inside it. Containers can be used as focus
+// scopes, so the focus is trapped inside them, like for a modal dialog.
+func (c *Container) ContainsEntity(entity engine.IEntity) bool {
+	found := false
+	for _, item := range c.items {
+		walkEntity(item.widget, func(child engine.IEntity) {
+			found = found || child == entity
+		})
+	}
+	return found
+}
+
+// GetItems method returns all container children.
+func (c *Container) GetItems() []*ContainerItem {
+	return c.items
+}
+
+// GetPreferredSize method returns the size required to display all children
+// with their preferred sizes.
+func (c *Container) GetPreferredSize() *api.Size {
+	return c.arranger.measure(c.items, c.measureItems())
+}
+
+// OnAdded method adds all children to the scene the container is added to.
+func (c *Container) OnAdded(scene engine.IScene) {
+	c.Widget.OnAdded(scene)
+	c.scene = scene
+	for _, item := range c.items {
+		if item.widget != nil {
+			scene.AddEntity(item.widget)
+		}
+	}
+	c.Reflow()
+}
+
+// OnRemoved method removes all children from the scene the container is
+// removed from.
+func (c *Container) OnRemoved(scene engine.IScene) {
+	c.Widget.OnRemoved(scene)
+	for _, item := range c.items {
+		if item.widget != nil {
+			scene.RemoveEntity(item.widget)
+		}
+	}
+	c.scene = nil
+}
+
+// Reflow method arranges all children again. Containers without any size
+// take their preferred size.
+func (c *Container) Reflow() {
+	sizes := c.measureItems()
+	if c.autoSize && c.GetLayout() == nil {
+		c.Widget.SetSize(c.arranger.measure(c.items, sizes))
+	}
+	rect := api.NewRect(api.ClonePoint(c.GetPosition()), api.CloneSize(c.GetSize()))
+	for i, childRect := range c.arranger.arrange(c.items, sizes, rect) {
+		widget := c.items[i].widget
+		if widget == nil {
+			continue
+		}
+		oldSize := widget.GetSize()
+		widget.SetPosition(childRect.Origin)
+		widget.SetSize(childRect.Size)
+		if oldSize == nil || !oldSize.IsEqual(childRect.Size) {
+			widget.Refresh()
+		}
+		// nested containers arrange their children in the new rectangle.
+		if container, ok := widget.(IContainer); ok {
+			container.Reflow()
+		}
+	}
+	c.lastRect = rect
+	c.lastSizes = c.measureItems()
+}
+
+// Refresh method arranges all children again.
+func (c *Container) Refresh() {
+	c.Reflow()
+}
+
+// Remove method removes the given child widget from the container and from
+// the scene the container is in.
+func (c *Container) Remove(widget engine.IEntity) bool {
+	for i, item := range c.items {
+		if item.widget == widget {
+			item.container = nil
+			c.items = append(c.items[:i], c.items[i+1:]...)
+			if c.scene != nil {
+				c.scene.RemoveEntity(widget)
+			}
+			c.Reflow()
+			return true
+		}
+	}
+	return false
+}
+
+// SetSize method sets a fixed size for the container, so it does not take its
+// preferred size anymore.
+func (c *Container) SetSize(size *api.Size) {
+	c.autoSize = false
+	c.Widget.SetSize(size)
+}
+
+// SetVisible method shows or hides the container and all its children.
+func (c *Container) SetVisible(visible bool) {
+	c.Widget.SetVisible(visible)
+	for _, item := range c.items {
+		if item.widget != nil {
+			item.widget.SetVisible(visible)
+		}
+	}
+}
+
+// Update method arranges all children again if their content or the
+// container position or size changed.
+func (c *Container) Update(event tcell.Event, scene engine.IScene) {
+	c.Widget.Update(event, scene)
+	if c.needsReflow() {
+		c.Reflow()
+	}
+}
+
+var _ engine.IObject = (*Container)(nil)
+var _ engine.IFocus = (*Container)(nil)
+var _ engine.IEntity = (*Container)(nil)
+var _ IWidget = (*Container)(nil)
+var _ IContainer = (*Container)(nil)
+var _ engine.IFocusScope = (*Container)(nil)
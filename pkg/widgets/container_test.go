@@ -0,0 +1,182 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+// checkRects function checks every given entity has the expected rectangle.
+func checkRects(t *testing.T, index int, entities []engine.IEntity, exps []*api.Rect) {
+	for i, entity := range entities {
+		if got := entity.GetRect(); !got.IsEqual(exps[i]) {
+			t.Errorf("[%d:%d] GetRect Error exp:%s got:%s", index, i, exps[i].ToString(), got.ToString())
+		}
+	}
+}
+
+func TestContainerBoxes(t *testing.T) {
+	style := tcell.StyleDefault
+	left := widgets.NewText("left", nil, nil, &style, "ok")
+	middle := widgets.NewWidget("middle", nil, api.NewSize(4, 1), &style)
+	right := widgets.NewText("right", nil, nil, &style, "quit\nnow")
+
+	// fixed size boxes share extra space between weighted children and
+	// align children in the cross axis.
+	hbox := widgets.NewHBox("hbox", api.NewPoint(1, 1), api.NewSize(20, 3), 1)
+	hbox.Add(left).WithAlign(widgets.AlignCenter)
+	hbox.Add(middle).WithWeight(1).WithAlign(widgets.AlignStretch)
+	hbox.Add(right).WithAlign(widgets.AlignEnd)
+	checkRects(t, 0, []engine.IEntity{left, middle, right}, []*api.Rect{
+		api.NewRect(api.NewPoint(1, 2), api.NewSize(2, 1)),
+		api.NewRect(api.NewPoint(4, 1), api.NewSize(12, 3)),
+		api.NewRect(api.NewPoint(17, 2), api.NewSize(4, 2)),
+	})
+
+	// boxes without any size take the size required by their children.
+	top := widgets.NewText("top", nil, nil, &style, "title")
+	bottom := widgets.NewText("bottom", nil, nil, &style, "status")
+	vbox := widgets.NewVBox("vbox", api.NewPoint(0, 5), nil, 1)
+	vbox.Add(top)
+	vbox.AddSpace(2)
+	vbox.Add(bottom)
+	checkRects(t, 1, []engine.IEntity{vbox, top, bottom}, []*api.Rect{
+		api.NewRect(api.NewPoint(0, 5), api.NewSize(6, 6)),
+		api.NewRect(api.NewPoint(0, 5), api.NewSize(5, 1)),
+		api.NewRect(api.NewPoint(0, 10), api.NewSize(6, 1)),
+	})
+
+	// nested containers are measured and arranged.
+	outer := widgets.NewHBox("outer", api.NewPoint(0, 0), nil, 0)
+	outer.Add(vbox)
+	outer.Add(hbox)
+	checkRects(t, 2, []engine.IEntity{outer, vbox, top, hbox, right}, []*api.Rect{
+		api.NewRect(api.NewPoint(0, 0), api.NewSize(26, 6)),
+		api.NewRect(api.NewPoint(0, 0), api.NewSize(6, 6)),
+		api.NewRect(api.NewPoint(0, 0), api.NewSize(5, 1)),
+		api.NewRect(api.NewPoint(6, 0), api.NewSize(20, 3)),
+		api.NewRect(api.NewPoint(22, 1), api.NewSize(4, 2)),
+	})
+}
+
+func TestContainerGridAndStack(t *testing.T) {
+	style := tcell.StyleDefault
+	title := widgets.NewText("title", nil, nil, &style, "character sheet")
+	strLabel := widgets.NewText("str-label", nil, nil, &style, "STR")
+	strValue := widgets.NewText("str-value", nil, nil, &style, "14")
+	dexLabel := widgets.NewText("dex-label", nil, nil, &style, "DEX")
+	dexValue := widgets.NewText("dex-value", nil, nil, &style, "12")
+	grid := widgets.NewGrid("grid", api.NewPoint(0, 0), nil, 1, 0)
+	grid.AddAt(title, 0, 0).WithSpan(1, 2)
+	grid.AddAt(strLabel, 1, 0)
+	grid.AddAt(strValue, 1, 1).WithAlign(widgets.AlignEnd)
+	grid.AddAt(dexLabel, 2, 0)
+	grid.AddAt(dexValue, 2, 1).WithAlign(widgets.AlignEnd)
+	checkRects(t, 0, []engine.IEntity{grid, title, strLabel, strValue, dexValue}, []*api.Rect{
+		api.NewRect(api.NewPoint(0, 0), api.NewSize(15, 3)),
+		api.NewRect(api.NewPoint(0, 0), api.NewSize(15, 1)),
+		api.NewRect(api.NewPoint(0, 1), api.NewSize(3, 1)),
+		api.NewRect(api.NewPoint(13, 1), api.NewSize(2, 1)),
+		api.NewRect(api.NewPoint(13, 2), api.NewSize(2, 1)),
+	})
+
+	// weighted columns share the extra width.
+	grid.SetSize(api.NewSize(21, 3))
+	grid.SetColumnWeight(0, 1)
+	checkRects(t, 1, []engine.IEntity{strLabel, strValue}, []*api.Rect{
+		api.NewRect(api.NewPoint(0, 1), api.NewSize(3, 1)),
+		api.NewRect(api.NewPoint(19, 1), api.NewSize(2, 1)),
+	})
+
+	background := widgets.NewWidget("background", nil, api.NewSize(1, 1), &style)
+	label := widgets.NewText("label", nil, nil, &style, "hi")
+	stack := widgets.NewStack("stack", api.NewPoint(2, 2), api.NewSize(10, 3))
+	stack.Add(background).WithAlign(widgets.AlignStretch)
+	stack.Add(label).WithAlign(widgets.AlignCenter)
+	checkRects(t, 2, []engine.IEntity{background, label}, []*api.Rect{
+		api.NewRect(api.NewPoint(2, 2), api.NewSize(10, 3)),
+		api.NewRect(api.NewPoint(6, 3), api.NewSize(2, 1)),
+	})
+	if label.GetZLevel() <= background.GetZLevel() {
+		t.Errorf("[2] GetZLevel Error exp:label over background got:%d %d", label.GetZLevel(), background.GetZLevel())
+	}
+}
+
+func TestContainerScene(t *testing.T) {
+	style := tcell.StyleDefault
+	scene := engine.NewEngine().NewScene("scene/test/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(40, 20)))
+	hp := widgets.NewText("hp", nil, nil, &style, "HP: 9")
+	status := widgets.NewText("status", nil, nil, &style, "ok")
+	vbox := widgets.NewVBox("vbox", api.NewPoint(1, 1), nil, 0)
+	vbox.Add(hp)
+	vbox.Add(status)
+
+	// children are added to and removed from the scene with the container.
+	scene.AddEntity(vbox)
+	if got := len(scene.GetEntities()); got != 3 {
+		t.Errorf("[0] AddEntity Error exp:3 got:%d", got)
+	}
+
+	// children are arranged again when their content changes.
+	hp.SetText("HP: 10\nPoisoned")
+	scene.Update(nil)
+	if got := status.GetPosition(); !got.IsEqual(api.NewPoint(1, 3)) {
+		t.Errorf("[1] Update Error exp:[1,3] got:%s", got.ToString())
+	}
+	if got := vbox.GetSize(); !got.IsEqual(api.NewSize(8, 3)) {
+		t.Errorf("[1] Update Error exp:[8,3] got:%s", got.ToString())
+	}
+
+	vbox.Remove(hp)
+	if got := status.GetPosition(); !got.IsEqual(api.NewPoint(1, 1)) {
+		t.Errorf("[2] Remove Error exp:[1,1] got:%s", got.ToString())
+	}
+	scene.RemoveEntity(vbox)
+	if got := len(scene.GetEntities()); got != 0 {
+		t.Errorf("[2] RemoveEntity Error exp:0 got:%d", got)
+	}
+}
+
+func TestContainerContainsEntity(t *testing.T) {
+	style := tcell.StyleDefault
+	ok := widgets.NewButton("ok", api.NewPoint(0, 0), api.NewSize(6, 1), &style, "OK")
+	cancel := widgets.NewButton("cancel", api.NewPoint(0, 0), api.NewSize(6, 1), &style, "Cancel")
+	outside := widgets.NewButton("outside", api.NewPoint(0, 0), api.NewSize(6, 1), &style, "Quit")
+	buttons := widgets.NewHBox("buttons", nil, nil, 1)
+	buttons.Add(ok)
+	buttons.Add(cancel)
+	dialog := widgets.NewVBox("dialog", api.NewPoint(2, 2), nil, 0)
+	dialog.Add(buttons)
+
+	cases := []struct {
+		entity engine.IEntity
+		exp    bool
+	}{
+		{buttons, true},
+		{ok, true},
+		{cancel, true},
+		{outside, false},
+		{dialog, false},
+	}
+	for i, c := range cases {
+		if got := dialog.ContainsEntity(c.entity); got != c.exp {
+			t.Errorf("[%d] ContainsEntity Error exp:%t got:%t", i, c.exp, got)
+		}
+	}
+
+	// the focus is trapped inside the dialog while it is the focus scope.
+	scene := engine.NewEngine().NewScene("scene/test/scope", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(40, 20)))
+	scene.AddEntity(outside)
+	scene.AddEntity(dialog)
+	focusManager := scene.GetEngine().GetFocusManager()
+	focusManager.PushFocusScope(scene, dialog)
+	for i, exp := range []engine.IEntity{ok, cancel, ok} {
+		if !exp.HasFocus() || outside.HasFocus() {
+			t.Errorf("[%d] PushFocusScope Error exp:%s got:%t", i, exp.GetName(), exp.HasFocus())
+		}
+		_ = focusManager.FocusNextInScene(scene)
+	}
+}
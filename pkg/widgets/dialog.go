@@ -42,8 +42,8 @@ func NewDialog(name string, position *api.Point, size *api.Size, style *tcell.St
 		opts:       opts,
 	}
 
-	d.background.GetCanvas().FillWithCell(engine.NewCell(style, ' '))
-	d.background.GetCanvas().WriteRectangleInCanvasAt(nil, nil, d.GetStyle(), engine.CanvasRectSingleLine)
+	d.background.GetCanvas().FillWithCell(engine.NewCell(GetThemeStyleFor("dialog", ThemeRoleNormal, style), ' '))
+	d.background.GetCanvas().WriteRectangleInCanvasAt(nil, nil, GetThemeStyleFor("dialog", ThemeRoleBorder, style), engine.CanvasRectSingleLine)
 	scene.AddEntity(d.background)
 
 	maxW := d.populateTexts()
@@ -67,7 +67,7 @@ func (d *Dialog) populateButtons() {
 	}
 	nextW := 0
 	for _, button := range d.GetButtons() {
-		w := len(button.GetLabel())
+		w := button.displayWidth(button.GetDisplayLabel())
 		padding := (spacePerLabel - w) / 2
 		button.SetPosition(api.NewPoint(x+nextW+padding, y))
 		button.SetSize(api.NewSize(w, 3))
@@ -91,7 +91,7 @@ func (d *Dialog) populateTexts() int {
 	maxW := 0
 	for i, text := range d.GetTexts() {
 		text.SetPosition(api.NewPoint(x, y+i))
-		w := len(text.GetText())
+		w := text.displayWidth(text.GetDisplayText())
 		maxW = tools.Max(maxW, w)
 		text.SetSize(api.NewSize(w, 1))
 		text.SetCanvas(engine.NewCanvas(text.GetSize()))
@@ -112,9 +112,10 @@ func (d *Dialog) populateTextInputs(maxW int) {
 		w := d.GetSize().W - maxW - 2
 		input.SetSize(api.NewSize(w, 1))
 		input.SetCanvas(engine.NewCanvas(input.GetSize()))
-		if style := input.GetStyle(); style == nil {
-			style = tools.ReverseStyle(d.GetStyle())
-			input.SetStyle(style)
+		// inputs without any style use the dialog selected style, so they
+		// stand out from the dialog background.
+		if input.GetStyle() == nil {
+			input.SetStyle(GetThemeStyleFor("dialog", ThemeRoleSelected, d.GetStyle()))
 		}
 		input.Refresh()
 		d.GetScene().AddEntity(input)
@@ -129,6 +130,30 @@ func (d *Dialog) Close() []string {
 	return d.GetOutputs()
 }
 
+// ContainsEntity method checks if the given entity is any text, text input or
+// button in the dialog, so the dialog can be used as a focus scope.
+func (d *Dialog) ContainsEntity(entity engine.IEntity) bool {
+	if entity == d.background {
+		return true
+	}
+	for _, text := range d.texts {
+		if engine.IEntity(text) == entity {
+			return true
+		}
+	}
+	for _, input := range d.inputs {
+		if engine.IEntity(input) == entity {
+			return true
+		}
+	}
+	for _, button := range d.buttons {
+		if engine.IEntity(button) == entity {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *Dialog) GetButtons() []*Button {
 	return d.buttons
 }
@@ -171,3 +196,5 @@ func (d *Dialog) GetTexts() []*Text {
 //func (d *Dialog) SetTexts(texts []*Text) {
 //    d.texts = texts
 //}
+
+var _ engine.IFocusScope = (*Dialog)(nil)
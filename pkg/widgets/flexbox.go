@@ -0,0 +1,210 @@
+// flexbox.go contains the HBox and VBox container widgets, which arrange
+// their children in a row or in a column.
+// Example:
+//
+//	toolbar := widgets.NewHBox("toolbar", api.NewPoint(0, 0), api.NewSize(80, 1), 1)
+//	toolbar.Add(saveButton)
+//	toolbar.Add(title).WithWeight(1).WithAlign(widgets.AlignCenter)
+//	toolbar.Add(quitButton)
+//	scene.AddEntity(toolbar)
+package widgets
+
+import (
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+//
+// flexArranger
+//
+// -----------------------------------------------------------------------------
+
+// flexArranger structure arranges children in a row or in a column with the
+// given spacing between them. Children get their preferred length in the
+// main axis, and children with a weight share any extra space. Every child is
+// aligned in the cross axis with its alignment.
+type flexArranger struct {
+	horizontal bool
+	spacing    int
+}
+
+// axis method returns the main and the cross lengths for the given size.
+func (a *flexArranger) axis(size *api.Size) (int, int) {
+	if a.horizontal {
+		return size.W, size.H
+	}
+	return size.H, size.W
+}
+
+// point method returns the point for the given main and cross positions.
+func (a *flexArranger) point(main int, cross int) *api.Point {
+	if a.horizontal {
+		return api.NewPoint(main, cross)
+	}
+	return api.NewPoint(cross, main)
+}
+
+// size method returns the size for the given main and cross lengths.
+func (a *flexArranger) size(main int, cross int) *api.Size {
+	if a.horizontal {
+		return api.NewSize(main, cross)
+	}
+	return api.NewSize(cross, main)
+}
+
+// measure method returns the size for all children in a row or in a column.
+func (a *flexArranger) measure(items []*ContainerItem, sizes []*api.Size) *api.Size {
+	mainLength, crossLength := 0, 0
+	for _, size := range sizes {
+		main, cross := a.axis(size)
+		mainLength += main
+		crossLength = tools.Max(crossLength, cross)
+	}
+	if len(sizes) > 1 {
+		mainLength += a.spacing * (len(sizes) - 1)
+	}
+	return a.size(mainLength, crossLength)
+}
+
+// arrange method returns the rectangle for every child in the row or in the
+// column.
+func (a *flexArranger) arrange(items []*ContainerItem, sizes []*api.Size, rect *api.Rect) []*api.Rect {
+	available, crossAvailable := a.axis(rect.Size)
+	lengths := make([]int, len(items))
+	weights := make([]int, len(items))
+	used := 0
+	for i, item := range items {
+		weights[i] = item.weight
+		if item.weight <= 0 {
+			lengths[i], _ = a.axis(sizes[i])
+		}
+		used += lengths[i]
+	}
+	if len(items) > 1 {
+		used += a.spacing * (len(items) - 1)
+	}
+	distributeLength(lengths, weights, available-used)
+
+	start, crossStart := rect.Origin.X, rect.Origin.Y
+	if !a.horizontal {
+		start, crossStart = crossStart, start
+	}
+	result := make([]*api.Rect, len(items))
+	main := start
+	for i, item := range items {
+		_, cross := a.axis(sizes[i])
+		offset, cross := alignLength(item.align, cross, crossAvailable)
+		result[i] = api.NewRect(a.point(main, crossStart+offset), a.size(lengths[i], cross))
+		main += lengths[i] + a.spacing
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+//
+// HBox
+//
+// -----------------------------------------------------------------------------
+
+// HBox structure defines a container which arranges its children in a row
+// from left to right.
+type HBox struct {
+	*Container
+	arranger *flexArranger
+}
+
+// NewHBox function creates a new HBox instance at the given position with the
+// given size and the given spacing between children. A nil size makes the box
+// to take the size required by its children.
+func NewHBox(name string, position *api.Point, size *api.Size, spacing int) *HBox {
+	arranger := &flexArranger{horizontal: true, spacing: spacing}
+	return &HBox{
+		Container: newContainer(name, position, size, arranger),
+		arranger:  arranger,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// HBox public methods
+// -----------------------------------------------------------------------------
+
+// Add method adds the given widget at the end of the box.
+func (b *HBox) Add(widget engine.IEntity) *ContainerItem {
+	return b.addItem(NewContainerItem(widget))
+}
+
+// AddSpace method adds an empty space with the given width at the end of the
+// box.
+func (b *HBox) AddSpace(width int) *ContainerItem {
+	item := NewContainerItem(nil)
+	item.space = api.NewSize(width, 0)
+	return b.addItem(item)
+}
+
+// GetSpacing method returns the space between children.
+func (b *HBox) GetSpacing() int {
+	return b.arranger.spacing
+}
+
+// SetSpacing method sets the space between children.
+func (b *HBox) SetSpacing(spacing int) {
+	b.arranger.spacing = spacing
+	b.Reflow()
+}
+
+// -----------------------------------------------------------------------------
+//
+// VBox
+//
+// -----------------------------------------------------------------------------
+
+// VBox structure defines a container which arranges its children in a column
+// from top to bottom.
+type VBox struct {
+	*Container
+	arranger *flexArranger
+}
+
+// NewVBox function creates a new VBox instance at the given position with the
+// given size and the given spacing between children. A nil size makes the box
+// to take the size required by its children.
+func NewVBox(name string, position *api.Point, size *api.Size, spacing int) *VBox {
+	arranger := &flexArranger{horizontal: false, spacing: spacing}
+	return &VBox{
+		Container: newContainer(name, position, size, arranger),
+		arranger:  arranger,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// VBox public methods
+// -----------------------------------------------------------------------------
+
+// Add method adds the given widget at the bottom of the box.
+func (b *VBox) Add(widget engine.IEntity) *ContainerItem {
+	return b.addItem(NewContainerItem(widget))
+}
+
+// AddSpace method adds an empty space with the given height at the bottom of
+// the box.
+func (b *VBox) AddSpace(height int) *ContainerItem {
+	item := NewContainerItem(nil)
+	item.space = api.NewSize(0, height)
+	return b.addItem(item)
+}
+
+// GetSpacing method returns the space between children.
+func (b *VBox) GetSpacing() int {
+	return b.arranger.spacing
+}
+
+// SetSpacing method sets the space between children.
+func (b *VBox) SetSpacing(spacing int) {
+	b.arranger.spacing = spacing
+	b.Reflow()
+}
+
+var _ IContainer = (*HBox)(nil)
+var _ IContainer = (*VBox)(nil)
@@ -70,6 +70,24 @@ func NewFrameWithCells(cells engine.CellGroup, maxTicks int) *Frame {
 // Frame public methods
 // -----------------------------------------------------------------------------
 
+// Clone method returns a new Frame instance with the same content and the
+// counter reset.
+func (f *Frame) Clone() *Frame {
+	frame := &Frame{
+		canvas:   nil,
+		cells:    nil,
+		maxTicks: f.maxTicks,
+		ticks:    0,
+	}
+	if f.canvas != nil {
+		frame.canvas = engine.CloneCanvas(f.canvas)
+	}
+	if f.cells != nil {
+		frame.cells = cloneCells(f.cells)
+	}
+	return frame
+}
+
 // GetCanvas method returns the canvas instance number.
 func (f *Frame) GetCanvas() *engine.Canvas {
 	return f.canvas
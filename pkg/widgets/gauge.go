@@ -9,21 +9,21 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/engine"
-	"github.com/jrecuero/thengine/pkg/tools"
 )
 
 // -----------------------------------------------------------------------------
 // Package private methods
 // -----------------------------------------------------------------------------
 
-// updateCanvas function updates the canvas for a widget instance as a gauge.
-func updateCanvas(widget engine.IEntity, total int, completed int) {
+// updateCanvas function updates the canvas for a widget instance as a gauge,
+// where the completed part uses the fill style.
+func updateCanvas(widget *Widget, total int, completed int) {
 	var step float64 = float64(total) / float64(widget.GetSize().W)
 	var completedSteps float64 = float64(completed) / step
 	completedPattern := strings.Repeat(" ", int(completedSteps))
 	basePattern := strings.Repeat(" ", widget.GetSize().W)
-	widget.GetCanvas().WriteStringInCanvas(basePattern, widget.GetStyle())
-	widget.GetCanvas().WriteStringInCanvas(completedPattern, tools.ReverseStyle(widget.GetStyle()))
+	widget.GetCanvas().WriteStringInCanvas(basePattern, widget.GetThemeStyle(ThemeRoleNormal))
+	widget.GetCanvas().WriteStringInCanvas(completedPattern, widget.GetThemeStyle(ThemeRoleFill))
 	//tools.Logger.WithField("module", "gauge").
 	//    WithField("function", "updateCanvas").
 	//    Debugf("%d/%d %f %f %s", total, completed, step, completedSteps, tools.StyleToString(widget.GetStyle()))
@@ -49,6 +49,7 @@ func NewGauge(name string, position *api.Point, size *api.Size, style *tcell.Sty
 		total:     total,
 		completed: 0,
 	}
+	gauge.SetThemeClass("gauge")
 	gauge.updateCanvas()
 	return gauge
 }
@@ -60,7 +61,7 @@ func NewGauge(name string, position *api.Point, size *api.Size, style *tcell.Sty
 // updateCanvas method updates the gauge widget canvas with latest completed
 // value.
 func (g *Gauge) updateCanvas() {
-	updateCanvas(g, g.total, g.completed)
+	updateCanvas(g.Widget, g.total, g.completed)
 }
 
 // -----------------------------------------------------------------------------
@@ -84,6 +85,11 @@ func (g *Gauge) IncCompleted(steps int) int {
 	return g.completed
 }
 
+// Refresh method refreshes the gauge with latest attribute values.
+func (g *Gauge) Refresh() {
+	g.updateCanvas()
+}
+
 // SetCompleted method sets a new value for the completed attribute.
 func (g *Gauge) SetCompleted(completed int) {
 	// completed gauge can not be lower than zero.
@@ -125,6 +131,7 @@ func NewTimerGauge(name string, position *api.Point, size *api.Size, style *tcel
 		total:     total,
 	}
 	gauge.Timer.Widget = NewWidget(name, position, size, style)
+	gauge.SetThemeClass("gauge")
 	gauge.updateCanvas()
 	return gauge
 }
@@ -136,7 +143,7 @@ func NewTimerGauge(name string, position *api.Point, size *api.Size, style *tcel
 // updateCanvas method updates the gauge widget canvas with latest completed
 // value.
 func (g *TimerGauge) updateCanvas() {
-	updateCanvas(g, g.total, g.completed)
+	updateCanvas(g.Widget, g.total, g.completed)
 	//tools.Logger.WithField("module", "gauge").
 	//    WithField("struct", "TimerGauge").
 	//    WithField("method", "updateCanvas").
@@ -153,6 +160,11 @@ func (g *TimerGauge) Draw(scene engine.IScene) {
 	g.Timer.Widget.Draw(scene)
 }
 
+// Refresh method refreshes the gauge with latest attribute values.
+func (g *TimerGauge) Refresh() {
+	g.updateCanvas()
+}
+
 // RestartTimer method re-starts the timer.
 func (g *TimerGauge) RestartTimer() {
 	g.Timer.RestartTimer()
@@ -0,0 +1,196 @@
+// grid.go contains the Grid container widget, which arranges its children in
+// rows and columns. Every child is placed in a cell and it can span several
+// rows and columns. Every column is as wide as its widest child and every row
+// is as high as its highest child, and columns and rows with a weight share
+// any extra space.
+// Example:
+//
+//	sheet := widgets.NewGrid("sheet", api.NewPoint(0, 0), nil, 1, 0)
+//	sheet.AddAt(title, 0, 0).WithSpan(1, 2).WithAlign(widgets.AlignCenter)
+//	sheet.AddAt(strLabel, 1, 0)
+//	sheet.AddAt(strValue, 1, 1).WithAlign(widgets.AlignEnd)
+//	scene.AddEntity(sheet)
+package widgets
+
+import (
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+//
+// gridArranger
+//
+// -----------------------------------------------------------------------------
+
+// gridArranger structure arranges children in rows and columns with the
+// given spacing between columns and rows.
+// columnWeights and rowWeights are the share of the extra space every column
+// and every row gets.
+type gridArranger struct {
+	columnSpacing int
+	rowSpacing    int
+	columnWeights map[int]int
+	rowWeights    map[int]int
+}
+
+// lengths method returns the width for every column and the height for every
+// row required to display all children with their preferred sizes.
+func (a *gridArranger) lengths(items []*ContainerItem, sizes []*api.Size) ([]int, []int) {
+	columns, rows := 0, 0
+	for _, item := range items {
+		columns = tools.Max(columns, item.column+item.columnSpan)
+		rows = tools.Max(rows, item.row+item.rowSpan)
+	}
+	widths := make([]int, columns)
+	heights := make([]int, rows)
+	// children using one cell set the length first, and children spanning
+	// several cells grow the last cell they use if they do not fit.
+	for _, spanning := range []bool{false, true} {
+		for i, item := range items {
+			if (item.columnSpan > 1) == spanning {
+				growLength(widths, item.column, item.columnSpan, a.columnSpacing, sizes[i].W)
+			}
+			if (item.rowSpan > 1) == spanning {
+				growLength(heights, item.row, item.rowSpan, a.rowSpacing, sizes[i].H)
+			}
+		}
+	}
+	return widths, heights
+}
+
+// measure method returns the size for all rows and columns.
+func (a *gridArranger) measure(items []*ContainerItem, sizes []*api.Size) *api.Size {
+	widths, heights := a.lengths(items, sizes)
+	return api.NewSize(sumLength(widths, 0, len(widths), a.columnSpacing),
+		sumLength(heights, 0, len(heights), a.rowSpacing))
+}
+
+// arrange method returns the rectangle for every child in its cells.
+func (a *gridArranger) arrange(items []*ContainerItem, sizes []*api.Size, rect *api.Rect) []*api.Rect {
+	widths, heights := a.lengths(items, sizes)
+	distributeLength(widths, weightsFor(a.columnWeights, len(widths)),
+		rect.Size.W-sumLength(widths, 0, len(widths), a.columnSpacing))
+	distributeLength(heights, weightsFor(a.rowWeights, len(heights)),
+		rect.Size.H-sumLength(heights, 0, len(heights), a.rowSpacing))
+	result := make([]*api.Rect, len(items))
+	for i, item := range items {
+		x := rect.Origin.X + sumLength(widths, 0, item.column, a.columnSpacing)
+		y := rect.Origin.Y + sumLength(heights, 0, item.row, a.rowSpacing)
+		if item.column > 0 {
+			x += a.columnSpacing
+		}
+		if item.row > 0 {
+			y += a.rowSpacing
+		}
+		width := sumLength(widths, item.column, item.column+item.columnSpan, a.columnSpacing)
+		height := sumLength(heights, item.row, item.row+item.rowSpan, a.rowSpacing)
+		offsetX, w := alignLength(item.align, tools.Min(sizes[i].W, width), width)
+		offsetY, h := alignLength(item.align, tools.Min(sizes[i].H, height), height)
+		result[i] = api.NewRect(api.NewPoint(x+offsetX, y+offsetY), api.NewSize(w, h))
+	}
+	return result
+}
+
+// growLength function grows the last of the given lengths from start, using
+// count lengths, if the given length does not fit in them.
+func growLength(lengths []int, start int, count int, spacing int, length int) {
+	if current := sumLength(lengths, start, start+count, spacing); current < length {
+		lengths[start+count-1] += length - current
+	}
+}
+
+// sumLength function returns the sum of the given lengths from start to end,
+// not included, with the given spacing between them.
+func sumLength(lengths []int, start int, end int, spacing int) int {
+	result := 0
+	for i := start; i < end; i++ {
+		result += lengths[i]
+	}
+	if end-start > 1 {
+		result += spacing * (end - start - 1)
+	}
+	return result
+}
+
+// weightsFor function returns the weight for every index from the given
+// weights by index.
+func weightsFor(weights map[int]int, count int) []int {
+	result := make([]int, count)
+	for index, weight := range weights {
+		if index < count {
+			result[index] = weight
+		}
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+//
+// Grid
+//
+// -----------------------------------------------------------------------------
+
+// Grid structure defines a container which arranges its children in rows and
+// columns.
+type Grid struct {
+	*Container
+	arranger *gridArranger
+}
+
+// NewGrid function creates a new Grid instance at the given position with the
+// given size and the given spacing between columns and between rows. A nil
+// size makes the grid to take the size required by its children.
+func NewGrid(name string, position *api.Point, size *api.Size, columnSpacing int, rowSpacing int) *Grid {
+	arranger := &gridArranger{
+		columnSpacing: columnSpacing,
+		rowSpacing:    rowSpacing,
+		columnWeights: make(map[int]int),
+		rowWeights:    make(map[int]int),
+	}
+	return &Grid{
+		Container: newContainer(name, position, size, arranger),
+		arranger:  arranger,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Grid public methods
+// -----------------------------------------------------------------------------
+
+// AddAt method adds the given widget in the cell at the given row and column.
+// The returned item can be used to span several rows and columns.
+func (g *Grid) AddAt(widget engine.IEntity, row int, column int) *ContainerItem {
+	item := NewContainerItem(widget)
+	item.row = tools.Max(0, row)
+	item.column = tools.Max(0, column)
+	return g.addItem(item)
+}
+
+// SetColumnWeight method sets the share of the extra width the given column
+// gets when the grid is wider than its children.
+func (g *Grid) SetColumnWeight(column int, weight int) {
+	g.arranger.columnWeights[column] = weight
+	g.Reflow()
+}
+
+// SetRowWeight method sets the share of the extra height the given row gets
+// when the grid is higher than its children.
+func (g *Grid) SetRowWeight(row int, weight int) {
+	g.arranger.rowWeights[row] = weight
+	g.Reflow()
+}
+
+// SetSpan method sets the number of rows and columns the given widget spans.
+func (g *Grid) SetSpan(widget engine.IEntity, rows int, columns int) bool {
+	for _, item := range g.items {
+		if item.widget == widget {
+			item.WithSpan(rows, columns)
+			return true
+		}
+	}
+	return false
+}
+
+var _ IContainer = (*Grid)(nil)
@@ -46,6 +46,13 @@ func (g *Group) GetWidgets() []IWidget {
 	return g.widgets
 }
 
+// Refresh method refreshes all widgets in the Group.
+func (g *Group) Refresh() {
+	for _, w := range g.widgets {
+		w.Refresh()
+	}
+}
+
 var _ engine.IObject = (*Group)(nil)
 var _ engine.IFocus = (*Group)(nil)
 var _ engine.IEntity = (*Group)(nil)
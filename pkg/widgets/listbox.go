@@ -3,7 +3,6 @@
 package widgets
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
@@ -31,21 +30,17 @@ func NewListBox(name string, position *api.Point, size *api.Size, style *tcell.S
 	tools.Logger.WithField("module", "listbox").
 		WithField("function", "NewListBox").
 		Infof("%s %s %s %+v", name, position.ToString(), size.ToString(), selections)
-	selectionsLength := len(selections)
-	// Add padding for every menu item to fill the whole horizontal length.
-	paddingSelections := make([]string, selectionsLength)
-	for i, s := range selections {
-		paddingSelections[i] = fmt.Sprintf("%-*s", size.W-2, s)
-	}
 	//tools.Logger.WithField("module", "listbox").
 	//    WithField("function", "NewListBox").
 	//    Debugf("%s %+v", name, paddingSelections)
 	listBox := &ListBox{
 		Widget:         NewWidget(name, position, size, style),
-		selections:     paddingSelections,
+		selections:     selections,
 		selectionIndex: selectionIndex,
 	}
-	listBox.scroller = NewVerticalScroller(selectionsLength, size.H-2)
+	listBox.scroller = NewVerticalScroller(len(selections), size.H-2)
+	listBox.SetThemeClass("listbox")
+	listBox.SetHandlesArrows(true)
 	listBox.SetFocusType(engine.SingleFocus)
 	listBox.SetFocusEnable(true)
 	listBox.updateCanvas()
@@ -83,25 +78,34 @@ func (l *ListBox) execute(args ...any) {
 	}
 }
 
+// padSelection method adds padding to the given selection to fill the whole
+// horizontal length. Padding is based on the visible width, so markup tags are
+// not taken into account when the list box handles markup.
+func (l *ListBox) padSelection(selection string) string {
+	return selection + strings.Repeat(" ", tools.Max(0, l.GetSize().W-2-l.displayWidth(selection)))
+}
+
 // updateCanvas method updates the list box canvas with proper selections to be
 // displayed and the proper selected option.
 func (l *ListBox) updateCanvas() {
+	canvas := l.GetCanvas()
+	canvas.WriteRectangleInCanvasAt(nil, nil, l.GetThemeStyle(ThemeRoleBorder), engine.CanvasRectSingleLine)
+	if len(l.selections) == 0 {
+		return
+	}
 	// update the scroller with the selection index.
 	l.scroller.Update(l.selectionIndex)
-	canvas := l.GetCanvas()
-	canvas.WriteRectangleInCanvasAt(nil, nil, l.GetStyle(), engine.CanvasRectSingleLine)
 	l.scroller.CreateIter()
 	//tools.Logger.WithField("module", "listbox").
 	//    WithField("method", "updateCanvas").
 	//    Debugf("iter %s", iter.ToString())
 	for x, y := 1, 1; l.scroller.IterHasNext(); y++ {
 		index, _ := l.scroller.IterGetNext()
-		selection := l.selections[index]
+		selection := l.padSelection(l.selections[index])
 		if index == l.selectionIndex {
-			reverseStyle := tools.ReverseStyle(l.GetStyle())
-			canvas.WriteStringInCanvasAt(selection, reverseStyle, api.NewPoint(x, y))
+			l.writeDisplayString(canvas, selection, l.GetThemeStyle(ThemeRoleSelected), api.NewPoint(x, y))
 		} else {
-			canvas.WriteStringInCanvasAt(selection, l.GetStyle(), api.NewPoint(x, y))
+			l.writeDisplayString(canvas, selection, l.GetThemeStyle(ThemeRoleNormal), api.NewPoint(x, y))
 		}
 	}
 }
@@ -110,8 +114,12 @@ func (l *ListBox) updateCanvas() {
 // ListBox public methods
 // -----------------------------------------------------------------------------
 
-// GetSelection method returns the option for the selected index.
+// GetSelection method returns the option for the selected index. It returns
+// an empty string if there is not any option.
 func (l *ListBox) GetSelection() string {
+	if l.selectionIndex < 0 || l.selectionIndex >= len(l.selections) {
+		return ""
+	}
 	return strings.TrimSpace(l.selections[l.selectionIndex])
 }
 
@@ -120,6 +128,29 @@ func (l *ListBox) GetSelectionIndex() int {
 	return l.selectionIndex
 }
 
+// Refresh method refreshes the list box canvas with latest attribute values.
+func (l *ListBox) Refresh() {
+	l.updateCanvas()
+}
+
+// SetMarkup method sets if the list box options handle inline markup tags.
+// Markup is disabled by default, so any option is displayed as it is.
+func (l *ListBox) SetMarkup(markup bool) {
+	l.markup = markup
+	l.updateCanvas()
+}
+
+// SetSelections method replaces all options in the list box and sets the
+// selected index.
+func (l *ListBox) SetSelections(selections []string, selectionIndex int) {
+	l.selections = selections
+	l.selectionIndex = tools.Min(tools.Max(0, selectionIndex), tools.Max(0, len(selections)-1))
+	l.scroller = NewVerticalScroller(len(selections), l.GetSize().H-2)
+	// create a new canvas to remove any previous option.
+	l.SetCanvas(engine.NewCanvas(l.GetSize()))
+	l.updateCanvas()
+}
+
 // Update method executes all listbox functionality every tick time. Keyboard
 // inut is scanned in order to move the selection index and proceed to select
 // any option.
@@ -151,3 +182,4 @@ func (l *ListBox) Update(event tcell.Event, scene engine.IScene) {
 var _ engine.IObject = (*ListBox)(nil)
 var _ engine.IFocus = (*ListBox)(nil)
 var _ engine.IEntity = (*ListBox)(nil)
+var _ engine.IArrowHandler = (*ListBox)(nil)
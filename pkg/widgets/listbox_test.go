@@ -76,3 +76,19 @@ func TestListBox(t *testing.T) {
 		t.Errorf("[1] GetSelection Error exp:%s got:%s", exp, gotSelection)
 	}
 }
+
+func TestListBoxSetSelections(t *testing.T) {
+	style := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorWhite)
+	got := widgets.NewListBox("test/1", api.NewPoint(0, 0), api.NewSize(20, 5), &style, []string{}, 0)
+	if exp, gotSelection := "", got.GetSelection(); gotSelection != exp {
+		t.Errorf("[1] GetSelection Error exp:%s got:%s", exp, gotSelection)
+	}
+	got.SetSelections([]string{"one", "two", "three"}, 5)
+	if exp, gotSelection := "three", got.GetSelection(); gotSelection != exp {
+		t.Errorf("[2] GetSelection Error exp:%s got:%s", exp, gotSelection)
+	}
+	got.SetSelections([]string{"four"}, 0)
+	if exp, gotSelection := "four", got.GetSelection(); gotSelection != exp {
+		t.Errorf("[3] GetSelection Error exp:%s got:%s", exp, gotSelection)
+	}
+}
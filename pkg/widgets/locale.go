@@ -0,0 +1,19 @@
+// locale.go module contains the localization support for all widgets. Every
+// label in any widget is used as a message ID in the i18n catalog, so it is
+// displayed in the active locale.
+package widgets
+
+import (
+	"github.com/jrecuero/thengine/pkg/i18n"
+)
+
+// -----------------------------------------------------------------------------
+// Init package
+// -----------------------------------------------------------------------------
+
+// init function refreshes all widgets every time the locale changes.
+func init() {
+	i18n.AddLocaleListener(func(string) {
+		RefreshAllWidgets()
+	})
+}
@@ -0,0 +1,61 @@
+package widgets_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/i18n"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+func TestLocaleWidgets(t *testing.T) {
+	previous := i18n.GetCatalog()
+	defer i18n.SetCatalog(previous)
+	catalog := i18n.NewCatalog("en")
+	catalog.AddMessage("es", "Yes", "Sí")
+	catalog.AddMessage("es", "Open door?", "¿Abrir?")
+	catalog.AddMessage("es", "File", "Archivo")
+	catalog.AddMessage("es", "Edit", "Edición")
+	i18n.SetCatalog(catalog)
+
+	style := tcell.StyleDefault
+	button := widgets.NewButton("button/1", api.NewPoint(0, 0), api.NewSize(3, 1), &style, "Yes")
+	text := widgets.NewText("text/1", api.NewPoint(0, 1), api.NewSize(10, 1), &style, "Open door?")
+	menuItems := []*widgets.MenuItem{widgets.NewMenuItem("File"), widgets.NewMenuItem("Edit")}
+	menu := widgets.NewTopMenu("menu/1", api.NewPoint(0, 2), api.NewSize(20, 3), &style, menuItems, 0)
+	camera := engine.NewCamera(api.NewPoint(0, 0), api.NewSize(20, 5))
+	camera.SetDryRun(true)
+	scene := engine.NewScene("scene/locale/1", camera)
+	scene.AddEntity(button)
+	scene.AddEntity(text)
+	scene.AddEntity(menu)
+	sceneManager := engine.GetEngine().GetSceneManager()
+	sceneManager.AddScene(scene)
+	defer sceneManager.RemoveScene(scene)
+
+	if got := canvasLine(button.GetCanvas(), 0); got != "Yes" {
+		t.Errorf("[1] Button Error exp:%s got:%s", "Yes", got)
+	}
+
+	i18n.SetLocale("es")
+	if got := canvasLine(button.GetCanvas(), 0); got != "Sí" {
+		t.Errorf("[2] Button Error exp:%s got:%s", "Sí", got)
+	}
+	if got := canvasLine(text.GetCanvas(), 0); got != "¿Abrir?" {
+		t.Errorf("[2] Text Error exp:%s got:%s", "¿Abrir?", got)
+	}
+	if got := text.GetText(); got != "Open door?" {
+		t.Errorf("[2] GetText Error exp:%s got:%s", "Open door?", got)
+	}
+	if got := menu.GetSelection(); got != "File" {
+		t.Errorf("[2] GetSelection Error exp:%s got:%s", "File", got)
+	}
+	for _, label := range []string{"Archivo", "Edición"} {
+		if got := canvasLine(menu.GetCanvas(), 1); !strings.Contains(got, label) {
+			t.Errorf("[2] Menu Error exp:%s got:%s", label, got)
+		}
+	}
+}
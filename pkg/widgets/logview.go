@@ -0,0 +1,467 @@
+// logview.go contains all attributes and methods required to implement a log
+// view widget, which displays lines of text wrapped at the widget width with a
+// bounded scrollback buffer. The view follows the newest line until it is
+// scrolled back, and lines can be searched. Lines can contain inline markup
+// and they can be fed from any ILogFeed, like the battle log, or from any
+// mailbox topic.
+// Key bindings:
+//
+//	Up, Down, PgUp, PgDn   scroll lines.
+//	Home, End              scroll to the oldest or to the newest line.
+//	n, N                   move to the previous or to the next search match.
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	// LogViewDefaultMaxLines is the default number of lines kept in the
+	// scrollback buffer.
+	LogViewDefaultMaxLines = 1000
+)
+
+// -----------------------------------------------------------------------------
+//
+// ILogFeed
+//
+// -----------------------------------------------------------------------------
+
+// ILogFeed interface defines any source of lines to be displayed in a LogView
+// as a live feed. Empty lines are skipped.
+type ILogFeed interface {
+	IsAny() bool
+	Pop() string
+}
+
+// -----------------------------------------------------------------------------
+// logViewLine
+// -----------------------------------------------------------------------------
+
+// logViewLine structure defines a line in the log view with its own style.
+// rows are the cells for the line wrapped at the width in rowsWidth.
+type logViewLine struct {
+	text      string
+	style     *tcell.Style
+	rows      [][]*engine.Cell
+	rowsWidth int
+}
+
+// -----------------------------------------------------------------------------
+// logViewTopic
+// -----------------------------------------------------------------------------
+
+// logViewTopic structure defines a mailbox topic the log view is subscribed
+// to, with the mailbox for the engine the topic belongs to.
+type logViewTopic struct {
+	mailbox *engine.Mailbox
+	name    string
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// wrapLogViewCells function splits the given cells in rows with the given
+// width, breaking rows at the last space when possible.
+func wrapLogViewCells(cells []*engine.Cell, width int) [][]*engine.Cell {
+	if width <= 0 {
+		return [][]*engine.Cell{cells}
+	}
+	var rows [][]*engine.Cell
+	for len(cells) > width {
+		cut := width
+		for i := width; i > 0; i-- {
+			if cells[i].GetRune() == ' ' {
+				cut = i
+				break
+			}
+		}
+		rows = append(rows, cells[:cut])
+		cells = cells[cut:]
+		// spaces at the start of the next row are dropped.
+		for len(cells) != 0 && cells[0].GetRune() == ' ' {
+			cells = cells[1:]
+		}
+	}
+	return append(rows, cells)
+}
+
+// -----------------------------------------------------------------------------
+//
+// LogView
+//
+// -----------------------------------------------------------------------------
+
+// LogView structure defines a widget which displays a scrollable log.
+// offset is the number of rows scrolled back from the newest row, so the view
+// follows new lines when it is zero.
+// searchIndex is the line for the current search match, -1 if there is not
+// any match.
+// topics are the mailbox topics the log view is subscribed to, every one in
+// the mailbox it was bound from.
+type LogView struct {
+	*Widget
+	lines       []*logViewLine
+	maxLines    int
+	offset      int
+	search      string
+	searchIndex int
+	feed        ILogFeed
+	topics      []*logViewTopic
+}
+
+// NewLogView function creates a new LogView instance widget.
+func NewLogView(name string, position *api.Point, size *api.Size, style *tcell.Style) *LogView {
+	logView := &LogView{
+		Widget:      NewWidget(name, position, size, style),
+		lines:       nil,
+		maxLines:    LogViewDefaultMaxLines,
+		offset:      0,
+		search:      "",
+		searchIndex: -1,
+		feed:        nil,
+		topics:      nil,
+	}
+	logView.SetThemeClass("logview")
+	logView.SetHandlesArrows(true)
+	logView.SetFocusType(engine.SingleFocus)
+	logView.SetFocusEnable(true)
+	logView.updateCanvas()
+	return logView
+}
+
+// -----------------------------------------------------------------------------
+// LogView private methods
+// -----------------------------------------------------------------------------
+
+// execute method runs the action for the given keyboard input.
+func (l *LogView) execute(args ...any) {
+	pageLength := tools.Max(1, l.GetSize().H-1)
+	switch args[0].(string) {
+	case "up":
+		l.ScrollBy(1)
+	case "down":
+		l.ScrollBy(-1)
+	case "page-up":
+		l.ScrollBy(pageLength)
+	case "page-down":
+		l.ScrollBy(-pageLength)
+	case "home":
+		l.ScrollToTop()
+	case "end":
+		l.ScrollToBottom()
+	case "search-previous":
+		l.SearchPrevious()
+	case "search-next":
+		l.SearchNext()
+	}
+}
+
+// handleMouse method scrolls the log view with the mouse wheel.
+func (l *LogView) handleMouse(event tcell.Event, scene engine.IScene) {
+	_, buttons, inside := l.GetMousePosition(event, scene)
+	if !inside {
+		return
+	}
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		l.ScrollBy(1)
+	case buttons&tcell.WheelDown != 0:
+		l.ScrollBy(-1)
+	}
+}
+
+// lineRows method returns the rows for the given line wrapped at the widget
+// width.
+func (l *LogView) lineRows(line *logViewLine) [][]*engine.Cell {
+	width := l.GetSize().W
+	if line.rows == nil || line.rowsWidth != width {
+		line.rows = nil
+		for _, cells := range engine.ParseMarkup(line.text, line.style) {
+			line.rows = append(line.rows, wrapLogViewCells(cells, width)...)
+		}
+		line.rowsWidth = width
+	}
+	return line.rows
+}
+
+// rowCount method returns the number of rows for all lines.
+func (l *LogView) rowCount() int {
+	count := 0
+	for _, line := range l.lines {
+		count += len(l.lineRows(line))
+	}
+	return count
+}
+
+// scrollToLine method scrolls the log view so the given line is the first
+// line displayed, when all rows after it fill the widget.
+func (l *LogView) scrollToLine(index int) {
+	row := 0
+	for _, line := range l.lines[:index] {
+		row += len(l.lineRows(line))
+	}
+	l.offset = l.rowCount() - row - l.GetSize().H
+	l.updateCanvas()
+}
+
+// searchFrom method looks for the search text from the given line in the
+// given direction and scrolls to the first line found.
+func (l *LogView) searchFrom(index int, delta int) bool {
+	if l.search == "" {
+		return false
+	}
+	for ; index >= 0 && index < len(l.lines); index += delta {
+		if strings.Contains(engine.StripMarkup(l.lines[index].text), l.search) {
+			l.searchIndex = index
+			l.scrollToLine(index)
+			return true
+		}
+	}
+	return false
+}
+
+// updateCanvas method updates the log view canvas with the rows to be
+// displayed for the scroll offset. The line for the current search match is
+// highlighted.
+func (l *LogView) updateCanvas() {
+	size := l.GetSize()
+	canvas := engine.NewCanvas(size)
+	canvas.FillWithCell(engine.NewCell(l.GetThemeStyle(ThemeRoleNormal), ' '))
+	total := l.rowCount()
+	l.offset = tools.Max(0, tools.Min(l.offset, total-size.H))
+	start := tools.Max(0, total-l.offset-size.H)
+	row, y := 0, 0
+	for index, line := range l.lines {
+		for _, cells := range l.lineRows(line) {
+			if row >= start && y < size.H {
+				for x, cell := range cells {
+					if index == l.searchIndex {
+						canvas.SetCellAt(api.NewPoint(x, y), engine.NewCell(l.GetThemeStyle(ThemeRoleSelected), cell.GetRune()))
+					} else {
+						canvas.SetCellAt(api.NewPoint(x, y), engine.CloneCell(cell))
+					}
+				}
+				y++
+			}
+			row++
+		}
+	}
+	l.SetCanvas(canvas)
+}
+
+// -----------------------------------------------------------------------------
+// LogView public methods
+// -----------------------------------------------------------------------------
+
+// AddLine method adds a new line at the end of the log using the log view
+// style. The line can contain markup tags.
+func (l *LogView) AddLine(text string) {
+	l.AddStyledLine(text, nil)
+}
+
+// AddStyledLine method adds a new line at the end of the log with the given
+// style, or the log view style if it is nil. The line can contain markup
+// tags. When the log view has been scrolled back, it keeps displaying the
+// same rows.
+func (l *LogView) AddStyledLine(text string, style *tcell.Style) {
+	if style == nil {
+		style = l.GetThemeStyle(ThemeRoleNormal)
+	}
+	line := &logViewLine{text: text, style: style}
+	l.lines = append(l.lines, line)
+	if l.offset != 0 {
+		l.offset += len(l.lineRows(line))
+	}
+	if drop := len(l.lines) - l.maxLines; drop > 0 {
+		l.lines = l.lines[drop:]
+		l.searchIndex = tools.Max(-1, l.searchIndex-drop)
+	}
+	l.updateCanvas()
+}
+
+// BindFeed method binds the log view to the given feed, so every line popped
+// from it is added to the log. A nil feed unbinds the current one.
+func (l *LogView) BindFeed(feed ILogFeed) {
+	l.feed = feed
+}
+
+// BindTopic method subscribes the log view to the given topic in the given
+// mailbox, so the content of every message is added to the log. The mailbox
+// is the mailbox for the engine the log view runs in, like
+// scene.GetEngine().GetMailbox().
+func (l *LogView) BindTopic(mailbox *engine.Mailbox, topic string) error {
+	consumer, isNew := mailbox.Subscribe(topic, l.GetName())
+	if consumer == nil {
+		return fmt.Errorf("topic %s not found", topic)
+	}
+	if isNew {
+		l.topics = append(l.topics, &logViewTopic{mailbox: mailbox, name: topic})
+	}
+	return nil
+}
+
+// Clear method removes all lines from the log.
+func (l *LogView) Clear() {
+	l.lines = nil
+	l.offset = 0
+	l.searchIndex = -1
+	l.updateCanvas()
+}
+
+// Consume method adds all lines from the bound feed and all messages from
+// the bound mailbox topics to the log.
+func (l *LogView) Consume() {
+	l.Widget.Consume()
+	for l.feed != nil && l.feed.IsAny() {
+		if str := l.feed.Pop(); str != "" {
+			l.AddLine(str)
+		}
+	}
+	for _, topic := range l.topics {
+		for {
+			message, _ := topic.mailbox.Consume(topic.name, l.GetName())
+			if message == nil {
+				break
+			}
+			if str, ok := message.Content.(string); ok {
+				l.AddLine(str)
+			} else {
+				l.AddLine(fmt.Sprintf("%v", message.Content))
+			}
+		}
+	}
+}
+
+// GetLines method returns all lines in the log.
+func (l *LogView) GetLines() []string {
+	result := make([]string, len(l.lines))
+	for i, line := range l.lines {
+		result[i] = line.text
+	}
+	return result
+}
+
+// GetMaxLines method returns the maximum number of lines kept in the log.
+func (l *LogView) GetMaxLines() int {
+	return l.maxLines
+}
+
+// GetOffset method returns the number of rows the log view has been scrolled
+// back from the newest row.
+func (l *LogView) GetOffset() int {
+	return l.offset
+}
+
+// GetSearchIndex method returns the line for the current search match, or -1
+// if there is not any match.
+func (l *LogView) GetSearchIndex() int {
+	return l.searchIndex
+}
+
+// IsFollowing method returns if the log view displays the newest row, so it
+// follows new lines.
+func (l *LogView) IsFollowing() bool {
+	return l.offset == 0
+}
+
+// Refresh method refreshes the log view canvas with latest attribute values.
+func (l *LogView) Refresh() {
+	l.updateCanvas()
+}
+
+// ScrollBy method scrolls the log view the given number of rows, back to
+// older rows if it is positive or forward to newer rows if it is negative.
+func (l *LogView) ScrollBy(delta int) {
+	l.offset += delta
+	l.updateCanvas()
+}
+
+// ScrollToBottom method scrolls the log view to the newest row, so it
+// follows new lines again.
+func (l *LogView) ScrollToBottom() {
+	l.offset = 0
+	l.updateCanvas()
+}
+
+// ScrollToTop method scrolls the log view to the oldest row.
+func (l *LogView) ScrollToTop() {
+	l.offset = l.rowCount()
+	l.updateCanvas()
+}
+
+// Search method sets the text to search and scrolls to the newest line
+// containing it. It returns false if no line contains the text.
+func (l *LogView) Search(text string) bool {
+	l.search = text
+	l.searchIndex = -1
+	found := l.searchFrom(len(l.lines)-1, -1)
+	if !found {
+		l.updateCanvas()
+	}
+	return found
+}
+
+// SearchNext method scrolls to the next line, newer than the current match,
+// containing the search text.
+func (l *LogView) SearchNext() bool {
+	return l.searchFrom(l.searchIndex+1, 1)
+}
+
+// SearchPrevious method scrolls to the previous line, older than the current
+// match, containing the search text.
+func (l *LogView) SearchPrevious() bool {
+	if l.searchIndex == -1 {
+		return l.searchFrom(len(l.lines)-1, -1)
+	}
+	return l.searchFrom(l.searchIndex-1, -1)
+}
+
+// SetMaxLines method sets the maximum number of lines kept in the log, the
+// oldest lines are dropped when there are more lines.
+func (l *LogView) SetMaxLines(maxLines int) {
+	l.maxLines = tools.Max(1, maxLines)
+	if drop := len(l.lines) - l.maxLines; drop > 0 {
+		l.lines = l.lines[drop:]
+		l.searchIndex = tools.Max(-1, l.searchIndex-drop)
+	}
+	l.updateCanvas()
+}
+
+// Update method executes all log view functionality every tick time. Mouse
+// wheel scrolls the log view and keyboard input is handled when it has the
+// focus.
+func (l *LogView) Update(event tcell.Event, scene engine.IScene) {
+	defer l.Entity.Update(event, scene)
+	l.handleMouse(event, scene)
+	if !l.HasFocus() {
+		return
+	}
+	actions := []*KeyboardAction{
+		NewKeyboardActionForKey(tcell.KeyUp, l.execute, []any{"up"}),
+		NewKeyboardActionForKey(tcell.KeyDown, l.execute, []any{"down"}),
+		NewKeyboardActionForKey(tcell.KeyPgUp, l.execute, []any{"page-up"}),
+		NewKeyboardActionForKey(tcell.KeyPgDn, l.execute, []any{"page-down"}),
+		NewKeyboardActionForKey(tcell.KeyHome, l.execute, []any{"home"}),
+		NewKeyboardActionForKey(tcell.KeyEnd, l.execute, []any{"end"}),
+		NewKeyboardActionForRune('n', l.execute, []any{"search-previous"}),
+		NewKeyboardActionForRune('N', l.execute, []any{"search-next"}),
+	}
+	l.HandleKeyboardForActions(event, actions)
+}
+
+var _ engine.IObject = (*LogView)(nil)
+var _ engine.IFocus = (*LogView)(nil)
+var _ engine.IEntity = (*LogView)(nil)
+var _ engine.IArrowHandler = (*LogView)(nil)
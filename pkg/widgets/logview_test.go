@@ -0,0 +1,123 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+type testLogFeed struct {
+	lines []string
+}
+
+func (f *testLogFeed) IsAny() bool {
+	return len(f.lines) != 0
+}
+
+func (f *testLogFeed) Pop() string {
+	line := f.lines[0]
+	f.lines = f.lines[1:]
+	return line
+}
+
+func checkLogViewCanvas(t *testing.T, id string, logView *widgets.LogView, exps []string) {
+	for i, exp := range exps {
+		if got := canvasLine(logView.GetCanvas(), i); got != exp {
+			t.Errorf("[%s:%d] canvas Error exp:%q got:%q", id, i, exp, got)
+		}
+	}
+}
+
+func TestLogViewWrapAndScroll(t *testing.T) {
+	style := tcell.StyleDefault
+	logView := widgets.NewLogView("log-view/1", api.NewPoint(0, 0), api.NewSize(10, 3), &style)
+	logView.AddLine("the quick brown fox")
+	logView.AddLine("[red]abcdefghijkl[/]")
+	checkLogViewCanvas(t, "0", logView, []string{"brown fox ", "abcdefghij", "kl        "})
+	if got := logView.GetCanvas().GetStyleAt(api.NewPoint(0, 1)); engine.GetForegroundFromStyle(got) != tcell.ColorRed {
+		t.Errorf("[0] canvas Error.Style exp:red got:%s", engine.StyleToString(got))
+	}
+
+	// scrolled back views keep displaying the same rows for new lines.
+	logView.Update(tcell.NewEventMouse(2, 1, tcell.WheelUp, 0), nil)
+	if logView.IsFollowing() {
+		t.Errorf("[1] IsFollowing Error exp:false got:true")
+	}
+	logView.AddLine("new")
+	checkLogViewCanvas(t, "1", logView, []string{"the quick ", "brown fox ", "abcdefghij"})
+	logView.AcquireFocus()
+	logView.Update(tcell.NewEventKey(tcell.KeyEnd, 0, 0), nil)
+	if !logView.IsFollowing() {
+		t.Errorf("[1] IsFollowing Error exp:true got:false")
+	}
+	checkLogViewCanvas(t, "1", logView, []string{"abcdefghij", "kl        ", "new       "})
+
+	// scrollback buffer is bounded.
+	logView.SetMaxLines(2)
+	if got := logView.GetLines(); len(got) != 2 || got[1] != "new" {
+		t.Errorf("[2] GetLines Error exp:2 got:%v", got)
+	}
+}
+
+func TestLogViewSearch(t *testing.T) {
+	style := tcell.StyleDefault
+	logView := widgets.NewLogView("log-view/1", api.NewPoint(0, 0), api.NewSize(10, 2), &style)
+	for _, line := range []string{"alpha one", "beta", "[b]alpha[/] two", "gamma"} {
+		logView.AddLine(line)
+	}
+	cases := []struct {
+		search func() bool
+		ok     bool
+		index  int
+		canvas []string
+	}{
+		{func() bool { return logView.Search("alpha") }, true, 2, []string{"alpha two ", "gamma     "}},
+		{logView.SearchPrevious, true, 0, []string{"alpha one ", "beta      "}},
+		{logView.SearchPrevious, false, 0, []string{"alpha one ", "beta      "}},
+		{logView.SearchNext, true, 2, []string{"alpha two ", "gamma     "}},
+		{func() bool { return logView.Search("delta") }, false, -1, []string{"alpha two ", "gamma     "}},
+	}
+	for i, c := range cases {
+		if got := c.search(); got != c.ok {
+			t.Errorf("[%d] Search Error exp:%t got:%t", i, c.ok, got)
+		}
+		if got := logView.GetSearchIndex(); got != c.index {
+			t.Errorf("[%d] GetSearchIndex Error exp:%d got:%d", i, c.index, got)
+		}
+		checkLogViewCanvas(t, string(rune('0'+i)), logView, c.canvas)
+	}
+}
+
+func TestLogViewFeeds(t *testing.T) {
+	style := tcell.StyleDefault
+	logView := widgets.NewLogView("log-view/feeds/1", api.NewPoint(0, 0), api.NewSize(10, 3), &style)
+	logView.BindFeed(&testLogFeed{lines: []string{"one", "", "two"}})
+	// only messages in the engine mailbox the topic was bound from are added.
+	mailbox := engine.NewEngine().GetMailbox()
+	other := engine.NewEngine().GetMailbox()
+	mailbox.CreateTopic("topic/log-view/1")
+	other.CreateTopic("topic/log-view/1")
+	if err := logView.BindTopic(mailbox, "topic/log-view/1"); err != nil {
+		t.Errorf("[0] BindTopic Error exp:nil got:%s", err)
+	}
+	if err := logView.BindTopic(mailbox, "topic/log-view/none"); err == nil {
+		t.Errorf("[0] BindTopic Error exp:error got:nil")
+	}
+	_ = mailbox.Publish("topic/log-view/1", engine.NewMessage("topic/log-view/1", nil, nil, "three"))
+	_ = other.Publish("topic/log-view/1", engine.NewMessage("topic/log-view/1", nil, nil, "other"))
+	logView.Consume()
+	exps := []string{"one", "two", "three"}
+	got := logView.GetLines()
+	if len(got) != len(exps) {
+		t.Errorf("[1] GetLines Error exp:%v got:%v", exps, got)
+		return
+	}
+	for i, exp := range exps {
+		if got[i] != exp {
+			t.Errorf("[1:%d] GetLines Error exp:%q got:%q", i, exp, got[i])
+		}
+	}
+}
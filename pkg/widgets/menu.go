@@ -5,10 +5,12 @@ package widgets
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/i18n"
 	"github.com/jrecuero/thengine/pkg/tools"
 )
 
@@ -111,6 +113,7 @@ type Menu struct {
 	*Widget
 	menuItems     []*MenuItem
 	menuLabels    []string
+	labelWidth    int
 	menuItemIndex int
 	scroller      *Scroller
 	parent        *Menu
@@ -119,44 +122,26 @@ type Menu struct {
 // NewTopMenu function creates a new Menu instance.
 func NewTopMenu(name string, position *api.Point, size *api.Size, style *tcell.Style, menuItems []*MenuItem, menuItemIndex int) *Menu {
 	numberOfMenuItems := len(menuItems)
-	// Look for the menu item with the largest string.
-	maxItemLength := 0
-	for _, item := range menuItems {
-		maxItemLength = tools.Max(maxItemLength, len(item.GetLabel()))
-	}
-	// Reassign the maximum menu item length if the horizontal size is greater
-	// than the number of items by the maximum number of character for any menu
-	// item.
-	if (maxItemLength * numberOfMenuItems) < (size.W - 2) {
-		maxItemLength = (size.W - 2) / numberOfMenuItems
-	}
-	// Add padding for every menu item to fill the whole horizontal length.
-	paddingMenuItems := make([]string, numberOfMenuItems)
-	paddingLength := maxItemLength - 1
-	menuItemX := position.X + 1
-	menuItemY := position.Y + 1
+	menuLabels := make([]string, numberOfMenuItems)
 	for i, menuItem := range menuItems {
-		menuItem.SetPosition(api.NewPoint(menuItemX, menuItemY))
-		paddingMenuItems[i] = fmt.Sprintf("%-*s", paddingLength, menuItem.GetLabel())
-		menuItemX += paddingLength
+		menuLabels[i] = menuItem.GetLabel()
 	}
-	// Assign the total number of characters required to contains all menu
-	// items.
-	totalSelectionLength := numberOfMenuItems * maxItemLength
 	tools.Logger.WithField("module", "menu").
 		WithField("function", "NewMenu").
 		Debugf("%s", name)
 	menu := &Menu{
 		Widget:        NewWidget(name, position, size, style),
 		menuItems:     menuItems,
-		menuLabels:    paddingMenuItems,
+		menuLabels:    menuLabels,
 		menuItemIndex: menuItemIndex,
 		parent:        nil,
 	}
 	for _, item := range menuItems {
 		item.SetMenu(menu)
 	}
-	menu.scroller = NewScroller(totalSelectionLength, size.W-2, maxItemLength)
+	menu.updateMenuItems()
+	menu.SetThemeClass("menu")
+	menu.SetHandlesArrows(true)
 	menu.SetFocusType(engine.SingleFocus)
 	menu.SetFocusEnable(true)
 	menu.updateCanvas()
@@ -165,26 +150,23 @@ func NewTopMenu(name string, position *api.Point, size *api.Size, style *tcell.S
 
 func NewSubMenu(name string, position *api.Point, size *api.Size, style *tcell.Style, menuItems []*MenuItem, menuItemIndex int, parent *Menu) *Menu {
 	selectionsLength := len(menuItems)
-	// Add padding for every menu item to fill the whole horizontal length.
-	paddingSelections := make([]string, selectionsLength)
-	menuItemX := position.X + 1
-	menuItemY := position.Y + 1
+	selections := make([]string, selectionsLength)
 	for i, menuItem := range menuItems {
-		menuItem.SetPosition(api.NewPoint(menuItemX, menuItemY))
-		paddingSelections[i] = fmt.Sprintf("%-*s", size.W-2, menuItem.GetLabel())
-		menuItemY++
+		selections[i] = menuItem.GetLabel()
 	}
 	tools.Logger.WithField("module", "menu").
 		WithField("function", "NewSubMenu").
-		Debugf("%s %+v", name, paddingSelections)
+		Debugf("%s %+v", name, selections)
 	menu := &Menu{
 		Widget:        NewWidget(name, position, size, style),
 		menuItems:     menuItems,
-		menuLabels:    paddingSelections,
+		menuLabels:    selections,
 		menuItemIndex: menuItemIndex,
 		parent:        parent,
 	}
-	menu.scroller = NewVerticalScroller(selectionsLength, size.H-2)
+	menu.updateMenuItems()
+	menu.SetThemeClass("menu")
+	menu.SetHandlesArrows(true)
 	menu.SetFocusType(engine.SingleFocus)
 	menu.SetFocusEnable(true)
 	menu.updateCanvas()
@@ -195,8 +177,11 @@ func NewSubMenu(name string, position *api.Point, size *api.Size, style *tcell.S
 // Menu private methods
 // -----------------------------------------------------------------------------
 
+// getMenuItemLabel method returns the label for the given menu item
+// translated to the active locale, padded or truncated to the length
+// reserved for every menu item.
 func (m *Menu) getMenuItemLabel(index int) string {
-	return m.menuLabels[index]
+	return fmt.Sprintf("%-*.*s", m.labelWidth, m.labelWidth, i18n.T(m.menuItems[index].GetLabel()))
 }
 
 func (m *Menu) execute(args ...any) {
@@ -260,18 +245,17 @@ func (m *Menu) prevMenuItem() {
 func (m *Menu) updateTopMenuCanvas() {
 	m.scroller.Update(m.menuItemIndex)
 	canvas := m.GetCanvas()
-	canvas.WriteRectangleInCanvasAt(nil, nil, m.GetStyle(), engine.CanvasRectSingleLine)
+	canvas.WriteRectangleInCanvasAt(nil, nil, m.GetThemeStyle(ThemeRoleBorder), engine.CanvasRectSingleLine)
 	m.scroller.CreateIter()
 	for y := 1; m.scroller.IterHasNext(); {
 		index, x := m.scroller.IterGetNext()
 		selection := m.getMenuItemLabel(index)
 		if index == m.menuItemIndex {
-			reverseStyle := tools.ReverseStyle(m.GetStyle())
-			canvas.WriteStringInCanvasAt(selection, reverseStyle, api.NewPoint(x+1, y))
+			canvas.WriteStringInCanvasAt(selection, m.GetThemeStyle(ThemeRoleSelected), api.NewPoint(x+1, y))
 		} else {
-			style := m.GetStyle()
+			style := m.GetThemeStyle(ThemeRoleNormal)
 			if !m.menuItems[index].IsEnabled() {
-				style = tools.SetAttrToStyle(style, tcell.AttrDim)
+				style = m.GetThemeStyle(ThemeRoleDisabled)
 			}
 			canvas.WriteStringInCanvasAt(selection, style, api.NewPoint(x+1, y))
 		}
@@ -281,23 +265,69 @@ func (m *Menu) updateTopMenuCanvas() {
 func (m *Menu) updateSubMenuCanvas() {
 	m.scroller.Update(m.menuItemIndex)
 	canvas := m.GetCanvas()
-	canvas.WriteRectangleInCanvasAt(nil, nil, m.GetStyle(), engine.CanvasRectSingleLine)
+	normalStyle := m.GetThemeStyle(ThemeRoleNormal)
+	selectedStyle := m.GetThemeStyle(ThemeRoleSelected)
+	disabledStyle := m.GetThemeStyle(ThemeRoleDisabled)
+	// without any active theme, sub-menu items are displayed reversed and
+	// the selected one with the menu style.
+	if GetTheme() == nil {
+		normalStyle, selectedStyle = selectedStyle, normalStyle
+		disabledStyle = tools.SetAttrToStyle(normalStyle, tcell.AttrDim)
+	}
+	canvas.WriteRectangleInCanvasAt(nil, nil, m.GetThemeStyle(ThemeRoleBorder), engine.CanvasRectSingleLine)
 	m.scroller.CreateIter()
 	for x := 1; m.scroller.IterHasNext(); {
 		index, y := m.scroller.IterGetNext()
 		selection := m.getMenuItemLabel(index)
 		if index == m.menuItemIndex {
-			canvas.WriteStringInCanvasAt(selection, m.GetStyle(), api.NewPoint(x, y+1))
+			canvas.WriteStringInCanvasAt(selection, selectedStyle, api.NewPoint(x, y+1))
+		} else if !m.menuItems[index].IsEnabled() {
+			canvas.WriteStringInCanvasAt(selection, disabledStyle, api.NewPoint(x, y+1))
 		} else {
-			reverseStyle := tools.ReverseStyle(m.GetStyle())
-			if !m.menuItems[index].IsEnabled() {
-				reverseStyle = tools.SetAttrToStyle(reverseStyle, tcell.AttrDim)
-			}
-			canvas.WriteStringInCanvasAt(selection, reverseStyle, api.NewPoint(x, y+1))
+			canvas.WriteStringInCanvasAt(selection, normalStyle, api.NewPoint(x, y+1))
 		}
 	}
 }
 
+// updateMenuItems method places all menu items and it sets the length
+// reserved for every menu item, measured in runes for labels translated to
+// the active locale, so it has to be called every time the locale changes.
+func (m *Menu) updateMenuItems() {
+	position := m.GetPosition()
+	size := m.GetSize()
+	numberOfMenuItems := len(m.menuItems)
+	menuItemX := position.X + 1
+	menuItemY := position.Y + 1
+	if m.parent != nil {
+		m.labelWidth = size.W - 2
+		for _, menuItem := range m.menuItems {
+			menuItem.SetPosition(api.NewPoint(menuItemX, menuItemY))
+			menuItemY++
+		}
+		m.scroller = NewVerticalScroller(numberOfMenuItems, size.H-2)
+		return
+	}
+	// Look for the menu item with the largest string.
+	maxItemLength := 0
+	for _, item := range m.menuItems {
+		maxItemLength = tools.Max(maxItemLength, utf8.RuneCountInString(i18n.T(item.GetLabel())))
+	}
+	// Reassign the maximum menu item length if the horizontal size is greater
+	// than the number of items by the maximum number of character for any menu
+	// item.
+	if numberOfMenuItems != 0 && (maxItemLength*numberOfMenuItems) < (size.W-2) {
+		maxItemLength = (size.W - 2) / numberOfMenuItems
+	}
+	m.labelWidth = maxItemLength - 1
+	for _, menuItem := range m.menuItems {
+		menuItem.SetPosition(api.NewPoint(menuItemX, menuItemY))
+		menuItemX += m.labelWidth
+	}
+	// Assign the total number of characters required to contains all menu
+	// items.
+	m.scroller = NewScroller(numberOfMenuItems*maxItemLength, size.W-2, maxItemLength)
+}
+
 // updateCanvas method updates the list box canvas with proper menuItems to be
 // displayed and the proper selected option.
 func (m *Menu) updateCanvas() {
@@ -370,12 +400,16 @@ func (m *Menu) FindMenuItemByLabel(label string) *MenuItem {
 	return nil
 }
 
-// GetSelection method returns the option for the selected index.
+// GetSelection method returns the option for the selected index. The label
+// is returned untranslated, so it does not depend on the active locale.
 func (m *Menu) GetSelection() string {
-	return strings.TrimSpace(m.getMenuItemLabel(m.menuItemIndex))
+	return strings.TrimSpace(m.menuLabels[m.menuItemIndex])
 }
 
+// Refresh method updates the menu with labels translated to the active
+// locale.
 func (m *Menu) Refresh() {
+	m.updateMenuItems()
 	m.updateCanvas()
 }
 
@@ -442,3 +476,4 @@ func (m *Menu) Update(event tcell.Event, scene engine.IScene) {
 var _ engine.IObject = (*Menu)(nil)
 var _ engine.IFocus = (*Menu)(nil)
 var _ engine.IEntity = (*Menu)(nil)
+var _ engine.IArrowHandler = (*Menu)(nil)
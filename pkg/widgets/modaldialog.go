@@ -24,7 +24,7 @@ func NewModalDialog(parentScene engine.IScene) *ModalDialog {
 	dialogSceneName := fmt.Sprintf("scene/dialog/%s", parentScene.GetName())
 	d := &ModalDialog{
 		parentScene: parentScene,
-		dialogScene: engine.NewScene(dialogSceneName, parentScene.GetCamera()),
+		dialogScene: parentScene.GetEngine().NewScene(dialogSceneName, parentScene.GetCamera()),
 		dialog:      nil,
 	}
 	return d
@@ -34,9 +34,13 @@ func NewModalDialog(parentScene engine.IScene) *ModalDialog {
 // ModalDialog public methods
 // -----------------------------------------------------------------------------
 
+// Close method closes the dialog scene, releasing the focus trapped in the
+// dialog, and the parent scene gets the focus back.
 func (d *ModalDialog) Close() {
-
-	sceneManager := engine.GetEngine().GetSceneManager()
+	if d.dialog != nil {
+		d.parentScene.GetEngine().GetFocusManager().PopFocusScope(d.dialogScene)
+	}
+	sceneManager := d.parentScene.GetEngine().GetSceneManager()
 	sceneManager.RemoveScene(d.dialogScene)
 	sceneManager.SetSceneAsActive(d.parentScene)
 	sceneManager.SetSceneAsVisible(d.parentScene)
@@ -55,12 +59,14 @@ func (d *ModalDialog) GetParentScene() engine.IScene {
 	return d.parentScene
 }
 
+// Open method opens the dialog scene over the parent scene. The focus is
+// trapped in the dialog until it is closed.
 func (d *ModalDialog) Open(dialog *Dialog) {
 	d.dialog = dialog
-	sceneManager := engine.GetEngine().GetSceneManager()
+	sceneManager := d.parentScene.GetEngine().GetSceneManager()
 	sceneManager.RemoveSceneAsActive(d.parentScene)
 	sceneManager.AddScene(d.dialogScene)
 	sceneManager.SetSceneAsActive(d.dialogScene)
 	sceneManager.SetSceneAsVisible(d.dialogScene)
-	sceneManager.UpdateFocus()
+	d.parentScene.GetEngine().GetFocusManager().PushFocusScope(d.dialogScene, dialog)
 }
@@ -0,0 +1,53 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+func TestModalDialogFocusScope(t *testing.T) {
+	style := tcell.StyleDefault
+	e := engine.NewEngine()
+	parent := e.NewScene("scene/modal/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(40, 20)))
+	quit := widgets.NewButton("button/quit", api.NewPoint(0, 0), api.NewSize(6, 1), &style, "Quit")
+	parent.AddEntity(quit)
+	sceneManager := e.GetSceneManager()
+	sceneManager.AddScene(parent)
+	sceneManager.SetSceneAsActive(parent)
+	sceneManager.UpdateFocus()
+
+	modal := widgets.NewModalDialog(parent)
+	question := widgets.NewText("text/question", nil, nil, &style, "Open door?")
+	yes := widgets.NewButton("button/yes", nil, nil, &style, "YES")
+	no := widgets.NewButton("button/no", nil, nil, &style, "NO")
+	dialog := widgets.NewDialog("dialog/1", api.NewPoint(1, 1), api.NewSize(20, 5), &style, modal.GetDialogScene(),
+		[]*widgets.Text{question}, nil, []*widgets.Button{yes, no})
+	other := widgets.NewButton("button/other", api.NewPoint(0, 10), api.NewSize(6, 1), &style, "Other")
+	modal.GetDialogScene().AddEntity(other)
+
+	// the focus is trapped in the dialog buttons while it is open.
+	modal.Open(dialog)
+	focusManager := e.GetFocusManager()
+	if got := focusManager.GetFocusScope(modal.GetDialogScene()); got != engine.IFocusScope(dialog) {
+		t.Errorf("[0] GetFocusScope Error exp:dialog/1 got:%v", got)
+	}
+	for i, exp := range []*widgets.Button{yes, no, yes} {
+		if !exp.HasFocus() || other.HasFocus() {
+			t.Errorf("[%d] HasFocus Error exp:%s got:%t", i, exp.GetName(), exp.HasFocus())
+		}
+		sceneManager.FocusNext()
+	}
+
+	// the parent scene gets the focus back when the dialog is closed.
+	modal.Close()
+	if got := focusManager.GetFocusScope(modal.GetDialogScene()); got != nil {
+		t.Errorf("[1] GetFocusScope Error exp:nil got:%v", got)
+	}
+	if !quit.HasFocus() {
+		t.Errorf("[1] HasFocus Error exp:true got:false")
+	}
+}
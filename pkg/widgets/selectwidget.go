@@ -2,7 +2,6 @@ package widgets
 
 import (
 	"github.com/gdamore/tcell/v2"
-	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/engine"
 	"github.com/jrecuero/thengine/pkg/tools"
 )
@@ -15,8 +14,9 @@ import (
 
 // SelectWidget struct defines a new Widget that contains a list of widgets
 // that could be horizontal or vertical aligned, all with the same size.
-// Selection process will update the widget color to the given color and one or
-// more widgets can be selected.
+// Selection process will update the widget color to the theme selected style
+// and one or more widgets can be selected. The widget at the selection index
+// uses the theme focused style.
 type SelectWidget struct {
 	*Widget
 	infocus        bool
@@ -41,8 +41,13 @@ func NewHorizontalSelectWidget(name string, style *tcell.Style, w []IWidget, s i
 		selectionIndex: s,
 		selection:      w,
 	}
+	for i, widget := range w {
+		selectWidget.originalStyles[i] = widget.GetStyle()
+	}
 	selectWidget.SetFocusType(engine.SingleFocus)
 	selectWidget.SetFocusEnable(true)
+	selectWidget.SetThemeClass("selectwidget")
+	selectWidget.SetHandlesArrows(true)
 	//selectWidget.updateCanvas()
 	return selectWidget
 }
@@ -55,14 +60,14 @@ func (w *SelectWidget) execute(args ...any) {
 	switch args[0].(string) {
 	case "left":
 		if w.selectionIndex > 0 {
-			w.updateCanvasForIndex(w.selectionIndex)
 			w.selectionIndex -= 1
+			w.updateCanvasForIndex(w.selectionIndex + 1)
 			w.updateCanvas()
 		}
 	case "right":
 		if w.selectionIndex < len(w.selection)-1 {
-			w.updateCanvasForIndex(w.selectionIndex)
 			w.selectionIndex += 1
+			w.updateCanvasForIndex(w.selectionIndex - 1)
 			w.updateCanvas()
 		}
 	case "select":
@@ -71,41 +76,38 @@ func (w *SelectWidget) execute(args ...any) {
 		} else {
 			w.selected = append(w.selected, w.selectionIndex)
 		}
-		w.updateCanvasForSelect()
+		w.updateCanvasForSelect(args[1].(engine.IScene))
 	default:
 	}
 }
 
-func (w *SelectWidget) updateCanvasForSelect() {
-	var style *tcell.Style
-	index := w.selectionIndex
-	widget := w.selection[index]
-	canvas := widget.GetCanvas()
+// styleForIndex method returns the style for the widget at the given index:
+// the focused style at the selection index, the selected style for selected
+// widgets and the widget original style for any other.
+func (w *SelectWidget) styleForIndex(index int) *tcell.Style {
+	if index == w.selectionIndex {
+		return w.GetThemeStyle(ThemeRoleFocused)
+	}
 	if _, ok := tools.Contains(w.selected, index); ok {
-		// if the entry is being selected, it is in the reversed style, so it
-		// have to be reversed to the original style before being stored.
-		reversed := canvas.GetStyleAt(api.NewPoint(0, 0))
-		w.originalStyles[index] = tools.ReverseStyle(reversed)
-		style = w.GetStyle()
-	} else {
-		style = w.originalStyles[index]
+		return w.GetThemeStyle(ThemeRoleSelected)
 	}
-	reverseStyle := tools.ReverseStyle(style)
-	canvas.SetStyleAt(nil, reverseStyle)
-	observerManager := engine.GetEngine().GetObserverManager()
+	return w.originalStyles[index]
+}
+
+func (w *SelectWidget) updateCanvasForSelect(scene engine.IScene) {
+	w.updateCanvas()
+	observerManager := scene.GetEngine().GetObserverManager()
 	observerManager.NotifyObservers(w.GetName(), w.selected)
 }
 
+// updateCanvasForIndex method sets the style for the widget at the given
+// index.
 func (w *SelectWidget) updateCanvasForIndex(index int) {
-	var style *tcell.Style
-	widget := w.selection[index]
-	canvas := widget.GetCanvas()
-	style = canvas.GetStyleAt(api.NewPoint(0, 0))
-	reverseStyle := tools.ReverseStyle(style)
-	canvas.SetStyleAt(nil, reverseStyle)
+	canvas := w.selection[index].GetCanvas()
+	canvas.SetStyleAt(nil, w.styleForIndex(index))
 }
 
-// updateCanvas method reverse the style for the selected widget.
+// updateCanvas method sets the style for the widget at the selection index.
 func (w *SelectWidget) updateCanvas() {
 	w.updateCanvasForIndex(w.selectionIndex)
 }
@@ -151,7 +153,7 @@ func (w *SelectWidget) Update(event tcell.Event, scene engine.IScene) {
 	actions := []*KeyboardAction{
 		NewKeyboardActionForKey(tcell.KeyLeft, w.execute, []any{"left"}),
 		NewKeyboardActionForKey(tcell.KeyRight, w.execute, []any{"right"}),
-		NewKeyboardActionForKey(tcell.KeyEnter, w.execute, []any{"select"}),
+		NewKeyboardActionForKey(tcell.KeyEnter, w.execute, []any{"select", scene}),
 	}
 	w.HandleKeyboardForActions(event, actions)
 }
@@ -159,3 +161,4 @@ func (w *SelectWidget) Update(event tcell.Event, scene engine.IScene) {
 var _ engine.IObject = (*SelectWidget)(nil)
 var _ engine.IFocus = (*SelectWidget)(nil)
 var _ engine.IEntity = (*SelectWidget)(nil)
+var _ engine.IArrowHandler = (*SelectWidget)(nil)
@@ -0,0 +1,356 @@
+// splitpane.go contains the SplitPane container widget, which divides its
+// area between two children with a divider that can be moved with the
+// keyboard or dragged with the mouse.
+// Key bindings:
+//
+//	Left, Right   move the divider in a horizontal split pane.
+//	Up, Down      move the divider in a vertical split pane.
+//
+// Example:
+//
+//	editor := widgets.NewHSplitPane("editor", api.NewPoint(0, 0), api.NewSize(80, 20), &style,
+//	    tree, inspector)
+//	editor.SetDivider(24)
+//	scene.AddEntity(editor)
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	// SplitPaneDefaultDivider is the divider position for split panes where
+	// the divider has not been set, which places it in the middle.
+	SplitPaneDefaultDivider = -1
+)
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// clampDivider function returns the given divider position kept inside the
+// given length, leaving at least one column or row at both sides.
+func clampDivider(divider int, length int) int {
+	return tools.Max(0, tools.Min(tools.Max(1, divider), length-2))
+}
+
+// -----------------------------------------------------------------------------
+//
+// splitArranger
+//
+// -----------------------------------------------------------------------------
+
+// splitArranger structure arranges the first child before the divider and the
+// second child after it, in a row or in a column. Every child is aligned in
+// both axes with its alignment in the space it gets.
+type splitArranger struct {
+	pane *SplitPane
+}
+
+// measure method returns the size for both children and the divider.
+func (a *splitArranger) measure(items []*ContainerItem, sizes []*api.Size) *api.Size {
+	mainLength, crossLength := 1, 0
+	for _, size := range sizes {
+		main, cross := a.pane.axis(size)
+		mainLength += main
+		crossLength = tools.Max(crossLength, cross)
+	}
+	return a.pane.size(mainLength, crossLength)
+}
+
+// arrange method returns the rectangle for every child at both sides of the
+// divider.
+func (a *splitArranger) arrange(items []*ContainerItem, sizes []*api.Size, rect *api.Rect) []*api.Rect {
+	result := make([]*api.Rect, len(items))
+	available, crossAvailable := a.pane.axis(rect.Size)
+	divider := a.pane.dividerFor(available)
+	starts := []int{0, divider + 1}
+	lengths := []int{divider, tools.Max(0, available-divider-1)}
+	for i, item := range items {
+		main, cross := a.pane.axis(sizes[i])
+		offset, length := alignLength(item.align, tools.Min(main, lengths[i]), lengths[i])
+		crossOffset, crossLength := alignLength(item.align, tools.Min(cross, crossAvailable), crossAvailable)
+		origin := a.pane.point(starts[i]+offset, crossOffset)
+		origin.Add(rect.Origin)
+		result[i] = api.NewRect(origin, a.pane.size(length, crossLength))
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+//
+// SplitPane
+//
+// -----------------------------------------------------------------------------
+
+// SplitPane structure defines a container which divides its area between two
+// children.
+// horizontal is true when children are placed side by side, and false when
+// they are placed one above the other.
+// divider is the position for the divider in the main axis, every child gets
+// at least one column or row.
+// dragging is true while the divider is being dragged with the mouse.
+// mouseButtons are the mouse buttons pressed in the last mouse event, used to
+// handle only new clicks.
+type SplitPane struct {
+	*Container
+	horizontal   bool
+	divider      int
+	dragging     bool
+	mouseButtons tcell.ButtonMask
+}
+
+// newSplitPane function creates a new SplitPane instance with the given
+// children.
+func newSplitPane(name string, position *api.Point, size *api.Size, style *tcell.Style, horizontal bool, first engine.IEntity, second engine.IEntity) *SplitPane {
+	tools.Logger.WithField("module", "splitpane").
+		WithField("function", "newSplitPane").
+		Infof("%s %t", name, horizontal)
+	pane := &SplitPane{
+		horizontal:   horizontal,
+		divider:      SplitPaneDefaultDivider,
+		dragging:     false,
+		mouseButtons: tcell.ButtonNone,
+	}
+	pane.Container = newContainer(name, position, size, &splitArranger{pane: pane})
+	pane.SetStyle(style)
+	pane.SetThemeClass("splitpane")
+	pane.SetHandlesArrows(true)
+	pane.SetFocusType(engine.SingleFocus)
+	pane.SetFocusEnable(true)
+	for _, child := range []engine.IEntity{first, second} {
+		item := NewContainerItem(child)
+		item.align = AlignStretch
+		pane.addItem(item)
+	}
+	pane.updateCanvas()
+	return pane
+}
+
+// NewHSplitPane function creates a new SplitPane instance with the given
+// children side by side and a vertical divider between them.
+func NewHSplitPane(name string, position *api.Point, size *api.Size, style *tcell.Style, left engine.IEntity, right engine.IEntity) *SplitPane {
+	return newSplitPane(name, position, size, style, true, left, right)
+}
+
+// NewVSplitPane function creates a new SplitPane instance with the given
+// children one above the other and a horizontal divider between them.
+func NewVSplitPane(name string, position *api.Point, size *api.Size, style *tcell.Style, top engine.IEntity, bottom engine.IEntity) *SplitPane {
+	return newSplitPane(name, position, size, style, false, top, bottom)
+}
+
+// -----------------------------------------------------------------------------
+// SplitPane private methods
+// -----------------------------------------------------------------------------
+
+// axis method returns the main and the cross lengths for the given size.
+func (p *SplitPane) axis(size *api.Size) (int, int) {
+	if p.horizontal {
+		return size.W, size.H
+	}
+	return size.H, size.W
+}
+
+// dividerFor method returns the divider position for the given length in the
+// main axis, leaving at least one column or row for every child.
+func (p *SplitPane) dividerFor(length int) int {
+	if p.divider == SplitPaneDefaultDivider {
+		return clampDivider(length/2, length)
+	}
+	return clampDivider(p.divider, length)
+}
+
+// execute method runs the action for the given keyboard input.
+func (p *SplitPane) execute(args ...any) {
+	tools.Logger.WithField("module", "splitpane").
+		WithField("method", "execute").
+		Debugf("%s %+v", p.GetName(), args)
+	switch args[0].(string) {
+	case "decrease":
+		p.MoveDivider(-1)
+	case "increase":
+		p.MoveDivider(1)
+	}
+}
+
+// handleMouse method starts dragging the divider when it is clicked, and it
+// moves the divider with the mouse until the button is released.
+func (p *SplitPane) handleMouse(event tcell.Event, scene engine.IScene) {
+	position, buttons, inside := p.GetMousePosition(event, scene)
+	if position == nil {
+		return
+	}
+	clicked := buttons&tcell.Button1 != 0 && p.mouseButtons&tcell.Button1 == 0
+	p.mouseButtons = buttons
+	main := position.Y
+	if p.horizontal {
+		main = position.X
+	}
+	switch {
+	case buttons&tcell.Button1 == 0:
+		if p.dragging {
+			p.dragging = false
+			p.updateCanvas()
+		}
+	case clicked && inside && main == p.GetDivider():
+		p.dragging = true
+		p.updateCanvas()
+		if scene != nil {
+			scene.GetEngine().GetFocusManager().AcquireFocusToEntity(p)
+		}
+	case p.dragging:
+		// the mouse can be dragged past the split pane start, where the
+		// position is negative and it could be taken as the default divider.
+		p.SetDivider(tools.Max(main, 0))
+	}
+}
+
+// point method returns the point for the given main and cross positions.
+func (p *SplitPane) point(main int, cross int) *api.Point {
+	if p.horizontal {
+		return api.NewPoint(main, cross)
+	}
+	return api.NewPoint(cross, main)
+}
+
+// size method returns the size for the given main and cross lengths.
+func (p *SplitPane) size(main int, cross int) *api.Size {
+	if p.horizontal {
+		return api.NewSize(main, cross)
+	}
+	return api.NewSize(cross, main)
+}
+
+// updateCanvas method updates the split pane canvas with the divider. The
+// rest of the canvas is empty, so children are displayed at both sides.
+func (p *SplitPane) updateCanvas() {
+	canvas := engine.NewCanvas(api.CloneSize(p.GetSize()))
+	style := p.GetThemeStyle(ThemeRoleBorder)
+	if p.HasFocus() || p.dragging {
+		style = p.GetThemeStyle(ThemeRoleFocused)
+	}
+	ch := '─'
+	if p.horizontal {
+		ch = '│'
+	}
+	divider := p.GetDivider()
+	_, crossLength := p.axis(p.GetSize())
+	for cross := 0; cross < crossLength; cross++ {
+		canvas.SetCellAt(p.point(divider, cross), engine.NewCell(style, ch))
+	}
+	p.SetCanvas(canvas)
+}
+
+// -----------------------------------------------------------------------------
+// SplitPane public methods
+// -----------------------------------------------------------------------------
+
+// AcquireFocus method acquires focus for the split pane.
+func (p *SplitPane) AcquireFocus() (bool, error) {
+	ok, err := p.Entity.AcquireFocus()
+	if err == nil {
+		p.updateCanvas()
+	}
+	return ok, err
+}
+
+// GetDivider method returns the divider position in the main axis for the
+// current split pane size.
+func (p *SplitPane) GetDivider() int {
+	main, _ := p.axis(p.GetSize())
+	return p.dividerFor(main)
+}
+
+// GetFirst method returns the child before the divider.
+func (p *SplitPane) GetFirst() engine.IEntity {
+	if len(p.items) > 0 {
+		return p.items[0].widget
+	}
+	return nil
+}
+
+// GetSecond method returns the child after the divider.
+func (p *SplitPane) GetSecond() engine.IEntity {
+	if len(p.items) > 1 {
+		return p.items[1].widget
+	}
+	return nil
+}
+
+// IsHorizontal method returns true if children are placed side by side.
+func (p *SplitPane) IsHorizontal() bool {
+	return p.horizontal
+}
+
+// MoveDivider method moves the divider by the given delta.
+func (p *SplitPane) MoveDivider(delta int) {
+	p.SetDivider(p.GetDivider() + delta)
+}
+
+// Reflow method arranges both children again and updates the divider.
+func (p *SplitPane) Reflow() {
+	p.Container.Reflow()
+	p.updateCanvas()
+}
+
+// Refresh method arranges both children again and updates the divider.
+func (p *SplitPane) Refresh() {
+	p.Reflow()
+}
+
+// ReleaseFocus method releases the focus for the split pane.
+func (p *SplitPane) ReleaseFocus() (bool, error) {
+	ok, err := p.Entity.ReleaseFocus()
+	if err == nil {
+		p.updateCanvas()
+	}
+	return ok, err
+}
+
+// SetDivider method sets the divider position in the main axis. It is kept
+// inside the split pane, leaving at least one column or row for every child.
+// SplitPaneDefaultDivider places the divider in the middle.
+func (p *SplitPane) SetDivider(divider int) {
+	if divider != SplitPaneDefaultDivider {
+		main, _ := p.axis(p.GetSize())
+		divider = clampDivider(divider, main)
+	}
+	p.divider = divider
+	p.Reflow()
+}
+
+// Update method drags the divider with the mouse, and it handles keyboard
+// inputs when the split pane has the focus.
+func (p *SplitPane) Update(event tcell.Event, scene engine.IScene) {
+	defer p.Entity.Update(event, scene)
+	if p.needsReflow() {
+		p.Reflow()
+	}
+	p.handleMouse(event, scene)
+	if !p.HasFocus() {
+		return
+	}
+	decrease, increase := tcell.KeyUp, tcell.KeyDown
+	if p.horizontal {
+		decrease, increase = tcell.KeyLeft, tcell.KeyRight
+	}
+	actions := []*KeyboardAction{
+		NewKeyboardActionForKey(decrease, p.execute, []any{"decrease"}),
+		NewKeyboardActionForKey(increase, p.execute, []any{"increase"}),
+	}
+	p.HandleKeyboardForActions(event, actions)
+}
+
+var _ engine.IObject = (*SplitPane)(nil)
+var _ engine.IFocus = (*SplitPane)(nil)
+var _ engine.IEntity = (*SplitPane)(nil)
+var _ IWidget = (*SplitPane)(nil)
+var _ IContainer = (*SplitPane)(nil)
+var _ engine.IArrowHandler = (*SplitPane)(nil)
@@ -0,0 +1,112 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+func TestSplitPaneArrange(t *testing.T) {
+	style := tcell.StyleDefault
+	left := widgets.NewWidget("left", nil, api.NewSize(2, 1), &style)
+	right := widgets.NewWidget("right", nil, api.NewSize(2, 1), &style)
+	pane := widgets.NewHSplitPane("hsplit", api.NewPoint(1, 1), api.NewSize(11, 3), &style, left, right)
+
+	// the divider is in the middle by default.
+	checkRects(t, 0, []engine.IEntity{left, right}, []*api.Rect{
+		api.NewRect(api.NewPoint(1, 1), api.NewSize(5, 3)),
+		api.NewRect(api.NewPoint(7, 1), api.NewSize(5, 3)),
+	})
+	if got := canvasLine(pane.GetCanvas(), 1); got != "\x00\x00\x00\x00\x00│\x00\x00\x00\x00\x00" {
+		t.Errorf("[0] canvas Error got:%q", got)
+	}
+
+	// the divider is kept inside the pane.
+	cases := []struct {
+		divider int
+		exp     int
+	}{
+		{3, 3},
+		{0, 1},
+		{20, 9},
+	}
+	for i, c := range cases {
+		pane.SetDivider(c.divider)
+		if got := pane.GetDivider(); got != c.exp {
+			t.Errorf("[%d] GetDivider Error exp:%d got:%d", i, c.exp, got)
+		}
+	}
+
+	// vertical split panes place children one above the other.
+	top := widgets.NewWidget("top", nil, api.NewSize(2, 1), &style)
+	bottom := widgets.NewWidget("bottom", nil, api.NewSize(2, 1), &style)
+	vpane := widgets.NewVSplitPane("vsplit", api.NewPoint(0, 0), api.NewSize(4, 7), &style, top, bottom)
+	vpane.SetDivider(2)
+	checkRects(t, 1, []engine.IEntity{top, bottom}, []*api.Rect{
+		api.NewRect(api.NewPoint(0, 0), api.NewSize(4, 2)),
+		api.NewRect(api.NewPoint(0, 3), api.NewSize(4, 4)),
+	})
+}
+
+func TestSplitPaneMove(t *testing.T) {
+	style := tcell.StyleDefault
+	left := widgets.NewWidget("left", nil, api.NewSize(2, 1), &style)
+	right := widgets.NewWidget("right", nil, api.NewSize(2, 1), &style)
+	pane := widgets.NewHSplitPane("hsplit", api.NewPoint(0, 0), api.NewSize(10, 3), &style, left, right)
+
+	// keys move the divider when the pane has the focus.
+	pane.AcquireFocus()
+	pane.Update(tcell.NewEventKey(tcell.KeyLeft, 0, 0), nil)
+	pane.Update(tcell.NewEventKey(tcell.KeyLeft, 0, 0), nil)
+	pane.Update(tcell.NewEventKey(tcell.KeyRight, 0, 0), nil)
+	if got := pane.GetDivider(); got != 4 {
+		t.Errorf("[0] GetDivider Error exp:4 got:%d", got)
+	}
+
+	// the divider is dragged with the mouse until the button is released.
+	events := []struct {
+		x       int
+		buttons tcell.ButtonMask
+		exp     int
+	}{
+		{7, tcell.Button1, 4},
+		{7, tcell.ButtonNone, 4},
+		{4, tcell.Button1, 4},
+		{7, tcell.Button1, 7},
+		{20, tcell.Button1, 8},
+		{2, tcell.ButtonNone, 8},
+		{2, tcell.ButtonNone, 8},
+	}
+	for i, e := range events {
+		pane.Update(tcell.NewEventMouse(e.x, 1, e.buttons, 0), nil)
+		if got := pane.GetDivider(); got != e.exp {
+			t.Errorf("[%d] GetDivider Error exp:%d got:%d", i, e.exp, got)
+		}
+	}
+	if got := left.GetSize(); !got.IsEqual(api.NewSize(8, 3)) {
+		t.Errorf("[1] GetSize Error exp:[8,3] got:%s", got.ToString())
+	}
+	// dragging the divider past the split pane start keeps it at the first
+	// column.
+	moved := widgets.NewHSplitPane("hsplit/moved", api.NewPoint(5, 0), api.NewSize(10, 3), &style,
+		widgets.NewWidget("left/moved", nil, api.NewSize(2, 1), &style),
+		widgets.NewWidget("right/moved", nil, api.NewSize(2, 1), &style))
+	for i, e := range []struct {
+		x       int
+		buttons tcell.ButtonMask
+		exp     int
+	}{
+		{10, tcell.Button1, 5},
+		{4, tcell.Button1, 1},
+		{0, tcell.Button1, 1},
+		{0, tcell.ButtonNone, 1},
+	} {
+		moved.Update(tcell.NewEventMouse(e.x, 1, e.buttons, 0), nil)
+		if got := moved.GetDivider(); got != e.exp {
+			t.Errorf("[2.%d] GetDivider Error exp:%d got:%d", i, e.exp, got)
+		}
+	}
+}
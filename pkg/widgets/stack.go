@@ -0,0 +1,83 @@
+// stack.go contains the Stack container widget, which places all its
+// children on top of each other, like a background with a label over it.
+// Example:
+//
+//	card := widgets.NewStack("card", api.NewPoint(10, 5), api.NewSize(20, 5))
+//	card.Add(frame).WithAlign(widgets.AlignStretch)
+//	card.Add(title).WithAlign(widgets.AlignCenter)
+//	scene.AddEntity(card)
+package widgets
+
+import (
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+//
+// stackArranger
+//
+// -----------------------------------------------------------------------------
+
+// stackArranger structure arranges all children in the same rectangle, every
+// one aligned in both axes with its alignment.
+type stackArranger struct {
+}
+
+// measure method returns the size for the largest child.
+func (a *stackArranger) measure(items []*ContainerItem, sizes []*api.Size) *api.Size {
+	result := api.NewSize(0, 0)
+	for _, size := range sizes {
+		result.W = tools.Max(result.W, size.W)
+		result.H = tools.Max(result.H, size.H)
+	}
+	return result
+}
+
+// arrange method returns the rectangle for every child in the stack.
+func (a *stackArranger) arrange(items []*ContainerItem, sizes []*api.Size, rect *api.Rect) []*api.Rect {
+	result := make([]*api.Rect, len(items))
+	for i, item := range items {
+		offsetX, w := alignLength(item.align, tools.Min(sizes[i].W, rect.Size.W), rect.Size.W)
+		offsetY, h := alignLength(item.align, tools.Min(sizes[i].H, rect.Size.H), rect.Size.H)
+		result[i] = api.NewRect(api.NewPoint(rect.Origin.X+offsetX, rect.Origin.Y+offsetY), api.NewSize(w, h))
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+//
+// Stack
+//
+// -----------------------------------------------------------------------------
+
+// Stack structure defines a container which places all its children on top
+// of each other. Children added later are displayed over children added
+// before.
+type Stack struct {
+	*Container
+}
+
+// NewStack function creates a new Stack instance at the given position with
+// the given size. A nil size makes the stack to take the size of its largest
+// child.
+func NewStack(name string, position *api.Point, size *api.Size) *Stack {
+	return &Stack{
+		Container: newContainer(name, position, size, &stackArranger{}),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Stack public methods
+// -----------------------------------------------------------------------------
+
+// Add method adds the given widget on top of all other children. The widget
+// z-level is set over the z-level for the stack and all other children, so it
+// has to be added before the stack is added to a scene.
+func (s *Stack) Add(widget engine.IEntity) *ContainerItem {
+	widget.SetZLevel(s.GetZLevel() + len(s.items) + 1)
+	return s.addItem(NewContainerItem(widget))
+}
+
+var _ IContainer = (*Stack)(nil)
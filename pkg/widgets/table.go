@@ -0,0 +1,742 @@
+// table.go contains all attributes and methods required to implement a table
+// widget, which displays rows of values in typed columns with headers. Rows
+// can be selected with the keyboard or with the mouse and they can be sorted
+// by any column. Tables scroll vertically through all rows and horizontally
+// through columns when they do not fit in the widget.
+// Example:
+//
+//	table := widgets.NewTable("table/inventory/1", api.NewPoint(0, 0), api.NewSize(40, 10), &style,
+//	    []*widgets.TableColumn{
+//	        widgets.NewTableColumn("Item", widgets.TableColumnString).WithFlex(1),
+//	        widgets.NewTableColumn("Qty", widgets.TableColumnInt),
+//	        widgets.NewTableColumn("Weight", widgets.TableColumnFloat).WithWidth(8),
+//	    })
+//	table.AddRow("dagger", 1, 0.5)
+//	table.AddRow(widgets.NewTableCell("cursed ring", &redStyle), 1, 0.1)
+package widgets
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// TableColumnType type defines the type of values in a table column, used to
+// format and to sort them.
+type TableColumnType int
+
+// TableWidthMode type defines how the width for a table column is computed.
+type TableWidthMode int
+
+// TableCellRenderer type defines a function which returns the string and the
+// style used to display a cell value with the given width. A nil style uses
+// the table style.
+type TableCellRenderer func(value any, width int) (string, *tcell.Style)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	TableColumnString TableColumnType = iota
+	TableColumnInt
+	TableColumnFloat
+	TableColumnBool
+)
+
+const (
+	// TableWidthAuto makes the column as wide as its widest value.
+	TableWidthAuto TableWidthMode = iota
+	// TableWidthFixed makes the column to have the given width.
+	TableWidthFixed
+	// TableWidthFlex makes the column to share the width not used by other
+	// columns.
+	TableWidthFlex
+)
+
+const (
+	// tableColumnSpacing is the space between columns.
+	tableColumnSpacing = 1
+	// tableHeaderHeight is the number of lines before the first row: the
+	// border and the headers.
+	tableHeaderHeight = 2
+)
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// compareTableValues function compares two values for the given column type.
+// It returns a negative number if a is lower than b, zero if they are equal,
+// and a positive number if a is greater than b.
+func compareTableValues(kind TableColumnType, a any, b any) int {
+	switch kind {
+	case TableColumnInt, TableColumnFloat:
+		x, y := toTableFloat(a), toTableFloat(b)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	case TableColumnBool:
+		x, _ := a.(bool)
+		y, _ := b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		}
+		return 1
+	default:
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+// fitTableString function returns the given string truncated or padded to the
+// given width and aligned with the given alignment.
+func fitTableString(str string, width int, align Align) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(str)
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	offset, _ := alignLength(align, len(runes), width)
+	return strings.Repeat(" ", offset) + str + strings.Repeat(" ", width-offset-len(runes))
+}
+
+// toTableFloat function returns the given numeric value as a float64.
+func toTableFloat(value any) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+// -----------------------------------------------------------------------------
+//
+// TableCell
+//
+// -----------------------------------------------------------------------------
+
+// TableCell structure defines a cell value with its own style. Rows can
+// contain TableCell instances or plain values.
+type TableCell struct {
+	value any
+	style *tcell.Style
+}
+
+// NewTableCell function creates a new TableCell instance.
+func NewTableCell(value any, style *tcell.Style) *TableCell {
+	return &TableCell{
+		value: value,
+		style: style,
+	}
+}
+
+// GetStyle method returns the cell style.
+func (c *TableCell) GetStyle() *tcell.Style {
+	return c.style
+}
+
+// GetValue method returns the cell value.
+func (c *TableCell) GetValue() any {
+	return c.value
+}
+
+// -----------------------------------------------------------------------------
+//
+// TableColumn
+//
+// -----------------------------------------------------------------------------
+
+// TableColumn structure defines a table column with its header title, the
+// type of its values and how it is displayed.
+// width is the width for fixed columns and the weight for flex columns.
+// precision is the number of decimals for float values.
+// renderer is used to display cell values if it is not nil.
+type TableColumn struct {
+	title     string
+	kind      TableColumnType
+	widthMode TableWidthMode
+	width     int
+	align     Align
+	precision int
+	sortable  bool
+	renderer  TableCellRenderer
+}
+
+// NewTableColumn function creates a new TableColumn instance with the given
+// title and type. Numeric columns are aligned to the end, any other column
+// is aligned to the start.
+func NewTableColumn(title string, kind TableColumnType) *TableColumn {
+	align := AlignStart
+	if kind == TableColumnInt || kind == TableColumnFloat {
+		align = AlignEnd
+	}
+	return &TableColumn{
+		title:     title,
+		kind:      kind,
+		widthMode: TableWidthAuto,
+		width:     0,
+		align:     align,
+		precision: 2,
+		sortable:  true,
+		renderer:  nil,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TableColumn public methods
+// -----------------------------------------------------------------------------
+
+// Format method returns the given value as a string for the column type.
+func (c *TableColumn) Format(value any) string {
+	if value == nil {
+		return ""
+	}
+	switch c.kind {
+	case TableColumnFloat:
+		return strconv.FormatFloat(toTableFloat(value), 'f', c.precision, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// GetAlign method returns the column alignment.
+func (c *TableColumn) GetAlign() Align {
+	return c.align
+}
+
+// GetTitle method returns the column header title.
+func (c *TableColumn) GetTitle() string {
+	return c.title
+}
+
+// GetType method returns the type for column values.
+func (c *TableColumn) GetType() TableColumnType {
+	return c.kind
+}
+
+// GetWidth method returns the width mode and the width, or the weight for
+// flex columns.
+func (c *TableColumn) GetWidth() (TableWidthMode, int) {
+	return c.widthMode, c.width
+}
+
+// IsSortable method returns if the table can be sorted by the column.
+func (c *TableColumn) IsSortable() bool {
+	return c.sortable
+}
+
+// WithAlign method sets the alignment for column values.
+func (c *TableColumn) WithAlign(align Align) *TableColumn {
+	c.align = align
+	return c
+}
+
+// WithFlex method makes the column to share the width not used by other
+// columns with the given weight.
+func (c *TableColumn) WithFlex(weight int) *TableColumn {
+	c.widthMode = TableWidthFlex
+	c.width = tools.Max(1, weight)
+	return c
+}
+
+// WithPrecision method sets the number of decimals for float values.
+func (c *TableColumn) WithPrecision(precision int) *TableColumn {
+	c.precision = precision
+	return c
+}
+
+// WithRenderer method sets a custom function to display cell values.
+func (c *TableColumn) WithRenderer(renderer TableCellRenderer) *TableColumn {
+	c.renderer = renderer
+	return c
+}
+
+// WithSortable method sets if the table can be sorted by the column.
+func (c *TableColumn) WithSortable(sortable bool) *TableColumn {
+	c.sortable = sortable
+	return c
+}
+
+// WithWidth method sets a fixed width for the column.
+func (c *TableColumn) WithWidth(width int) *TableColumn {
+	c.widthMode = TableWidthFixed
+	c.width = tools.Max(1, width)
+	return c
+}
+
+// -----------------------------------------------------------------------------
+//
+// Table
+//
+// -----------------------------------------------------------------------------
+
+// Table structure defines a widget which displays rows of values in columns.
+// order contains the row indexes in the order they are displayed, and
+// selectionIndex is the selected position in that order.
+// sortColumn is the column rows are sorted by, -1 if they are not sorted.
+// columnOffset is the first column displayed when the table is scrolled
+// horizontally.
+// mouseButtons are the mouse buttons pressed in the last mouse event, used to
+// handle only new clicks.
+type Table struct {
+	*Widget
+	columns        []*TableColumn
+	rows           [][]any
+	order          []int
+	selectionIndex int
+	sortColumn     int
+	sortAscending  bool
+	columnOffset   int
+	scroller       *Scroller
+	mouseButtons   tcell.ButtonMask
+}
+
+// NewTable function creates a new Table instance with the given columns.
+func NewTable(name string, position *api.Point, size *api.Size, style *tcell.Style, columns []*TableColumn) *Table {
+	tools.Logger.WithField("module", "table").
+		WithField("function", "NewTable").
+		Infof("%s %s %s %d", name, position.ToString(), size.ToString(), len(columns))
+	table := &Table{
+		Widget:         NewWidget(name, position, size, style),
+		columns:        columns,
+		rows:           nil,
+		order:          nil,
+		selectionIndex: 0,
+		sortColumn:     -1,
+		sortAscending:  true,
+		columnOffset:   0,
+		scroller:       nil,
+		mouseButtons:   tcell.ButtonNone,
+	}
+	table.SetThemeClass("table")
+	table.SetHandlesArrows(true)
+	table.SetFocusType(engine.SingleFocus)
+	table.SetFocusEnable(true)
+	table.resetScroller()
+	table.updateCanvas()
+	return table
+}
+
+// -----------------------------------------------------------------------------
+// Table private methods
+// -----------------------------------------------------------------------------
+
+// cellAt method returns the value and the style for the given row and column.
+func (t *Table) cellAt(row int, column int) (any, *tcell.Style) {
+	values := t.rows[row]
+	if column >= len(values) {
+		return nil, nil
+	}
+	if cell, ok := values[column].(*TableCell); ok {
+		return cell.value, cell.style
+	}
+	return values[column], nil
+}
+
+// columnWidths method returns the width for every column. Auto columns are as
+// wide as their widest value or title, and flex columns share the width not
+// used by other columns.
+func (t *Table) columnWidths() []int {
+	widths := make([]int, len(t.columns))
+	weights := make([]int, len(t.columns))
+	used := tools.Max(0, tableColumnSpacing*(len(t.columns)-1))
+	for i, column := range t.columns {
+		switch column.widthMode {
+		case TableWidthFixed:
+			widths[i] = column.width
+		case TableWidthFlex:
+			widths[i] = utf8.RuneCountInString(column.title) + 1
+			weights[i] = column.width
+		default:
+			// one more character for the sort indicator.
+			widths[i] = utf8.RuneCountInString(column.title) + 1
+			for row := range t.rows {
+				str, _ := t.renderCell(row, i, 0)
+				widths[i] = tools.Max(widths[i], utf8.RuneCountInString(str))
+			}
+		}
+		used += widths[i]
+	}
+	distributeLength(widths, weights, t.innerSize().W-used)
+	return widths
+}
+
+// execute method runs the action for the given keyboard input.
+func (t *Table) execute(args ...any) {
+	tools.Logger.WithField("module", "table").
+		WithField("method", "execute").
+		Debugf("%s %+v", t.GetName(), args)
+	pageLength := tools.Max(1, t.innerSize().H-1)
+	switch args[0].(string) {
+	case "up":
+		t.SetSelectionIndex(t.selectionIndex - 1)
+	case "down":
+		t.SetSelectionIndex(t.selectionIndex + 1)
+	case "page-up":
+		t.SetSelectionIndex(t.selectionIndex - pageLength)
+	case "page-down":
+		t.SetSelectionIndex(t.selectionIndex + pageLength)
+	case "home":
+		t.SetSelectionIndex(0)
+	case "end":
+		t.SetSelectionIndex(len(t.order) - 1)
+	case "left":
+		t.ScrollColumns(-1)
+	case "right":
+		t.ScrollColumns(1)
+	case "sort":
+		t.sortNext()
+	case "reverse":
+		if t.sortColumn != -1 {
+			t.SortBy(t.sortColumn, !t.sortAscending)
+		}
+	case "run":
+		if len(t.order) != 0 {
+			t.RunCallback(t)
+		}
+	}
+}
+
+// handleMouse method selects the clicked row, or sorts by the clicked column
+// header. Mouse wheel scrolls through rows.
+func (t *Table) handleMouse(event tcell.Event, scene engine.IScene) {
+	position, buttons, inside := t.GetMousePosition(event, scene)
+	if position == nil {
+		return
+	}
+	clicked := buttons&tcell.Button1 != 0 && t.mouseButtons&tcell.Button1 == 0
+	t.mouseButtons = buttons
+	if !inside {
+		return
+	}
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		t.SetSelectionIndex(t.selectionIndex - 1)
+	case buttons&tcell.WheelDown != 0:
+		t.SetSelectionIndex(t.selectionIndex + 1)
+	case clicked && position.Y == tableHeaderHeight-1:
+		if column := t.columnAt(position.X); column != -1 {
+			ascending := true
+			if column == t.sortColumn {
+				ascending = !t.sortAscending
+			}
+			t.SortBy(column, ascending)
+		}
+	case clicked && position.Y >= tableHeaderHeight && position.Y < t.GetSize().H-1:
+		index := t.scroller.StartSelection + position.Y - tableHeaderHeight
+		if index < len(t.order) {
+			if index == t.selectionIndex {
+				t.execute("run")
+			} else {
+				t.SetSelectionIndex(index)
+			}
+		}
+	}
+}
+
+// columnAt method returns the column displayed at the given horizontal
+// position in the widget, or -1 if there is not any column.
+func (t *Table) columnAt(x int) int {
+	widths := t.columnWidths()
+	start := 1
+	for i := t.columnOffset; i < len(t.columns); i++ {
+		if x >= start && x < start+widths[i] {
+			return i
+		}
+		start += widths[i] + tableColumnSpacing
+	}
+	return -1
+}
+
+// innerSize method returns the size inside the table border.
+func (t *Table) innerSize() *api.Size {
+	size := t.GetSize()
+	return api.NewSize(tools.Max(0, size.W-2), tools.Max(0, size.H-2))
+}
+
+// renderCell method returns the string and the style used to display the
+// cell for the given row and column with the given width.
+func (t *Table) renderCell(row int, column int, width int) (string, *tcell.Style) {
+	value, style := t.cellAt(row, column)
+	if renderer := t.columns[column].renderer; renderer != nil {
+		str, renderStyle := renderer(value, width)
+		if renderStyle != nil {
+			style = renderStyle
+		}
+		return str, style
+	}
+	return t.columns[column].Format(value), style
+}
+
+// resetScroller method creates a new vertical scroller for all rows and the
+// number of rows displayed.
+func (t *Table) resetScroller() {
+	t.scroller = NewVerticalScroller(len(t.order), tools.Max(1, t.GetSize().H-tableHeaderHeight-1))
+}
+
+// sortNext method sorts the table by the next sortable column.
+func (t *Table) sortNext() {
+	for i := 1; i <= len(t.columns); i++ {
+		column := (t.sortColumn + i) % len(t.columns)
+		if t.columns[column].sortable {
+			t.SortBy(column, true)
+			return
+		}
+	}
+}
+
+// sortRows method sorts the display order for all rows by the sort column.
+func (t *Table) sortRows() {
+	if t.sortColumn == -1 {
+		return
+	}
+	kind := t.columns[t.sortColumn].kind
+	sort.SliceStable(t.order, func(i, j int) bool {
+		a, _ := t.cellAt(t.order[i], t.sortColumn)
+		b, _ := t.cellAt(t.order[j], t.sortColumn)
+		if t.sortAscending {
+			return compareTableValues(kind, a, b) < 0
+		}
+		return compareTableValues(kind, a, b) > 0
+	})
+}
+
+// updateCanvas method updates the table canvas with the headers and the rows
+// to be displayed.
+func (t *Table) updateCanvas() {
+	canvas := t.GetCanvas()
+	canvas.WriteRectangleInCanvasAt(nil, nil, t.GetThemeStyle(ThemeRoleBorder), engine.CanvasRectSingleLine)
+	inner := t.innerSize()
+	if inner.W == 0 || inner.H == 0 {
+		return
+	}
+	widths := t.columnWidths()
+	normalStyle := t.GetThemeStyle(ThemeRoleNormal)
+	// writeLine writes the string for every column in the given line, with
+	// the given style or with the style returned for every column.
+	writeLine := func(y int, style *tcell.Style, cell func(column int, width int) (string, *tcell.Style)) {
+		canvas.WriteStringInCanvasAt(strings.Repeat(" ", inner.W), style, api.NewPoint(1, y))
+		x := 1
+		for i := t.columnOffset; i < len(t.columns) && x <= inner.W; i++ {
+			width := tools.Min(widths[i], inner.W-x+1)
+			str, cellStyle := cell(i, widths[i])
+			if cellStyle == nil {
+				cellStyle = style
+			}
+			// last column displayed is cut at the table border.
+			fitted := []rune(fitTableString(str, widths[i], t.columns[i].align))
+			canvas.WriteStringInCanvasAt(string(fitted[:width]), cellStyle, api.NewPoint(x, y))
+			x += widths[i] + tableColumnSpacing
+		}
+	}
+
+	writeLine(1, t.GetThemeStyle(ThemeRoleTitle), func(column int, width int) (string, *tcell.Style) {
+		title := fitTableString(t.columns[column].title, width-1, t.columns[column].align)
+		switch {
+		case column != t.sortColumn:
+			return title + " ", nil
+		case t.sortAscending:
+			return title + "▲", nil
+		}
+		return title + "▼", nil
+	})
+
+	y := tableHeaderHeight
+	if len(t.order) != 0 {
+		t.scroller.Update(t.selectionIndex)
+		t.scroller.CreateIter()
+	}
+	for ; len(t.order) != 0 && t.scroller.IterHasNext() && y <= inner.H; y++ {
+		index, _ := t.scroller.IterGetNext()
+		row := t.order[index]
+		if index == t.selectionIndex {
+			// selected row uses the selected style for all its cells.
+			selectedStyle := t.GetThemeStyle(ThemeRoleSelected)
+			writeLine(y, selectedStyle, func(column int, width int) (string, *tcell.Style) {
+				str, _ := t.renderCell(row, column, width)
+				return str, selectedStyle
+			})
+			continue
+		}
+		writeLine(y, normalStyle, func(column int, width int) (string, *tcell.Style) {
+			return t.renderCell(row, column, width)
+		})
+	}
+	// clear lines without rows.
+	for ; y <= inner.H; y++ {
+		canvas.WriteStringInCanvasAt(strings.Repeat(" ", inner.W), normalStyle, api.NewPoint(1, y))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Table public methods
+// -----------------------------------------------------------------------------
+
+// AddRow method adds a new row with the given values, one for every column.
+// Values can be TableCell instances to use their own style.
+func (t *Table) AddRow(values ...any) {
+	t.rows = append(t.rows, values)
+	t.order = append(t.order, len(t.rows)-1)
+	t.sortRows()
+	t.resetScroller()
+	t.updateCanvas()
+}
+
+// GetColumns method returns all table columns.
+func (t *Table) GetColumns() []*TableColumn {
+	return t.columns
+}
+
+// GetColumnOffset method returns the first column displayed.
+func (t *Table) GetColumnOffset() int {
+	return t.columnOffset
+}
+
+// GetRow method returns the values for the row at the given index, in the
+// order rows were added.
+func (t *Table) GetRow(index int) []any {
+	if index < 0 || index >= len(t.rows) {
+		return nil
+	}
+	return t.rows[index]
+}
+
+// GetRowCount method returns the number of rows in the table.
+func (t *Table) GetRowCount() int {
+	return len(t.rows)
+}
+
+// GetSelectedRow method returns the index, in the order rows were added, and
+// the values for the selected row. It returns -1 and nil if there is not any
+// row.
+func (t *Table) GetSelectedRow() (int, []any) {
+	if t.selectionIndex < 0 || t.selectionIndex >= len(t.order) {
+		return -1, nil
+	}
+	row := t.order[t.selectionIndex]
+	return row, t.rows[row]
+}
+
+// GetSelectionIndex method returns the selected position in the displayed
+// rows.
+func (t *Table) GetSelectionIndex() int {
+	return t.selectionIndex
+}
+
+// GetSort method returns the column rows are sorted by, -1 if they are not
+// sorted, and if they are sorted in ascending order.
+func (t *Table) GetSort() (int, bool) {
+	return t.sortColumn, t.sortAscending
+}
+
+// Refresh method refreshes the table canvas with latest attribute values.
+func (t *Table) Refresh() {
+	// create a new canvas if the table has been resized.
+	if !t.GetCanvas().Size().IsEqual(t.GetSize()) {
+		t.SetCanvas(engine.NewCanvas(t.GetSize()))
+	}
+	t.resetScroller()
+	t.updateCanvas()
+}
+
+// ScrollColumns method scrolls horizontally the given number of columns.
+func (t *Table) ScrollColumns(delta int) {
+	t.columnOffset = tools.Min(tools.Max(0, t.columnOffset+delta), tools.Max(0, len(t.columns)-1))
+	t.updateCanvas()
+}
+
+// SetRows method replaces all rows in the table.
+func (t *Table) SetRows(rows [][]any) {
+	t.rows = rows
+	t.order = make([]int, len(rows))
+	for i := range t.order {
+		t.order[i] = i
+	}
+	t.selectionIndex = 0
+	t.sortRows()
+	t.resetScroller()
+	t.updateCanvas()
+}
+
+// SetSelectionIndex method sets the selected position in the displayed rows.
+func (t *Table) SetSelectionIndex(index int) {
+	t.selectionIndex = tools.Min(tools.Max(0, index), tools.Max(0, len(t.order)-1))
+	t.updateCanvas()
+}
+
+// SortBy method sorts all rows by the given column in ascending or descending
+// order. The selected row is kept selected.
+func (t *Table) SortBy(column int, ascending bool) bool {
+	if column < 0 || column >= len(t.columns) || !t.columns[column].sortable {
+		return false
+	}
+	selected, _ := t.GetSelectedRow()
+	t.sortColumn = column
+	t.sortAscending = ascending
+	t.sortRows()
+	for i, row := range t.order {
+		if row == selected {
+			t.selectionIndex = i
+		}
+	}
+	t.updateCanvas()
+	return true
+}
+
+// Update method executes all table functionality every tick time. Keyboard
+// input moves the selection, scrolls columns and sorts rows when the table
+// has the focus, and mouse input selects rows and sorts columns.
+func (t *Table) Update(event tcell.Event, scene engine.IScene) {
+	defer t.Entity.Update(event, scene)
+	t.handleMouse(event, scene)
+	if !t.HasFocus() {
+		return
+	}
+	actions := []*KeyboardAction{
+		NewKeyboardActionForKey(tcell.KeyUp, t.execute, []any{"up"}),
+		NewKeyboardActionForKey(tcell.KeyDown, t.execute, []any{"down"}),
+		NewKeyboardActionForKey(tcell.KeyPgUp, t.execute, []any{"page-up"}),
+		NewKeyboardActionForKey(tcell.KeyPgDn, t.execute, []any{"page-down"}),
+		NewKeyboardActionForKey(tcell.KeyHome, t.execute, []any{"home"}),
+		NewKeyboardActionForKey(tcell.KeyEnd, t.execute, []any{"end"}),
+		NewKeyboardActionForKey(tcell.KeyLeft, t.execute, []any{"left"}),
+		NewKeyboardActionForKey(tcell.KeyRight, t.execute, []any{"right"}),
+		NewKeyboardActionForKey(tcell.KeyEnter, t.execute, []any{"run"}),
+		NewKeyboardActionForRune('s', t.execute, []any{"sort"}),
+		NewKeyboardActionForRune('r', t.execute, []any{"reverse"}),
+	}
+	t.HandleKeyboardForActions(event, actions)
+}
+
+var _ engine.IObject = (*Table)(nil)
+var _ engine.IFocus = (*Table)(nil)
+var _ engine.IEntity = (*Table)(nil)
+var _ engine.IArrowHandler = (*Table)(nil)
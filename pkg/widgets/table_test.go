@@ -0,0 +1,165 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+func newTestTable(size *api.Size) *widgets.Table {
+	style := tcell.StyleDefault
+	table := widgets.NewTable("table/test/1", api.NewPoint(0, 0), size, &style, []*widgets.TableColumn{
+		widgets.NewTableColumn("Item", widgets.TableColumnString).WithFlex(1),
+		widgets.NewTableColumn("Qty", widgets.TableColumnInt),
+		widgets.NewTableColumn("Weight", widgets.TableColumnFloat).WithWidth(7),
+	})
+	table.AddRow("dagger", 2, 0.5)
+	table.AddRow("ring", 1, 0.1)
+	table.AddRow("axe", 1, 3.0)
+	table.AddRow("rope", 10, 1.25)
+	return table
+}
+
+func TestTableCanvas(t *testing.T) {
+	table := newTestTable(api.NewSize(24, 5))
+	cases := []struct {
+		input int
+		exp   string
+	}{
+		{
+			input: 1,
+			exp:   "│Item      Qty  Weight │",
+		},
+		{
+			input: 2,
+			exp:   "│dagger       2    0.50│",
+		},
+		{
+			input: 3,
+			exp:   "│ring         1    0.10│",
+		},
+	}
+	for i, c := range cases {
+		got := canvasLine(table.GetCanvas(), c.input)
+		if got != c.exp {
+			t.Errorf("[%d] canvas Error exp:%q got:%q", i, c.exp, got)
+		}
+	}
+}
+
+func TestTableSelectionAndSort(t *testing.T) {
+	table := newTestTable(api.NewSize(24, 5))
+	table.AcquireFocus()
+
+	// selected row is kept selected when rows are sorted.
+	table.Update(tcell.NewEventKey(tcell.KeyDown, 0, 0), nil)
+	if got, _ := table.GetSelectedRow(); got != 1 {
+		t.Errorf("[0] GetSelectedRow Error exp:1 got:%d", got)
+	}
+	if ok := table.SortBy(1, false); !ok {
+		t.Errorf("[0] SortBy Error exp:true got:%t", ok)
+	}
+	if got := canvasLine(table.GetCanvas(), 2); got != "│dagger       2    0.50│" {
+		t.Errorf("[0] canvas Error exp:%q got:%q", "│dagger       2    0.50│", got)
+	}
+	if got := canvasLine(table.GetCanvas(), 1); got != "│Item      Qty▼ Weight │" {
+		t.Errorf("[0] canvas Error exp:%q got:%q", "│Item      Qty▼ Weight │", got)
+	}
+	if got, row := table.GetSelectedRow(); got != 1 || row[0] != "ring" {
+		t.Errorf("[0] GetSelectedRow Error exp:1 ring got:%d %v", got, row)
+	}
+
+	// sort keys sort by the next column and reverse the order.
+	table.Update(tcell.NewEventKey(tcell.KeyRune, 's', 0), nil)
+	if column, ascending := table.GetSort(); column != 2 || !ascending {
+		t.Errorf("[1] GetSort Error exp:2 true got:%d %t", column, ascending)
+	}
+	table.Update(tcell.NewEventKey(tcell.KeyRune, 'r', 0), nil)
+	if column, ascending := table.GetSort(); column != 2 || ascending {
+		t.Errorf("[1] GetSort Error exp:2 false got:%d %t", column, ascending)
+	}
+	if got := canvasLine(table.GetCanvas(), 3); got != "│ring         1    0.10│" {
+		t.Errorf("[1] canvas Error exp:%q got:%q", "│ring         1    0.10│", got)
+	}
+
+	// rows scroll vertically with the selection.
+	table.Update(tcell.NewEventKey(tcell.KeyHome, 0, 0), nil)
+	if got := table.GetSelectionIndex(); got != 0 {
+		t.Errorf("[2] GetSelectionIndex Error exp:0 got:%d", got)
+	}
+	if got := canvasLine(table.GetCanvas(), 2); got != "│axe          1    3.00│" {
+		t.Errorf("[2] canvas Error exp:%q got:%q", "│axe          1    3.00│", got)
+	}
+}
+
+func TestTableMouseAndScroll(t *testing.T) {
+	table := newTestTable(api.NewSize(24, 6))
+	var selected []any
+	table.SetWidgetCallback(func(entity engine.IEntity, args ...any) bool {
+		_, selected = entity.(*widgets.Table).GetSelectedRow()
+		return true
+	})
+
+	// clicking a row selects it, and clicking it again runs the callback.
+	click := tcell.NewEventMouse(2, 3, tcell.Button1, 0)
+	release := tcell.NewEventMouse(2, 3, tcell.ButtonNone, 0)
+	table.Update(click, nil)
+	table.Update(release, nil)
+	if got := table.GetSelectionIndex(); got != 1 {
+		t.Errorf("[0] GetSelectionIndex Error exp:1 got:%d", got)
+	}
+	table.Update(click, nil)
+	table.Update(release, nil)
+	if len(selected) == 0 || selected[0] != "ring" {
+		t.Errorf("[0] callback Error exp:ring got:%v", selected)
+	}
+
+	// clicking a header sorts by the column, and clicking it again reverses
+	// the order.
+	header := tcell.NewEventMouse(12, 1, tcell.Button1, 0)
+	headerRelease := tcell.NewEventMouse(12, 1, tcell.ButtonNone, 0)
+	table.Update(header, nil)
+	table.Update(headerRelease, nil)
+	if column, ascending := table.GetSort(); column != 1 || !ascending {
+		t.Errorf("[1] GetSort Error exp:1 true got:%d %t", column, ascending)
+	}
+	table.Update(header, nil)
+	if column, ascending := table.GetSort(); column != 1 || ascending {
+		t.Errorf("[1] GetSort Error exp:1 false got:%d %t", column, ascending)
+	}
+
+	// columns scroll horizontally.
+	table.AcquireFocus()
+	table.Update(tcell.NewEventKey(tcell.KeyRight, 0, 0), nil)
+	if got := table.GetColumnOffset(); got != 1 {
+		t.Errorf("[2] GetColumnOffset Error exp:1 got:%d", got)
+	}
+	if got := canvasLine(table.GetCanvas(), 1); got != "│Qty▼ Weight           │" {
+		t.Errorf("[2] canvas Error exp:%q got:%q", "│Qty▼ Weight           │", got)
+	}
+}
+
+func TestTableRenderer(t *testing.T) {
+	style := tcell.StyleDefault
+	red := tcell.StyleDefault.Foreground(tcell.ColorRed)
+	table := widgets.NewTable("table/test/1", api.NewPoint(0, 0), api.NewSize(12, 5), &style, []*widgets.TableColumn{
+		widgets.NewTableColumn("Alive", widgets.TableColumnBool).WithRenderer(func(value any, width int) (string, *tcell.Style) {
+			if value.(bool) {
+				return "yes", nil
+			}
+			return "no", &red
+		}),
+	})
+	table.AddRow(true)
+	table.AddRow(false)
+	table.SetSelectionIndex(0)
+	if got := canvasLine(table.GetCanvas(), 3); got != "│no        │" {
+		t.Errorf("[0] canvas Error exp:%q got:%q", "│no        │", got)
+	}
+	if got := table.GetCanvas().GetStyleAt(api.NewPoint(1, 3)); *got != red {
+		t.Errorf("[0] canvas Error exp:%v got:%v", red, *got)
+	}
+}
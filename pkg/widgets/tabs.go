@@ -0,0 +1,371 @@
+// tabs.go contains the Tabs container widget, which displays one child panel
+// at a time with a tab strip on top to select the panel. Only the selected
+// panel is in the scene, so widgets in any other panel are not updated, drawn
+// or focused.
+// Key bindings:
+//
+//	Left, Right   select the previous or the next tab.
+//	Home, End     select the first or the last tab.
+//
+// Example:
+//
+//	sheet := widgets.NewTabs("sheet", api.NewPoint(0, 0), api.NewSize(40, 12), &style)
+//	sheet.SetMarkup(true)
+//	sheet.AddTab("Stats", statsPanel)
+//	sheet.AddTab("Inventory", inventoryPanel)
+//	sheet.AddTab("[yellow]Spells[/]", spellsPanel)
+//	scene.AddEntity(sheet)
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+//
+// tabsArranger
+//
+// -----------------------------------------------------------------------------
+
+// tabsArranger structure arranges all panels in the same rectangle below the
+// tab strip, every one aligned in both axes with its alignment.
+type tabsArranger struct {
+	tabs *Tabs
+}
+
+// measure method returns the size for the largest panel and the tab strip.
+func (a *tabsArranger) measure(items []*ContainerItem, sizes []*api.Size) *api.Size {
+	result := api.NewSize(a.tabs.stripWidth(), 0)
+	for _, size := range sizes {
+		result.W = tools.Max(result.W, size.W)
+		result.H = tools.Max(result.H, size.H)
+	}
+	result.H++
+	return result
+}
+
+// arrange method returns the rectangle for every panel below the tab strip.
+func (a *tabsArranger) arrange(items []*ContainerItem, sizes []*api.Size, rect *api.Rect) []*api.Rect {
+	result := make([]*api.Rect, len(items))
+	width, height := rect.Size.W, tools.Max(0, rect.Size.H-1)
+	for i, item := range items {
+		offsetX, w := alignLength(item.align, tools.Min(sizes[i].W, width), width)
+		offsetY, h := alignLength(item.align, tools.Min(sizes[i].H, height), height)
+		result[i] = api.NewRect(api.NewPoint(rect.Origin.X+offsetX, rect.Origin.Y+1+offsetY), api.NewSize(w, h))
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+//
+// Tabs
+//
+// -----------------------------------------------------------------------------
+
+// Tabs structure defines a container which displays only the selected panel.
+// titles contains the title for every panel, which can contain markup tags
+// when the tabs handle markup.
+// selected is the index for the panel being displayed.
+// mouseButtons are the mouse buttons pressed in the last mouse event, used to
+// handle only new clicks.
+type Tabs struct {
+	*Container
+	titles       []string
+	selected     int
+	mouseButtons tcell.ButtonMask
+}
+
+// NewTabs function creates a new Tabs instance at the given position with the
+// given size. A nil size makes the tabs to take the size of the largest panel
+// and the tab strip.
+func NewTabs(name string, position *api.Point, size *api.Size, style *tcell.Style) *Tabs {
+	tools.Logger.WithField("module", "tabs").
+		WithField("function", "NewTabs").
+		Infof("%s", name)
+	tabs := &Tabs{
+		titles:       nil,
+		selected:     0,
+		mouseButtons: tcell.ButtonNone,
+	}
+	tabs.Container = newContainer(name, position, size, &tabsArranger{tabs: tabs})
+	tabs.SetStyle(style)
+	tabs.SetThemeClass("tabs")
+	tabs.SetHandlesArrows(true)
+	tabs.SetFocusType(engine.SingleFocus)
+	tabs.SetFocusEnable(true)
+	tabs.updateCanvas()
+	return tabs
+}
+
+// -----------------------------------------------------------------------------
+// Tabs private methods
+// -----------------------------------------------------------------------------
+
+// execute method runs the action for the given keyboard input.
+func (t *Tabs) execute(args ...any) {
+	tools.Logger.WithField("module", "tabs").
+		WithField("method", "execute").
+		Debugf("%s %+v", t.GetName(), args)
+	if len(t.items) == 0 {
+		return
+	}
+	switch args[0].(string) {
+	case "previous":
+		t.SelectTab((t.selected + len(t.items) - 1) % len(t.items))
+	case "next":
+		t.SelectTab((t.selected + 1) % len(t.items))
+	case "first":
+		t.SelectTab(0)
+	case "last":
+		t.SelectTab(len(t.items) - 1)
+	}
+}
+
+// handleMouse method selects the tab clicked in the tab strip and moves the
+// focus to the tabs.
+func (t *Tabs) handleMouse(event tcell.Event, scene engine.IScene) {
+	position, buttons, inside := t.GetMousePosition(event, scene)
+	if position == nil {
+		return
+	}
+	clicked := buttons&tcell.Button1 != 0 && t.mouseButtons&tcell.Button1 == 0
+	t.mouseButtons = buttons
+	if !inside || !clicked || position.Y != 0 {
+		return
+	}
+	if index := t.tabAt(position.X); index != -1 {
+		t.SelectTab(index)
+		if scene != nil {
+			scene.GetEngine().GetFocusManager().AcquireFocusToEntity(t)
+		}
+	}
+}
+
+// stripWidth method returns the width required to display all titles.
+func (t *Tabs) stripWidth() int {
+	result := 0
+	for _, title := range t.titles {
+		result += t.displayWidth(title) + 2
+	}
+	return result
+}
+
+// tabAt method returns the index for the tab displayed at the given column in
+// the tab strip, or -1 if there is not any tab there.
+func (t *Tabs) tabAt(x int) int {
+	start := 0
+	for i, title := range t.titles {
+		end := start + t.displayWidth(title) + 2
+		if x >= start && x < end {
+			return i
+		}
+		start = end
+	}
+	return -1
+}
+
+// updateCanvas method updates the tabs canvas with the tab strip. The rest of
+// the canvas is empty, so panels are displayed below the tab strip.
+func (t *Tabs) updateCanvas() {
+	width := t.GetSize().W
+	canvas := engine.NewCanvas(api.NewSize(width, 1))
+	for x := 0; x < width; x++ {
+		canvas.SetCellAt(api.NewPoint(x, 0), engine.NewCell(t.GetThemeStyle(ThemeRoleNormal), ' '))
+	}
+	x := 0
+	for i, title := range t.titles {
+		style := t.GetThemeStyle(ThemeRoleNormal)
+		if i == t.selected {
+			style = t.GetThemeStyle(ThemeRoleSelected)
+			if t.HasFocus() {
+				style = t.GetThemeStyle(ThemeRoleFocused)
+			}
+		}
+		t.writeDisplayString(canvas, " "+title+" ", style, api.NewPoint(x, 0))
+		x += t.displayWidth(title) + 2
+	}
+	t.SetCanvas(canvas)
+}
+
+// -----------------------------------------------------------------------------
+// Tabs public methods
+// -----------------------------------------------------------------------------
+
+// AcquireFocus method acquires focus for the tabs.
+func (t *Tabs) AcquireFocus() (bool, error) {
+	ok, err := t.Entity.AcquireFocus()
+	if err == nil {
+		t.updateCanvas()
+	}
+	return ok, err
+}
+
+// AddTab method adds the given panel with the given title. The panel is
+// stretched to the space below the tab strip by default, and it is added to
+// the scene only when it is selected.
+func (t *Tabs) AddTab(title string, panel engine.IEntity) *ContainerItem {
+	item := NewContainerItem(panel)
+	item.align = AlignStretch
+	item.container = t.Container
+	t.items = append(t.items, item)
+	t.titles = append(t.titles, title)
+	if t.scene != nil && len(t.items)-1 == t.selected {
+		t.scene.AddEntity(panel)
+	}
+	t.Reflow()
+	return item
+}
+
+// GetSelectedIndex method returns the index for the selected tab.
+func (t *Tabs) GetSelectedIndex() int {
+	return t.selected
+}
+
+// GetSelectedPanel method returns the panel for the selected tab, nil if
+// there are not any tabs.
+func (t *Tabs) GetSelectedPanel() engine.IEntity {
+	if t.selected < len(t.items) {
+		return t.items[t.selected].widget
+	}
+	return nil
+}
+
+// GetTitles method returns the title for every tab.
+func (t *Tabs) GetTitles() []string {
+	return t.titles
+}
+
+// OnAdded method adds only the selected panel to the scene the tabs are added
+// to.
+func (t *Tabs) OnAdded(scene engine.IScene) {
+	t.Widget.OnAdded(scene)
+	t.scene = scene
+	if panel := t.GetSelectedPanel(); panel != nil {
+		scene.AddEntity(panel)
+	}
+	t.Reflow()
+}
+
+// OnRemoved method removes the selected panel from the scene the tabs are
+// removed from.
+func (t *Tabs) OnRemoved(scene engine.IScene) {
+	t.Widget.OnRemoved(scene)
+	if panel := t.GetSelectedPanel(); panel != nil {
+		scene.RemoveEntity(panel)
+	}
+	t.scene = nil
+}
+
+// Reflow method arranges all panels again and updates the tab strip.
+func (t *Tabs) Reflow() {
+	t.Container.Reflow()
+	t.updateCanvas()
+}
+
+// Refresh method arranges all panels again and updates the tab strip.
+func (t *Tabs) Refresh() {
+	t.Reflow()
+}
+
+// ReleaseFocus method releases the focus for the tabs.
+func (t *Tabs) ReleaseFocus() (bool, error) {
+	ok, err := t.Entity.ReleaseFocus()
+	if err == nil {
+		t.updateCanvas()
+	}
+	return ok, err
+}
+
+// Remove method removes the given panel and its tab. The next tab is
+// selected if the panel was selected.
+func (t *Tabs) Remove(panel engine.IEntity) bool {
+	for i, item := range t.items {
+		if item.widget != panel {
+			continue
+		}
+		wasSelected := i == t.selected
+		if wasSelected && t.scene != nil {
+			t.scene.RemoveEntity(panel)
+		}
+		item.container = nil
+		t.items = append(t.items[:i], t.items[i+1:]...)
+		t.titles = append(t.titles[:i], t.titles[i+1:]...)
+		if i < t.selected || t.selected >= len(t.items) {
+			t.selected = tools.Max(0, t.selected-1)
+		}
+		if wasSelected && t.scene != nil {
+			if selected := t.GetSelectedPanel(); selected != nil {
+				t.scene.AddEntity(selected)
+			}
+		}
+		t.Reflow()
+		return true
+	}
+	return false
+}
+
+// SelectTab method displays the panel for the given tab index. The panel
+// displayed before is removed from the scene, so its widgets release the
+// focus. The widget callback is called when the selected tab changes.
+func (t *Tabs) SelectTab(index int) bool {
+	if index < 0 || index >= len(t.items) {
+		return false
+	}
+	if index == t.selected {
+		return true
+	}
+	if t.scene != nil {
+		t.scene.RemoveEntity(t.items[t.selected].widget)
+		t.scene.AddEntity(t.items[index].widget)
+	}
+	t.selected = index
+	t.updateCanvas()
+	t.RunCallback(t)
+	return true
+}
+
+// SetMarkup method sets if tab titles handle markup tags.
+func (t *Tabs) SetMarkup(markup bool) {
+	t.markup = markup
+	t.updateCanvas()
+}
+
+// SetTitle method sets the title for the given tab index.
+func (t *Tabs) SetTitle(index int, title string) bool {
+	if index < 0 || index >= len(t.titles) {
+		return false
+	}
+	t.titles[index] = title
+	t.Reflow()
+	return true
+}
+
+// Update method selects tabs clicked in the tab strip, and it handles
+// keyboard inputs when the tabs have the focus.
+func (t *Tabs) Update(event tcell.Event, scene engine.IScene) {
+	defer t.Entity.Update(event, scene)
+	if t.needsReflow() {
+		t.Reflow()
+	}
+	t.handleMouse(event, scene)
+	if !t.HasFocus() {
+		return
+	}
+	actions := []*KeyboardAction{
+		NewKeyboardActionForKey(tcell.KeyLeft, t.execute, []any{"previous"}),
+		NewKeyboardActionForKey(tcell.KeyRight, t.execute, []any{"next"}),
+		NewKeyboardActionForKey(tcell.KeyHome, t.execute, []any{"first"}),
+		NewKeyboardActionForKey(tcell.KeyEnd, t.execute, []any{"last"}),
+	}
+	t.HandleKeyboardForActions(event, actions)
+}
+
+var _ engine.IObject = (*Tabs)(nil)
+var _ engine.IFocus = (*Tabs)(nil)
+var _ engine.IEntity = (*Tabs)(nil)
+var _ IWidget = (*Tabs)(nil)
+var _ IContainer = (*Tabs)(nil)
+var _ engine.IArrowHandler = (*Tabs)(nil)
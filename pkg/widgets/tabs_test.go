@@ -0,0 +1,102 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+func TestTabsArrange(t *testing.T) {
+	style := tcell.StyleDefault
+	stats := widgets.NewText("stats", nil, nil, &style, "STR 10")
+	spells := widgets.NewText("spells", nil, nil, &style, "fireball\nheal")
+	tabs := widgets.NewTabs("tabs", api.NewPoint(2, 1), nil, &style)
+	tabs.SetMarkup(true)
+	tabs.AddTab("Stats", stats)
+	tabs.AddTab("[yellow]Spells[/]", spells)
+
+	// tabs without any size take the size for the tab strip and the largest
+	// panel, and all panels are stretched below the tab strip.
+	checkRects(t, 0, []engine.IEntity{tabs, stats, spells}, []*api.Rect{
+		api.NewRect(api.NewPoint(2, 1), api.NewSize(15, 3)),
+		api.NewRect(api.NewPoint(2, 2), api.NewSize(15, 2)),
+		api.NewRect(api.NewPoint(2, 2), api.NewSize(15, 2)),
+	})
+	if got := canvasLine(tabs.GetCanvas(), 0); got != " Stats  Spells " {
+		t.Errorf("[0] canvas Error exp:%q got:%q", " Stats  Spells ", got)
+	}
+	if got := tabs.GetCanvas().GetStyleAt(api.NewPoint(8, 0)); engine.GetForegroundFromStyle(got) != tcell.ColorYellow {
+		t.Errorf("[0] canvas Error.Style exp:yellow got:%s", engine.StyleToString(got))
+	}
+
+	// keys move the selection when the tabs have the focus.
+	tabs.AcquireFocus()
+	cases := []struct {
+		key tcell.Key
+		exp int
+	}{
+		{tcell.KeyRight, 1},
+		{tcell.KeyRight, 0},
+		{tcell.KeyLeft, 1},
+		{tcell.KeyHome, 0},
+		{tcell.KeyEnd, 1},
+	}
+	for i, c := range cases {
+		tabs.Update(tcell.NewEventKey(c.key, 0, 0), nil)
+		if got := tabs.GetSelectedIndex(); got != c.exp {
+			t.Errorf("[%d] GetSelectedIndex Error exp:%d got:%d", i, c.exp, got)
+		}
+	}
+}
+
+func TestTabsScene(t *testing.T) {
+	style := tcell.StyleDefault
+	scene := engine.NewEngine().NewScene("scene/tabs/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(40, 20)))
+	first := widgets.NewButton("first", nil, api.NewSize(6, 1), &style, "first")
+	second := widgets.NewButton("second", nil, api.NewSize(6, 1), &style, "second")
+	tabs := widgets.NewTabs("tabs", api.NewPoint(0, 0), api.NewSize(20, 5), &style)
+	tabs.AddTab("One", first)
+	tabs.AddTab("Two", second)
+	scene.AddEntity(tabs)
+
+	// only the selected panel is in the scene, so only its widgets can take
+	// the focus.
+	if got := scene.GetEntityByName("second"); got != nil {
+		t.Errorf("[0] GetEntityByName Error exp:nil got:%s", got.GetName())
+	}
+	focusManager := scene.GetEngine().GetFocusManager()
+	if got := len(focusManager.GetEntities()["scene/tabs/1"]); got != 2 {
+		t.Errorf("[0] GetEntities Error exp:2 got:%d", got)
+	}
+
+	// clicking a tab displays its panel and moves the focus to the tabs.
+	tabs.Update(tcell.NewEventMouse(6, 0, tcell.Button1, 0), scene)
+	if got := tabs.GetSelectedIndex(); got != 1 {
+		t.Errorf("[1] GetSelectedIndex Error exp:1 got:%d", got)
+	}
+	if got := scene.GetEntityByName("first"); got != nil {
+		t.Errorf("[1] GetEntityByName Error exp:nil got:%s", got.GetName())
+	}
+	if got := scene.GetEntityByName("second"); got != second {
+		t.Errorf("[1] GetEntityByName Error exp:second got:%v", got)
+	}
+	if first.HasFocus() || !tabs.HasFocus() {
+		t.Errorf("[1] HasFocus Error exp:tabs got:first:%t tabs:%t", first.HasFocus(), tabs.HasFocus())
+	}
+
+	// removing the selected panel displays the previous one.
+	tabs.Remove(second)
+	if got := scene.GetEntityByName("first"); got != first {
+		t.Errorf("[2] Remove Error exp:first got:%v", got)
+	}
+	if got := tabs.GetTitles(); len(got) != 1 || got[0] != "One" {
+		t.Errorf("[2] GetTitles Error exp:[One] got:%v", got)
+	}
+	scene.RemoveEntity(tabs)
+	if got := len(scene.GetEntities()); got != 0 {
+		t.Errorf("[3] RemoveEntity Error exp:0 got:%d", got)
+	}
+}
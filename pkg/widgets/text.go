@@ -7,6 +7,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/i18n"
 )
 
 // -----------------------------------------------------------------------------
@@ -44,6 +45,7 @@ func NewText(name string, position *api.Point, size *api.Size, style *tcell.Styl
 		anchor: nil,
 		label:  label,
 	}
+	text.SetThemeClass("text")
 	text.updateCanvas()
 	return text
 }
@@ -61,9 +63,10 @@ func NewAnchorText(name string, pos *api.Point, size *api.Size, style *tcell.Sty
 // -----------------------------------------------------------------------------
 
 // updateCanvas method updates the text widget canvas with the string
-// information.
+// information. Any markup tag in the string is applied to the default style
+// when the text handles markup.
 func (t *Text) updateCanvas() {
-	canvas := engine.NewCanvasFromString(t.label, t.GetStyle())
+	canvas := t.newDisplayCanvas(t.GetDisplayText(), t.GetThemeStyle(ThemeRoleNormal))
 	t.SetCanvas(canvas)
 	if t.IsAnchor() {
 		t.SetAnchor()
@@ -79,6 +82,20 @@ func (t *Text) GetAnchor() *api.Point {
 	return t.anchor
 }
 
+// GetDisplayText method returns the Text instance string translated to the
+// active locale.
+func (t *Text) GetDisplayText() string {
+	return i18n.T(t.label)
+}
+
+// GetPreferredSize method returns the size required to display the text.
+func (t *Text) GetPreferredSize() *api.Size {
+	if canvas := t.GetCanvas(); canvas != nil {
+		return api.NewSize(canvas.Width(), canvas.Height())
+	}
+	return api.NewSize(0, 0)
+}
+
 // GetText method returns the Text instance string.
 func (t *Text) GetText() string {
 	return t.label
@@ -96,15 +113,22 @@ func (t *Text) Refresh() {
 
 // SetAnchor method sets the anchor attribute based on the string.
 func (t *Text) SetAnchor() *api.Point {
-	split := strings.Split(t.label, "\n")
+	split := strings.Split(t.GetDisplayText(), "\n")
 	lines := len(split)
-	cols := len(split[lines-1])
+	cols := t.displayWidth(split[lines-1])
 	anchor := api.ClonePoint(t.GetPosition())
 	anchor.AddScale(cols, lines-1)
 	t.anchor = anchor
 	return t.anchor
 }
 
+// SetMarkup method sets if the text handles inline markup tags. Markup is
+// disabled by default, so any string is displayed as it is.
+func (t *Text) SetMarkup(markup bool) {
+	t.markup = markup
+	t.updateCanvas()
+}
+
 // SetText method sets the Text instance string.
 func (t *Text) SetText(label string) {
 	t.label = label
@@ -114,3 +138,4 @@ func (t *Text) SetText(label string) {
 var _ engine.IObject = (*Text)(nil)
 var _ engine.IFocus = (*Text)(nil)
 var _ engine.IEntity = (*Text)(nil)
+var _ IMeasurable = (*Text)(nil)
@@ -0,0 +1,818 @@
+// textarea.go contains all attributes and methods required to implement a
+// multi-line text editor widget. The text area has a cursor which can be
+// moved anywhere in the text, it can insert and delete text at the cursor,
+// select text with shift and undo and redo any change. Long lines are wrapped
+// or scrolled horizontally, and line numbers can be displayed.
+// Key bindings:
+//
+//	arrows, Home, End, PgUp, PgDn   move the cursor.
+//	Ctrl+Left, Ctrl+Right           move the cursor to the previous/next word.
+//	Shift + any movement            selects text.
+//	Ctrl+A                          selects all text.
+//	Ctrl+Insert                     copies the selection.
+//	Ctrl+X, Shift+Delete            cuts the selection.
+//	Ctrl+V, Shift+Insert            pastes the clipboard.
+//	Ctrl+Z, Ctrl+Y                  undoes and redoes the last change.
+package widgets
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package private constants
+// -----------------------------------------------------------------------------
+
+const (
+	// textAreaMaxUndo is the maximum number of changes that can be undone.
+	textAreaMaxUndo = 100
+)
+
+// -----------------------------------------------------------------------------
+// Package private variables
+// -----------------------------------------------------------------------------
+
+var (
+	// clipboard contains the text copied or cut from any text widget.
+	clipboard string
+)
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// GetClipboard function returns the text in the internal clipboard shared by
+// all text widgets.
+func GetClipboard() string {
+	return clipboard
+}
+
+// SetClipboard function sets the text in the internal clipboard shared by all
+// text widgets.
+func SetClipboard(str string) {
+	clipboard = str
+}
+
+// -----------------------------------------------------------------------------
+// Package private types
+// -----------------------------------------------------------------------------
+
+// textPosition structure defines a position in a text by line and column.
+type textPosition struct {
+	row int
+	col int
+}
+
+// before method returns if the position is before the given position.
+func (p textPosition) before(position textPosition) bool {
+	return p.row < position.row || (p.row == position.row && p.col < position.col)
+}
+
+// textSnapshot structure defines the text and the cursor position saved to
+// undo and redo changes.
+type textSnapshot struct {
+	text   string
+	cursor textPosition
+}
+
+// visualLine structure defines the part of a text line displayed in one line
+// of the widget, from start to end columns.
+type visualLine struct {
+	row   int
+	start int
+	end   int
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// isWordRune function returns if the given rune is part of a word.
+func isWordRune(ch rune) bool {
+	return unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '_'
+}
+
+// -----------------------------------------------------------------------------
+//
+// TextArea
+//
+// -----------------------------------------------------------------------------
+
+// TextArea structure defines a multi-line text editor widget.
+// anchor is the position where the selection starts, nil if there is not any
+// selection.
+// goalCol is the column in the displayed line the cursor tries to keep when
+// it moves up and down.
+// topLine is the first visual line displayed, and leftCol is the first column
+// displayed when lines are not wrapped.
+// lastEdit is the kind of the last change, so consecutive changes of the same
+// kind, like typing a word, are undone at once.
+// scene is the scene the text area has been added to, its camera is used to
+// place the cursor in the screen.
+type TextArea struct {
+	*Widget
+	lines       [][]rune
+	cursor      textPosition
+	anchor      *textPosition
+	goalCol     int
+	wrapped     bool
+	lineNumbers bool
+	topLine     int
+	leftCol     int
+	scene       engine.IScene
+	undoStack   []*textSnapshot
+	redoStack   []*textSnapshot
+	lastEdit    string
+}
+
+// NewTextArea function creates a new TextArea instance widget with the given
+// text.
+func NewTextArea(name string, position *api.Point, size *api.Size, style *tcell.Style, text string) *TextArea {
+	textArea := &TextArea{
+		Widget:      NewWidget(name, position, size, style),
+		lines:       nil,
+		cursor:      textPosition{},
+		anchor:      nil,
+		goalCol:     0,
+		wrapped:     false,
+		lineNumbers: false,
+		topLine:     0,
+		leftCol:     0,
+		scene:       nil,
+		undoStack:   nil,
+		redoStack:   nil,
+		lastEdit:    "",
+	}
+	textArea.SetThemeClass("textarea")
+	textArea.SetHandlesArrows(true)
+	textArea.SetFocusType(engine.SingleFocus)
+	textArea.SetFocusEnable(true)
+	textArea.SetText(text)
+	return textArea
+}
+
+// -----------------------------------------------------------------------------
+// TextArea private methods
+// -----------------------------------------------------------------------------
+
+// clampPosition method returns the given position inside the text.
+func (t *TextArea) clampPosition(position textPosition) textPosition {
+	position.row = tools.Min(tools.Max(0, position.row), len(t.lines)-1)
+	position.col = tools.Min(tools.Max(0, position.col), len(t.lines[position.row]))
+	return position
+}
+
+// delete method deletes the selection, or the text between the given
+// positions if there is not any selection.
+func (t *TextArea) delete(start textPosition, end textPosition) {
+	if _, _, ok := t.selection(); !ok && start == end {
+		return
+	}
+	t.saveUndo("delete")
+	if !t.deleteSelection() {
+		t.deleteRange(start, end)
+	}
+	t.edited()
+}
+
+// deleteRange method deletes the text between the given positions.
+func (t *TextArea) deleteRange(start textPosition, end textPosition) {
+	tail := append([]rune{}, t.lines[end.row][end.col:]...)
+	t.lines[start.row] = append(t.lines[start.row][:start.col], tail...)
+	t.lines = append(t.lines[:start.row+1], t.lines[end.row+1:]...)
+	t.cursor = start
+}
+
+// deleteSelection method deletes the selected text. It returns false if there
+// is not any selection.
+func (t *TextArea) deleteSelection() bool {
+	start, end, ok := t.selection()
+	if !ok {
+		return false
+	}
+	t.deleteRange(start, end)
+	t.anchor = nil
+	return true
+}
+
+// edited method updates the widget after the text has been changed.
+func (t *TextArea) edited() {
+	t.goalCol = t.visualCol(t.cursor)
+	t.updateCanvas()
+	t.updateCursor()
+}
+
+// gutterWidth method returns the width used to display line numbers.
+func (t *TextArea) gutterWidth() int {
+	if !t.lineNumbers {
+		return 0
+	}
+	return len(strconv.Itoa(len(t.lines))) + 1
+}
+
+// handleKey method runs the action for the given key event. It returns false
+// if the key is not handled by the text area.
+func (t *TextArea) handleKey(ev *tcell.EventKey) bool {
+	shift := ev.Modifiers()&tcell.ModShift != 0
+	ctrl := ev.Modifiers()&tcell.ModCtrl != 0
+	switch ev.Key() {
+	case tcell.KeyLeft:
+		if ctrl {
+			t.moveCursor(t.wordLeft(t.cursor), shift)
+		} else {
+			t.moveCursor(t.runeLeft(t.cursor), shift)
+		}
+	case tcell.KeyRight:
+		if ctrl {
+			t.moveCursor(t.wordRight(t.cursor), shift)
+		} else {
+			t.moveCursor(t.runeRight(t.cursor), shift)
+		}
+	case tcell.KeyUp:
+		t.moveVertical(-1, shift)
+	case tcell.KeyDown:
+		t.moveVertical(1, shift)
+	case tcell.KeyPgUp:
+		t.moveVertical(-tools.Max(1, t.GetSize().H-1), shift)
+	case tcell.KeyPgDn:
+		t.moveVertical(tools.Max(1, t.GetSize().H-1), shift)
+	case tcell.KeyHome:
+		if ctrl {
+			t.moveCursor(textPosition{}, shift)
+		} else {
+			t.moveCursor(textPosition{row: t.cursor.row, col: 0}, shift)
+		}
+	case tcell.KeyEnd:
+		if ctrl {
+			last := len(t.lines) - 1
+			t.moveCursor(textPosition{row: last, col: len(t.lines[last])}, shift)
+		} else {
+			t.moveCursor(textPosition{row: t.cursor.row, col: len(t.lines[t.cursor.row])}, shift)
+		}
+	case tcell.KeyCtrlA:
+		t.SelectAll()
+	case tcell.KeyCtrlX:
+		t.Cut()
+	case tcell.KeyCtrlV:
+		t.Paste()
+	case tcell.KeyCtrlZ:
+		t.Undo()
+	case tcell.KeyCtrlY:
+		t.Redo()
+	case tcell.KeyInsert:
+		switch {
+		case ctrl:
+			t.Copy()
+		case shift:
+			t.Paste()
+		default:
+			return false
+		}
+	case tcell.KeyDelete:
+		if shift {
+			t.Cut()
+		} else {
+			t.delete(t.cursor, t.runeRight(t.cursor))
+		}
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		t.delete(t.runeLeft(t.cursor), t.cursor)
+	case tcell.KeyEnter:
+		t.saveUndo("")
+		t.insert("\n")
+	case tcell.KeyRune:
+		if ev.Rune() == ' ' {
+			// every word is undone on its own.
+			t.saveUndo("")
+		} else {
+			t.saveUndo("insert")
+		}
+		t.insert(string(ev.Rune()))
+	default:
+		return false
+	}
+	return true
+}
+
+// insert method replaces the selection with the given text, or inserts it at
+// the cursor position if there is not any selection.
+func (t *TextArea) insert(str string) {
+	t.deleteSelection()
+	inserted := strings.Split(str, "\n")
+	line := t.lines[t.cursor.row]
+	head := append([]rune{}, line[:t.cursor.col]...)
+	tail := append([]rune{}, line[t.cursor.col:]...)
+	newLines := make([][]rune, len(inserted))
+	for i, s := range inserted {
+		newLines[i] = []rune(s)
+	}
+	last := len(newLines) - 1
+	col := len(newLines[last])
+	newLines[0] = append(head, newLines[0]...)
+	if last == 0 {
+		col += len(head)
+	}
+	newLines[last] = append(newLines[last], tail...)
+	lines := append([][]rune{}, t.lines[:t.cursor.row]...)
+	lines = append(lines, newLines...)
+	t.lines = append(lines, t.lines[t.cursor.row+1:]...)
+	t.cursor = textPosition{row: t.cursor.row + last, col: col}
+	t.edited()
+}
+
+// moveCursor method moves the cursor to the given position. If extend is
+// true the selection is extended to the new position, else any selection is
+// removed.
+func (t *TextArea) moveCursor(position textPosition, extend bool) {
+	if extend && t.anchor == nil {
+		anchor := t.cursor
+		t.anchor = &anchor
+	} else if !extend {
+		t.anchor = nil
+	}
+	t.cursor = t.clampPosition(position)
+	t.goalCol = t.visualCol(t.cursor)
+	t.lastEdit = ""
+	t.updateCanvas()
+	t.updateCursor()
+}
+
+// moveVertical method moves the cursor the given number of visual lines up
+// or down, keeping the cursor column when it is possible.
+func (t *TextArea) moveVertical(delta int, extend bool) {
+	lines := t.visualLines()
+	index := t.visualIndex(lines, t.cursor)
+	target := lines[tools.Min(tools.Max(0, index+delta), len(lines)-1)]
+	col := tools.Min(target.start+t.goalCol, target.end)
+	// keep the cursor in the wrapped part when it is not the last one.
+	if target.end != len(t.lines[target.row]) && col == target.end {
+		col = tools.Max(target.start, col-1)
+	}
+	goalCol := t.goalCol
+	t.moveCursor(textPosition{row: target.row, col: col}, extend)
+	t.goalCol = goalCol
+}
+
+// restore method sets the text and the cursor from the given snapshot.
+func (t *TextArea) restore(snapshot *textSnapshot) {
+	t.setLines(snapshot.text)
+	t.cursor = t.clampPosition(snapshot.cursor)
+	t.anchor = nil
+	t.lastEdit = ""
+	t.edited()
+}
+
+// runeLeft method returns the position before the given one.
+func (t *TextArea) runeLeft(position textPosition) textPosition {
+	if position.col > 0 {
+		return textPosition{row: position.row, col: position.col - 1}
+	}
+	if position.row > 0 {
+		return textPosition{row: position.row - 1, col: len(t.lines[position.row-1])}
+	}
+	return position
+}
+
+// runeRight method returns the position after the given one.
+func (t *TextArea) runeRight(position textPosition) textPosition {
+	if position.col < len(t.lines[position.row]) {
+		return textPosition{row: position.row, col: position.col + 1}
+	}
+	if position.row < len(t.lines)-1 {
+		return textPosition{row: position.row + 1, col: 0}
+	}
+	return position
+}
+
+// saveUndo method saves the current text to be undone. Consecutive changes
+// with the same not empty kind are saved only once.
+func (t *TextArea) saveUndo(kind string) {
+	if kind != "" && kind == t.lastEdit {
+		return
+	}
+	t.lastEdit = kind
+	t.undoStack = append(t.undoStack, t.snapshot())
+	if len(t.undoStack) > textAreaMaxUndo {
+		t.undoStack = t.undoStack[1:]
+	}
+	t.redoStack = nil
+}
+
+// scrollToCursor method scrolls the text so the cursor is displayed.
+func (t *TextArea) scrollToCursor() {
+	height := t.GetSize().H
+	index := t.visualIndex(t.visualLines(), t.cursor)
+	if index < t.topLine {
+		t.topLine = index
+	} else if index >= t.topLine+height {
+		t.topLine = index - height + 1
+	}
+	if t.wrapped {
+		t.leftCol = 0
+		return
+	}
+	width := tools.Max(1, t.textWidth())
+	if t.cursor.col < t.leftCol {
+		t.leftCol = t.cursor.col
+	} else if t.cursor.col >= t.leftCol+width {
+		t.leftCol = t.cursor.col - width + 1
+	}
+}
+
+// selection method returns the start and end positions for the selection. It
+// returns false if there is not any selection.
+func (t *TextArea) selection() (textPosition, textPosition, bool) {
+	if t.anchor == nil || *t.anchor == t.cursor {
+		return t.cursor, t.cursor, false
+	}
+	if t.anchor.before(t.cursor) {
+		return *t.anchor, t.cursor, true
+	}
+	return t.cursor, *t.anchor, true
+}
+
+// setLines method splits the given text in lines.
+func (t *TextArea) setLines(text string) {
+	lines := strings.Split(text, "\n")
+	t.lines = make([][]rune, len(lines))
+	for i, line := range lines {
+		t.lines[i] = []rune(line)
+	}
+}
+
+// snapshot method returns the current text and cursor position.
+func (t *TextArea) snapshot() *textSnapshot {
+	return &textSnapshot{
+		text:   t.GetText(),
+		cursor: t.cursor,
+	}
+}
+
+// textWidth method returns the width used to display the text.
+func (t *TextArea) textWidth() int {
+	return t.GetSize().W - t.gutterWidth()
+}
+
+// updateCanvas method updates the text area canvas with the lines displayed,
+// the selection and the line numbers.
+func (t *TextArea) updateCanvas() {
+	t.scrollToCursor()
+	canvas := t.GetCanvas()
+	normalStyle := t.GetThemeStyle(ThemeRoleNormal)
+	selectedStyle := t.GetThemeStyle(ThemeRoleSelected)
+	gutterStyle := t.GetThemeStyle(ThemeRoleDisabled)
+	canvas.FillWithCell(engine.NewCell(normalStyle, ' '))
+	gutter := t.gutterWidth()
+	width := t.textWidth()
+	start, end, selected := t.selection()
+	lines := t.visualLines()
+	for y := 0; y < t.GetSize().H && t.topLine+y < len(lines); y++ {
+		line := lines[t.topLine+y]
+		// line number is only displayed in the first part of wrapped lines.
+		if gutter > 0 && line.start == 0 {
+			number := strconv.Itoa(line.row + 1)
+			canvas.WriteStringInCanvasAt(strings.Repeat(" ", gutter-1-len(number))+number, gutterStyle, api.NewPoint(0, y))
+		}
+		for x := 0; x < width; x++ {
+			col := line.start + t.leftCol + x
+			position := textPosition{row: line.row, col: col}
+			inSelection := selected && !position.before(start) && position.before(end)
+			if col >= line.end {
+				// selected line breaks are displayed after the last rune.
+				if inSelection && col == line.end && line.end == len(t.lines[line.row]) {
+					canvas.SetCellAt(api.NewPoint(gutter+x, y), engine.NewCell(selectedStyle, ' '))
+				}
+				break
+			}
+			style := normalStyle
+			if inSelection {
+				style = selectedStyle
+			}
+			canvas.SetCellAt(api.NewPoint(gutter+x, y), engine.NewCell(style, t.lines[line.row][col]))
+		}
+	}
+}
+
+// updateCursor method updates the cursor position in the screen.
+func (t *TextArea) updateCursor() {
+	screen := t.GetScreen()
+	if screen == nil || !t.HasFocus() {
+		return
+	}
+	lines := t.visualLines()
+	index := t.visualIndex(lines, t.cursor)
+	position := api.NewPoint(t.GetPosition().X+t.gutterWidth()+t.cursor.col-lines[index].start-t.leftCol,
+		t.GetPosition().Y+index-t.topLine)
+	// the text area is rendered in the scene camera.
+	if t.scene != nil && t.scene.GetCamera() != nil {
+		position.Add(t.scene.GetCamera().GetOrigin())
+	}
+	screen.ShowCursor(position.X, position.Y)
+}
+
+// visualIndex method returns the index for the visual line where the given
+// position is displayed.
+func (t *TextArea) visualIndex(lines []visualLine, position textPosition) int {
+	result := 0
+	for i, line := range lines {
+		if line.row > position.row {
+			break
+		}
+		if line.row == position.row {
+			result = i
+			if position.col < line.end {
+				break
+			}
+		}
+	}
+	return result
+}
+
+// visualCol method returns the column where the given position is displayed
+// in its visual line.
+func (t *TextArea) visualCol(position textPosition) int {
+	lines := t.visualLines()
+	return position.col - lines[t.visualIndex(lines, position)].start
+}
+
+// visualLines method returns all lines displayed. Every text line is one
+// visual line, or several visual lines if lines are wrapped. Wrapped lines
+// are broken after the last space that fits in the line, or at the text
+// width if there is not any.
+func (t *TextArea) visualLines() []visualLine {
+	width := t.textWidth()
+	result := []visualLine{}
+	for row, line := range t.lines {
+		if !t.wrapped || width <= 0 || len(line) <= width {
+			result = append(result, visualLine{row: row, start: 0, end: len(line)})
+			continue
+		}
+		for start := 0; start < len(line); {
+			end := tools.Min(start+width, len(line))
+			if end < len(line) {
+				for i := end - 1; i > start; i-- {
+					if unicode.IsSpace(line[i]) {
+						end = i + 1
+						break
+					}
+				}
+			}
+			result = append(result, visualLine{row: row, start: start, end: end})
+			start = end
+		}
+	}
+	return result
+}
+
+// wordLeft method returns the position for the start of the word before the
+// given position.
+func (t *TextArea) wordLeft(position textPosition) textPosition {
+	if position.col == 0 {
+		return t.runeLeft(position)
+	}
+	line := t.lines[position.row]
+	col := position.col
+	for col > 0 && !isWordRune(line[col-1]) {
+		col--
+	}
+	for col > 0 && isWordRune(line[col-1]) {
+		col--
+	}
+	return textPosition{row: position.row, col: col}
+}
+
+// wordRight method returns the position for the start of the word after the
+// given position.
+func (t *TextArea) wordRight(position textPosition) textPosition {
+	line := t.lines[position.row]
+	if position.col == len(line) {
+		return t.runeRight(position)
+	}
+	col := position.col
+	for col < len(line) && isWordRune(line[col]) {
+		col++
+	}
+	for col < len(line) && !isWordRune(line[col]) {
+		col++
+	}
+	return textPosition{row: position.row, col: col}
+}
+
+// -----------------------------------------------------------------------------
+// TextArea public methods
+// -----------------------------------------------------------------------------
+
+// AcquireFocus method acquires focus for the entity.
+func (t *TextArea) AcquireFocus() (bool, error) {
+	ok, err := t.Entity.AcquireFocus()
+	if err == nil {
+		t.updateCursor()
+	}
+	return ok, err
+}
+
+// Copy method copies the selected text to the clipboard.
+func (t *TextArea) Copy() {
+	if str := t.GetSelectedText(); str != "" {
+		SetClipboard(str)
+	}
+}
+
+// Cut method copies the selected text to the clipboard and deletes it.
+func (t *TextArea) Cut() {
+	if _, _, ok := t.selection(); !ok {
+		return
+	}
+	t.Copy()
+	t.saveUndo("")
+	t.deleteSelection()
+	t.edited()
+}
+
+// GetCursor method returns the line and the column for the cursor.
+func (t *TextArea) GetCursor() (int, int) {
+	return t.cursor.row, t.cursor.col
+}
+
+// GetSelectedText method returns the selected text, or an empty string if
+// there is not any selection.
+func (t *TextArea) GetSelectedText() string {
+	start, end, ok := t.selection()
+	if !ok {
+		return ""
+	}
+	if start.row == end.row {
+		return string(t.lines[start.row][start.col:end.col])
+	}
+	result := []string{string(t.lines[start.row][start.col:])}
+	for row := start.row + 1; row < end.row; row++ {
+		result = append(result, string(t.lines[row]))
+	}
+	result = append(result, string(t.lines[end.row][:end.col]))
+	return strings.Join(result, "\n")
+}
+
+// GetText method returns the whole text.
+func (t *TextArea) GetText() string {
+	result := make([]string, len(t.lines))
+	for i, line := range t.lines {
+		result[i] = string(line)
+	}
+	return strings.Join(result, "\n")
+}
+
+// HasLineNumbers method returns if line numbers are displayed.
+func (t *TextArea) HasLineNumbers() bool {
+	return t.lineNumbers
+}
+
+// InsertText method replaces the selection with the given text, or inserts it
+// at the cursor position. The change can be undone.
+func (t *TextArea) InsertText(str string) {
+	t.saveUndo("")
+	t.insert(str)
+}
+
+// IsWrapped method returns if long lines are wrapped.
+func (t *TextArea) IsWrapped() bool {
+	return t.wrapped
+}
+
+// OnAdded method stores the scene the text area is added to, so the cursor
+// is placed in the scene camera.
+func (t *TextArea) OnAdded(scene engine.IScene) {
+	t.Widget.OnAdded(scene)
+	t.scene = scene
+}
+
+// OnRemoved method clears the scene the text area is removed from.
+func (t *TextArea) OnRemoved(scene engine.IScene) {
+	t.Widget.OnRemoved(scene)
+	t.scene = nil
+}
+
+// Paste method inserts the clipboard text at the cursor position.
+func (t *TextArea) Paste() {
+	if str := GetClipboard(); str != "" {
+		t.InsertText(str)
+	}
+}
+
+// Redo method redoes the last change undone. It returns false if there is not
+// any change to redo.
+func (t *TextArea) Redo() bool {
+	if len(t.redoStack) == 0 {
+		return false
+	}
+	last := len(t.redoStack) - 1
+	snapshot := t.redoStack[last]
+	t.redoStack = t.redoStack[:last]
+	t.undoStack = append(t.undoStack, t.snapshot())
+	t.restore(snapshot)
+	return true
+}
+
+// Refresh method refreshes the text area canvas with latest attribute values.
+func (t *TextArea) Refresh() {
+	// create a new canvas if the text area has been resized.
+	if !t.GetCanvas().Size().IsEqual(t.GetSize()) {
+		t.SetCanvas(engine.NewCanvas(t.GetSize()))
+	}
+	t.updateCanvas()
+}
+
+// ReleaseFocus method release the focus for the entity.
+func (t *TextArea) ReleaseFocus() (bool, error) {
+	ok, err := t.Entity.ReleaseFocus()
+	if screen := t.GetScreen(); err == nil && screen != nil {
+		screen.HideCursor()
+	}
+	return ok, err
+}
+
+// Select method selects the text between the given positions and moves the
+// cursor to the end position.
+func (t *TextArea) Select(startRow int, startCol int, endRow int, endCol int) {
+	anchor := t.clampPosition(textPosition{row: startRow, col: startCol})
+	t.cursor = t.clampPosition(textPosition{row: endRow, col: endCol})
+	t.anchor = &anchor
+	t.goalCol = t.visualCol(t.cursor)
+	t.updateCanvas()
+	t.updateCursor()
+}
+
+// SelectAll method selects the whole text.
+func (t *TextArea) SelectAll() {
+	last := len(t.lines) - 1
+	t.Select(0, 0, last, len(t.lines[last]))
+}
+
+// SetCursor method moves the cursor to the given line and column and removes
+// any selection.
+func (t *TextArea) SetCursor(row int, col int) {
+	t.moveCursor(textPosition{row: row, col: col}, false)
+}
+
+// SetLineNumbers method sets if line numbers are displayed.
+func (t *TextArea) SetLineNumbers(lineNumbers bool) {
+	t.lineNumbers = lineNumbers
+	t.updateCanvas()
+}
+
+// SetText method replaces the whole text, moves the cursor to the start and
+// removes any change to undo.
+func (t *TextArea) SetText(text string) {
+	t.setLines(text)
+	t.cursor = textPosition{}
+	t.anchor = nil
+	t.topLine = 0
+	t.leftCol = 0
+	t.undoStack = nil
+	t.redoStack = nil
+	t.lastEdit = ""
+	t.edited()
+}
+
+// SetWrapped method sets if long lines are wrapped, else they are scrolled
+// horizontally.
+func (t *TextArea) SetWrapped(wrapped bool) {
+	t.wrapped = wrapped
+	t.updateCanvas()
+}
+
+// Undo method undoes the last change. It returns false if there is not any
+// change to undo.
+func (t *TextArea) Undo() bool {
+	if len(t.undoStack) == 0 {
+		return false
+	}
+	last := len(t.undoStack) - 1
+	snapshot := t.undoStack[last]
+	t.undoStack = t.undoStack[:last]
+	t.redoStack = append(t.redoStack, t.snapshot())
+	t.restore(snapshot)
+	return true
+}
+
+// Update method runs every cycle to handle keyboard input when the text area
+// has the focus.
+func (t *TextArea) Update(event tcell.Event, scene engine.IScene) {
+	defer t.Entity.Update(event, scene)
+	if !t.HasFocus() {
+		return
+	}
+	if ev, ok := event.(*tcell.EventKey); ok {
+		t.handleKey(ev)
+	}
+}
+
+var _ engine.IObject = (*TextArea)(nil)
+var _ engine.IFocus = (*TextArea)(nil)
+var _ engine.IEntity = (*TextArea)(nil)
+var _ engine.IArrowHandler = (*TextArea)(nil)
@@ -0,0 +1,189 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+func TestTextAreaEdit(t *testing.T) {
+	style := tcell.StyleDefault
+	textArea := widgets.NewTextArea("text-area/1", api.NewPoint(0, 0), api.NewSize(20, 4), &style, "hello world\nsecond line")
+	textArea.AcquireFocus()
+	cases := []struct {
+		input []*tcell.EventKey
+		exp   string
+		row   int
+		col   int
+	}{
+		{
+			// word jump and insertion in the middle of the line.
+			input: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModCtrl),
+				tcell.NewEventKey(tcell.KeyRune, 'b', 0),
+				tcell.NewEventKey(tcell.KeyRune, 'i', 0),
+				tcell.NewEventKey(tcell.KeyRune, 'g', 0),
+				tcell.NewEventKey(tcell.KeyRune, ' ', 0),
+			},
+			exp: "hello big world\nsecond line",
+			row: 0,
+			col: 10,
+		},
+		{
+			// moving down keeps the column and enter splits the line.
+			input: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyDown, 0, 0),
+				tcell.NewEventKey(tcell.KeyEnter, 0, 0),
+			},
+			exp: "hello big world\nsecond lin\ne",
+			row: 2,
+			col: 0,
+		},
+		{
+			// backspace joins lines.
+			input: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyBackspace2, 0, 0),
+				tcell.NewEventKey(tcell.KeyEnd, 0, 0),
+				tcell.NewEventKey(tcell.KeyDelete, 0, 0),
+			},
+			exp: "hello big world\nsecond line",
+			row: 1,
+			col: 11,
+		},
+		{
+			// shift selects text, and typing replaces the selection.
+			input: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModCtrl|tcell.ModShift),
+				tcell.NewEventKey(tcell.KeyRune, 'x', 0),
+			},
+			exp: "hello big world\nsecond x",
+			row: 1,
+			col: 8,
+		},
+	}
+	for i, c := range cases {
+		for _, event := range c.input {
+			textArea.Update(event, nil)
+		}
+		if got := textArea.GetText(); got != c.exp {
+			t.Errorf("[%d] GetText Error exp:%q got:%q", i, c.exp, got)
+		}
+		if row, col := textArea.GetCursor(); row != c.row || col != c.col {
+			t.Errorf("[%d] GetCursor Error exp:%d,%d got:%d,%d", i, c.row, c.col, row, col)
+		}
+	}
+}
+
+func TestTextAreaUndoAndClipboard(t *testing.T) {
+	style := tcell.StyleDefault
+	textArea := widgets.NewTextArea("text-area/1", api.NewPoint(0, 0), api.NewSize(20, 4), &style, "")
+	textArea.AcquireFocus()
+	for _, ch := range "one two" {
+		textArea.Update(tcell.NewEventKey(tcell.KeyRune, ch, 0), nil)
+	}
+
+	// every word is undone at once.
+	if ok := textArea.Undo(); !ok || textArea.GetText() != "one " {
+		t.Errorf("[0] Undo Error exp:%q got:%q", "one ", textArea.GetText())
+	}
+	textArea.Update(tcell.NewEventKey(tcell.KeyCtrlZ, 0, 0), nil)
+	if got := textArea.GetText(); got != "one" {
+		t.Errorf("[0] Undo Error exp:%q got:%q", "one", got)
+	}
+	textArea.Update(tcell.NewEventKey(tcell.KeyCtrlY, 0, 0), nil)
+	if got := textArea.GetText(); got != "one " {
+		t.Errorf("[0] Redo Error exp:%q got:%q", "one ", got)
+	}
+
+	// cut and paste through the clipboard.
+	textArea.SetText("alpha\nbeta")
+	textArea.Select(0, 2, 1, 2)
+	if got := textArea.GetSelectedText(); got != "pha\nbe" {
+		t.Errorf("[1] GetSelectedText Error exp:%q got:%q", "pha\nbe", got)
+	}
+	textArea.Update(tcell.NewEventKey(tcell.KeyCtrlX, 0, 0), nil)
+	if got := textArea.GetText(); got != "alta" {
+		t.Errorf("[1] Cut Error exp:%q got:%q", "alta", got)
+	}
+	textArea.Update(tcell.NewEventKey(tcell.KeyEnd, 0, 0), nil)
+	textArea.Update(tcell.NewEventKey(tcell.KeyCtrlV, 0, 0), nil)
+	if got := textArea.GetText(); got != "altapha\nbe" {
+		t.Errorf("[1] Paste Error exp:%q got:%q", "altapha\nbe", got)
+	}
+	if row, col := textArea.GetCursor(); row != 1 || col != 2 {
+		t.Errorf("[1] GetCursor Error exp:1,2 got:%d,%d", row, col)
+	}
+	textArea.Undo()
+	if got := textArea.GetText(); got != "alta" {
+		t.Errorf("[1] Undo Error exp:%q got:%q", "alta", got)
+	}
+}
+
+func TestTextAreaCanvas(t *testing.T) {
+	style := tcell.StyleDefault
+	textArea := widgets.NewTextArea("text-area/1", api.NewPoint(0, 0), api.NewSize(8, 3), &style, "abcdefghij\nxy")
+	textArea.SetLineNumbers(true)
+
+	// long lines scroll horizontally with the cursor.
+	textArea.SetCursor(0, 10)
+	if got := canvasLine(textArea.GetCanvas(), 0); got != "1 fghij " {
+		t.Errorf("[0] canvas Error exp:%q got:%q", "1 fghij ", got)
+	}
+
+	// long lines are wrapped and line numbers are displayed once.
+	textArea.SetWrapped(true)
+	exps := []string{"1 abcdef", "  ghij  ", "2 xy    "}
+	for i, exp := range exps {
+		if got := canvasLine(textArea.GetCanvas(), i); got != exp {
+			t.Errorf("[1:%d] canvas Error exp:%q got:%q", i, exp, got)
+		}
+	}
+
+	// moving up and down goes through wrapped lines.
+	textArea.SetCursor(0, 1)
+	textArea.AcquireFocus()
+	textArea.Update(tcell.NewEventKey(tcell.KeyDown, 0, 0), nil)
+	if row, col := textArea.GetCursor(); row != 0 || col != 7 {
+		t.Errorf("[2] GetCursor Error exp:0,7 got:%d,%d", row, col)
+	}
+	textArea.Update(tcell.NewEventKey(tcell.KeyDown, 0, 0), nil)
+	if row, col := textArea.GetCursor(); row != 1 || col != 1 {
+		t.Errorf("[2] GetCursor Error exp:1,1 got:%d,%d", row, col)
+	}
+
+	// wrapped lines are broken after the last space that fits.
+	textArea.SetText("one two three")
+	exps = []string{"1 one   ", "  two   ", "  three "}
+	for i, exp := range exps {
+		if got := canvasLine(textArea.GetCanvas(), i); got != exp {
+			t.Errorf("[3:%d] canvas Error exp:%q got:%q", i, exp, got)
+		}
+	}
+	textArea.SetCursor(0, 9)
+	textArea.Update(tcell.NewEventKey(tcell.KeyUp, 0, 0), nil)
+	if row, col := textArea.GetCursor(); row != 0 || col != 5 {
+		t.Errorf("[3] GetCursor Error exp:0,5 got:%d,%d", row, col)
+	}
+}
+
+func TestTextAreaCursor(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	screen.Init()
+	screen.SetSize(40, 20)
+	style := tcell.StyleDefault
+	camera := engine.NewCamera(api.NewPoint(10, 5), api.NewSize(20, 10))
+	scene := engine.NewScene("scene/text-area/1", camera)
+	textArea := widgets.NewTextArea("text-area/1", api.NewPoint(1, 1), api.NewSize(10, 3), &style, "abc")
+	textArea.Init(screen)
+	scene.AddEntity(textArea)
+
+	// the cursor is displayed in the scene camera.
+	textArea.SetCursor(0, 2)
+	textArea.AcquireFocus()
+	if x, y, visible := screen.GetCursor(); x != 13 || y != 6 || !visible {
+		t.Errorf("[1] GetCursor Error exp:13,6 got:%d,%d", x, y)
+	}
+}
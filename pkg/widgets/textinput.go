@@ -1,14 +1,50 @@
 // textInput.go contains all attributes and methods required to implement a
-// generic text box input widget.
+// generic text box input widget. The input string can be edited at any cursor
+// position and it is scrolled horizontally when it does not fit in the widget.
+// Text inputs can mask the input string for passwords, limit its length,
+// recall previous input strings with up and down keys and complete the input
+// string with the tab key.
 package widgets
 
 import (
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/engine"
 	"github.com/jrecuero/thengine/pkg/tools"
 )
 
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// TextInputCompleter type defines a function which returns all candidates to
+// complete the given input string.
+type TextInputCompleter func(str string) []string
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// commonPrefix function returns the longest prefix shared by all given
+// strings.
+func commonPrefix(strs []string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	prefix := []rune(strs[0])
+	for _, str := range strs[1:] {
+		runes := []rune(str)
+		i := 0
+		for i < len(prefix) && i < len(runes) && prefix[i] == runes[i] {
+			i++
+		}
+		prefix = prefix[:i]
+	}
+	return string(prefix)
+}
+
 // -----------------------------------------------------------------------------
 //
 // TextInput
@@ -17,19 +53,51 @@ import (
 
 // TextInput structure defines all attributes and methods required for any
 // generic text input widget.
+// cursor is the position in the input string where runes are inserted, and
+// offset is the first rune displayed when the input string is scrolled.
+// mask is the rune displayed for every rune in the input string, zero to
+// display the input string.
+// maxLength is the maximum number of runes in the input string, zero if there
+// is not any limit.
+// history contains previous input strings, up to historySize entries, and
+// historyIndex is the entry being recalled. draft is the input string before
+// any entry was recalled.
+// completions are the candidates found in the last completion.
 type TextInput struct {
 	*Widget
-	inputStr    string
-	backupStyle *tcell.Style
+	inputStr     string
+	invalid      bool
+	cursor       int
+	offset       int
+	mask         rune
+	maxLength    int
+	history      []string
+	historySize  int
+	historyIndex int
+	draft        string
+	completer    TextInputCompleter
+	completions  []string
 }
 
 // NewTextInput function creates a new TextInput instance widget.
 func NewTextInput(name string, position *api.Point, size *api.Size, style *tcell.Style, defaultStr string) *TextInput {
 	textInput := &TextInput{
-		Widget:      NewWidget(name, position, size, style),
-		inputStr:    defaultStr,
-		backupStyle: style,
+		Widget:       NewWidget(name, position, size, style),
+		inputStr:     defaultStr,
+		invalid:      false,
+		cursor:       len([]rune(defaultStr)),
+		offset:       0,
+		mask:         0,
+		maxLength:    0,
+		history:      nil,
+		historySize:  0,
+		historyIndex: 0,
+		draft:        "",
+		completer:    nil,
+		completions:  nil,
 	}
+	textInput.SetThemeClass("textinput")
+	textInput.SetHandlesArrows(true)
 	textInput.updateCanvas()
 	textInput.SetFocusType(engine.SingleFocus)
 	textInput.SetFocusEnable(true)
@@ -40,23 +108,200 @@ func NewTextInput(name string, position *api.Point, size *api.Size, style *tcell
 // TextInput private methods
 // -----------------------------------------------------------------------------
 
+// complete method completes the input string before the cursor with the
+// candidates returned by the completer. A single candidate replaces the input
+// string, and several candidates complete their common prefix.
+func (t *TextInput) complete() {
+	if t.completer == nil {
+		return
+	}
+	runes := []rune(t.inputStr)
+	head := string(runes[:t.cursor])
+	t.completions = t.completer(head)
+	if len(t.completions) == 0 {
+		return
+	}
+	completion := commonPrefix(t.completions)
+	if len(t.completions) == 1 {
+		completion = t.completions[0]
+	}
+	if len(completion) > len(head) {
+		t.setInput(completion+string(runes[t.cursor:]), len([]rune(completion)))
+	}
+}
+
+// edit method runs the action for the given key event. It returns false if
+// the key is not handled by the text input.
+func (t *TextInput) edit(ev *tcell.EventKey) bool {
+	runes := []rune(t.inputStr)
+	ctrl := ev.Modifiers()&tcell.ModCtrl != 0
+	switch ev.Key() {
+	case tcell.KeyLeft:
+		if ctrl {
+			t.moveCursor(t.wordLeft())
+		} else {
+			t.moveCursor(t.cursor - 1)
+		}
+	case tcell.KeyRight:
+		if ctrl {
+			t.moveCursor(t.wordRight())
+		} else {
+			t.moveCursor(t.cursor + 1)
+		}
+	case tcell.KeyHome, tcell.KeyCtrlA:
+		t.moveCursor(0)
+	case tcell.KeyEnd, tcell.KeyCtrlE:
+		t.moveCursor(len(runes))
+	case tcell.KeyUp:
+		t.recall(-1)
+	case tcell.KeyDown:
+		t.recall(1)
+	case tcell.KeyTab:
+		t.complete()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if t.cursor > 0 {
+			t.setInput(string(runes[:t.cursor-1])+string(runes[t.cursor:]), t.cursor-1)
+		}
+	case tcell.KeyDelete:
+		if t.cursor < len(runes) {
+			t.setInput(string(runes[:t.cursor])+string(runes[t.cursor+1:]), t.cursor)
+		}
+	case tcell.KeyCtrlU:
+		t.setInput(string(runes[t.cursor:]), 0)
+	case tcell.KeyCtrlK:
+		t.setInput(string(runes[:t.cursor]), t.cursor)
+	case tcell.KeyCtrlV:
+		t.InsertText(GetClipboard())
+	case tcell.KeyRune:
+		t.InsertText(string(ev.Rune()))
+	default:
+		return false
+	}
+	return true
+}
+
+// getInputStyle method returns the style to be used for the input string. If
+// the input string is not valid, the validator error style is used, or the
+// theme error style if the validator does not provide any.
+func (t *TextInput) getInputStyle() *tcell.Style {
+	if t.invalid {
+		if validator := t.GetValidator(); validator != nil && validator.GetErrorStyle() != nil {
+			return validator.GetErrorStyle()
+		}
+		return t.GetThemeStyle(ThemeRoleError)
+	}
+	return t.GetThemeStyle(ThemeRoleNormal)
+}
+
+// moveCursor method moves the cursor to the given position in the input
+// string.
+func (t *TextInput) moveCursor(cursor int) {
+	t.cursor = tools.Min(tools.Max(0, cursor), len([]rune(t.inputStr)))
+	t.updateCanvas()
+	t.updateCursor()
+}
+
+// recall method replaces the input string with the previous or the next
+// entry in the history.
+func (t *TextInput) recall(delta int) {
+	index := t.historyIndex + delta
+	if index < 0 || index > len(t.history) || index == t.historyIndex {
+		return
+	}
+	if t.historyIndex == len(t.history) {
+		t.draft = t.inputStr
+	}
+	t.historyIndex = index
+	str := t.draft
+	if index < len(t.history) {
+		str = t.history[index]
+	}
+	t.setInput(str, len([]rune(str)))
+}
+
+// saveHistory method adds the input string to the history if it is enabled.
+func (t *TextInput) saveHistory() {
+	if t.historySize > 0 && t.inputStr != "" {
+		if last := len(t.history) - 1; last < 0 || t.history[last] != t.inputStr {
+			t.history = append(t.history, t.inputStr)
+		}
+		if len(t.history) > t.historySize {
+			t.history = t.history[len(t.history)-t.historySize:]
+		}
+	}
+	t.historyIndex = len(t.history)
+	t.draft = ""
+}
+
+// setInput method sets the input string and the cursor position, and
+// validates the new input string.
+func (t *TextInput) setInput(str string, cursor int) {
+	t.inputStr = str
+	t.invalid = t.Validate(str) != nil
+	t.moveCursor(cursor)
+}
+
 // updateCanvas method updates the text inputwidget canvas with the string
 // information.
 func (t *TextInput) updateCanvas() {
-	//canvas := engine.NewCanvasFromString(t.inputStr, t.GetStyle())
-	//t.SetCanvas(canvas)
+	width := t.GetSize().W
+	// scroll the input string so the cursor is always displayed.
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	} else if t.cursor >= t.offset+width {
+		t.offset = t.cursor - width + 1
+	}
+	runes := []rune(t.inputStr)
+	t.offset = tools.Max(0, tools.Min(t.offset, len(runes)))
+	visible := runes[t.offset:tools.Min(len(runes), t.offset+width)]
+	str := string(visible)
+	if t.mask != 0 {
+		str = strings.Repeat(string(t.mask), len(visible))
+	}
+	style := t.getInputStyle()
 	canvas := t.GetCanvas()
-	cell := engine.NewCell(t.GetStyle(), ' ')
+	cell := engine.NewCell(style, ' ')
 	canvas.FillWithCell(cell)
-	canvas.WriteStringInCanvas(t.inputStr, t.GetStyle())
+	canvas.WriteStringInCanvas(str, style)
 }
 
 // updateCursor method updates the cursor position inside the input text.
 func (t *TextInput) updateCursor() {
-	lenInputStr := len(t.inputStr)
-	col := t.GetPosition().X + lenInputStr
+	screen := t.GetScreen()
+	if screen == nil || !t.HasFocus() {
+		return
+	}
+	col := t.GetPosition().X + t.cursor - t.offset
 	row := t.GetPosition().Y
-	t.GetScreen().ShowCursor(col, row)
+	screen.ShowCursor(col, row)
+}
+
+// wordLeft method returns the position for the start of the word before the
+// cursor.
+func (t *TextInput) wordLeft() int {
+	runes := []rune(t.inputStr)
+	cursor := t.cursor
+	for cursor > 0 && !isWordRune(runes[cursor-1]) {
+		cursor--
+	}
+	for cursor > 0 && isWordRune(runes[cursor-1]) {
+		cursor--
+	}
+	return cursor
+}
+
+// wordRight method returns the position for the start of the word after the
+// cursor.
+func (t *TextInput) wordRight() int {
+	runes := []rune(t.inputStr)
+	cursor := t.cursor
+	for cursor < len(runes) && isWordRune(runes[cursor]) {
+		cursor++
+	}
+	for cursor < len(runes) && !isWordRune(runes[cursor]) {
+		cursor++
+	}
+	return cursor
 }
 
 // -----------------------------------------------------------------------------
@@ -75,11 +320,52 @@ func (t *TextInput) AcquireFocus() (bool, error) {
 	return ok, err
 }
 
+// GetCompletions method returns the candidates found in the last completion.
+func (t *TextInput) GetCompletions() []string {
+	return t.completions
+}
+
+// GetCursor method returns the cursor position in the input string.
+func (t *TextInput) GetCursor() int {
+	return t.cursor
+}
+
+// GetHistory method returns all previous input strings.
+func (t *TextInput) GetHistory() []string {
+	return t.history
+}
+
 // GetInputText method returns the input text string.
 func (t *TextInput) GetInputText() string {
 	return t.inputStr
 }
 
+// HandlesTab method returns if the text input uses the tab key to complete
+// the input string.
+func (t *TextInput) HandlesTab() bool {
+	return t.completer != nil
+}
+
+// InsertText method inserts the given string at the cursor position. Runes
+// exceeding the maximum length are not inserted.
+func (t *TextInput) InsertText(str string) {
+	runes := []rune(t.inputStr)
+	inserted := []rune(str)
+	if t.maxLength > 0 {
+		inserted = inserted[:tools.Max(0, tools.Min(len(inserted), t.maxLength-len(runes)))]
+	}
+	if len(inserted) == 0 {
+		return
+	}
+	t.setInput(string(runes[:t.cursor])+string(inserted)+string(runes[t.cursor:]), t.cursor+len(inserted))
+}
+
+// IsValid method returns if the input string is valid for the text input
+// validator.
+func (t *TextInput) IsValid() bool {
+	return !t.invalid
+}
+
 func (t *TextInput) Refresh() {
 	t.updateCanvas()
 }
@@ -87,39 +373,80 @@ func (t *TextInput) Refresh() {
 // ReleaseFocus method release the focus for the entity.
 func (t *TextInput) ReleaseFocus() (bool, error) {
 	ok, err := t.Entity.ReleaseFocus()
-	if err == nil {
-		t.GetScreen().HideCursor()
+	if screen := t.GetScreen(); err == nil && screen != nil {
+		screen.HideCursor()
 	}
 	return ok, err
 }
 
-// SetInputText method sets the input text string.
+// SetCompleter method sets the function which returns the candidates to
+// complete the input string with the tab key. A nil completer disables the
+// completion.
+func (t *TextInput) SetCompleter(completer TextInputCompleter) {
+	t.completer = completer
+}
+
+// SetHistorySize method sets the maximum number of input strings saved in
+// the history when they are entered. Zero disables the history.
+func (t *TextInput) SetHistorySize(size int) {
+	t.historySize = tools.Max(0, size)
+	if len(t.history) > t.historySize {
+		t.history = t.history[len(t.history)-t.historySize:]
+	}
+	t.historyIndex = len(t.history)
+}
+
+// SetInputText method sets the input text string and moves the cursor to the
+// end.
 func (t *TextInput) SetInputText(str string) {
-	t.inputStr = str
+	t.setInput(str, len([]rune(str)))
+}
+
+// SetMask method sets the rune displayed for every rune in the input string,
+// like '*' for passwords. Zero displays the input string.
+func (t *TextInput) SetMask(mask rune) {
+	t.mask = mask
 	t.updateCanvas()
 }
 
+// SetMaxLength method sets the maximum number of runes in the input string.
+// Zero removes any limit.
+func (t *TextInput) SetMaxLength(maxLength int) {
+	t.maxLength = tools.Max(0, maxLength)
+	if runes := []rune(t.inputStr); t.maxLength > 0 && len(runes) > t.maxLength {
+		t.setInput(string(runes[:t.maxLength]), t.cursor)
+	}
+}
+
 // Update method runs every cycle to update the text input.
 func (t *TextInput) Update(event tcell.Event, scene engine.IScene) {
 	defer t.Entity.Update(event, scene)
 	if !t.HasFocus() {
 		return
 	}
-	if str, ok, run := t.HandleKeyboardInputForString(event, t.inputStr); ok {
-		if run {
-			tools.Logger.WithField("module", "textinput").
-				WithField("method", "AcquireFocus").
-				Debugf("execute command")
-		}
-		t.inputStr = str
-		if t.Validate(str) != nil && t.GetValidator().GetErrorStyle() != nil {
-			t.SetStyle(t.GetValidator().GetErrorStyle())
-		} else {
-			t.SetStyle(t.backupStyle)
+	ev, ok := event.(*tcell.EventKey)
+	if !ok {
+		return
+	}
+	if ev.Key() == tcell.KeyEnter {
+		// invalid input text is not entered.
+		if !t.IsValid() {
+			return
 		}
+		tools.Logger.WithField("module", "textinput").
+			WithField("method", "Update").
+			Debugf("execute command")
+		t.saveHistory()
+		// the callback is called when the input text is entered, and it can
+		// update the input text.
+		t.RunCallback(t)
 		t.updateCanvas()
 		t.updateCursor()
+		return
 	}
+	t.edit(ev)
 }
 
 var _ engine.IEntity = (*TextInput)(nil)
+var _ engine.ITabHandler = (*TextInput)(nil)
+var _ engine.IArrowHandler = (*TextInput)(nil)
@@ -0,0 +1,208 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+func TestTextInputEdit(t *testing.T) {
+	style := tcell.StyleDefault
+	input := widgets.NewTextInput("text-input/1", api.NewPoint(0, 0), api.NewSize(6, 1), &style, "hello")
+	input.AcquireFocus()
+	cases := []struct {
+		input  []*tcell.EventKey
+		exp    string
+		cursor int
+		canvas string
+	}{
+		{
+			// insertion in the middle of the string.
+			input: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyHome, 0, 0),
+				tcell.NewEventKey(tcell.KeyRight, 0, 0),
+				tcell.NewEventKey(tcell.KeyRune, 'x', 0),
+			},
+			exp:    "hxello",
+			cursor: 2,
+			canvas: "hxello",
+		},
+		{
+			// the string scrolls when the cursor moves past the width.
+			input: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyEnd, 0, 0),
+				tcell.NewEventKey(tcell.KeyRune, '!', 0),
+			},
+			exp:    "hxello!",
+			cursor: 7,
+			canvas: "ello! ",
+		},
+		{
+			// word jump, and backspace and delete at the cursor position.
+			input: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModCtrl),
+				tcell.NewEventKey(tcell.KeyRight, 0, 0),
+				tcell.NewEventKey(tcell.KeyRight, 0, 0),
+				tcell.NewEventKey(tcell.KeyBackspace2, 0, 0),
+				tcell.NewEventKey(tcell.KeyDelete, 0, 0),
+			},
+			exp:    "hllo!",
+			cursor: 1,
+			canvas: "hllo! ",
+		},
+	}
+	for i, c := range cases {
+		for _, event := range c.input {
+			input.Update(event, nil)
+		}
+		if got := input.GetInputText(); got != c.exp {
+			t.Errorf("[%d] GetInputText Error exp:%q got:%q", i, c.exp, got)
+		}
+		if got := input.GetCursor(); got != c.cursor {
+			t.Errorf("[%d] GetCursor Error exp:%d got:%d", i, c.cursor, got)
+		}
+		if got := canvasLine(input.GetCanvas(), 0); got != c.canvas {
+			t.Errorf("[%d] canvas Error exp:%q got:%q", i, c.canvas, got)
+		}
+	}
+
+	// masked input strings with a maximum length.
+	input.SetInputText("")
+	input.SetMask('*')
+	input.SetMaxLength(4)
+	input.InsertText("secret")
+	if got := input.GetInputText(); got != "secr" {
+		t.Errorf("[3] InsertText Error exp:%q got:%q", "secr", got)
+	}
+	if got := canvasLine(input.GetCanvas(), 0); got != "****  " {
+		t.Errorf("[3] canvas Error exp:%q got:%q", "****  ", got)
+	}
+}
+
+func TestTextInputValidator(t *testing.T) {
+	style := tcell.StyleDefault
+	errorStyle := tcell.StyleDefault.Background(tcell.ColorRed)
+	input := widgets.NewTextInput("text-input/1", api.NewPoint(0, 0), api.NewSize(6, 1), &style, "")
+	input.SetValidator(engine.NewNumericValidator("validator/1", &errorStyle))
+	input.AcquireFocus()
+	input.Update(tcell.NewEventKey(tcell.KeyRune, '4', 0), nil)
+	if !input.IsValid() {
+		t.Errorf("[0] IsValid Error exp:true got:false")
+	}
+	input.Update(tcell.NewEventKey(tcell.KeyRune, 'x', 0), nil)
+	if input.IsValid() {
+		t.Errorf("[1] IsValid Error exp:false got:true")
+	}
+	if got := input.GetCanvas().GetStyleAt(api.NewPoint(0, 0)); *got != errorStyle {
+		t.Errorf("[1] canvas Error exp:%v got:%v", errorStyle, *got)
+	}
+	// invalid input text is not entered.
+	entered := 0
+	input.SetWidgetCallback(func(engine.IEntity, ...any) bool {
+		entered++
+		return true
+	})
+	input.Update(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+	if entered != 0 {
+		t.Errorf("[1] Enter Error exp:%d got:%d", 0, entered)
+	}
+
+	// integer validator does not accept decimal numbers.
+	input.SetValidator(engine.NewIntegerValidator("validator/4", &errorStyle))
+	input.SetInputText("1.5")
+	if input.IsValid() {
+		t.Errorf("[1] IsValid Error exp:false got:true")
+	}
+	input.SetInputText("15")
+	input.Update(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+	if entered != 1 {
+		t.Errorf("[1] Enter Error exp:%d got:%d", 1, entered)
+	}
+
+	validator, err := engine.NewRegexpValidator("validator/2", `^\d+,\d+$`, &errorStyle)
+	if err != nil {
+		t.Fatalf("[2] NewRegexpValidator Error exp:nil got:%s", err)
+	}
+	input.SetValidator(validator)
+	input.SetInputText("3,4")
+	if !input.IsValid() {
+		t.Errorf("[2] IsValid Error exp:true got:false")
+	}
+	if _, err := engine.NewRegexpValidator("validator/3", `(`, nil); err == nil {
+		t.Errorf("[3] NewRegexpValidator Error exp:error got:nil")
+	}
+}
+
+func TestTextInputHistoryAndCompletion(t *testing.T) {
+	style := tcell.StyleDefault
+	input := widgets.NewTextInput("text-input/1", api.NewPoint(0, 0), api.NewSize(20, 1), &style, "")
+	input.SetWidgetCallback(func(entity engine.IEntity, args ...any) bool {
+		entity.(*widgets.TextInput).SetInputText("")
+		return true
+	})
+	input.SetHistorySize(2)
+	input.AcquireFocus()
+	enter := tcell.NewEventKey(tcell.KeyEnter, 0, 0)
+	up := tcell.NewEventKey(tcell.KeyUp, 0, 0)
+	down := tcell.NewEventKey(tcell.KeyDown, 0, 0)
+	for _, str := range []string{"one", "two", "three"} {
+		input.InsertText(str)
+		input.Update(enter, nil)
+	}
+	if got := input.GetHistory(); len(got) != 2 || got[0] != "two" {
+		t.Errorf("[0] GetHistory Error exp:[two three] got:%v", got)
+	}
+
+	// up and down keys recall entries and return to the draft.
+	input.InsertText("dr")
+	exps := []struct {
+		event *tcell.EventKey
+		exp   string
+	}{
+		{up, "three"},
+		{up, "two"},
+		{up, "two"},
+		{down, "three"},
+		{down, "dr"},
+	}
+	for i, e := range exps {
+		input.Update(e.event, nil)
+		if got := input.GetInputText(); got != e.exp {
+			t.Errorf("[1:%d] recall Error exp:%q got:%q", i, e.exp, got)
+		}
+	}
+
+	// tab completes a single candidate or the common prefix.
+	if input.HandlesTab() {
+		t.Errorf("[2] HandlesTab Error exp:false got:true")
+	}
+	input.SetCompleter(func(str string) []string {
+		result := []string{}
+		for _, candidate := range []string{"teleport", "tell", "select"} {
+			if len(str) <= len(candidate) && candidate[:len(str)] == str {
+				result = append(result, candidate)
+			}
+		}
+		return result
+	})
+	if !input.HandlesTab() {
+		t.Errorf("[2] HandlesTab Error exp:true got:false")
+	}
+	tab := tcell.NewEventKey(tcell.KeyTab, 0, 0)
+	input.SetInputText("t")
+	input.Update(tab, nil)
+	if got := input.GetInputText(); got != "tel" {
+		t.Errorf("[2] complete Error exp:%q got:%q", "tel", got)
+	}
+	if got := input.GetCompletions(); len(got) != 2 {
+		t.Errorf("[2] GetCompletions Error exp:2 got:%v", got)
+	}
+	input.InsertText("e")
+	input.Update(tab, nil)
+	if got := input.GetInputText(); got != "teleport" {
+		t.Errorf("[2] complete Error exp:%q got:%q", "teleport", got)
+	}
+}
@@ -0,0 +1,374 @@
+// theme.go contains all data and methods required to handle widget themes. A
+// theme defines the style to be used for every named role (normal, focused,
+// selected, ...) and for every widget type. Themes can be loaded from JSON
+// files and switched at runtime.
+package widgets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// ThemeRole type defines the role a style plays inside a widget.
+type ThemeRole string
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	ThemeRoleNormal   ThemeRole = "normal"
+	ThemeRoleFocused  ThemeRole = "focused"
+	ThemeRoleSelected ThemeRole = "selected"
+	ThemeRoleDisabled ThemeRole = "disabled"
+	ThemeRoleBorder   ThemeRole = "border"
+	ThemeRoleError    ThemeRole = "error"
+	ThemeRoleTitle    ThemeRole = "title"
+	ThemeRoleFill     ThemeRole = "fill"
+)
+
+const (
+	// ThemeDefaultClass is the widget class used when the theme does not
+	// define a style for the widget class.
+	ThemeDefaultClass = "default"
+
+	ThemeDark           = "dark"
+	ThemeLight          = "light"
+	ThemeHighContrast   = "high-contrast"
+	ThemeColorBlindSafe = "color-blind-safe"
+)
+
+// -----------------------------------------------------------------------------
+// Package private variables
+// -----------------------------------------------------------------------------
+
+var (
+	// activeTheme is the theme being used by all widgets. When it is nil
+	// widgets use their own style.
+	activeTheme *Theme
+
+	// themes contains all themes registered by name.
+	themes map[string]*Theme = map[string]*Theme{}
+
+	// themesMutex protects activeTheme and themes, because engines can run
+	// in different goroutines.
+	themesMutex sync.RWMutex
+)
+
+// -----------------------------------------------------------------------------
+// Init package
+// -----------------------------------------------------------------------------
+
+// init function registers all themes shipped with the package.
+func init() {
+	RegisterTheme(NewDarkTheme())
+	RegisterTheme(NewLightTheme())
+	RegisterTheme(NewHighContrastTheme())
+	RegisterTheme(NewColorBlindSafeTheme())
+}
+
+// -----------------------------------------------------------------------------
+// Package private functions
+// -----------------------------------------------------------------------------
+
+// styleFromStrings function creates a new tcell.Style from a list of strings
+// with foreground color, background color and attributes.
+func styleFromStrings(content []string) (*tcell.Style, error) {
+	if len(content) < 2 {
+		return nil, fmt.Errorf("style %+v requires foreground and background", content)
+	}
+	style := tcell.StyleDefault.
+		Foreground(tcell.GetColor(content[0])).
+		Background(tcell.GetColor(content[1]))
+	if len(content) > 2 {
+		attrs, err := strconv.Atoi(content[2])
+		if err != nil {
+			return nil, fmt.Errorf("style %+v invalid attributes: %w", content, err)
+		}
+		style = style.Attributes(tcell.AttrMask(attrs))
+	}
+	return &style, nil
+}
+
+// styleToStrings function returns the given style as a list of strings with
+// foreground color, background color and attributes.
+func styleToStrings(style *tcell.Style) []string {
+	fg, bg, attrs := style.Decompose()
+	return []string{fg.String(), bg.String(), strconv.Itoa(int(attrs))}
+}
+
+// newPaletteTheme function creates a theme where all widget classes use the
+// same styles for the given colors.
+func newPaletteTheme(name string, fg, bg, accent, muted, border, failure tcell.Color) *Theme {
+	theme := NewTheme(name)
+	theme.SetStyleFor(ThemeDefaultClass, ThemeRoleNormal, engine.NewStyle(fg, bg, tcell.AttrNone))
+	theme.SetStyleFor(ThemeDefaultClass, ThemeRoleFocused, engine.NewStyle(bg, accent, tcell.AttrBold))
+	theme.SetStyleFor(ThemeDefaultClass, ThemeRoleSelected, engine.NewStyle(bg, fg, tcell.AttrNone))
+	theme.SetStyleFor(ThemeDefaultClass, ThemeRoleDisabled, engine.NewStyle(muted, bg, tcell.AttrDim))
+	theme.SetStyleFor(ThemeDefaultClass, ThemeRoleBorder, engine.NewStyle(border, bg, tcell.AttrNone))
+	theme.SetStyleFor(ThemeDefaultClass, ThemeRoleError, engine.NewStyle(failure, bg, tcell.AttrBold))
+	theme.SetStyleFor(ThemeDefaultClass, ThemeRoleTitle, engine.NewStyle(accent, bg, tcell.AttrBold))
+	theme.SetStyleFor(ThemeDefaultClass, ThemeRoleFill, engine.NewStyle(bg, accent, tcell.AttrNone))
+	return theme
+}
+
+// -----------------------------------------------------------------------------
+// Package public functions
+// -----------------------------------------------------------------------------
+
+// DefaultStyleForRole function returns the style for the given role derived
+// from the given widget style. It is used when there is not any active theme
+// or the theme does not define the role.
+func DefaultStyleForRole(style *tcell.Style, role ThemeRole) *tcell.Style {
+	if style == nil {
+		return nil
+	}
+	switch role {
+	case ThemeRoleFocused, ThemeRoleSelected, ThemeRoleFill:
+		return tools.ReverseStyle(style)
+	case ThemeRoleDisabled:
+		return tools.SetAttrToStyle(style, tcell.AttrDim)
+	}
+	return style
+}
+
+// GetTheme function returns the active theme. It returns nil if widgets are
+// using their own style.
+func GetTheme() *Theme {
+	themesMutex.RLock()
+	defer themesMutex.RUnlock()
+	return activeTheme
+}
+
+// GetThemeByName function returns the registered theme with the given name.
+func GetThemeByName(name string) *Theme {
+	themesMutex.RLock()
+	defer themesMutex.RUnlock()
+	return themes[name]
+}
+
+// GetThemeStyleFor function returns the style for the given widget class and
+// role. The style is taken from the active theme, if it is not defined there,
+// it is derived from the given style. It is used by entities which are not
+// widgets, like boxes and dialogs.
+func GetThemeStyleFor(class string, role ThemeRole, style *tcell.Style) *tcell.Style {
+	if themeStyle := GetTheme().GetStyleFor(class, role); themeStyle != nil {
+		return themeStyle
+	}
+	return DefaultStyleForRole(style, role)
+}
+
+// RefreshAllWidgets function refreshes all entities in all scenes handled by
+// every engine, so they are redrawn with the active theme. Engines running
+// their loop refresh their entities at the start of the next tick.
+func RefreshAllWidgets() {
+	for _, e := range engine.GetEngines() {
+		e.RequestRefresh()
+	}
+}
+
+// RefreshEngineWidgets function refreshes all entities in all scenes handled
+// by the given engine.
+func RefreshEngineWidgets(e *engine.Engine) {
+	e.RefreshAll()
+}
+
+// RegisterTheme function registers the given theme by its name.
+func RegisterTheme(theme *Theme) {
+	themesMutex.Lock()
+	defer themesMutex.Unlock()
+	themes[theme.GetName()] = theme
+}
+
+// SetTheme function sets the given theme as the active theme and refreshes
+// all widgets. A nil theme makes widgets to use their own style.
+func SetTheme(theme *Theme) {
+	tools.Logger.WithField("module", "theme").
+		WithField("function", "SetTheme").
+		Debugf("theme %s", theme.GetName())
+	themesMutex.Lock()
+	activeTheme = theme
+	themesMutex.Unlock()
+	RefreshAllWidgets()
+}
+
+// SetThemeByName function sets the registered theme with the given name as
+// the active theme.
+func SetThemeByName(name string) error {
+	theme := GetThemeByName(name)
+	if theme == nil {
+		return fmt.Errorf("theme %s not found", name)
+	}
+	SetTheme(theme)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+//
+// Theme
+//
+// -----------------------------------------------------------------------------
+
+// Theme structure defines styles for every role in every widget class.
+// styles maps a widget class to the style for every role. The
+// ThemeDefaultClass entry is used for any widget class not defined.
+type Theme struct {
+	name   string
+	styles map[string]map[ThemeRole]*tcell.Style
+}
+
+// -----------------------------------------------------------------------------
+// New Theme functions
+// -----------------------------------------------------------------------------
+
+// NewTheme function creates a new empty Theme instance.
+func NewTheme(name string) *Theme {
+	return &Theme{
+		name:   name,
+		styles: make(map[string]map[ThemeRole]*tcell.Style),
+	}
+}
+
+// NewThemeFromJSON function creates a new Theme instance with the content of
+// the given JSON file.
+func NewThemeFromJSON(filename string) (*Theme, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	theme := NewTheme("")
+	if err := json.Unmarshal(content, theme); err != nil {
+		return nil, fmt.Errorf("theme %s: %w", filename, err)
+	}
+	return theme, nil
+}
+
+// NewDarkTheme function creates the dark theme, light text over a black
+// background.
+func NewDarkTheme() *Theme {
+	return newPaletteTheme(ThemeDark,
+		tcell.ColorWhite, tcell.ColorBlack, tcell.ColorTeal,
+		tcell.ColorGray, tcell.ColorSilver, tcell.ColorRed)
+}
+
+// NewLightTheme function creates the light theme, dark text over a white
+// background.
+func NewLightTheme() *Theme {
+	return newPaletteTheme(ThemeLight,
+		tcell.ColorBlack, tcell.ColorWhite, tcell.ColorNavy,
+		tcell.ColorGray, tcell.ColorGray, tcell.ColorMaroon)
+}
+
+// NewHighContrastTheme function creates the high contrast theme, which only
+// uses black, white and yellow.
+func NewHighContrastTheme() *Theme {
+	return newPaletteTheme(ThemeHighContrast,
+		tcell.ColorWhite, tcell.ColorBlack, tcell.ColorYellow,
+		tcell.ColorWhite, tcell.ColorYellow, tcell.ColorYellow)
+}
+
+// NewColorBlindSafeTheme function creates a theme based on the Okabe-Ito
+// palette, distinguishable for all common color vision deficiencies.
+func NewColorBlindSafeTheme() *Theme {
+	return newPaletteTheme(ThemeColorBlindSafe,
+		tcell.ColorWhite, tcell.ColorBlack, tcell.NewHexColor(0x56B4E9),
+		tcell.NewHexColor(0x999999), tcell.NewHexColor(0xE69F00), tcell.NewHexColor(0xD55E00))
+}
+
+// -----------------------------------------------------------------------------
+// Theme public methods
+// -----------------------------------------------------------------------------
+
+// GetName method returns the theme name.
+func (t *Theme) GetName() string {
+	if t == nil {
+		return ""
+	}
+	return t.name
+}
+
+// GetStyleFor method returns the style for the given widget class and role.
+// If the widget class does not define the role, the default class is used. It
+// returns nil if the role is not defined.
+func (t *Theme) GetStyleFor(class string, role ThemeRole) *tcell.Style {
+	if t == nil {
+		return nil
+	}
+	if styles, ok := t.styles[class]; ok {
+		if style, ok := styles[role]; ok {
+			return style
+		}
+	}
+	if styles, ok := t.styles[ThemeDefaultClass]; ok {
+		if style, ok := styles[role]; ok {
+			return style
+		}
+	}
+	return nil
+}
+
+// MarshalJSON method is the custom marshal method to generate JSON from an
+// instance.
+func (t *Theme) MarshalJSON() ([]byte, error) {
+	styles := map[string]map[string][]string{}
+	for class, roles := range t.styles {
+		styles[class] = map[string][]string{}
+		for role, style := range roles {
+			styles[class][string(role)] = styleToStrings(style)
+		}
+	}
+	content := map[string]any{
+		"name":   t.name,
+		"styles": styles,
+	}
+	return json.Marshal(content)
+}
+
+// SetName method sets the theme name.
+func (t *Theme) SetName(name string) {
+	t.name = name
+}
+
+// SetStyleFor method sets the style for the given widget class and role.
+func (t *Theme) SetStyleFor(class string, role ThemeRole, style *tcell.Style) {
+	if _, ok := t.styles[class]; !ok {
+		t.styles[class] = make(map[ThemeRole]*tcell.Style)
+	}
+	t.styles[class][role] = style
+}
+
+// UnmarshalJSON method is the custom method to unmarshal JSON data into an
+// instance. Every style is a list with foreground color, background color and
+// optional attributes, same format used for entities.
+func (t *Theme) UnmarshalJSON(data []byte) error {
+	var content struct {
+		Name   string                         `json:"name"`
+		Styles map[string]map[string][]string `json:"styles"`
+	}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+	t.name = content.Name
+	t.styles = make(map[string]map[ThemeRole]*tcell.Style)
+	for class, roles := range content.Styles {
+		for role, strs := range roles {
+			style, err := styleFromStrings(strs)
+			if err != nil {
+				return fmt.Errorf("theme %s class %s role %s: %w", t.name, class, role, err)
+			}
+			t.SetStyleFor(class, ThemeRole(role), style)
+		}
+	}
+	return nil
+}
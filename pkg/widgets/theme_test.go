@@ -0,0 +1,150 @@
+package widgets_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+func TestThemeStyleFor(t *testing.T) {
+	theme := widgets.NewTheme("test")
+	normal := engine.NewStyle(tcell.ColorWhite, tcell.ColorBlack, tcell.AttrNone)
+	buttonNormal := engine.NewStyle(tcell.ColorYellow, tcell.ColorBlack, tcell.AttrBold)
+	theme.SetStyleFor(widgets.ThemeDefaultClass, widgets.ThemeRoleNormal, normal)
+	theme.SetStyleFor("button", widgets.ThemeRoleNormal, buttonNormal)
+
+	if got := theme.GetStyleFor("button", widgets.ThemeRoleNormal); !engine.CompareStyle(got, buttonNormal) {
+		t.Errorf("[1] GetStyleFor Error exp:%s got:%s", engine.StyleToString(buttonNormal), engine.StyleToString(got))
+	}
+	if got := theme.GetStyleFor("listbox", widgets.ThemeRoleNormal); !engine.CompareStyle(got, normal) {
+		t.Errorf("[2] GetStyleFor Error exp:%s got:%s", engine.StyleToString(normal), engine.StyleToString(got))
+	}
+	if got := theme.GetStyleFor("listbox", widgets.ThemeRoleFocused); got != nil {
+		t.Errorf("[3] GetStyleFor Error exp:nil got:%s", engine.StyleToString(got))
+	}
+}
+
+func TestThemeJSON(t *testing.T) {
+	theme := widgets.NewDarkTheme()
+	data, err := json.Marshal(theme)
+	if err != nil {
+		t.Errorf("[1] MarshalJSON Error exp:nil got:%s", err)
+		return
+	}
+	got := widgets.NewTheme("")
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Errorf("[1] UnmarshalJSON Error exp:nil got:%s", err)
+		return
+	}
+	if got.GetName() != widgets.ThemeDark {
+		t.Errorf("[1] GetName Error exp:%s got:%s", widgets.ThemeDark, got.GetName())
+	}
+	roles := []widgets.ThemeRole{
+		widgets.ThemeRoleNormal,
+		widgets.ThemeRoleFocused,
+		widgets.ThemeRoleSelected,
+		widgets.ThemeRoleDisabled,
+		widgets.ThemeRoleBorder,
+		widgets.ThemeRoleError,
+		widgets.ThemeRoleTitle,
+		widgets.ThemeRoleFill,
+	}
+	for i, role := range roles {
+		exp := theme.GetStyleFor(widgets.ThemeDefaultClass, role)
+		gotStyle := got.GetStyleFor(widgets.ThemeDefaultClass, role)
+		if !engine.CompareStyle(exp, gotStyle) {
+			t.Errorf("[%d] GetStyleFor Error exp:%s got:%s", i+2, engine.StyleToString(exp), engine.StyleToString(gotStyle))
+		}
+	}
+
+	bad := []byte(`{"name":"bad","styles":{"default":{"normal":["white"]}}}`)
+	if err := json.Unmarshal(bad, widgets.NewTheme("")); err == nil {
+		t.Errorf("[10] UnmarshalJSON Error exp:error got:nil")
+	}
+}
+
+func TestThemeWidget(t *testing.T) {
+	style := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorWhite)
+	got := widgets.NewListBox("test/1", api.NewPoint(0, 0), api.NewSize(20, 5), &style, []string{"one", "two"}, 0)
+
+	widgets.SetTheme(nil)
+	exp := tools.ReverseStyle(&style)
+	if gotStyle := got.GetThemeStyle(widgets.ThemeRoleSelected); !engine.CompareStyle(gotStyle, exp) {
+		t.Errorf("[1] GetThemeStyle Error exp:%s got:%s", engine.StyleToString(exp), engine.StyleToString(gotStyle))
+	}
+	if gotStyle := got.GetCanvas().GetStyleAt(api.NewPoint(1, 1)); !engine.CompareStyle(gotStyle, exp) {
+		t.Errorf("[1] GetStyleAt Error exp:%s got:%s", engine.StyleToString(exp), engine.StyleToString(gotStyle))
+	}
+
+	if err := widgets.SetThemeByName(widgets.ThemeHighContrast); err != nil {
+		t.Errorf("[2] SetThemeByName Error exp:nil got:%s", err)
+		return
+	}
+	defer widgets.SetTheme(nil)
+	got.Refresh()
+	exp = widgets.GetThemeByName(widgets.ThemeHighContrast).GetStyleFor("listbox", widgets.ThemeRoleSelected)
+	if gotStyle := got.GetCanvas().GetStyleAt(api.NewPoint(1, 1)); !engine.CompareStyle(gotStyle, exp) {
+		t.Errorf("[2] GetStyleAt Error exp:%s got:%s", engine.StyleToString(exp), engine.StyleToString(gotStyle))
+	}
+
+	if err := widgets.SetThemeByName("unknown"); err == nil {
+		t.Errorf("[3] SetThemeByName Error exp:error got:nil")
+	}
+}
+
+func TestThemeAllEngines(t *testing.T) {
+	style := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorWhite)
+	got := widgets.NewListBox("test/1", api.NewPoint(0, 0), api.NewSize(20, 5), &style, []string{"one", "two"}, 0)
+	session := engine.NewEngine()
+	scene := session.NewScene("scene/theme/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(20, 5)))
+	scene.AddEntity(got)
+	session.GetSceneManager().AddScene(scene)
+
+	// widgets in any engine are refreshed when the theme changes, not only
+	// widgets in the default engine.
+	if err := widgets.SetThemeByName(widgets.ThemeHighContrast); err != nil {
+		t.Errorf("[0] SetThemeByName Error exp:nil got:%s", err)
+		return
+	}
+	defer widgets.SetTheme(nil)
+	exp := widgets.GetThemeByName(widgets.ThemeHighContrast).GetStyleFor("listbox", widgets.ThemeRoleSelected)
+	if gotStyle := got.GetCanvas().GetStyleAt(api.NewPoint(1, 1)); !engine.CompareStyle(gotStyle, exp) {
+		t.Errorf("[0] GetStyleAt Error exp:%s got:%s", engine.StyleToString(exp), engine.StyleToString(gotStyle))
+	}
+}
+
+func TestThemeGauge(t *testing.T) {
+	style := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorWhite)
+	got := widgets.NewGauge("test/1", api.NewPoint(0, 0), api.NewSize(4, 1), &style, 4)
+	got.SetCompleted(2)
+
+	// the completed part uses the fill role, the rest uses the normal role.
+	widgets.SetTheme(nil)
+	cases := []struct {
+		col int
+		exp *tcell.Style
+	}{
+		{0, tools.ReverseStyle(&style)},
+		{3, &style},
+	}
+	for i, c := range cases {
+		if gotStyle := got.GetCanvas().GetStyleAt(api.NewPoint(c.col, 0)); !engine.CompareStyle(gotStyle, c.exp) {
+			t.Errorf("[%d] GetStyleAt Error exp:%s got:%s", i, engine.StyleToString(c.exp), engine.StyleToString(gotStyle))
+		}
+	}
+
+	theme := widgets.NewTheme("test")
+	fill := engine.NewStyle(tcell.ColorBlack, tcell.ColorGreen, tcell.AttrNone)
+	theme.SetStyleFor("gauge", widgets.ThemeRoleFill, fill)
+	widgets.SetTheme(theme)
+	defer widgets.SetTheme(nil)
+	got.Refresh()
+	if gotStyle := got.GetCanvas().GetStyleAt(api.NewPoint(0, 0)); !engine.CompareStyle(gotStyle, fill) {
+		t.Errorf("[2] GetStyleAt Error exp:%s got:%s", engine.StyleToString(fill), engine.StyleToString(gotStyle))
+	}
+}
@@ -0,0 +1,536 @@
+// treeview.go contains all attributes and methods required to implement a
+// tree view widget, which displays hierarchical data as nodes that can be
+// expanded and collapsed. Children for any node can be loaded only when the
+// node is expanded for the first time, and every node kind can be displayed
+// with its own icon.
+// Key bindings:
+//
+//	Up, Down, PgUp, PgDn, Home, End   move the selection.
+//	Right                             expands the node, or selects its first
+//	                                  child if it is already expanded.
+//	Left                              collapses the node, or selects its
+//	                                  parent if it is already collapsed.
+//	Space                             expands or collapses the node.
+//	Enter                             runs the widget callback.
+//
+// Example:
+//
+//	root := widgets.NewTreeNode("gear", "section", nil).
+//	    AddChildren(widgets.NewTreeNode("sword", "weapon", sword))
+//	tree := widgets.NewTreeView("tree-view/1", api.NewPoint(0, 0), api.NewSize(30, 10), &style,
+//	    []*widgets.TreeNode{root})
+//	tree.SetIcon("weapon", '⚔')
+package widgets
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public types
+// -----------------------------------------------------------------------------
+
+// TreeNodeLoader type defines the function which returns all children for
+// the given node. It is called only once, when the node is expanded for the
+// first time.
+type TreeNodeLoader func(node *TreeNode) []*TreeNode
+
+// TreeViewCallback type defines the function called when the selected node
+// changes in a tree view.
+type TreeViewCallback func(tree *TreeView, node *TreeNode)
+
+// -----------------------------------------------------------------------------
+// Package private constants
+// -----------------------------------------------------------------------------
+
+const (
+	treeViewIndent    = "  "
+	treeViewCollapsed = '▸'
+	treeViewExpanded  = '▾'
+)
+
+// -----------------------------------------------------------------------------
+//
+// TreeNode
+//
+// -----------------------------------------------------------------------------
+
+// TreeNode structure defines every node in a tree view.
+// kind is used to select the icon displayed for the node.
+// payload is any data the application links to the node.
+// loader returns the children for the node when it is expanded, loaded is
+// set once it has been called.
+type TreeNode struct {
+	label    string
+	kind     string
+	payload  any
+	parent   *TreeNode
+	children []*TreeNode
+	expanded bool
+	loader   TreeNodeLoader
+	loaded   bool
+}
+
+// NewTreeNode function creates a new TreeNode instance.
+func NewTreeNode(label string, kind string, payload any) *TreeNode {
+	return &TreeNode{
+		label:    label,
+		kind:     kind,
+		payload:  payload,
+		parent:   nil,
+		children: nil,
+		expanded: false,
+		loader:   nil,
+		loaded:   false,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TreeNode private methods
+// -----------------------------------------------------------------------------
+
+// load method loads the children for the node if they have not been loaded.
+func (n *TreeNode) load() {
+	if n.loader != nil && !n.loaded {
+		n.loaded = true
+		n.AddChildren(n.loader(n)...)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TreeNode public methods
+// -----------------------------------------------------------------------------
+
+// AddChildren method adds the given nodes as children for the node.
+func (n *TreeNode) AddChildren(children ...*TreeNode) *TreeNode {
+	for _, child := range children {
+		child.parent = n
+		n.children = append(n.children, child)
+	}
+	return n
+}
+
+// GetChildren method returns all children for the node.
+func (n *TreeNode) GetChildren() []*TreeNode {
+	return n.children
+}
+
+// GetDepth method returns the number of ancestors for the node.
+func (n *TreeNode) GetDepth() int {
+	depth := 0
+	for parent := n.parent; parent != nil; parent = parent.parent {
+		depth++
+	}
+	return depth
+}
+
+// GetKind method returns the node kind.
+func (n *TreeNode) GetKind() string {
+	return n.kind
+}
+
+// GetLabel method returns the string displayed for the node.
+func (n *TreeNode) GetLabel() string {
+	return n.label
+}
+
+// GetParent method returns the node parent, nil for root nodes.
+func (n *TreeNode) GetParent() *TreeNode {
+	return n.parent
+}
+
+// GetPayload method returns the data linked to the node.
+func (n *TreeNode) GetPayload() any {
+	return n.payload
+}
+
+// IsExpanded method returns if the node children are displayed.
+func (n *TreeNode) IsExpanded() bool {
+	return n.expanded
+}
+
+// IsLeaf method returns if the node does not have any children and it does
+// not have any children to be loaded.
+func (n *TreeNode) IsLeaf() bool {
+	return len(n.children) == 0 && (n.loader == nil || n.loaded)
+}
+
+// WithExpanded method sets if the node is expanded when it is displayed.
+func (n *TreeNode) WithExpanded(expanded bool) *TreeNode {
+	n.expanded = expanded
+	return n
+}
+
+// WithLoader method sets the function which loads the node children the
+// first time it is expanded.
+func (n *TreeNode) WithLoader(loader TreeNodeLoader) *TreeNode {
+	n.loader = loader
+	n.loaded = false
+	return n
+}
+
+// -----------------------------------------------------------------------------
+//
+// TreeView
+//
+// -----------------------------------------------------------------------------
+
+// TreeView structure defines a widget which displays a tree of nodes.
+// visible contains all nodes displayed, which are root nodes and children for
+// expanded nodes, and selectionIndex is the selected position in them.
+// icons contains the icon for every node kind.
+// onSelect is called when the selected node changes.
+// mouseButtons are the mouse buttons pressed in the last mouse event, used to
+// handle only new clicks.
+type TreeView struct {
+	*Widget
+	roots          []*TreeNode
+	visible        []*TreeNode
+	selectionIndex int
+	scroller       *Scroller
+	icons          map[string]rune
+	onSelect       TreeViewCallback
+	mouseButtons   tcell.ButtonMask
+}
+
+// NewTreeView function creates a new TreeView instance with the given root
+// nodes.
+func NewTreeView(name string, position *api.Point, size *api.Size, style *tcell.Style, roots []*TreeNode) *TreeView {
+	tools.Logger.WithField("module", "treeview").
+		WithField("function", "NewTreeView").
+		Infof("%s %s %s %d", name, position.ToString(), size.ToString(), len(roots))
+	tree := &TreeView{
+		Widget:         NewWidget(name, position, size, style),
+		roots:          nil,
+		visible:        nil,
+		selectionIndex: 0,
+		scroller:       nil,
+		icons:          make(map[string]rune),
+		onSelect:       nil,
+		mouseButtons:   tcell.ButtonNone,
+	}
+	tree.SetThemeClass("treeview")
+	tree.SetHandlesArrows(true)
+	tree.SetFocusType(engine.SingleFocus)
+	tree.SetFocusEnable(true)
+	tree.SetRoots(roots)
+	return tree
+}
+
+// -----------------------------------------------------------------------------
+// TreeView private methods
+// -----------------------------------------------------------------------------
+
+// execute method runs the action for the given keyboard input.
+func (t *TreeView) execute(args ...any) {
+	tools.Logger.WithField("module", "treeview").
+		WithField("method", "execute").
+		Debugf("%s %+v", t.GetName(), args)
+	pageLength := tools.Max(1, t.innerSize().H-1)
+	node := t.GetSelectedNode()
+	switch args[0].(string) {
+	case "up":
+		t.SetSelectionIndex(t.selectionIndex - 1)
+	case "down":
+		t.SetSelectionIndex(t.selectionIndex + 1)
+	case "page-up":
+		t.SetSelectionIndex(t.selectionIndex - pageLength)
+	case "page-down":
+		t.SetSelectionIndex(t.selectionIndex + pageLength)
+	case "home":
+		t.SetSelectionIndex(0)
+	case "end":
+		t.SetSelectionIndex(len(t.visible) - 1)
+	case "right":
+		if node == nil || node.IsLeaf() {
+			return
+		}
+		if !node.expanded {
+			t.Expand(node)
+		} else if len(node.children) != 0 {
+			t.SetSelectionIndex(t.selectionIndex + 1)
+		}
+	case "left":
+		if node == nil {
+			return
+		}
+		if node.expanded && !node.IsLeaf() {
+			t.Collapse(node)
+		} else if node.parent != nil {
+			t.SelectNode(node.parent)
+		}
+	case "toggle":
+		if node != nil {
+			t.Toggle(node)
+		}
+	case "run":
+		if node != nil {
+			t.RunCallback(t)
+		}
+	}
+}
+
+// flatten method appends the given nodes, and children for all expanded
+// nodes, to the visible nodes. Children are loaded when they are required.
+func (t *TreeView) flatten(nodes []*TreeNode) {
+	for _, node := range nodes {
+		t.visible = append(t.visible, node)
+		if node.expanded {
+			node.load()
+			t.flatten(node.children)
+		}
+	}
+}
+
+// handleMouse method selects the clicked node, and expands or collapses it
+// when it was already selected. Mouse wheel moves the selection.
+func (t *TreeView) handleMouse(event tcell.Event, scene engine.IScene) {
+	position, buttons, inside := t.GetMousePosition(event, scene)
+	if position == nil {
+		return
+	}
+	clicked := buttons&tcell.Button1 != 0 && t.mouseButtons&tcell.Button1 == 0
+	t.mouseButtons = buttons
+	if !inside {
+		return
+	}
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		t.SetSelectionIndex(t.selectionIndex - 1)
+	case buttons&tcell.WheelDown != 0:
+		t.SetSelectionIndex(t.selectionIndex + 1)
+	case clicked && position.Y >= 1 && position.Y < t.GetSize().H-1:
+		index := t.scroller.StartSelection + position.Y - 1
+		if index < len(t.visible) {
+			if index == t.selectionIndex {
+				t.execute("toggle")
+			} else {
+				t.SetSelectionIndex(index)
+			}
+		}
+	}
+}
+
+// innerSize method returns the size inside the tree view border.
+func (t *TreeView) innerSize() *api.Size {
+	size := t.GetSize()
+	return api.NewSize(tools.Max(0, size.W-2), tools.Max(0, size.H-2))
+}
+
+// nodeLine method returns the string displayed for the given node, with the
+// indentation for its depth, the expander and the icon for its kind.
+func (t *TreeView) nodeLine(node *TreeNode) string {
+	expander := ' '
+	if !node.IsLeaf() {
+		expander = treeViewCollapsed
+		if node.expanded {
+			expander = treeViewExpanded
+		}
+	}
+	line := strings.Repeat(treeViewIndent, node.GetDepth()) + string(expander) + " "
+	if icon, ok := t.icons[node.kind]; ok {
+		line += string(icon) + " "
+	}
+	return line + node.label
+}
+
+// updateVisible method updates all visible nodes and keeps the selected node
+// selected if it is still visible.
+func (t *TreeView) updateVisible() {
+	selected := t.GetSelectedNode()
+	t.visible = nil
+	t.flatten(t.roots)
+	for i, node := range t.visible {
+		if node == selected {
+			t.selectionIndex = i
+		}
+	}
+	t.selectionIndex = tools.Min(tools.Max(0, t.selectionIndex), tools.Max(0, len(t.visible)-1))
+	t.scroller = NewVerticalScroller(len(t.visible), tools.Max(1, t.innerSize().H))
+	t.updateCanvas()
+	if selected != t.GetSelectedNode() && t.onSelect != nil {
+		t.onSelect(t, t.GetSelectedNode())
+	}
+}
+
+// updateCanvas method updates the tree view canvas with the visible nodes to
+// be displayed and the selected node.
+func (t *TreeView) updateCanvas() {
+	canvas := t.GetCanvas()
+	canvas.WriteRectangleInCanvasAt(nil, nil, t.GetThemeStyle(ThemeRoleBorder), engine.CanvasRectSingleLine)
+	inner := t.innerSize()
+	y := 1
+	if len(t.visible) != 0 {
+		t.scroller.Update(t.selectionIndex)
+		t.scroller.CreateIter()
+	}
+	for ; len(t.visible) != 0 && t.scroller.IterHasNext() && y <= inner.H; y++ {
+		index, _ := t.scroller.IterGetNext()
+		style := t.GetThemeStyle(ThemeRoleNormal)
+		if index == t.selectionIndex {
+			style = t.GetThemeStyle(ThemeRoleSelected)
+		}
+		// runes are written one by one, so the expander and icons take one
+		// column.
+		line := []rune(t.nodeLine(t.visible[index]))
+		for x := 0; x < inner.W; x++ {
+			ch := ' '
+			if x < len(line) {
+				ch = line[x]
+			}
+			canvas.SetCellAt(api.NewPoint(x+1, y), engine.NewCell(style, ch))
+		}
+	}
+	// clear lines without nodes.
+	for ; y <= inner.H; y++ {
+		canvas.WriteStringInCanvasAt(strings.Repeat(" ", inner.W), t.GetThemeStyle(ThemeRoleNormal), api.NewPoint(1, y))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TreeView public methods
+// -----------------------------------------------------------------------------
+
+// Collapse method collapses the given node, so its children are not
+// displayed.
+func (t *TreeView) Collapse(node *TreeNode) {
+	node.expanded = false
+	t.updateVisible()
+}
+
+// Expand method expands the given node, so its children are displayed. They
+// are loaded if the node has a loader.
+func (t *TreeView) Expand(node *TreeNode) {
+	node.expanded = true
+	t.updateVisible()
+}
+
+// GetIcon method returns the icon for the given node kind.
+func (t *TreeView) GetIcon(kind string) (rune, bool) {
+	icon, ok := t.icons[kind]
+	return icon, ok
+}
+
+// GetRoots method returns all root nodes.
+func (t *TreeView) GetRoots() []*TreeNode {
+	return t.roots
+}
+
+// GetSelectedNode method returns the selected node, nil if there is not any
+// node.
+func (t *TreeView) GetSelectedNode() *TreeNode {
+	if t.selectionIndex < 0 || t.selectionIndex >= len(t.visible) {
+		return nil
+	}
+	return t.visible[t.selectionIndex]
+}
+
+// GetSelectionIndex method returns the selected position in the visible
+// nodes.
+func (t *TreeView) GetSelectionIndex() int {
+	return t.selectionIndex
+}
+
+// GetVisibleNodes method returns all nodes displayed, which are root nodes
+// and children for expanded nodes.
+func (t *TreeView) GetVisibleNodes() []*TreeNode {
+	return t.visible
+}
+
+// Refresh method refreshes the tree view canvas with latest attribute values.
+func (t *TreeView) Refresh() {
+	// create a new canvas if the tree view has been resized.
+	if !t.GetCanvas().Size().IsEqual(t.GetSize()) {
+		t.SetCanvas(engine.NewCanvas(t.GetSize()))
+	}
+	t.updateVisible()
+}
+
+// SelectNode method selects the given node, expanding all its ancestors so
+// it is displayed. It returns false if the node is not in the tree.
+func (t *TreeView) SelectNode(node *TreeNode) bool {
+	for parent := node.parent; parent != nil; parent = parent.parent {
+		parent.expanded = true
+	}
+	t.updateVisible()
+	for i, visible := range t.visible {
+		if visible == node {
+			t.SetSelectionIndex(i)
+			return true
+		}
+	}
+	return false
+}
+
+// SetIcon method sets the icon displayed for all nodes with the given kind.
+func (t *TreeView) SetIcon(kind string, icon rune) {
+	t.icons[kind] = icon
+	t.updateCanvas()
+}
+
+// SetRoots method replaces all root nodes in the tree view.
+func (t *TreeView) SetRoots(roots []*TreeNode) {
+	t.roots = roots
+	t.selectionIndex = 0
+	t.updateVisible()
+}
+
+// SetSelectionCallback method sets the function called when the selected
+// node changes.
+func (t *TreeView) SetSelectionCallback(callback TreeViewCallback) {
+	t.onSelect = callback
+}
+
+// SetSelectionIndex method sets the selected position in the visible nodes.
+func (t *TreeView) SetSelectionIndex(index int) {
+	selected := t.GetSelectedNode()
+	t.selectionIndex = tools.Min(tools.Max(0, index), tools.Max(0, len(t.visible)-1))
+	t.updateCanvas()
+	if selected != t.GetSelectedNode() && t.onSelect != nil {
+		t.onSelect(t, t.GetSelectedNode())
+	}
+}
+
+// Toggle method expands the given node if it is collapsed or collapses it if
+// it is expanded.
+func (t *TreeView) Toggle(node *TreeNode) {
+	if node.expanded {
+		t.Collapse(node)
+		return
+	}
+	t.Expand(node)
+}
+
+// Update method executes all tree view functionality every tick time.
+// Keyboard input moves the selection and expands or collapses nodes when the
+// tree view has the focus, and mouse input selects nodes.
+func (t *TreeView) Update(event tcell.Event, scene engine.IScene) {
+	defer t.Entity.Update(event, scene)
+	t.handleMouse(event, scene)
+	if !t.HasFocus() {
+		return
+	}
+	actions := []*KeyboardAction{
+		NewKeyboardActionForKey(tcell.KeyUp, t.execute, []any{"up"}),
+		NewKeyboardActionForKey(tcell.KeyDown, t.execute, []any{"down"}),
+		NewKeyboardActionForKey(tcell.KeyPgUp, t.execute, []any{"page-up"}),
+		NewKeyboardActionForKey(tcell.KeyPgDn, t.execute, []any{"page-down"}),
+		NewKeyboardActionForKey(tcell.KeyHome, t.execute, []any{"home"}),
+		NewKeyboardActionForKey(tcell.KeyEnd, t.execute, []any{"end"}),
+		NewKeyboardActionForKey(tcell.KeyRight, t.execute, []any{"right"}),
+		NewKeyboardActionForKey(tcell.KeyLeft, t.execute, []any{"left"}),
+		NewKeyboardActionForRune(' ', t.execute, []any{"toggle"}),
+		NewKeyboardActionForKey(tcell.KeyEnter, t.execute, []any{"run"}),
+	}
+	t.HandleKeyboardForActions(event, actions)
+}
+
+var _ engine.IObject = (*TreeView)(nil)
+var _ engine.IFocus = (*TreeView)(nil)
+var _ engine.IEntity = (*TreeView)(nil)
+var _ engine.IArrowHandler = (*TreeView)(nil)
@@ -0,0 +1,107 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+func newTestTree() (*widgets.TreeView, *int) {
+	loads := 0
+	gear := widgets.NewTreeNode("gear", "section", nil).AddChildren(
+		widgets.NewTreeNode("sword", "weapon", nil),
+		widgets.NewTreeNode("shield", "armor", nil),
+	)
+	spells := widgets.NewTreeNode("spells", "section", nil).WithLoader(func(node *widgets.TreeNode) []*widgets.TreeNode {
+		loads++
+		return []*widgets.TreeNode{widgets.NewTreeNode("fireball", "spell", nil)}
+	})
+	style := tcell.StyleDefault
+	tree := widgets.NewTreeView("tree-view/1", api.NewPoint(0, 0), api.NewSize(16, 4), &style, []*widgets.TreeNode{gear, spells})
+	return tree, &loads
+}
+
+func TestTreeViewNavigation(t *testing.T) {
+	tree, loads := newTestTree()
+	tree.SetIcon("weapon", '⚔')
+	tree.AcquireFocus()
+	selections := []string{}
+	tree.SetSelectionCallback(func(tree *widgets.TreeView, node *widgets.TreeNode) {
+		selections = append(selections, node.GetLabel())
+	})
+	cases := []struct {
+		key     tcell.Key
+		exp     string
+		visible int
+		canvas  []string
+	}{
+		{tcell.KeyRight, "gear", 4, []string{"│▾ gear        │", "│    ⚔ sword   │"}},
+		{tcell.KeyRight, "sword", 4, []string{"│▾ gear        │", "│    ⚔ sword   │"}},
+		{tcell.KeyDown, "shield", 4, []string{"│    ⚔ sword   │", "│    shield    │"}},
+		{tcell.KeyLeft, "gear", 4, []string{"│▾ gear        │", "│    ⚔ sword   │"}},
+		{tcell.KeyLeft, "gear", 2, []string{"│▸ gear        │", "│▸ spells      │"}},
+		{tcell.KeyDown, "spells", 2, []string{"│▸ gear        │", "│▸ spells      │"}},
+		{tcell.KeyRight, "spells", 3, []string{"│▸ gear        │", "│▾ spells      │"}},
+		{tcell.KeyEnd, "fireball", 3, []string{"│▾ spells      │", "│    fireball  │"}},
+	}
+	for i, c := range cases {
+		tree.Update(tcell.NewEventKey(c.key, 0, 0), nil)
+		if got := tree.GetSelectedNode().GetLabel(); got != c.exp {
+			t.Errorf("[%d] GetSelectedNode Error exp:%s got:%s", i, c.exp, got)
+		}
+		if got := len(tree.GetVisibleNodes()); got != c.visible {
+			t.Errorf("[%d] GetVisibleNodes Error exp:%d got:%d", i, c.visible, got)
+		}
+		for row, exp := range c.canvas {
+			if got := canvasLine(tree.GetCanvas(), row+1); got != exp {
+				t.Errorf("[%d:%d] canvas Error exp:%q got:%q", i, row, exp, got)
+			}
+		}
+	}
+	if *loads != 1 {
+		t.Errorf("loader Error exp:1 got:%d", *loads)
+	}
+	exps := []string{"sword", "shield", "gear", "spells", "fireball"}
+	if len(selections) != len(exps) {
+		t.Errorf("SetSelectionCallback Error exp:%v got:%v", exps, selections)
+		return
+	}
+	for i, exp := range exps {
+		if selections[i] != exp {
+			t.Errorf("[%d] SetSelectionCallback Error exp:%s got:%s", i, exp, selections[i])
+		}
+	}
+}
+
+func TestTreeViewSelectNode(t *testing.T) {
+	tree, loads := newTestTree()
+	spells := tree.GetRoots()[1]
+	tree.Expand(spells)
+	fireball := spells.GetChildren()[0]
+	tree.Collapse(spells)
+	if *loads != 1 {
+		t.Errorf("[0] loader Error exp:1 got:%d", *loads)
+	}
+	if ok := tree.SelectNode(fireball); !ok {
+		t.Errorf("[1] SelectNode Error exp:true got:false")
+	}
+	if got := tree.GetSelectionIndex(); got != 2 {
+		t.Errorf("[1] GetSelectionIndex Error exp:2 got:%d", got)
+	}
+	if got := fireball.GetDepth(); got != 1 {
+		t.Errorf("[1] GetDepth Error exp:1 got:%d", got)
+	}
+
+	// clicking a node selects it, and clicking the selected node toggles it.
+	tree.Update(tcell.NewEventMouse(2, 1, tcell.Button1, 0), nil)
+	tree.Update(tcell.NewEventMouse(2, 1, tcell.ButtonNone, 0), nil)
+	tree.Update(tcell.NewEventMouse(2, 1, tcell.Button1, 0), nil)
+	if spells.IsExpanded() {
+		t.Errorf("[2] IsExpanded Error exp:false got:true")
+	}
+	if got := tree.GetSelectedNode(); got != spells {
+		t.Errorf("[2] GetSelectedNode Error exp:spells got:%s", got.GetLabel())
+	}
+}
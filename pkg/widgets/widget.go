@@ -2,6 +2,8 @@
 package widgets
 
 import (
+	"unicode/utf8"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/jrecuero/thengine/pkg/api"
 	"github.com/jrecuero/thengine/pkg/engine"
@@ -64,10 +66,17 @@ type IWidget interface {
 
 // Widget structure defines all attributes and methods for any basic and common
 // widget.
+// markup is true when text displayed by the widget handles inline markup
+// tags. It is false by default, so text is displayed as it is.
+// handlesArrows is true when the widget uses arrow keys while it has the
+// focus. Widgets moving a selection or a cursor set it in their constructor.
 type Widget struct {
 	*engine.Entity
-	callback     WidgetCallback
-	callbackArgs WidgetArgs
+	callback      WidgetCallback
+	callbackArgs  WidgetArgs
+	themeClass    string
+	markup        bool
+	handlesArrows bool
 }
 
 // NewWidget function creates a new Widget instance.
@@ -80,6 +89,7 @@ func NewWidget(name string, position *api.Point, size *api.Size, style *tcell.St
 		Entity:       engine.NewEntity(name, position, size, style),
 		callback:     nil,
 		callbackArgs: nil,
+		themeClass:   ThemeDefaultClass,
 	}
 }
 
@@ -89,6 +99,7 @@ func NewNamedWidget(name string) *Widget {
 		Entity:       engine.NewNamedEntity(name),
 		callback:     nil,
 		callbackArgs: nil,
+		themeClass:   ThemeDefaultClass,
 	}
 }
 
@@ -99,9 +110,44 @@ func NewEmptyWidget() *Widget {
 		Entity:       engine.NewEmptyEntity(),
 		callback:     nil,
 		callbackArgs: nil,
+		themeClass:   ThemeDefaultClass,
 	}
 }
 
+// -----------------------------------------------------------------------------
+// Widget private methods
+// -----------------------------------------------------------------------------
+
+// displayWidth method returns the number of columns required to display the
+// given single line string, where markup tags are not taken into account
+// when the widget handles markup.
+func (w *Widget) displayWidth(str string) int {
+	if w.markup {
+		return engine.MarkupWidth(str)
+	}
+	return utf8.RuneCountInString(str)
+}
+
+// newDisplayCanvas method returns a new canvas with the given string, where
+// markup tags are applied to the given style when the widget handles markup.
+func (w *Widget) newDisplayCanvas(str string, style *tcell.Style) *engine.Canvas {
+	if w.markup {
+		return engine.NewCanvasFromMarkup(str, style)
+	}
+	return engine.NewCanvasFromString(str, style)
+}
+
+// writeDisplayString method writes the given string in the canvas at the
+// given position, where markup tags are applied to the given style when the
+// widget handles markup.
+func (w *Widget) writeDisplayString(canvas *engine.Canvas, str string, style *tcell.Style, position *api.Point) {
+	if w.markup {
+		canvas.WriteMarkupInCanvasAt(str, style, position)
+		return
+	}
+	canvas.WriteStringInCanvasAt(str, style, position)
+}
+
 // -----------------------------------------------------------------------------
 // Widget public methods
 // -----------------------------------------------------------------------------
@@ -116,6 +162,42 @@ func (w *Widget) GetWidgetCallbackArgs() WidgetArgs {
 	return w.callbackArgs
 }
 
+// GetThemeClass method returns the widget class used to look up styles in the
+// active theme.
+func (w *Widget) GetThemeClass() string {
+	return w.themeClass
+}
+
+// GetThemeStyle method returns the style for the given role. The style is
+// taken from the active theme, if it is not defined there, it is derived from
+// the widget style.
+func (w *Widget) GetThemeStyle(role ThemeRole) *tcell.Style {
+	return GetThemeStyleFor(w.themeClass, role, w.GetStyle())
+}
+
+// GetMousePosition method returns the position for the given mouse event
+// relative to the widget and the mouse buttons pressed. It returns false if
+// the event is not a mouse event or it is outside the widget.
+func (w *Widget) GetMousePosition(event tcell.Event, scene engine.IScene) (*api.Point, tcell.ButtonMask, bool) {
+	ev, ok := event.(*tcell.EventMouse)
+	if !ok {
+		return nil, tcell.ButtonNone, false
+	}
+	x, y := ev.Position()
+	position := api.NewPoint(x, y)
+	// mouse position is given in screen coordinates, where the widget is
+	// rendered in the scene camera.
+	if scene != nil && scene.GetCamera() != nil {
+		position.Subtract(scene.GetCamera().GetOrigin())
+	}
+	position.Subtract(w.GetPosition())
+	size := w.GetSize()
+	if position.X < 0 || position.Y < 0 || position.X >= size.W || position.Y >= size.H {
+		return position, ev.Buttons(), false
+	}
+	return position, ev.Buttons(), true
+}
+
 // HandleKeyboardInputForActions method handles keyboard inputs related with
 // the given information provided. Input parameters provide the keys that have
 // to be handled and the callbacks for each of them.
@@ -161,6 +243,18 @@ func (w *Widget) HandleKeyboardInputForString(event tcell.Event, str string) (st
 	return str, false, false
 }
 
+// HandlesArrows method returns if the widget uses arrow keys while it has the
+// focus.
+func (w *Widget) HandlesArrows() bool {
+	return w.handlesArrows
+}
+
+// IsMarkup method returns if text displayed by the widget handles inline
+// markup tags.
+func (w *Widget) IsMarkup() bool {
+	return w.markup
+}
+
 // RunCallback method executes the widget callback. If not any arguments are
 // provided, it used those in the widget arguments attributes.
 func (w *Widget) RunCallback(entity engine.IEntity, args ...any) bool {
@@ -179,6 +273,18 @@ func (w *Widget) RunCallback(entity engine.IEntity, args ...any) bool {
 	return w.callback(entity, nil)
 }
 
+// SetHandlesArrows method sets if the widget uses arrow keys while it has the
+// focus.
+func (w *Widget) SetHandlesArrows(handlesArrows bool) {
+	w.handlesArrows = handlesArrows
+}
+
+// SetThemeClass method sets the widget class used to look up styles in the
+// active theme.
+func (w *Widget) SetThemeClass(class string) {
+	w.themeClass = class
+}
+
 // SetWidgetCallback method sets a new widget callback and arguments to be
 // passed.
 func (w *Widget) SetWidgetCallback(f WidgetCallback, args ...any) {
@@ -196,3 +302,4 @@ var _ engine.IObject = (*Widget)(nil)
 var _ engine.IFocus = (*Widget)(nil)
 var _ engine.IEntity = (*Widget)(nil)
 var _ IWidget = (*Widget)(nil)
+var _ engine.IArrowHandler = (*Widget)(nil)
@@ -20,6 +20,15 @@ func widgetCb(entity engine.IEntity, args ...any) bool {
 	return true
 }
 
+// canvasLine function returns the string in the given canvas line.
+func canvasLine(canvas *engine.Canvas, y int) string {
+	result := []rune{}
+	for x := 0; x < canvas.Width(); x++ {
+		result = append(result, canvas.GetRuneAt(api.NewPoint(x, y)))
+	}
+	return string(result)
+}
+
 func TestWidgetCallback(t *testing.T) {
 	style := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorWhite)
 	widget := widgets.NewWidget("test", api.NewPoint(0, 0), api.NewSize(1, 1), &style)
@@ -124,3 +133,74 @@ func TestWidgetCallback(t *testing.T) {
 		t.Errorf("[4] GetWidgetCallbackArgs Error.Callback.Args exp:%d got:%d", 5, widgetCbArgs[2].(int))
 	}
 }
+
+func TestWidgetMarkup(t *testing.T) {
+	style := tcell.StyleDefault
+	text := widgets.NewText("text/1", api.NewPoint(0, 0), nil, &style, "[b]hit[/]")
+	button := widgets.NewButton("button/1", api.NewPoint(0, 1), nil, &style, "[[OK]")
+	listBox := widgets.NewListBox("list-box/1", api.NewPoint(0, 2), api.NewSize(14, 3), &style, []string{"[red]fire[/]"}, 0)
+
+	// markup is disabled by default, so strings are displayed as they are.
+	if text.IsMarkup() {
+		t.Errorf("[1] IsMarkup Error exp:false got:true")
+	}
+	cases := []struct {
+		canvas *engine.Canvas
+		row    int
+		exp    string
+	}{
+		{text.GetCanvas(), 0, "[b]hit[/]"},
+		{button.GetCanvas(), 0, "[[OK]"},
+		{listBox.GetCanvas(), 1, "│[red]fire[/]│"},
+	}
+	for i, c := range cases {
+		if got := canvasLine(c.canvas, c.row); got != c.exp {
+			t.Errorf("[1:%d] Canvas Error exp:%s got:%s", i, c.exp, got)
+		}
+	}
+
+	text.SetMarkup(true)
+	button.SetMarkup(true)
+	listBox.SetMarkup(true)
+	cases = []struct {
+		canvas *engine.Canvas
+		row    int
+		exp    string
+	}{
+		{text.GetCanvas(), 0, "hit"},
+		{button.GetCanvas(), 0, "[OK]"},
+		{listBox.GetCanvas(), 1, "│fire        │"},
+	}
+	for i, c := range cases {
+		if got := canvasLine(c.canvas, c.row); got != c.exp {
+			t.Errorf("[2:%d] Canvas Error exp:%s got:%s", i, c.exp, got)
+		}
+	}
+	if _, _, attrs := text.GetCanvas().GetCellAt(api.NewPoint(0, 0)).GetStyle().Decompose(); attrs&tcell.AttrBold == 0 {
+		t.Errorf("[2] Style Error exp:bold got:%d", attrs)
+	}
+}
+
+func TestWidgetHandlesArrows(t *testing.T) {
+	style := tcell.StyleDefault
+	cases := []struct {
+		entity engine.IEntity
+		exp    bool
+	}{
+		{widgets.NewText("text/1", api.NewPoint(0, 0), nil, &style, "text"), false},
+		{widgets.NewButton("button/1", api.NewPoint(0, 0), api.NewSize(4, 1), &style, "ok"), false},
+		{widgets.NewListBox("listbox/1", api.NewPoint(0, 0), api.NewSize(10, 4), &style, []string{"one"}, 0), true},
+		{widgets.NewTextInput("input/1", api.NewPoint(0, 0), api.NewSize(10, 1), &style, ""), true},
+		{widgets.NewTabs("tabs/1", api.NewPoint(0, 0), nil, &style), true},
+	}
+	for i, c := range cases {
+		handler, ok := c.entity.(engine.IArrowHandler)
+		if !ok {
+			t.Errorf("[%d] IArrowHandler Error exp:true got:false", i)
+			continue
+		}
+		if got := handler.HandlesArrows(); got != c.exp {
+			t.Errorf("[%d] HandlesArrows Error exp:%t got:%t", i, c.exp, got)
+		}
+	}
+}
@@ -0,0 +1,373 @@
+// window.go contains the Window container widget, which displays its content
+// inside a titled border with buttons to minimize, maximize and close it.
+// Windows are opened in a WindowManager, which moves, resizes and raises them
+// over each other.
+// Example:
+//
+//	inspector := widgets.NewWindow("window/inspector", "Inspector", api.NewPoint(2, 2), api.NewSize(30, 10),
+//	    &style, tree)
+//	inspector.SetResizable(true)
+//	desktop.Open(inspector)
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	// WindowMinWidth and WindowMinHeight are the minimum size for any window
+	// being resized, which is enough for the border and the buttons.
+	WindowMinWidth  = 8
+	WindowMinHeight = 3
+)
+
+// -----------------------------------------------------------------------------
+// Package private constants
+// -----------------------------------------------------------------------------
+
+const (
+	windowButtonMinimize = '_'
+	windowButtonMaximize = '□'
+	windowButtonClose    = 'x'
+)
+
+// -----------------------------------------------------------------------------
+//
+// windowArranger
+//
+// -----------------------------------------------------------------------------
+
+// windowArranger structure arranges the window content inside the border.
+type windowArranger struct {
+}
+
+// measure method returns the size for the content and the border.
+func (a *windowArranger) measure(items []*ContainerItem, sizes []*api.Size) *api.Size {
+	result := api.NewSize(WindowMinWidth, WindowMinHeight)
+	for _, size := range sizes {
+		result.W = tools.Max(result.W, size.W+2)
+		result.H = tools.Max(result.H, size.H+2)
+	}
+	return result
+}
+
+// arrange method returns the rectangle for the content inside the border.
+func (a *windowArranger) arrange(items []*ContainerItem, sizes []*api.Size, rect *api.Rect) []*api.Rect {
+	result := make([]*api.Rect, len(items))
+	width, height := tools.Max(0, rect.Size.W-2), tools.Max(0, rect.Size.H-2)
+	for i, item := range items {
+		offsetX, w := alignLength(item.align, tools.Min(sizes[i].W, width), width)
+		offsetY, h := alignLength(item.align, tools.Min(sizes[i].H, height), height)
+		result[i] = api.NewRect(api.NewPoint(rect.Origin.X+1+offsetX, rect.Origin.Y+1+offsetY), api.NewSize(w, h))
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+//
+// Window
+//
+// -----------------------------------------------------------------------------
+
+// Window structure defines a container which displays its content inside a
+// titled border.
+// manager is the window manager the window is opened in.
+// resizable is true when the window can be resized with the mouse or the
+// keyboard.
+// minimized is true when only the title bar is displayed.
+// restoreRect is the window rectangle before it was maximized, nil when the
+// window is not maximized.
+// lastFocus is the content widget with the focus when the window was moved
+// behind another window, and it gets the focus back when the window is
+// raised again.
+type Window struct {
+	*Container
+	title       string
+	manager     *WindowManager
+	resizable   bool
+	minimized   bool
+	restoreRect *api.Rect
+	lastFocus   engine.IEntity
+}
+
+// NewWindow function creates a new Window instance with the given title and
+// content. The content is stretched to the space inside the border. A nil size
+// makes the window to take the size of its content.
+func NewWindow(name string, title string, position *api.Point, size *api.Size, style *tcell.Style, content engine.IEntity) *Window {
+	tools.Logger.WithField("module", "window").
+		WithField("function", "NewWindow").
+		Infof("%s %s", name, title)
+	window := &Window{
+		Container:   newContainer(name, position, size, &windowArranger{}),
+		title:       title,
+		manager:     nil,
+		resizable:   false,
+		minimized:   false,
+		restoreRect: nil,
+		lastFocus:   nil,
+	}
+	window.SetStyle(style)
+	window.SetThemeClass("window")
+	if content != nil {
+		// content is displayed over the window background.
+		delta := window.GetZLevel() + 1 - content.GetZLevel()
+		walkEntity(content, func(entity engine.IEntity) {
+			entity.SetZLevel(entity.GetZLevel() + delta)
+		})
+		item := NewContainerItem(content)
+		item.align = AlignStretch
+		window.addItem(item)
+	}
+	window.updateCanvas()
+	return window
+}
+
+// -----------------------------------------------------------------------------
+// Window private methods
+// -----------------------------------------------------------------------------
+
+// buttonAt method returns the title bar button at the given column, or zero
+// if there is not any button there.
+func (w *Window) buttonAt(x int) rune {
+	width := w.GetSize().W
+	switch x {
+	case width - 4:
+		return windowButtonMinimize
+	case width - 3:
+		return windowButtonMaximize
+	case width - 2:
+		return windowButtonClose
+	}
+	return 0
+}
+
+// isActive method checks if the window is the active window in its window
+// manager.
+func (w *Window) isActive() bool {
+	return w.manager != nil && w.manager.GetActiveWindow() == w
+}
+
+// setContentVisible method sets if the content is displayed. Hidden content
+// is not active either, so its widgets do not handle any input.
+func (w *Window) setContentVisible(visible bool) {
+	walkEntity(w.GetContent(), func(entity engine.IEntity) {
+		entity.SetActive(visible)
+		entity.SetVisible(visible)
+	})
+}
+
+// updateCanvas method updates the window canvas with the border, the title
+// bar and the background for the content. Minimized windows display only the
+// title bar.
+func (w *Window) updateCanvas() {
+	size := api.CloneSize(w.GetSize())
+	if w.minimized {
+		size.H = tools.Min(size.H, 1)
+	}
+	canvas := engine.NewCanvas(size)
+	canvas.FillWithCell(engine.NewCell(w.GetThemeStyle(ThemeRoleNormal), ' '))
+	borderStyle := w.GetThemeStyle(ThemeRoleBorder)
+	titleStyle := w.GetThemeStyle(ThemeRoleNormal)
+	if w.isActive() {
+		borderStyle = w.GetThemeStyle(ThemeRoleFocused)
+		titleStyle = w.GetThemeStyle(ThemeRoleTitle)
+	}
+	canvas.WriteRectangleInCanvasAt(nil, nil, borderStyle, engine.CanvasRectSingleLine)
+	// title is cut before the buttons, which take one column each.
+	title := w.newDisplayCanvas(" "+w.title+" ", titleStyle)
+	for x := 0; x < title.Size().W && x < size.W-6; x++ {
+		canvas.SetCellAt(api.NewPoint(x+1, 0), engine.CloneCell(title.GetCellAt(api.NewPoint(x, 0))))
+	}
+	if size.W >= WindowMinWidth {
+		for _, x := range []int{size.W - 4, size.W - 3, size.W - 2} {
+			canvas.SetCellAt(api.NewPoint(x, 0), engine.NewCell(borderStyle, w.buttonAt(x)))
+		}
+	}
+	w.SetCanvas(canvas)
+}
+
+// -----------------------------------------------------------------------------
+// Window public methods
+// -----------------------------------------------------------------------------
+
+// Close method closes the window in its window manager.
+func (w *Window) Close() {
+	if w.manager != nil {
+		w.manager.Close(w)
+	}
+}
+
+// GetContent method returns the window content.
+func (w *Window) GetContent() engine.IEntity {
+	if len(w.items) != 0 {
+		return w.items[0].widget
+	}
+	return nil
+}
+
+// GetManager method returns the window manager the window is opened in.
+func (w *Window) GetManager() *WindowManager {
+	return w.manager
+}
+
+// GetTitle method returns the window title.
+func (w *Window) GetTitle() string {
+	return w.title
+}
+
+// IsMaximized method checks if the window takes all the window manager area.
+func (w *Window) IsMaximized() bool {
+	return w.restoreRect != nil
+}
+
+// IsMinimized method checks if only the window title bar is displayed.
+func (w *Window) IsMinimized() bool {
+	return w.minimized
+}
+
+// IsResizable method checks if the window can be resized.
+func (w *Window) IsResizable() bool {
+	return w.resizable
+}
+
+// Maximize method makes the window to take all the window manager area. It
+// is restored if it was already maximized.
+func (w *Window) Maximize() {
+	if w.manager == nil {
+		return
+	}
+	if w.IsMaximized() {
+		w.Restore()
+		return
+	}
+	if w.minimized {
+		w.Restore()
+	}
+	w.restoreRect = api.NewRect(api.ClonePoint(w.GetPosition()), api.CloneSize(w.GetSize()))
+	w.SetPosition(api.ClonePoint(w.manager.GetPosition()))
+	w.Container.SetSize(api.CloneSize(w.manager.GetSize()))
+	w.Reflow()
+}
+
+// Minimize method displays only the window title bar, and the window is moved
+// behind all other windows.
+func (w *Window) Minimize() {
+	if w.minimized {
+		return
+	}
+	w.minimized = true
+	if w.manager != nil {
+		w.manager.lower(w)
+	} else {
+		w.setContentVisible(false)
+	}
+	w.updateCanvas()
+}
+
+// MoveTo method moves the window to the given position. The window is kept
+// inside the window manager area.
+func (w *Window) MoveTo(position *api.Point) {
+	position = api.ClonePoint(position)
+	if w.manager != nil {
+		area, size := w.manager.GetRect(), w.GetSize()
+		position.X = tools.Max(area.Origin.X, tools.Min(position.X, area.Origin.X+area.Size.W-size.W))
+		position.Y = tools.Max(area.Origin.Y, tools.Min(position.Y, area.Origin.Y+area.Size.H-size.H))
+	}
+	w.SetPosition(position)
+	w.Reflow()
+}
+
+// OnAdded method adds the content to the scene the window is added to, and it
+// gives the focus to the content if the window is the active window.
+func (w *Window) OnAdded(scene engine.IScene) {
+	w.Container.OnAdded(scene)
+	if w.manager != nil {
+		w.manager.updateScope()
+	}
+}
+
+// Reflow method arranges the content again and updates the window canvas.
+func (w *Window) Reflow() {
+	w.Container.Reflow()
+	w.updateCanvas()
+}
+
+// Refresh method arranges the content again and updates the window canvas.
+func (w *Window) Refresh() {
+	w.Reflow()
+}
+
+// ResizeTo method resizes a resizable window to the given size. The size is
+// kept between the minimum window size and the window manager area.
+func (w *Window) ResizeTo(size *api.Size) {
+	if !w.resizable {
+		return
+	}
+	size = api.NewSize(tools.Max(WindowMinWidth, size.W), tools.Max(WindowMinHeight, size.H))
+	if w.manager != nil {
+		area, position := w.manager.GetRect(), w.GetPosition()
+		size.W = tools.Min(size.W, area.Origin.X+area.Size.W-position.X)
+		size.H = tools.Min(size.H, area.Origin.Y+area.Size.H-position.Y)
+	}
+	w.restoreRect = nil
+	w.Container.SetSize(size)
+	w.Reflow()
+}
+
+// Restore method displays a minimized window again, or it restores the
+// rectangle for a maximized window.
+func (w *Window) Restore() {
+	if w.minimized {
+		w.minimized = false
+		if w.manager != nil {
+			w.manager.Raise(w)
+		} else {
+			w.setContentVisible(true)
+		}
+	} else if w.restoreRect != nil {
+		w.SetPosition(w.restoreRect.Origin)
+		w.Container.SetSize(w.restoreRect.Size)
+		w.restoreRect = nil
+	}
+	w.Reflow()
+}
+
+// SetMarkup method sets if the window title handles markup tags.
+func (w *Window) SetMarkup(markup bool) {
+	w.markup = markup
+	w.updateCanvas()
+}
+
+// SetResizable method sets if the window can be resized.
+func (w *Window) SetResizable(resizable bool) {
+	w.resizable = resizable
+}
+
+// SetTitle method sets the window title.
+func (w *Window) SetTitle(title string) {
+	w.title = title
+	w.updateCanvas()
+}
+
+// Update method arranges the content again if the window position or size
+// changed.
+func (w *Window) Update(event tcell.Event, scene engine.IScene) {
+	defer w.Entity.Update(event, scene)
+	if w.needsReflow() {
+		w.Reflow()
+	}
+}
+
+var _ engine.IObject = (*Window)(nil)
+var _ engine.IFocus = (*Window)(nil)
+var _ engine.IEntity = (*Window)(nil)
+var _ IWidget = (*Window)(nil)
+var _ IContainer = (*Window)(nil)
+var _ engine.IFocusScope = (*Window)(nil)
@@ -0,0 +1,187 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/widgets"
+)
+
+func newTestWindows() (engine.IScene, *widgets.WindowManager, []*widgets.Window, []*widgets.Button) {
+	style := tcell.StyleDefault
+	scene := engine.NewEngine().NewScene("scene/windows/1", engine.NewCamera(api.NewPoint(0, 0), api.NewSize(40, 20)))
+	manager := widgets.NewWindowManager("desktop", api.NewPoint(0, 0), api.NewSize(40, 20))
+	scene.AddEntity(manager)
+	buttons := []*widgets.Button{
+		widgets.NewButton("button/1", nil, api.NewSize(6, 1), &style, "one"),
+		widgets.NewButton("button/2", nil, api.NewSize(6, 1), &style, "two"),
+	}
+	windows := []*widgets.Window{
+		widgets.NewWindow("window/1", "One", api.NewPoint(0, 0), api.NewSize(12, 5), &style, buttons[0]),
+		widgets.NewWindow("window/2", "Two", api.NewPoint(5, 2), api.NewSize(12, 5), &style, buttons[1]),
+	}
+	for _, window := range windows {
+		manager.Open(window)
+	}
+	return scene, manager, windows, buttons
+}
+
+func TestWindowCanvas(t *testing.T) {
+	_, _, windows, buttons := newTestWindows()
+	checkRects(t, 0, []engine.IEntity{buttons[0], buttons[1]}, []*api.Rect{
+		api.NewRect(api.NewPoint(1, 1), api.NewSize(10, 3)),
+		api.NewRect(api.NewPoint(6, 3), api.NewSize(10, 3)),
+	})
+	if got := canvasLine(windows[1].GetCanvas(), 0); got != "┌ Two ──_□x┐" {
+		t.Errorf("[0] canvas Error exp:%q got:%q", "┌ Two ──_□x┐", got)
+	}
+	// titles are displayed as plain text unless the window handles markup.
+	windows[0].SetTitle("[red]Log")
+	if got := canvasLine(windows[0].GetCanvas(), 0); got != "┌ [red]─_□x┐" {
+		t.Errorf("[1] canvas Error exp:%q got:%q", "┌ [red]─_□x┐", got)
+	}
+	windows[0].SetMarkup(true)
+	if got := canvasLine(windows[0].GetCanvas(), 0); got != "┌ Log ──_□x┐" {
+		t.Errorf("[2] canvas Error exp:%q got:%q", "┌ Log ──_□x┐", got)
+	}
+	windows[1].Minimize()
+	if got := windows[1].GetCanvas().Height(); got != 1 {
+		t.Errorf("[3] canvas Error.Height exp:1 got:%d", got)
+	}
+	if buttons[1].IsVisible() {
+		t.Errorf("[3] IsVisible Error exp:false got:true")
+	}
+}
+
+func TestWindowManagerFocusAndOrder(t *testing.T) {
+	scene, manager, windows, buttons := newTestWindows()
+
+	// the last window opened is in front, and only its widgets can take the
+	// focus.
+	if got := manager.GetActiveWindow(); got != windows[1] {
+		t.Errorf("[0] GetActiveWindow Error exp:window/2 got:%v", got)
+	}
+	if !buttons[1].HasFocus() || buttons[0].HasFocus() {
+		t.Errorf("[0] HasFocus Error exp:button/2 got:%t %t", buttons[0].HasFocus(), buttons[1].HasFocus())
+	}
+	focusManager := scene.GetEngine().GetFocusManager()
+	if got := focusManager.GetFocusScope(scene); got != engine.IFocusScope(windows[1]) {
+		t.Errorf("[0] GetFocusScope Error exp:window/2 got:%v", got)
+	}
+	if err := focusManager.AcquireFocusToEntity(buttons[0]); err == nil {
+		t.Errorf("[0] AcquireFocusToEntity Error exp:error got:nil")
+	}
+	if windows[1].GetZLevel() <= buttons[0].GetZLevel() || buttons[1].GetZLevel() <= windows[1].GetZLevel() {
+		t.Errorf("[0] GetZLevel Error got:%d %d %d", buttons[0].GetZLevel(), windows[1].GetZLevel(), buttons[1].GetZLevel())
+	}
+
+	// clicking a window behind raises it and moves the focus to its widgets.
+	manager.Update(tcell.NewEventMouse(1, 0, tcell.Button1, 0), scene)
+	manager.Update(tcell.NewEventMouse(1, 0, tcell.ButtonNone, 0), scene)
+	if got := manager.GetActiveWindow(); got != windows[0] {
+		t.Errorf("[1] GetActiveWindow Error exp:window/1 got:%v", got)
+	}
+	if !buttons[0].HasFocus() || buttons[1].HasFocus() {
+		t.Errorf("[1] HasFocus Error exp:button/1 got:%t %t", buttons[0].HasFocus(), buttons[1].HasFocus())
+	}
+	if windows[0].GetZLevel() <= buttons[1].GetZLevel() {
+		t.Errorf("[1] GetZLevel Error exp:window/1 over button/2 got:%d %d", windows[0].GetZLevel(), buttons[1].GetZLevel())
+	}
+
+	// closing the active window activates the window behind it.
+	closed := false
+	windows[0].SetWidgetCallback(func(engine.IEntity, ...any) bool {
+		closed = true
+		return true
+	})
+	manager.Update(tcell.NewEventMouse(10, 0, tcell.Button1, 0), scene)
+	if !closed || scene.GetEntityByName("window/1") != nil || scene.GetEntityByName("button/1") != nil {
+		t.Errorf("[2] Close Error exp:closed got:%t", closed)
+	}
+	if got := manager.GetActiveWindow(); got != windows[1] || !buttons[1].HasFocus() {
+		t.Errorf("[2] GetActiveWindow Error exp:window/2 got:%v", got)
+	}
+
+	// minimized windows are not the focus scope anymore.
+	windows[1].Minimize()
+	if got := focusManager.GetFocusScope(scene); got != nil {
+		t.Errorf("[3] GetFocusScope Error exp:nil got:%v", got)
+	}
+	scene.RemoveEntity(manager)
+	if got := focusManager.GetFocusScope(scene); got != nil {
+		t.Errorf("[4] GetFocusScope Error exp:nil got:%v", got)
+	}
+}
+
+func TestWindowManagerMoveAndResize(t *testing.T) {
+	scene, manager, windows, buttons := newTestWindows()
+	windows[1].SetResizable(true)
+
+	// dragging the title bar moves the window inside the window manager.
+	events := []struct {
+		x, y    int
+		buttons tcell.ButtonMask
+	}{
+		{7, 2, tcell.Button1},
+		{9, 4, tcell.Button1},
+		{60, 4, tcell.Button1},
+		{0, 0, tcell.ButtonNone},
+	}
+	for _, e := range events {
+		manager.Update(tcell.NewEventMouse(e.x, e.y, e.buttons, 0), scene)
+	}
+	checkRects(t, 0, []engine.IEntity{windows[1], buttons[1]}, []*api.Rect{
+		api.NewRect(api.NewPoint(28, 4), api.NewSize(12, 5)),
+		api.NewRect(api.NewPoint(29, 5), api.NewSize(10, 3)),
+	})
+
+	// keys move and resize the active window.
+	keys := []tcell.ModMask{tcell.ModAlt, tcell.ModAlt | tcell.ModShift, tcell.ModAlt | tcell.ModShift}
+	for _, mod := range keys {
+		manager.Update(tcell.NewEventKey(tcell.KeyDown, 0, mod), scene)
+	}
+	manager.Update(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModAlt|tcell.ModShift), scene)
+	if got := windows[1].GetRect(); !got.IsEqual(api.NewRect(api.NewPoint(28, 5), api.NewSize(11, 7))) {
+		t.Errorf("[1] GetRect Error exp:[28,5 11,7] got:%s", got.ToString())
+	}
+
+	// maximized windows take the window manager area until they are
+	// restored.
+	windows[1].Maximize()
+	if got := windows[1].GetRect(); !got.IsEqual(api.NewRect(api.NewPoint(0, 0), api.NewSize(40, 20))) {
+		t.Errorf("[2] Maximize Error got:%s", got.ToString())
+	}
+	windows[1].Maximize()
+	if got := windows[1].GetRect(); !got.IsEqual(api.NewRect(api.NewPoint(28, 5), api.NewSize(11, 7))) {
+		t.Errorf("[2] Restore Error got:%s", got.ToString())
+	}
+}
+
+func TestWindowManagerLastFocus(t *testing.T) {
+	style := tcell.StyleDefault
+	scene, manager, windows, _ := newTestWindows()
+	first := widgets.NewButton("button/3/1", nil, api.NewSize(6, 1), &style, "first")
+	second := widgets.NewButton("button/3/2", nil, api.NewSize(6, 1), &style, "second")
+	buttons := widgets.NewVBox("vbox/3", nil, nil, 0)
+	buttons.Add(first)
+	buttons.Add(second)
+	window := widgets.NewWindow("window/3", "Three", api.NewPoint(10, 5), api.NewSize(12, 6), &style, buttons)
+	manager.Open(window)
+	focusManager := scene.GetEngine().GetFocusManager()
+	if !first.HasFocus() {
+		t.Errorf("[0] HasFocus Error exp:button/3/1 got:false")
+	}
+
+	// the widget with the focus gets it back when its window is raised again.
+	_ = focusManager.FocusNextInScene(scene)
+	manager.Raise(windows[0])
+	if second.HasFocus() {
+		t.Errorf("[1] HasFocus Error exp:false got:true")
+	}
+	manager.Raise(window)
+	if !second.HasFocus() || first.HasFocus() {
+		t.Errorf("[2] HasFocus Error exp:button/3/2 got:%t %t", first.HasFocus(), second.HasFocus())
+	}
+}
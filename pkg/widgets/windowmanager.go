@@ -0,0 +1,441 @@
+// windowmanager.go contains the WindowManager widget, which handles several
+// windows opened at the same time over each other in the same scene. Windows
+// are raised when they are clicked, moved when their title bar is dragged and
+// resized when their bottom-right corner is dragged. The active window, which
+// is the window in front, is the focus scope for the scene, so only its
+// widgets can take the focus.
+// Key bindings:
+//
+//	F6                  activates the window behind all other windows.
+//	Alt+Arrows          move the active window.
+//	Alt+Shift+Arrows    resize the active window.
+//
+// Example:
+//
+//	desktop := widgets.NewWindowManager("desktop", api.NewPoint(0, 0), api.NewSize(80, 24))
+//	scene.AddEntity(desktop)
+//	desktop.Open(widgets.NewWindow("window/sprites", "Sprites", api.NewPoint(1, 1), api.NewSize(30, 10),
+//	    &style, spriteList))
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/jrecuero/thengine/pkg/api"
+	"github.com/jrecuero/thengine/pkg/engine"
+	"github.com/jrecuero/thengine/pkg/tools"
+)
+
+// -----------------------------------------------------------------------------
+// Package public constants
+// -----------------------------------------------------------------------------
+
+const (
+	// WindowZLevelStep is the z-level distance between windows, which leaves
+	// room for the z-levels used by widgets inside every window.
+	WindowZLevelStep = 100
+)
+
+// -----------------------------------------------------------------------------
+//
+// WindowManager
+//
+// -----------------------------------------------------------------------------
+
+// WindowManager structure defines a widget which handles windows opened over
+// each other. Its rectangle is the area where windows can be moved.
+// windows contains all windows opened, from back to front, so the last one is
+// the active window.
+// scene is the scene the window manager has been added to.
+// dragging is the window being moved or resized with the mouse, and grab is
+// the position in the window where it was grabbed.
+// mouseButtons are the mouse buttons pressed in the last mouse event, used to
+// handle only new clicks.
+// scoped is the window pushed as the focus scope for the scene.
+// listening is the focus manager notifying focus changes to the window
+// manager, used to remember the widget with the focus in every window.
+type WindowManager struct {
+	*Widget
+	windows      []*Window
+	scene        engine.IScene
+	scoped       *Window
+	listening    *engine.FocusManager
+	dragging     *Window
+	resizing     bool
+	grab         *api.Point
+	mouseButtons tcell.ButtonMask
+}
+
+// NewWindowManager function creates a new WindowManager instance for the
+// given area.
+func NewWindowManager(name string, position *api.Point, size *api.Size) *WindowManager {
+	tools.Logger.WithField("module", "windowmanager").
+		WithField("function", "NewWindowManager").
+		Infof("%s %s %s", name, position.ToString(), size.ToString())
+	manager := &WindowManager{
+		Widget:       NewNamedWidget(name),
+		windows:      nil,
+		scene:        nil,
+		scoped:       nil,
+		listening:    nil,
+		dragging:     nil,
+		resizing:     false,
+		grab:         nil,
+		mouseButtons: tcell.ButtonNone,
+	}
+	manager.SetPosition(position)
+	manager.SetSize(size)
+	return manager
+}
+
+// -----------------------------------------------------------------------------
+// WindowManager private methods
+// -----------------------------------------------------------------------------
+
+// activateWindow method raises the given window, restoring it when it is
+// minimized. The window becomes the focus scope for the scene.
+func (m *WindowManager) activateWindow(window *Window) {
+	if window.IsMinimized() {
+		window.Restore()
+		return
+	}
+	m.Raise(window)
+}
+
+// clickWindow method raises the given window and handles the click at the
+// given position in it. Buttons in the title bar are pressed, the title bar
+// starts moving the window and the bottom-right corner starts resizing it.
+func (m *WindowManager) clickWindow(window *Window, position *api.Point) {
+	m.Raise(window)
+	size := window.GetSize()
+	if position.Y == 0 {
+		switch window.buttonAt(position.X) {
+		case windowButtonMinimize:
+			window.Minimize()
+		case windowButtonMaximize:
+			window.Maximize()
+		case windowButtonClose:
+			window.Close()
+		default:
+			m.dragging, m.resizing, m.grab = window, false, position
+		}
+		return
+	}
+	if window.IsResizable() && position.X == size.W-1 && position.Y == size.H-1 {
+		m.dragging, m.resizing, m.grab = window, true, position
+	}
+}
+
+// execute method runs the action for the given keyboard input in the active
+// window.
+func (m *WindowManager) execute(action string, dx int, dy int) {
+	tools.Logger.WithField("module", "windowmanager").
+		WithField("method", "execute").
+		Debugf("%s %s %d %d", m.GetName(), action, dx, dy)
+	if action == "next" {
+		if len(m.windows) != 0 {
+			m.activateWindow(m.windows[0])
+		}
+		return
+	}
+	window := m.GetActiveWindow()
+	if window == nil {
+		return
+	}
+	switch action {
+	case "move":
+		position := window.GetPosition()
+		window.MoveTo(api.NewPoint(position.X+dx, position.Y+dy))
+	case "resize":
+		size := window.GetSize()
+		window.ResizeTo(api.NewSize(size.W+dx, size.H+dy))
+	}
+}
+
+// focusedEntity method returns the single-focus entity with the focus in the
+// window manager scene.
+func (m *WindowManager) focusedEntity() engine.IEntity {
+	if m.scene == nil {
+		return nil
+	}
+	focusManager := m.scene.GetEngine().GetFocusManager()
+	for _, entity := range focusManager.GetEntitiesWithFocus()[m.scene.GetName()] {
+		if entity.GetFocusType() == engine.SingleFocus {
+			return entity
+		}
+	}
+	return nil
+}
+
+// handleKeyboard method moves and resizes the active window, and it
+// activates other windows.
+func (m *WindowManager) handleKeyboard(event tcell.Event) {
+	ev, ok := event.(*tcell.EventKey)
+	if !ok {
+		return
+	}
+	if ev.Key() == tcell.KeyF6 {
+		m.execute("next", 0, 0)
+		return
+	}
+	if ev.Modifiers()&tcell.ModAlt == 0 {
+		return
+	}
+	action := "move"
+	if ev.Modifiers()&tcell.ModShift != 0 {
+		action = "resize"
+	}
+	switch ev.Key() {
+	case tcell.KeyUp:
+		m.execute(action, 0, -1)
+	case tcell.KeyDown:
+		m.execute(action, 0, 1)
+	case tcell.KeyLeft:
+		m.execute(action, -1, 0)
+	case tcell.KeyRight:
+		m.execute(action, 1, 0)
+	}
+}
+
+// handleMouse method raises clicked windows, and it moves or resizes the
+// window being dragged until the mouse button is released.
+func (m *WindowManager) handleMouse(event tcell.Event, scene engine.IScene) {
+	position, buttons, _ := m.GetMousePosition(event, scene)
+	if position == nil {
+		return
+	}
+	clicked := buttons&tcell.Button1 != 0 && m.mouseButtons&tcell.Button1 == 0
+	m.mouseButtons = buttons
+	// mouse position in the scene.
+	position.Add(m.GetPosition())
+	switch {
+	case buttons&tcell.Button1 == 0:
+		m.dragging = nil
+	case clicked:
+		m.dragging = nil
+		if window, windowPosition := m.windowAt(position); window != nil {
+			m.clickWindow(window, windowPosition)
+		}
+	case m.dragging != nil && m.resizing:
+		origin := m.dragging.GetPosition()
+		m.dragging.ResizeTo(api.NewSize(position.X-origin.X+1, position.Y-origin.Y+1))
+	case m.dragging != nil:
+		m.dragging.MoveTo(api.NewPoint(position.X-m.grab.X, position.Y-m.grab.Y))
+	}
+}
+
+// indexOf method returns the index for the given window, or -1 if it is not
+// opened in the window manager.
+func (m *WindowManager) indexOf(window *Window) int {
+	for i, w := range m.windows {
+		if w == window {
+			return i
+		}
+	}
+	return -1
+}
+
+// lower method moves the given window behind all other windows.
+func (m *WindowManager) lower(window *Window) {
+	if index := m.indexOf(window); index != -1 {
+		m.windows = append(m.windows[:index], m.windows[index+1:]...)
+		m.windows = append([]*Window{window}, m.windows...)
+		m.restack()
+	}
+}
+
+// onFocus method remembers the widget taking the focus in the window which is
+// the focus scope, so it gets the focus back when the window is raised again.
+// Focus changes while the focus scope is being replaced are not remembered.
+func (m *WindowManager) onFocus(event *engine.FocusEvent) {
+	if !event.Focused || m.scene == nil || m.scoped == nil || event.Scene != m.scene.GetName() {
+		return
+	}
+	if m.scoped.ContainsEntity(event.Entity) {
+		m.scoped.lastFocus = event.Entity
+	}
+}
+
+// popScope method removes the window pushed as the focus scope for the
+// scene.
+func (m *WindowManager) popScope() {
+	focusManager := m.scene.GetEngine().GetFocusManager()
+	if m.scoped != nil && focusManager.GetFocusScope(m.scene) == engine.IFocusScope(m.scoped) {
+		focusManager.PopFocusScope(m.scene)
+	}
+	m.scoped = nil
+}
+
+// restack method sets the z-level for every window and its content in the
+// order they are displayed, and it updates the focus scope.
+func (m *WindowManager) restack() {
+	for i, window := range m.windows {
+		delta := m.GetZLevel() + 1 + i*WindowZLevelStep - window.GetZLevel()
+		if delta == 0 {
+			continue
+		}
+		window.SetZLevel(window.GetZLevel() + delta)
+		walkEntity(window.GetContent(), func(entity engine.IEntity) {
+			entity.SetZLevel(entity.GetZLevel() + delta)
+		})
+	}
+	if m.scene != nil {
+		m.scene.SortEntities()
+	}
+	m.updateScope()
+}
+
+// updateScope method pushes the active window as the focus scope for the
+// scene, so only its widgets can take the focus. The widget with the focus in
+// the active window before it was moved behind other windows gets the focus
+// back. The content of minimized windows is hidden.
+func (m *WindowManager) updateScope() {
+	active := m.GetActiveWindow()
+	for _, window := range m.windows {
+		window.setContentVisible(!window.IsMinimized())
+		window.updateCanvas()
+	}
+	if m.scene == nil {
+		return
+	}
+	focusManager := m.scene.GetEngine().GetFocusManager()
+	if m.scoped != active {
+		m.popScope()
+		if active != nil {
+			focusManager.PushFocusScope(m.scene, active)
+			m.scoped = active
+			if target := active.lastFocus; target != nil && target.CanHaveFocus() {
+				focusManager.AcquireFocusToEntity(target)
+			}
+		}
+	}
+	focused := m.focusedEntity()
+	if active == nil || (focused != nil && active.ContainsEntity(focused)) {
+		return
+	}
+	// widgets in the window could have been added to the scene after the
+	// window was pushed as the focus scope.
+	focusManager.FocusNextInScene(m.scene)
+}
+
+// windowAt method returns the window displayed in front at the given scene
+// position and the position relative to that window.
+func (m *WindowManager) windowAt(position *api.Point) (*Window, *api.Point) {
+	for i := len(m.windows) - 1; i >= 0; i-- {
+		window := m.windows[i]
+		origin, size := window.GetPosition(), window.GetSize()
+		height := size.H
+		if window.IsMinimized() {
+			height = 1
+		}
+		x, y := position.X-origin.X, position.Y-origin.Y
+		if x >= 0 && y >= 0 && x < size.W && y < height {
+			return window, api.NewPoint(x, y)
+		}
+	}
+	return nil, nil
+}
+
+// -----------------------------------------------------------------------------
+// WindowManager public methods
+// -----------------------------------------------------------------------------
+
+// Close method closes the given window and removes it from the scene. The
+// window behind it becomes the active window. The window widget callback is
+// called when it is closed.
+func (m *WindowManager) Close(window *Window) bool {
+	index := m.indexOf(window)
+	if index == -1 {
+		return false
+	}
+	if m.dragging == window {
+		m.dragging = nil
+	}
+	m.windows = append(m.windows[:index], m.windows[index+1:]...)
+	if m.scene != nil {
+		m.scene.RemoveEntity(window)
+	}
+	window.manager = nil
+	window.lastFocus = nil
+	window.updateCanvas()
+	m.restack()
+	window.RunCallback(window)
+	return true
+}
+
+// GetActiveWindow method returns the window in front of all other windows,
+// nil if there are not any windows or all of them are minimized.
+func (m *WindowManager) GetActiveWindow() *Window {
+	if len(m.windows) == 0 || m.windows[len(m.windows)-1].IsMinimized() {
+		return nil
+	}
+	return m.windows[len(m.windows)-1]
+}
+
+// GetWindows method returns all windows opened, from back to front.
+func (m *WindowManager) GetWindows() []*Window {
+	return m.windows
+}
+
+// OnAdded method adds all windows to the scene the window manager is added
+// to, and it listens to focus changes in the scene.
+func (m *WindowManager) OnAdded(scene engine.IScene) {
+	m.Widget.OnAdded(scene)
+	m.scene = scene
+	if focusManager := scene.GetEngine().GetFocusManager(); m.listening != focusManager {
+		focusManager.AddFocusListener(m.onFocus)
+		m.listening = focusManager
+	}
+	for _, window := range m.windows {
+		scene.AddEntity(window)
+	}
+	m.restack()
+}
+
+// OnRemoved method removes all windows from the scene the window manager is
+// removed from, and the active window is not the focus scope anymore.
+func (m *WindowManager) OnRemoved(scene engine.IScene) {
+	m.Widget.OnRemoved(scene)
+	m.popScope()
+	for _, window := range m.windows {
+		scene.RemoveEntity(window)
+	}
+	m.scene = nil
+}
+
+// Open method opens the given window in front of all other windows. The
+// window is added to the scene if the window manager is already in a scene.
+func (m *WindowManager) Open(window *Window) {
+	if window.manager != nil {
+		window.manager.Close(window)
+	}
+	window.manager = m
+	m.windows = append(m.windows, window)
+	if m.scene != nil {
+		m.scene.AddEntity(window)
+	}
+	m.restack()
+}
+
+// Raise method moves the given window in front of all other windows, so it
+// becomes the active window.
+func (m *WindowManager) Raise(window *Window) {
+	index := m.indexOf(window)
+	if index == -1 {
+		return
+	}
+	m.windows = append(m.windows[:index], m.windows[index+1:]...)
+	m.windows = append(m.windows, window)
+	m.restack()
+}
+
+// Update method handles the mouse and keyboard inputs for all windows.
+func (m *WindowManager) Update(event tcell.Event, scene engine.IScene) {
+	defer m.Entity.Update(event, scene)
+	m.handleMouse(event, scene)
+	m.handleKeyboard(event)
+}
+
+var _ engine.IObject = (*WindowManager)(nil)
+var _ engine.IFocus = (*WindowManager)(nil)
+var _ engine.IEntity = (*WindowManager)(nil)
+var _ IWidget = (*WindowManager)(nil)